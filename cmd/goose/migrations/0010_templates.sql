@@ -0,0 +1,71 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE templates (
+    id text primary key,
+    name text not null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE TABLE template_exercise_items (
+    id text primary key,
+    type text not null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+    template_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id),
+    FOREIGN KEY(template_id) REFERENCES templates(id)
+);
+
+CREATE TABLE template_exercises (
+    id text primary key,
+    name text not null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+    template_id text not null,
+    template_exercise_item_id text not null,
+    exercise_type_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id),
+    FOREIGN KEY(template_id) REFERENCES templates(id),
+    FOREIGN KEY(template_exercise_item_id) REFERENCES template_exercise_items(id),
+    FOREIGN KEY(exercise_type_id) REFERENCES exercise_types(id)
+);
+
+CREATE TABLE template_sets (
+    id text primary key,
+    repetitions integer not null,
+    weight real not null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+    template_id text not null,
+    template_exercise_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id),
+    FOREIGN KEY(template_id) REFERENCES templates(id),
+    FOREIGN KEY(template_exercise_id) REFERENCES template_exercises(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE template_sets;
+DROP TABLE template_exercises;
+DROP TABLE template_exercise_items;
+DROP TABLE templates;
+-- +goose StatementEnd
@@ -0,0 +1,74 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE programs (
+    id text primary key,
+    name text not null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE TABLE program_days (
+    id text primary key,
+    week integer not null,
+    day integer not null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+    program_id text not null,
+    template_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id),
+    FOREIGN KEY(program_id) REFERENCES programs(id),
+    FOREIGN KEY(template_id) REFERENCES templates(id)
+);
+
+CREATE TABLE program_enrollments (
+    id text primary key,
+    started_on text not null,
+    ended_on text null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+    program_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id),
+    FOREIGN KEY(program_id) REFERENCES programs(id)
+);
+
+CREATE TABLE program_progress (
+    id text primary key,
+    completed_on text null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+    program_id text not null,
+    program_enrollment_id text not null,
+    program_day_id text not null,
+    workout_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id),
+    FOREIGN KEY(program_id) REFERENCES programs(id),
+    FOREIGN KEY(program_enrollment_id) REFERENCES program_enrollments(id),
+    FOREIGN KEY(program_day_id) REFERENCES program_days(id),
+    FOREIGN KEY(workout_id) REFERENCES workouts(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE program_progress;
+DROP TABLE program_enrollments;
+DROP TABLE program_days;
+DROP TABLE programs;
+-- +goose StatementEnd
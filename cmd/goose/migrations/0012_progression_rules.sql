@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE progression_rules (
+    id text primary key,
+    rule text not null,
+    increment real not null,
+    min_reps integer not null,
+    max_reps integer not null,
+    training_max real not null,
+    percentage real not null,
+    bar_weight real not null,
+    plates text not null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+    exercise_type_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id),
+    FOREIGN KEY(exercise_type_id) REFERENCES exercise_types(id),
+    UNIQUE(user_id, exercise_type_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE progression_rules;
+-- +goose StatementEnd
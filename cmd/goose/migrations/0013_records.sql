@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE records (
+    id text primary key,
+    kind text not null,
+    value real not null,
+    weight real not null,
+    repetitions integer not null,
+    achieved_on text not null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+    exercise_type_id text not null,
+    workout_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id),
+    FOREIGN KEY(exercise_type_id) REFERENCES exercise_types(id),
+    FOREIGN KEY(workout_id) REFERENCES workouts(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE records;
+-- +goose StatementEnd
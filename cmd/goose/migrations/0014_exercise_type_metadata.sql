@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE exercise_types
+ADD COLUMN equipment text null;
+
+ALTER TABLE exercise_types
+ADD COLUMN movement_pattern text null;
+
+CREATE TABLE exercise_type_muscle_groups (
+    id text primary key,
+    muscle_group text not null,
+    role text not null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+    exercise_type_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id),
+    FOREIGN KEY(exercise_type_id) REFERENCES exercise_types(id),
+    UNIQUE(exercise_type_id, muscle_group)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE exercise_type_muscle_groups;
+
+ALTER TABLE exercise_types
+DROP COLUMN movement_pattern;
+
+ALTER TABLE exercise_types
+DROP COLUMN equipment;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sets
+ADD COLUMN type text not null DEFAULT 'working';
+
+ALTER TABLE template_sets
+ADD COLUMN type text not null DEFAULT 'working';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE template_sets
+DROP COLUMN type;
+
+ALTER TABLE sets
+DROP COLUMN type;
+-- +goose StatementEnd
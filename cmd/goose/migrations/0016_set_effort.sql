@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sets
+ADD COLUMN rpe REAL null;
+
+ALTER TABLE sets
+ADD COLUMN rir INTEGER null;
+
+ALTER TABLE sets
+ADD COLUMN note text null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sets
+DROP COLUMN note;
+
+ALTER TABLE sets
+DROP COLUMN rir;
+
+ALTER TABLE sets
+DROP COLUMN rpe;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE exercise_items
+ADD COLUMN position INTEGER not null DEFAULT 0;
+
+ALTER TABLE exercises
+ADD COLUMN position INTEGER not null DEFAULT 0;
+
+ALTER TABLE sets
+ADD COLUMN position INTEGER not null DEFAULT 0;
+
+-- Keep the order existing rows were shown in, which followed their time ordered ids
+UPDATE exercise_items
+SET position = (SELECT COUNT(*) FROM exercise_items i WHERE i.workout_id = exercise_items.workout_id AND i.id < exercise_items.id);
+
+UPDATE exercises
+SET position = (SELECT COUNT(*) FROM exercises e WHERE e.exercise_item_id = exercises.exercise_item_id AND e.id < exercises.id);
+
+UPDATE sets
+SET position = (SELECT COUNT(*) FROM sets s WHERE s.exercise_id = sets.exercise_id AND s.id < sets.id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sets
+DROP COLUMN position;
+
+ALTER TABLE exercises
+DROP COLUMN position;
+
+ALTER TABLE exercise_items
+DROP COLUMN position;
+-- +goose StatementEnd
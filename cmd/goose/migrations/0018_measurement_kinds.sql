@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE exercise_types
+ADD COLUMN measurement text not null DEFAULT 'weight_reps';
+
+ALTER TABLE sets
+ADD COLUMN duration_seconds INTEGER null;
+
+ALTER TABLE sets
+ADD COLUMN distance_meters REAL null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sets
+DROP COLUMN distance_meters;
+
+ALTER TABLE sets
+DROP COLUMN duration_seconds;
+
+ALTER TABLE exercise_types
+DROP COLUMN measurement;
+-- +goose StatementEnd
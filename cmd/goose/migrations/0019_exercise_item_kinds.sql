@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE exercise_items
+ADD COLUMN rounds INTEGER null;
+
+ALTER TABLE exercise_items
+ADD COLUMN interval_seconds INTEGER null;
+
+ALTER TABLE exercise_items
+ADD COLUMN time_cap_seconds INTEGER null;
+
+ALTER TABLE template_exercise_items
+ADD COLUMN rounds INTEGER null;
+
+ALTER TABLE template_exercise_items
+ADD COLUMN interval_seconds INTEGER null;
+
+ALTER TABLE template_exercise_items
+ADD COLUMN time_cap_seconds INTEGER null;
+
+ALTER TABLE sets
+ADD COLUMN round_number INTEGER null;
+
+-- Items used to take any type, everything that is not a known kind is a straight set
+UPDATE exercise_items
+SET type = 'straight'
+WHERE type NOT IN ('straight', 'superset', 'giant', 'circuit', 'emom', 'amrap');
+
+UPDATE template_exercise_items
+SET type = 'straight'
+WHERE type NOT IN ('straight', 'superset', 'giant', 'circuit', 'emom', 'amrap');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sets
+DROP COLUMN round_number;
+
+ALTER TABLE template_exercise_items
+DROP COLUMN time_cap_seconds;
+
+ALTER TABLE template_exercise_items
+DROP COLUMN interval_seconds;
+
+ALTER TABLE template_exercise_items
+DROP COLUMN rounds;
+
+ALTER TABLE exercise_items
+DROP COLUMN time_cap_seconds;
+
+ALTER TABLE exercise_items
+DROP COLUMN interval_seconds;
+
+ALTER TABLE exercise_items
+DROP COLUMN rounds;
+-- +goose StatementEnd
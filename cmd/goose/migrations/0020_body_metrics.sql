@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE body_metrics (
+    id text primary key,
+    measured_on text not null,
+    bodyweight REAL null,
+    body_fat_percentage REAL null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE TABLE body_measurements (
+    id text primary key,
+    site text not null,
+    centimeters REAL not null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+    body_metric_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id),
+    FOREIGN KEY(body_metric_id) REFERENCES body_metrics(id),
+    UNIQUE(body_metric_id, site)
+);
+
+ALTER TABLE workouts
+ADD COLUMN bodyweight REAL null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workouts
+DROP COLUMN bodyweight;
+
+DROP TABLE body_measurements;
+
+DROP TABLE body_metrics;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_preferences (
+    user_id text primary key,
+    weight_unit text not null default 'kg',
+    week_start text not null default 'monday',
+    timezone text not null default 'UTC',
+    default_rest_seconds INTEGER not null default 120,
+
+    created_on text not null,
+    updated_on text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_preferences;
+-- +goose StatementEnd
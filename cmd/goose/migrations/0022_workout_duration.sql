@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE workouts
+ADD COLUMN started_on text null;
+
+ALTER TABLE workouts
+ADD COLUMN resumed_on text null;
+
+ALTER TABLE workouts
+ADD COLUMN active_seconds INTEGER not null default 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workouts
+DROP COLUMN active_seconds;
+
+ALTER TABLE workouts
+DROP COLUMN resumed_on;
+
+ALTER TABLE workouts
+DROP COLUMN started_on;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sets
+ADD COLUMN performed_on text null;
+
+ALTER TABLE sets
+ADD COLUMN rest_seconds INTEGER null;
+
+ALTER TABLE exercise_types
+ADD COLUMN rest_seconds INTEGER null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE exercise_types
+DROP COLUMN rest_seconds;
+
+ALTER TABLE sets
+DROP COLUMN rest_seconds;
+
+ALTER TABLE sets
+DROP COLUMN performed_on;
+-- +goose StatementEnd
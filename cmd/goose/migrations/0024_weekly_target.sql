@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE user_preferences
+ADD COLUMN weekly_target INTEGER not null default 3;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_preferences
+DROP COLUMN weekly_target;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE goals (
+    id text primary key,
+    kind text not null,
+    target_weight REAL null,
+    target_repetitions INTEGER null,
+    target_value REAL not null,
+    start_value REAL not null,
+    deadline text not null,
+    achieved_on text null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+    exercise_type_id text null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id),
+    FOREIGN KEY(exercise_type_id) REFERENCES exercise_types(id)
+);
+
+CREATE TABLE goal_progress (
+    id text primary key,
+    value REAL not null,
+    recorded_on text not null,
+
+    created_on text not null,
+    updated_on text not null,
+
+    user_id text not null,
+    goal_id text not null,
+
+    FOREIGN KEY(user_id) REFERENCES users(id),
+    FOREIGN KEY(goal_id) REFERENCES goals(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE goal_progress;
+
+DROP TABLE goals;
+-- +goose StatementEnd
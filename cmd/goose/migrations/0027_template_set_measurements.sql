@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE template_sets
+ADD COLUMN duration_seconds INTEGER null;
+
+ALTER TABLE template_sets
+ADD COLUMN distance_meters REAL null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE template_sets
+DROP COLUMN distance_meters;
+
+ALTER TABLE template_sets
+DROP COLUMN duration_seconds;
+-- +goose StatementEnd
@@ -0,0 +1,101 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+	"weight-tracker/internal/database"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
+)
+
+type deleteRequest struct {
+	Password string `json:"password"`
+}
+
+type handler struct {
+	service Service
+}
+
+func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
+	handler := handler{
+		service: NewService(
+			NewAccountRepository(s.GetRepository()),
+			func(ctx context.Context, fn func(AccountRepository) error) error {
+				return s.WithTx(ctx, func(q repository.Querier) error {
+					return fn(NewAccountRepository(q))
+				})
+			},
+		),
+	}
+
+	mux.Handle("GET /me/archive", authenticationWrapper(http.HandlerFunc(handler.archiveHandler)))
+	mux.Handle("DELETE /me", authenticationWrapper(http.HandlerFunc(handler.deleteHandler)))
+}
+
+func (h *handler) archiveHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	archive, err := h.service.GetArchive(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get archive", "error", err)
+		http.Error(w, "Failed to create archive", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="weight-tracker-archive.zip"`)
+	err = WriteArchive(w, archive)
+	if err != nil {
+		// The headers are sent, the client sees a broken zip file
+		slog.Error("Failed to write archive", "error", err)
+	}
+}
+
+func (h *handler) deleteHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	// The tokens are only revoked when the request was authenticated with them
+	accessToken, _ := r.Context().Value("access_token").(string)
+	refreshToken, _ := r.Context().Value("refresh_token").(string)
+
+	var request deleteRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil || request.Password == "" {
+		slog.Warn("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.Delete(r.Context(), userId, request.Password, accessToken, refreshToken)
+	if errors.Is(err, ErrPasswordMismatch) {
+		slog.Warn("Failed to delete account", "error", err)
+		http.Error(w, "Password does not match", http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		slog.Warn("Failed to delete account", "error", err)
+		http.Error(w, "Failed to delete account", http.StatusBadRequest)
+		return
+	}
+
+	accessCookie := expiredCookie(utils.AccessTokenCookieName)
+	refreshCookie := expiredCookie(utils.RefreshTokenCookieName)
+	http.SetCookie(w, &accessCookie)
+	http.SetCookie(w, &refreshCookie)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func expiredCookie(name string) http.Cookie {
+	return http.Cookie{
+		Name:     name,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		Expires:  time.Now(),
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -0,0 +1,161 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func populateContextWithSub(req *http.Request, userId string) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, "sub", userId)
+	return req.WithContext(ctx)
+}
+
+type serviceMock struct {
+	mock.Mock
+}
+
+func (m *serviceMock) GetArchive(ctx context.Context, userId string) (Archive, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(Archive), args.Error(1)
+}
+
+func (m *serviceMock) Delete(ctx context.Context, userId string, password string, accessToken string, refreshToken string) error {
+	args := m.Called(ctx, userId, password, accessToken, refreshToken)
+	return args.Error(0)
+}
+
+func TestArchiveHandler(t *testing.T) {
+	req, err := http.NewRequest("GET", "/me/archive", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetArchive", mock.Anything, "userId").Return(Archive{
+		User:     User{ID: "userId", Username: "lifter", Password: "hash", CreatedOn: "2024-01-01T00:00:00Z", UpdatedOn: "2024-01-01T00:00:00Z"},
+		Workouts: []repository.Workout{{ID: "workout", Name: "Push", UserID: "userId", CreatedOn: "2024-01-15T18:30:00Z"}},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock}
+	http.HandlerFunc(h.archiveHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/zip", rr.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="weight-tracker-archive.zip"`, rr.Header().Get("Content-Disposition"))
+
+	archive, err := zip.NewReader(bytes.NewReader(rr.Body.Bytes()), int64(rr.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(r)
+		files[f.Name] = string(content)
+	}
+	assert.Len(t, files, 22)
+	// The password hash is never part of the archive
+	assert.Equal(t, "{\n  \"id\": \"userId\",\n  \"username\": \"lifter\",\n  \"email\": null,\n  \"is_verified\": false,\n"+
+		"  \"created_on\": \"2024-01-01T00:00:00Z\",\n  \"updated_on\": \"2024-01-01T00:00:00Z\"\n}\n", files["user.json"])
+	assert.Equal(t, "null\n", files["preferences.json"])
+	assert.Contains(t, files["workouts.json"], `"name": "Push"`)
+	assert.Equal(t, "[]\n", files["sets.json"])
+}
+
+func TestArchiveHandlerFailure(t *testing.T) {
+	req, err := http.NewRequest("GET", "/me/archive", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetArchive", mock.Anything, "userId").Return(Archive{}, errors.New("failed to get user by ID")).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock}
+	http.HandlerFunc(h.archiveHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "Failed to create archive\n", rr.Body.String())
+}
+
+func newDeleteRequest(t *testing.T, body string) *http.Request {
+	req, err := http.NewRequest("DELETE", "/me", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+	ctx := context.WithValue(req.Context(), "access_token", "access")
+	ctx = context.WithValue(ctx, "refresh_token", "refresh")
+	return req.WithContext(ctx)
+}
+
+func TestDeleteHandler(t *testing.T) {
+	req := newDeleteRequest(t, `{"password":"secret"}`)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("Delete", mock.Anything, "userId", "secret", "access", "refresh").Return(nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock}
+	http.HandlerFunc(h.deleteHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	cookies := rr.Result().Cookies()
+	assert.Len(t, cookies, 2)
+	assert.Equal(t, utils.AccessTokenCookieName, cookies[0].Name)
+	assert.Equal(t, utils.RefreshTokenCookieName, cookies[1].Name)
+	for _, cookie := range cookies {
+		assert.Equal(t, "", cookie.Value)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestDeleteHandlerWrongPassword(t *testing.T) {
+	req := newDeleteRequest(t, `{"password":"guess"}`)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("Delete", mock.Anything, "userId", "guess", "access", "refresh").Return(ErrPasswordMismatch).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock}
+	http.HandlerFunc(h.deleteHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Equal(t, "Password does not match\n", rr.Body.String())
+	assert.Empty(t, rr.Result().Cookies())
+}
+
+func TestDeleteHandlerInvalidBody(t *testing.T) {
+	for _, body := range []string{`{}`, `not json`} {
+		req := newDeleteRequest(t, body)
+
+		serviceMock := serviceMock{}
+
+		rr := httptest.NewRecorder()
+		h := handler{service: &serviceMock}
+		http.HandlerFunc(h.deleteHandler).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+		assert.Equal(t, "Invalid request body\n", rr.Body.String(), body)
+		serviceMock.AssertNotCalled(t, "Delete")
+	}
+}
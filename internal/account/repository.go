@@ -0,0 +1,233 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"weight-tracker/internal/repository"
+)
+
+type User struct {
+	ID         string `json:"id"`
+	Username   string `json:"username"`
+	Password   string `json:"-"`
+	Email      any    `json:"email"`
+	IsVerified bool   `json:"is_verified"`
+	CreatedOn  string `json:"created_on"`
+	UpdatedOn  string `json:"updated_on"`
+}
+
+// Archive is everything stored about a user, the rows of every table that
+// belong to them. Preferences are nil when the user never saved any.
+type Archive struct {
+	User                     User
+	Preferences              *repository.UserPreference
+	Workouts                 []repository.Workout
+	ExerciseItems            []repository.ExerciseItem
+	Exercises                []repository.Exercise
+	Sets                     []repository.Set
+	ExerciseTypes            []repository.ExerciseType
+	ExerciseTypeMuscleGroups []repository.ExerciseTypeMuscleGroup
+	ProgressionRules         []repository.ProgressionRule
+	Records                  []repository.Record
+	Templates                []repository.Template
+	TemplateExerciseItems    []repository.TemplateExerciseItem
+	TemplateExercises        []repository.TemplateExercise
+	TemplateSets             []repository.TemplateSet
+	Programs                 []repository.Program
+	ProgramDays              []repository.ProgramDay
+	ProgramEnrollments       []repository.ProgramEnrollment
+	ProgramProgress          []repository.ProgramProgress
+	BodyMetrics              []repository.BodyMetric
+	BodyMeasurements         []repository.BodyMeasurement
+	Goals                    []repository.Goal
+	GoalProgress             []repository.GoalProgress
+}
+
+type AccountRepository interface {
+	GetUser(ctx context.Context, userId string) (User, error)
+	GetArchive(ctx context.Context, userId string) (Archive, error)
+	// Delete removes the user and every row that belongs to them
+	Delete(ctx context.Context, userId string) error
+	InvalidateToken(ctx context.Context, arg repository.CreateExpiredTokenParams) error
+}
+
+func NewAccountRepository(repo repository.Querier) AccountRepository {
+	return &accountRepository{repo: repo}
+}
+
+type accountRepository struct {
+	repo repository.Querier
+}
+
+func (a *accountRepository) GetUser(ctx context.Context, userId string) (User, error) {
+	user, err := a.repo.GetByUserId(ctx, userId)
+	if err != nil {
+		return User{}, fmt.Errorf("failed to get user by ID: %w", err)
+	}
+
+	return User{
+		ID:         user.ID,
+		Username:   user.Username,
+		Password:   user.Password,
+		Email:      user.Email,
+		IsVerified: user.IsVerified,
+		CreatedOn:  user.CreatedOn,
+		UpdatedOn:  user.UpdatedOn,
+	}, nil
+}
+
+func (a *accountRepository) GetArchive(ctx context.Context, userId string) (Archive, error) {
+	user, err := a.GetUser(ctx, userId)
+	if err != nil {
+		return Archive{}, err
+	}
+	archive := Archive{User: user}
+
+	preferences, err := a.repo.GetPreferencesByUserId(ctx, userId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return Archive{}, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	if err == nil {
+		archive.Preferences = &preferences
+	}
+
+	archive.Workouts, err = a.repo.GetWorkoutsByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get workouts: %w", err)
+	}
+	archive.ExerciseItems, err = a.repo.GetExerciseItemsByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get exercise items: %w", err)
+	}
+	archive.Exercises, err = a.repo.GetExercisesByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get exercises: %w", err)
+	}
+	archive.Sets, err = a.repo.GetSetsByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get sets: %w", err)
+	}
+	archive.ExerciseTypes, err = a.repo.GetExerciseTypesByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get exercise types: %w", err)
+	}
+	archive.ExerciseTypeMuscleGroups, err = a.repo.GetMuscleGroupsByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get muscle groups: %w", err)
+	}
+	archive.ProgressionRules, err = a.repo.GetProgressionRulesByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get progression rules: %w", err)
+	}
+	archive.Records, err = a.repo.GetRecordsByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get records: %w", err)
+	}
+	archive.Templates, err = a.repo.GetTemplatesByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get templates: %w", err)
+	}
+	archive.TemplateExerciseItems, err = a.repo.GetTemplateExerciseItemsByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get template exercise items: %w", err)
+	}
+	archive.TemplateExercises, err = a.repo.GetTemplateExercisesByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get template exercises: %w", err)
+	}
+	archive.TemplateSets, err = a.repo.GetTemplateSetsByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get template sets: %w", err)
+	}
+	archive.Programs, err = a.repo.GetProgramsByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get programs: %w", err)
+	}
+	archive.ProgramDays, err = a.repo.GetProgramDaysByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get program days: %w", err)
+	}
+	archive.ProgramEnrollments, err = a.repo.GetProgramEnrollmentsByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get program enrollments: %w", err)
+	}
+	archive.ProgramProgress, err = a.repo.GetProgramProgressByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get program progress: %w", err)
+	}
+	archive.BodyMetrics, err = a.repo.GetBodyMetricsByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get body metrics: %w", err)
+	}
+	archive.BodyMeasurements, err = a.repo.GetBodyMeasurementsByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get body measurements: %w", err)
+	}
+	archive.Goals, err = a.repo.GetGoalsByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get goals: %w", err)
+	}
+	archive.GoalProgress, err = a.repo.GetGoalProgressByUserId(ctx, userId)
+	if err != nil {
+		return Archive{}, fmt.Errorf("failed to get goal progress: %w", err)
+	}
+
+	return archive, nil
+}
+
+func (a *accountRepository) Delete(ctx context.Context, userId string) error {
+	// Rows are deleted children first, so no row is left pointing at a deleted one
+	deletes := []struct {
+		name   string
+		delete func(ctx context.Context, userId string) (int64, error)
+	}{
+		{"goal progress", a.repo.DeleteGoalProgressByUserId},
+		{"goals", a.repo.DeleteGoalsByUserId},
+		{"body measurements", a.repo.DeleteBodyMeasurementsByUserId},
+		{"body metrics", a.repo.DeleteBodyMetricsByUserId},
+		{"preferences", a.repo.DeletePreferencesByUserId},
+		{"records", a.repo.DeleteRecordsByUserId},
+		{"progression rules", a.repo.DeleteProgressionRulesByUserId},
+		{"muscle groups", a.repo.DeleteExerciseTypeMuscleGroupsByUserId},
+		{"program progress", a.repo.DeleteProgramProgressByUserId},
+		{"program enrollments", a.repo.DeleteProgramEnrollmentsByUserId},
+		{"program days", a.repo.DeleteProgramDaysByUserId},
+		{"programs", a.repo.DeleteProgramsByUserId},
+		{"template sets", a.repo.DeleteTemplateSetsByUserId},
+		{"template exercises", a.repo.DeleteTemplateExercisesByUserId},
+		{"template exercise items", a.repo.DeleteTemplateExerciseItemsByUserId},
+		{"templates", a.repo.DeleteTemplatesByUserId},
+		{"sets", a.repo.DeleteSetsByUserId},
+		{"exercises", a.repo.DeleteExercisesByUserId},
+		{"exercise items", a.repo.DeleteExerciseItemsByUserId},
+		{"workouts", a.repo.DeleteWorkoutsByUserId},
+		{"exercise types", a.repo.DeleteExerciseTypesByUserId},
+	}
+	for _, v := range deletes {
+		_, err := v.delete(ctx, userId)
+		if err != nil {
+			return fmt.Errorf("failed to delete %s: %w", v.name, err)
+		}
+	}
+
+	rows, err := a.repo.DeleteUser(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (a *accountRepository) InvalidateToken(ctx context.Context, arg repository.CreateExpiredTokenParams) error {
+	_, err := a.repo.CreateExpiredToken(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to invalidate token: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrPasswordMismatch is returned when the password given to confirm a deletion is not the one of the user
+var ErrPasswordMismatch = errors.New("password does not match")
+
+type Service interface {
+	// GetArchive reads everything stored about the user
+	GetArchive(ctx context.Context, userId string) (Archive, error)
+	// Delete removes the user and everything stored about them in one
+	// transaction, after checking their password. The tokens of the session
+	// are invalidated in the same transaction.
+	Delete(ctx context.Context, userId string, password string, accessToken string, refreshToken string) error
+}
+
+// Transactor runs fn with a repository whose queries share one transaction
+type Transactor func(ctx context.Context, fn func(AccountRepository) error) error
+
+type accountService struct {
+	repo   AccountRepository
+	withTx Transactor
+}
+
+func NewService(repo AccountRepository, withTx Transactor) Service {
+	return &accountService{repo: repo, withTx: withTx}
+}
+
+func (s *accountService) GetArchive(ctx context.Context, userId string) (Archive, error) {
+	// The tables are read in one transaction so the archive is a single point in time
+	var archive Archive
+	err := s.withTx(ctx, func(repo AccountRepository) error {
+		var err error
+		archive, err = repo.GetArchive(ctx, userId)
+		return err
+	})
+	if err != nil {
+		return Archive{}, err
+	}
+	return archive, nil
+}
+
+func (s *accountService) Delete(ctx context.Context, userId string, password string, accessToken string, refreshToken string) error {
+	user, err := s.repo.GetUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	if err != nil {
+		return ErrPasswordMismatch
+	}
+
+	now := time.Now().UTC()
+	tokens := []repository.CreateExpiredTokenParams{}
+	if accessToken != "" {
+		tokens = append(tokens, expiredToken(accessToken, "access_token", utils.EnvJwtExpireMinutes, now))
+	}
+	if refreshToken != "" {
+		tokens = append(tokens, expiredToken(refreshToken, "refresh_token", utils.EnvJwtRefreshExpireMinutes, now))
+	}
+
+	err = s.withTx(ctx, func(repo AccountRepository) error {
+		err := repo.Delete(ctx, userId)
+		if err != nil {
+			return err
+		}
+		for _, token := range tokens {
+			err = repo.InvalidateToken(ctx, token)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+	return nil
+}
+
+// expiredToken keeps the token as expired for as long as it would have been valid
+func expiredToken(token string, tokenType string, expireMinutesEnv string, now time.Time) repository.CreateExpiredTokenParams {
+	expireMinutes, _ := strconv.Atoi(os.Getenv(expireMinutesEnv))
+	return repository.CreateExpiredTokenParams{
+		Token:     token,
+		TokenType: tokenType,
+		CreatedOn: now.Format(time.RFC3339),
+		RemoveOn:  now.Add(time.Minute * time.Duration(expireMinutes)).Format(time.RFC3339),
+	}
+}
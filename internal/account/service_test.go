@@ -0,0 +1,150 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type repoMock struct {
+	mock.Mock
+}
+
+func (m *repoMock) GetUser(ctx context.Context, userId string) (User, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(User), args.Error(1)
+}
+
+func (m *repoMock) GetArchive(ctx context.Context, userId string) (Archive, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(Archive), args.Error(1)
+}
+
+func (m *repoMock) Delete(ctx context.Context, userId string) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+func (m *repoMock) InvalidateToken(ctx context.Context, arg repository.CreateExpiredTokenParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+// transactorStub runs fn on the repository it holds and counts the transactions
+type transactorStub struct {
+	repo AccountRepository
+	runs int
+}
+
+func (s *transactorStub) withTx(ctx context.Context, fn func(AccountRepository) error) error {
+	s.runs++
+	return fn(s.repo)
+}
+
+func userWithPassword(t *testing.T, password string) User {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return User{ID: "userId", Username: "lifter", Password: string(hash)}
+}
+
+func TestDeleteRemovesAccountAndRevokesTokensInOneTransaction(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+	t.Setenv(utils.EnvJwtExpireMinutes, "15")
+	t.Setenv(utils.EnvJwtRefreshExpireMinutes, "1440")
+
+	txMock := repoMock{}
+	repoMock := repoMock{}
+	repoMock.On("GetUser", ctx, userId).Return(userWithPassword(t, "secret"), nil).Once()
+
+	txMock.On("Delete", ctx, userId).Return(nil).Once()
+	txMock.On("InvalidateToken", ctx, mock.MatchedBy(func(arg repository.CreateExpiredTokenParams) bool {
+		return arg.Token == "access" && arg.TokenType == "access_token" && arg.RemoveOn > arg.CreatedOn
+	})).Return(nil).Once()
+	txMock.On("InvalidateToken", ctx, mock.MatchedBy(func(arg repository.CreateExpiredTokenParams) bool {
+		return arg.Token == "refresh" && arg.TokenType == "refresh_token" && arg.RemoveOn > arg.CreatedOn
+	})).Return(nil).Once()
+
+	transactor := transactorStub{repo: &txMock}
+	err := NewService(&repoMock, transactor.withTx).Delete(ctx, userId, "secret", "access", "refresh")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
+	txMock.AssertExpectations(t)
+	repoMock.AssertExpectations(t)
+}
+
+func TestDeleteWithoutTokens(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	txMock := repoMock{}
+	repoMock := repoMock{}
+	repoMock.On("GetUser", ctx, userId).Return(userWithPassword(t, "secret"), nil).Once()
+	txMock.On("Delete", ctx, userId).Return(nil).Once()
+
+	transactor := transactorStub{repo: &txMock}
+	err := NewService(&repoMock, transactor.withTx).Delete(ctx, userId, "secret", "", "")
+
+	assert.Nil(t, err)
+	txMock.AssertExpectations(t)
+	txMock.AssertNotCalled(t, "InvalidateToken", mock.Anything, mock.Anything)
+}
+
+func TestDeleteWithWrongPassword(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetUser", ctx, userId).Return(userWithPassword(t, "secret"), nil).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	err := NewService(&repoMock, transactor.withTx).Delete(ctx, userId, "guess", "access", "refresh")
+
+	assert.ErrorIs(t, err, ErrPasswordMismatch)
+	assert.Equal(t, 0, transactor.runs)
+	repoMock.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestDeleteReturnsErrorOfTransaction(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	txMock := repoMock{}
+	repoMock := repoMock{}
+	repoMock.On("GetUser", ctx, userId).Return(userWithPassword(t, "secret"), nil).Once()
+	txMock.On("Delete", ctx, userId).Return(errors.New("failed to delete sets: disk full")).Once()
+
+	transactor := transactorStub{repo: &txMock}
+	err := NewService(&repoMock, transactor.withTx).Delete(ctx, userId, "secret", "access", "refresh")
+
+	assert.ErrorContains(t, err, "disk full")
+	txMock.AssertNotCalled(t, "InvalidateToken", mock.Anything, mock.Anything)
+}
+
+func TestGetArchive(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	archive := Archive{
+		User:     User{ID: userId, Username: "lifter"},
+		Workouts: []repository.Workout{{ID: "workout", Name: "Push", UserID: userId}},
+	}
+	txMock := repoMock{}
+	txMock.On("GetArchive", ctx, userId).Return(archive, nil).Once()
+
+	transactor := transactorStub{repo: &txMock}
+	result, err := NewService(&repoMock{}, transactor.withTx).GetArchive(ctx, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
+	assert.Equal(t, archive, result)
+}
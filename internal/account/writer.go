@@ -0,0 +1,60 @@
+package account
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// WriteArchive writes the archive as a zip file with a JSON file per table
+func WriteArchive(w io.Writer, archive Archive) error {
+	files := []struct {
+		name string
+		data any
+	}{
+		{"user.json", archive.User},
+		{"preferences.json", archive.Preferences},
+		{"workouts.json", archive.Workouts},
+		{"exercise_items.json", archive.ExerciseItems},
+		{"exercises.json", archive.Exercises},
+		{"sets.json", archive.Sets},
+		{"exercise_types.json", archive.ExerciseTypes},
+		{"exercise_type_muscle_groups.json", archive.ExerciseTypeMuscleGroups},
+		{"progression_rules.json", archive.ProgressionRules},
+		{"records.json", archive.Records},
+		{"templates.json", archive.Templates},
+		{"template_exercise_items.json", archive.TemplateExerciseItems},
+		{"template_exercises.json", archive.TemplateExercises},
+		{"template_sets.json", archive.TemplateSets},
+		{"programs.json", archive.Programs},
+		{"program_days.json", archive.ProgramDays},
+		{"program_enrollments.json", archive.ProgramEnrollments},
+		{"program_progress.json", archive.ProgramProgress},
+		{"body_metrics.json", archive.BodyMetrics},
+		{"body_measurements.json", archive.BodyMeasurements},
+		{"goals.json", archive.Goals},
+		{"goal_progress.json", archive.GoalProgress},
+	}
+
+	zipWriter := zip.NewWriter(w)
+	for _, file := range files {
+		f, err := zipWriter.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", file.name, err)
+		}
+		data := file.data
+		// Tables without rows are written as an empty list
+		if v := reflect.ValueOf(data); v.Kind() == reflect.Slice && v.IsNil() {
+			data = []any{}
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(data)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+	return zipWriter.Close()
+}
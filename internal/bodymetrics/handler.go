@@ -0,0 +1,292 @@
+package bodymetrics
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+	"weight-tracker/internal/database"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/units"
+	"weight-tracker/internal/utils"
+)
+
+type handler struct {
+	service Service
+	units   unitPreferences
+}
+
+// Bodyweight is stored in kilograms, requests and responses use the unit of the user
+type unitPreferences interface {
+	GetWeightUnit(ctx context.Context, userId string) (string, error)
+}
+
+type bodyMetricRequest struct {
+	MeasuredOn        string        `json:"measured_on"`
+	Bodyweight        *float64      `json:"bodyweight"`
+	BodyFatPercentage *float64      `json:"body_fat_percentage"`
+	Measurements      []Measurement `json:"measurements"`
+}
+
+func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
+	handler := handler{
+		service: NewServiceFromDatabase(s),
+		units:   preferences.NewServiceFromDatabase(s),
+	}
+
+	mux.Handle("GET /body-metrics", authenticationWrapper(http.HandlerFunc(handler.getBodyMetricsHandler)))
+	mux.Handle("GET /body-metrics/trend", authenticationWrapper(http.HandlerFunc(handler.getTrendHandler)))
+	mux.Handle("GET /body-metrics/{id}", authenticationWrapper(http.HandlerFunc(handler.getBodyMetricByIdHandler)))
+	mux.Handle("POST /body-metrics", authenticationWrapper(http.HandlerFunc(handler.createBodyMetricHandler)))
+	mux.Handle("PUT /body-metrics/{id}", authenticationWrapper(http.HandlerFunc(handler.updateBodyMetricByIdHandler)))
+	mux.Handle("DELETE /body-metrics/{id}", authenticationWrapper(http.HandlerFunc(handler.deleteBodyMetricByIdHandler)))
+}
+
+// NewServiceFromDatabase wires the body metrics service from the database service
+func NewServiceFromDatabase(s database.Service) Service {
+	return NewService(NewBodyMetricsRepository(s.GetRepository()),
+		func(ctx context.Context, fn func(BodyMetricsRepository) error) error {
+			return s.WithTx(ctx, func(q repository.Querier) error {
+				return fn(NewBodyMetricsRepository(q))
+			})
+		},
+	)
+}
+
+// convertBodyweight converts a bodyweight, when known, with the conversion
+func convertBodyweight(bodyweight *float64, convert func(float64, string) float64, unit string) *float64 {
+	if bodyweight == nil {
+		return nil
+	}
+	converted := convert(*bodyweight, unit)
+	return &converted
+}
+
+// Number of days of body metrics returned when no range is given
+const defaultDays = 90
+
+// Upper limit for the moving average window
+const maxTrendWindow = 90
+
+// parseRange reads the from and to dates, both inclusive, from the query. A
+// missing to is today and a missing from is the default number of days before to.
+func parseRange(r *http.Request) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	var err error
+	if v := r.URL.Query().Get("to"); v != "" {
+		to, err = time.Parse(time.DateOnly, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	from := to.AddDate(0, 0, -(defaultDays - 1))
+	if v := r.URL.Query().Get("from"); v != "" {
+		from, err = time.Parse(time.DateOnly, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	return from, to, nil
+}
+
+func (h *handler) getBodyMetricsHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	from, to, err := parseRange(r)
+	if err != nil {
+		http.Error(w, "Invalid date range", http.StatusBadRequest)
+		return
+	}
+
+	metrics, err := h.service.GetBetweenDates(r.Context(), from, to, userId)
+	if err != nil {
+		slog.Warn("Failed to get body metrics", "error", err)
+		http.Error(w, "Failed to get body metrics", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get body metrics", http.StatusBadRequest)
+		return
+	}
+	for i, v := range metrics {
+		metrics[i].Bodyweight = convertBodyweight(v.Bodyweight, units.FromKilograms, unit)
+	}
+
+	jsonResp, err := utils.CreateResponse(metrics)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) getTrendHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	from, to, err := parseRange(r)
+	if err != nil {
+		http.Error(w, "Invalid date range", http.StatusBadRequest)
+		return
+	}
+
+	window := DefaultTrendWindow
+	if v := r.URL.Query().Get("window"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 || parsed > maxTrendWindow {
+			http.Error(w, "Invalid window", http.StatusBadRequest)
+			return
+		}
+		window = parsed
+	}
+
+	trend, err := h.service.GetTrend(r.Context(), from, to, window, userId)
+	if err != nil {
+		slog.Warn("Failed to get body metrics trend", "error", err)
+		http.Error(w, "Failed to get body metrics trend", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get body metrics trend", http.StatusBadRequest)
+		return
+	}
+	for i, v := range trend {
+		trend[i].Bodyweight = convertBodyweight(v.Bodyweight, units.FromKilograms, unit)
+		trend[i].BodyweightAverage = convertBodyweight(v.BodyweightAverage, units.FromKilograms, unit)
+	}
+
+	jsonResp, err := utils.CreateResponse(trend)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) getBodyMetricByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	id := r.PathValue("id")
+
+	metric, err := h.service.GetById(r.Context(), id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		slog.Warn("Failed to get body metric", "error", err, "bodyMetricId", id)
+		http.Error(w, "Failed to get body metric", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get body metric", http.StatusBadRequest)
+		return
+	}
+	metric.Bodyweight = convertBodyweight(metric.Bodyweight, units.FromKilograms, unit)
+
+	jsonResp, err := utils.CreateResponse(metric)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) createBodyMetricHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	var req bodyMetricRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to create body metric", http.StatusBadRequest)
+		return
+	}
+	req.Bodyweight = convertBodyweight(req.Bodyweight, units.ToKilograms, unit)
+
+	id, err := h.service.CreateAndReturnId(r.Context(), req, userId)
+	if err != nil {
+		slog.Warn("Failed to create body metric", "error", err)
+		http.Error(w, "Failed to create body metric", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	jsonResp, err := utils.CreateIdResponse(id)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) updateBodyMetricByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	id := r.PathValue("id")
+
+	var req bodyMetricRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to update body metric", http.StatusBadRequest)
+		return
+	}
+	req.Bodyweight = convertBodyweight(req.Bodyweight, units.ToKilograms, unit)
+
+	err = h.service.UpdateById(r.Context(), id, req, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		slog.Warn("Failed to update body metric", "error", err, "bodyMetricId", id)
+		http.Error(w, "Failed to update body metric", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) deleteBodyMetricByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	id := r.PathValue("id")
+
+	err := h.service.DeleteById(r.Context(), id, userId)
+	if err != nil {
+		slog.Error("Failed to delete body metric", "error", err, "bodyMetricId", id)
+		http.Error(w, "Failed to delete body metric", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,255 @@
+package bodymetrics
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"weight-tracker/internal/units"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func populateContextWithSub(req *http.Request, userId string) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, "sub", userId)
+	return req.WithContext(ctx)
+}
+
+type unitsStub struct {
+	unit string
+}
+
+func (u unitsStub) GetWeightUnit(ctx context.Context, userId string) (string, error) {
+	return u.unit, nil
+}
+
+type serviceMock struct {
+	mock.Mock
+}
+
+func (s *serviceMock) GetBetweenDates(ctx context.Context, from time.Time, to time.Time, userId string) ([]BodyMetric, error) {
+	args := s.Called(ctx, from, to, userId)
+	return args.Get(0).([]BodyMetric), args.Error(1)
+}
+
+func (s *serviceMock) GetById(ctx context.Context, bodyMetricId string, userId string) (BodyMetric, error) {
+	args := s.Called(ctx, bodyMetricId, userId)
+	return args.Get(0).(BodyMetric), args.Error(1)
+}
+
+func (s *serviceMock) CreateAndReturnId(ctx context.Context, t bodyMetricRequest, userId string) (string, error) {
+	args := s.Called(ctx, t, userId)
+	return args.String(0), args.Error(1)
+}
+
+func (s *serviceMock) UpdateById(ctx context.Context, bodyMetricId string, t bodyMetricRequest, userId string) error {
+	args := s.Called(ctx, bodyMetricId, t, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) DeleteById(ctx context.Context, bodyMetricId string, userId string) error {
+	args := s.Called(ctx, bodyMetricId, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) GetTrend(ctx context.Context, from time.Time, to time.Time, window int, userId string) ([]TrendPoint, error) {
+	args := s.Called(ctx, from, to, window, userId)
+	return args.Get(0).([]TrendPoint), args.Error(1)
+}
+
+func (s *serviceMock) OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error {
+	args := s.Called(ctx, workoutId, userId)
+	return args.Error(0)
+}
+
+func TestGetBodyMetricsHandler(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/body-metrics?from=2025-01-01&to=2025-01-31", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetBetweenDates", req.Context(), from, to, userId).Return([]BodyMetric{
+		{ID: "a", MeasuredOn: "2025-01-05", Bodyweight: ptr(82.5), Measurements: []Measurement{{Site: "waist", Centimeters: 84}}, CreatedOn: "c", UpdatedOn: "u"},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.getBodyMetricsHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":[{"id":"a","measured_on":"2025-01-05","bodyweight":82.5,"measurements":[{"site":"waist","centimeters":84}],"created_on":"c","updated_on":"u"}]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetBodyMetricByIdHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/body-metrics/metricId", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", "metricId")
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetById", req.Context(), "metricId", userId).Return(BodyMetric{
+		ID: "metricId", MeasuredOn: "2025-01-05", Bodyweight: ptr(90.718474), Measurements: []Measurement{}, CreatedOn: "c", UpdatedOn: "u",
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(h.getBodyMetricByIdHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":{"id":"metricId","measured_on":"2025-01-05","bodyweight":200,"measurements":[],"created_on":"c","updated_on":"u"}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetTrendHandler(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/body-metrics/trend?from=2025-01-01&to=2025-01-31&window=14", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetTrend", req.Context(), from, to, 14, userId).Return([]TrendPoint{
+		{Date: "2025-01-05", Bodyweight: ptr(82), BodyweightAverage: ptr(81.5)},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.getTrendHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":[{"date":"2025-01-05","bodyweight":82,"bodyweight_average":81.5}]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetTrendHandlerInvalidWindow(t *testing.T) {
+	req, err := http.NewRequest("GET", "/body-metrics/trend?window=0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	serviceMock := serviceMock{}
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.getTrendHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+	serviceMock.AssertNotCalled(t, "GetTrend", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateBodyMetricHandler(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("POST", "/body-metrics", bytes.NewBufferString(`{"measured_on":"2025-01-05","bodyweight":82.5}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("CreateAndReturnId", req.Context(), mock.MatchedBy(func(input bodyMetricRequest) bool {
+		return input.MeasuredOn == "2025-01-05" && *input.Bodyweight == 82.5
+	}), userId).Return("metricId", nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.createBodyMetricHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	expected := `{"id":"metricId"}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestCreateBodyMetricHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("POST", "/body-metrics", bytes.NewBufferString(`{"measured_on":"2025-01-05","bodyweight":200}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("CreateAndReturnId", req.Context(), mock.MatchedBy(func(input bodyMetricRequest) bool {
+		return units.FromKilograms(*input.Bodyweight, units.Kilograms) == 90.72
+	}), userId).Return("metricId", nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(h.createBodyMetricHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestUpdateBodyMetricByIdHandlerNotFound(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("PUT", "/body-metrics/metricId", bytes.NewBufferString(`{"bodyweight":82.5}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", "metricId")
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("UpdateById", req.Context(), "metricId", mock.Anything, userId).Return(sql.ErrNoRows).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.updateBodyMetricByIdHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+	serviceMock.AssertExpectations(t)
+}
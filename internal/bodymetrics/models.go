@@ -0,0 +1,53 @@
+package bodymetrics
+
+import (
+	"fmt"
+	"slices"
+)
+
+var SiteNames = []string{
+	"neck", "shoulders", "chest", "waist", "hips",
+	"left_arm", "right_arm", "left_forearm", "right_forearm",
+	"left_thigh", "right_thigh", "left_calf", "right_calf",
+}
+
+// BodyMetric is one weigh-in, bodyweight is stored in kilograms and
+// measurements in centimeters. Every value is optional on its own.
+type BodyMetric struct {
+	ID                string        `json:"id"`
+	MeasuredOn        string        `json:"measured_on"`
+	Bodyweight        *float64      `json:"bodyweight,omitempty"`
+	BodyFatPercentage *float64      `json:"body_fat_percentage,omitempty"`
+	Measurements      []Measurement `json:"measurements"`
+	CreatedOn         string        `json:"created_on"`
+	UpdatedOn         string        `json:"updated_on"`
+}
+
+// Measurement is a tape measurement of one site of the body
+type Measurement struct {
+	Site        string  `json:"site"`
+	Centimeters float64 `json:"centimeters"`
+}
+
+// BodyMeasurement is a measurement together with the body metric it was taken with
+type BodyMeasurement struct {
+	BodyMetricID string
+	Measurement
+}
+
+func validateMeasurements(measurements []Measurement) error {
+	seen := map[string]bool{}
+	for _, v := range measurements {
+		if !slices.Contains(SiteNames, v.Site) {
+			return fmt.Errorf("unknown measurement site: %s", v.Site)
+		}
+		if v.Centimeters <= 0 {
+			return fmt.Errorf("measurement of %s must be positive", v.Site)
+		}
+		if seen[v.Site] {
+			return fmt.Errorf("measurement site %s added more than once", v.Site)
+		}
+		seen[v.Site] = true
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package bodymetrics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
+)
+
+type BodyMetricsRepository interface {
+	GetBetweenDates(ctx context.Context, arg repository.GetBodyMetricsBetweenDatesParams) ([]BodyMetric, error)
+	GetById(ctx context.Context, arg repository.GetBodyMetricByIdParams) (BodyMetric, error)
+	CreateAndReturnId(ctx context.Context, arg repository.CreateBodyMetricAndReturnIdParams) (string, error)
+	UpdateById(ctx context.Context, arg repository.UpdateBodyMetricParams) error
+	DeleteById(ctx context.Context, bodyMetricId string, userId string) error
+	GetMeasurementsBetweenDates(ctx context.Context, arg repository.GetBodyMeasurementsBetweenDatesParams) ([]BodyMeasurement, error)
+	GetMeasurementsByBodyMetricId(ctx context.Context, bodyMetricId string, userId string) ([]Measurement, error)
+	CreateMeasurementAndReturnId(ctx context.Context, arg repository.CreateBodyMeasurementAndReturnIdParams) (string, error)
+	DeleteMeasurementsByBodyMetricId(ctx context.Context, bodyMetricId string, userId string) error
+	SetWorkoutBodyweight(ctx context.Context, arg repository.SetWorkoutBodyweightFromBodyMetricsParams) error
+}
+
+func NewBodyMetricsRepository(repo repository.Querier) BodyMetricsRepository {
+	return bodyMetricsRepository{repo: repo}
+}
+
+type bodyMetricsRepository struct {
+	repo repository.Querier
+}
+
+func (b bodyMetricsRepository) GetBetweenDates(ctx context.Context, arg repository.GetBodyMetricsBetweenDatesParams) ([]BodyMetric, error) {
+	metrics, err := b.repo.GetBodyMetricsBetweenDates(ctx, arg)
+	if err != nil {
+		return []BodyMetric{}, fmt.Errorf("failed to get body metrics: %w", err)
+	}
+
+	result := []BodyMetric{}
+	for _, v := range metrics {
+		result = append(result, newBodyMetric(v))
+	}
+	return result, nil
+}
+
+func (b bodyMetricsRepository) GetById(ctx context.Context, arg repository.GetBodyMetricByIdParams) (BodyMetric, error) {
+	metric, err := b.repo.GetBodyMetricById(ctx, arg)
+	if err != nil {
+		return BodyMetric{}, fmt.Errorf("failed to get body metric by id: %w", err)
+	}
+	return newBodyMetric(metric), nil
+}
+
+func (b bodyMetricsRepository) CreateAndReturnId(ctx context.Context, arg repository.CreateBodyMetricAndReturnIdParams) (string, error) {
+	id, err := b.repo.CreateBodyMetricAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create body metric: %w", err)
+	}
+	return id, nil
+}
+
+func (b bodyMetricsRepository) UpdateById(ctx context.Context, arg repository.UpdateBodyMetricParams) error {
+	rows, err := b.repo.UpdateBodyMetric(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update body metric: %w", err)
+	}
+
+	if rows == 0 {
+		slog.Warn("Tried to update body metric that did not exist", "bodyMetricId", arg.ID)
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (b bodyMetricsRepository) DeleteById(ctx context.Context, bodyMetricId string, userId string) error {
+	err := b.DeleteMeasurementsByBodyMetricId(ctx, bodyMetricId, userId)
+	if err != nil {
+		return err
+	}
+
+	rows, err := b.repo.DeleteBodyMetricById(ctx, repository.DeleteBodyMetricByIdParams{
+		ID:     bodyMetricId,
+		UserID: userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete body metric: %w", err)
+	}
+
+	if rows == 0 {
+		slog.Warn("Tried to delete body metric that did not exist", "bodyMetricId", bodyMetricId)
+	}
+	return nil
+}
+
+func (b bodyMetricsRepository) GetMeasurementsBetweenDates(ctx context.Context, arg repository.GetBodyMeasurementsBetweenDatesParams) ([]BodyMeasurement, error) {
+	measurements, err := b.repo.GetBodyMeasurementsBetweenDates(ctx, arg)
+	if err != nil {
+		return []BodyMeasurement{}, fmt.Errorf("failed to get body measurements: %w", err)
+	}
+
+	result := []BodyMeasurement{}
+	for _, v := range measurements {
+		result = append(result, BodyMeasurement{
+			BodyMetricID: v.BodyMetricID,
+			Measurement:  Measurement{Site: v.Site, Centimeters: v.Centimeters},
+		})
+	}
+	return result, nil
+}
+
+func (b bodyMetricsRepository) GetMeasurementsByBodyMetricId(ctx context.Context, bodyMetricId string, userId string) ([]Measurement, error) {
+	measurements, err := b.repo.GetBodyMeasurementsByBodyMetricId(ctx, repository.GetBodyMeasurementsByBodyMetricIdParams{
+		BodyMetricID: bodyMetricId,
+		UserID:       userId,
+	})
+	if err != nil {
+		return []Measurement{}, fmt.Errorf("failed to get body measurements by body metric id: %w", err)
+	}
+
+	result := []Measurement{}
+	for _, v := range measurements {
+		result = append(result, Measurement{Site: v.Site, Centimeters: v.Centimeters})
+	}
+	return result, nil
+}
+
+func (b bodyMetricsRepository) CreateMeasurementAndReturnId(ctx context.Context, arg repository.CreateBodyMeasurementAndReturnIdParams) (string, error) {
+	id, err := b.repo.CreateBodyMeasurementAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create body measurement: %w", err)
+	}
+	return id, nil
+}
+
+func (b bodyMetricsRepository) DeleteMeasurementsByBodyMetricId(ctx context.Context, bodyMetricId string, userId string) error {
+	_, err := b.repo.DeleteBodyMeasurementsByBodyMetricId(ctx, repository.DeleteBodyMeasurementsByBodyMetricIdParams{
+		BodyMetricID: bodyMetricId,
+		UserID:       userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete body measurements: %w", err)
+	}
+	return nil
+}
+
+func (b bodyMetricsRepository) SetWorkoutBodyweight(ctx context.Context, arg repository.SetWorkoutBodyweightFromBodyMetricsParams) error {
+	_, err := b.repo.SetWorkoutBodyweightFromBodyMetrics(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to set workout bodyweight: %w", err)
+	}
+	return nil
+}
+
+func newBodyMetric(v repository.BodyMetric) BodyMetric {
+	return BodyMetric{
+		ID:                v.ID,
+		MeasuredOn:        v.MeasuredOn,
+		Bodyweight:        utils.NullableFloat(v.Bodyweight),
+		BodyFatPercentage: utils.NullableFloat(v.BodyFatPercentage),
+		Measurements:      []Measurement{},
+		CreatedOn:         v.CreatedOn,
+		UpdatedOn:         v.UpdatedOn,
+	}
+}
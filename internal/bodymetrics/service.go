@@ -0,0 +1,254 @@
+package bodymetrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"weight-tracker/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	GetBetweenDates(ctx context.Context, from time.Time, to time.Time, userId string) ([]BodyMetric, error)
+	GetById(ctx context.Context, bodyMetricId string, userId string) (BodyMetric, error)
+	CreateAndReturnId(ctx context.Context, t bodyMetricRequest, userId string) (string, error)
+	UpdateById(ctx context.Context, bodyMetricId string, t bodyMetricRequest, userId string) error
+	DeleteById(ctx context.Context, bodyMetricId string, userId string) error
+	GetTrend(ctx context.Context, from time.Time, to time.Time, window int, userId string) ([]TrendPoint, error)
+	OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error
+}
+
+type bodyMetricsService struct {
+	repo   BodyMetricsRepository
+	withTx Transactor
+}
+
+// Transactor runs fn with a repository whose writes are committed together
+type Transactor func(ctx context.Context, fn func(BodyMetricsRepository) error) error
+
+func NewService(repo BodyMetricsRepository, withTx Transactor) Service {
+	return &bodyMetricsService{repo: repo, withTx: withTx}
+}
+
+func (s *bodyMetricsService) GetBetweenDates(ctx context.Context, from time.Time, to time.Time, userId string) ([]BodyMetric, error) {
+	if to.Before(from) {
+		return []BodyMetric{}, fmt.Errorf("from must not be after to")
+	}
+
+	metrics, err := s.repo.GetBetweenDates(ctx, repository.GetBodyMetricsBetweenDatesParams{
+		UserID:    userId,
+		StartDate: from.Format(time.DateOnly),
+		EndDate:   to.Format(time.DateOnly),
+	})
+	if err != nil {
+		return []BodyMetric{}, err
+	}
+
+	measurements, err := s.repo.GetMeasurementsBetweenDates(ctx, repository.GetBodyMeasurementsBetweenDatesParams{
+		UserID:    userId,
+		StartDate: from.Format(time.DateOnly),
+		EndDate:   to.Format(time.DateOnly),
+	})
+	if err != nil {
+		return []BodyMetric{}, err
+	}
+
+	byBodyMetric := map[string][]Measurement{}
+	for _, v := range measurements {
+		byBodyMetric[v.BodyMetricID] = append(byBodyMetric[v.BodyMetricID], v.Measurement)
+	}
+	for i, v := range metrics {
+		if m, ok := byBodyMetric[v.ID]; ok {
+			metrics[i].Measurements = m
+		}
+	}
+	return metrics, nil
+}
+
+func (s *bodyMetricsService) GetById(ctx context.Context, bodyMetricId string, userId string) (BodyMetric, error) {
+	metric, err := s.repo.GetById(ctx, repository.GetBodyMetricByIdParams{
+		ID:     bodyMetricId,
+		UserID: userId,
+	})
+	if err != nil {
+		return BodyMetric{}, err
+	}
+
+	measurements, err := s.repo.GetMeasurementsByBodyMetricId(ctx, bodyMetricId, userId)
+	if err != nil {
+		return BodyMetric{}, err
+	}
+	metric.Measurements = measurements
+	return metric, nil
+}
+
+func (s *bodyMetricsService) CreateAndReturnId(ctx context.Context, t bodyMetricRequest, userId string) (string, error) {
+	measuredOn, err := validateBodyMetricRequest(t)
+	if err != nil {
+		return "", err
+	}
+
+	uuid, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	bodyweight, bodyFat := nullableValues(t)
+	now := time.Now().UTC().Format(time.RFC3339)
+	var id string
+	err = s.withTx(ctx, func(repo BodyMetricsRepository) error {
+		var err error
+		id, err = repo.CreateAndReturnId(ctx, repository.CreateBodyMetricAndReturnIdParams{
+			ID:                uuid.String(),
+			MeasuredOn:        measuredOn,
+			Bodyweight:        bodyweight,
+			BodyFatPercentage: bodyFat,
+			CreatedOn:         now,
+			UpdatedOn:         now,
+			UserID:            userId,
+		})
+		if err != nil {
+			return err
+		}
+
+		return createMeasurements(ctx, repo, id, t.Measurements, userId)
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// UpdateById replaces the body metric, measurements left out of the request are removed
+func (s *bodyMetricsService) UpdateById(ctx context.Context, bodyMetricId string, t bodyMetricRequest, userId string) error {
+	measuredOn, err := validateBodyMetricRequest(t)
+	if err != nil {
+		return err
+	}
+
+	// The measurements are replaced in the same transaction, a failure keeps the previous ones
+	bodyweight, bodyFat := nullableValues(t)
+	return s.withTx(ctx, func(repo BodyMetricsRepository) error {
+		err := repo.UpdateById(ctx, repository.UpdateBodyMetricParams{
+			MeasuredOn:        measuredOn,
+			Bodyweight:        bodyweight,
+			BodyFatPercentage: bodyFat,
+			UpdatedOn:         time.Now().UTC().Format(time.RFC3339),
+			ID:                bodyMetricId,
+			UserID:            userId,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = repo.DeleteMeasurementsByBodyMetricId(ctx, bodyMetricId, userId)
+		if err != nil {
+			return err
+		}
+		return createMeasurements(ctx, repo, bodyMetricId, t.Measurements, userId)
+	})
+}
+
+// DeleteById deletes the body metric together with its measurements
+func (s *bodyMetricsService) DeleteById(ctx context.Context, bodyMetricId string, userId string) error {
+	return s.withTx(ctx, func(repo BodyMetricsRepository) error {
+		return repo.DeleteById(ctx, bodyMetricId, userId)
+	})
+}
+
+func (s *bodyMetricsService) GetTrend(ctx context.Context, from time.Time, to time.Time, window int, userId string) ([]TrendPoint, error) {
+	if to.Before(from) {
+		return []TrendPoint{}, fmt.Errorf("from must not be after to")
+	}
+	if window <= 0 {
+		return []TrendPoint{}, fmt.Errorf("window must be positive")
+	}
+
+	// The first days of the range need the days before it to fill their window
+	metrics, err := s.repo.GetBetweenDates(ctx, repository.GetBodyMetricsBetweenDatesParams{
+		UserID:    userId,
+		StartDate: from.AddDate(0, 0, -(window - 1)).Format(time.DateOnly),
+		EndDate:   to.Format(time.DateOnly),
+	})
+	if err != nil {
+		return []TrendPoint{}, err
+	}
+
+	return Trend(metrics, from, window), nil
+}
+
+// OnWorkoutCompleted links the latest logged bodyweight to the workout, so
+// bodyweight exercises count the weight that was carried at the time. A
+// bodyweight that was already set on the workout is kept.
+func (s *bodyMetricsService) OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error {
+	return s.repo.SetWorkoutBodyweight(ctx, repository.SetWorkoutBodyweightFromBodyMetricsParams{
+		UpdatedOn: time.Now().UTC().Format(time.RFC3339),
+		ID:        workoutId,
+		UserID:    userId,
+	})
+}
+
+func createMeasurements(ctx context.Context, repo BodyMetricsRepository, bodyMetricId string, measurements []Measurement, userId string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, v := range measurements {
+		uuid, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("failed to generate UUID: %w", err)
+		}
+
+		_, err = repo.CreateMeasurementAndReturnId(ctx, repository.CreateBodyMeasurementAndReturnIdParams{
+			ID:           uuid.String(),
+			Site:         v.Site,
+			Centimeters:  v.Centimeters,
+			CreatedOn:    now,
+			UpdatedOn:    now,
+			UserID:       userId,
+			BodyMetricID: bodyMetricId,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateBodyMetricRequest returns the date the metric was measured on,
+// metrics without a date are measured today
+func validateBodyMetricRequest(t bodyMetricRequest) (string, error) {
+	measuredOn := time.Now().UTC().Format(time.DateOnly)
+	if t.MeasuredOn != "" {
+		date, err := time.Parse(time.DateOnly, t.MeasuredOn)
+		if err != nil {
+			return "", fmt.Errorf("invalid measured on date: %w", err)
+		}
+		measuredOn = date.Format(time.DateOnly)
+	}
+
+	if t.Bodyweight == nil && t.BodyFatPercentage == nil && len(t.Measurements) == 0 {
+		return "", fmt.Errorf("bodyweight, body fat percentage or measurements are required")
+	}
+	if t.Bodyweight != nil && *t.Bodyweight <= 0 {
+		return "", fmt.Errorf("bodyweight must be positive")
+	}
+	if t.BodyFatPercentage != nil && (*t.BodyFatPercentage <= 0 || *t.BodyFatPercentage >= 100) {
+		return "", fmt.Errorf("body fat percentage must be between 0 and 100")
+	}
+
+	err := validateMeasurements(t.Measurements)
+	if err != nil {
+		return "", err
+	}
+	return measuredOn, nil
+}
+
+func nullableValues(t bodyMetricRequest) (interface{}, interface{}) {
+	var bodyweight, bodyFat interface{}
+	if t.Bodyweight != nil {
+		bodyweight = *t.Bodyweight
+	}
+	if t.BodyFatPercentage != nil {
+		bodyFat = *t.BodyFatPercentage
+	}
+	return bodyweight, bodyFat
+}
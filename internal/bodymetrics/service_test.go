@@ -0,0 +1,241 @@
+package bodymetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"weight-tracker/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type repoMock struct {
+	mock.Mock
+}
+
+func (r *repoMock) GetBetweenDates(ctx context.Context, arg repository.GetBodyMetricsBetweenDatesParams) ([]BodyMetric, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]BodyMetric), args.Error(1)
+}
+
+func (r *repoMock) GetById(ctx context.Context, arg repository.GetBodyMetricByIdParams) (BodyMetric, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(BodyMetric), args.Error(1)
+}
+
+func (r *repoMock) CreateAndReturnId(ctx context.Context, arg repository.CreateBodyMetricAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) UpdateById(ctx context.Context, arg repository.UpdateBodyMetricParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *repoMock) DeleteById(ctx context.Context, bodyMetricId string, userId string) error {
+	args := r.Called(ctx, bodyMetricId, userId)
+	return args.Error(0)
+}
+
+func (r *repoMock) GetMeasurementsBetweenDates(ctx context.Context, arg repository.GetBodyMeasurementsBetweenDatesParams) ([]BodyMeasurement, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]BodyMeasurement), args.Error(1)
+}
+
+func (r *repoMock) GetMeasurementsByBodyMetricId(ctx context.Context, bodyMetricId string, userId string) ([]Measurement, error) {
+	args := r.Called(ctx, bodyMetricId, userId)
+	return args.Get(0).([]Measurement), args.Error(1)
+}
+
+func (r *repoMock) CreateMeasurementAndReturnId(ctx context.Context, arg repository.CreateBodyMeasurementAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) DeleteMeasurementsByBodyMetricId(ctx context.Context, bodyMetricId string, userId string) error {
+	args := r.Called(ctx, bodyMetricId, userId)
+	return args.Error(0)
+}
+
+func (r *repoMock) SetWorkoutBodyweight(ctx context.Context, arg repository.SetWorkoutBodyweightFromBodyMetricsParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+// transactorStub runs fn on the repository it holds and counts the transactions
+type transactorStub struct {
+	repo BodyMetricsRepository
+	runs int
+}
+
+func (s *transactorStub) withTx(ctx context.Context, fn func(BodyMetricsRepository) error) error {
+	s.runs++
+	return fn(s.repo)
+}
+
+var testError = errors.New("Testerror")
+
+func TestCreateAndReturnId(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateBodyMetricAndReturnIdParams) bool {
+		return input.MeasuredOn == "2025-01-05" && input.Bodyweight == 82.5 && input.BodyFatPercentage == nil && input.UserID == "userId"
+	})).Return("metricId", nil).Once()
+	repoMock.On("CreateMeasurementAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateBodyMeasurementAndReturnIdParams) bool {
+		return input.Site == "waist" && input.Centimeters == 84 && input.BodyMetricID == "metricId"
+	})).Return("measurementId", nil).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, transactor.withTx)
+	id, err := service.CreateAndReturnId(ctx, bodyMetricRequest{
+		MeasuredOn:   "2025-01-05",
+		Bodyweight:   ptr(82.5),
+		Measurements: []Measurement{{Site: "waist", Centimeters: 84}},
+	}, "userId")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "metricId", id)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateAndReturnIdInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	service := NewService(&repoMock, nil)
+
+	for _, request := range []bodyMetricRequest{
+		{},
+		{MeasuredOn: "05-01-2025", Bodyweight: ptr(80)},
+		{Bodyweight: ptr(-80)},
+		{BodyFatPercentage: ptr(100)},
+		{Measurements: []Measurement{{Site: "ankle", Centimeters: 20}}},
+		{Measurements: []Measurement{{Site: "waist", Centimeters: 0}}},
+		{Measurements: []Measurement{{Site: "waist", Centimeters: 80}, {Site: "waist", Centimeters: 81}}},
+	} {
+		_, err := service.CreateAndReturnId(ctx, request, "userId")
+		assert.NotNil(t, err)
+	}
+
+	repoMock.AssertNotCalled(t, "CreateAndReturnId", mock.Anything, mock.Anything)
+}
+
+func TestUpdateByIdReplacesMeasurements(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("UpdateById", ctx, mock.MatchedBy(func(input repository.UpdateBodyMetricParams) bool {
+		return input.ID == "metricId" && input.Bodyweight == nil && input.BodyFatPercentage == 15.5
+	})).Return(nil).Once()
+	repoMock.On("DeleteMeasurementsByBodyMetricId", ctx, "metricId", "userId").Return(nil).Once()
+	repoMock.On("CreateMeasurementAndReturnId", ctx, mock.Anything).Return("measurementId", nil).Twice()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, transactor.withTx)
+	err := service.UpdateById(ctx, "metricId", bodyMetricRequest{
+		BodyFatPercentage: ptr(15.5),
+		Measurements:      []Measurement{{Site: "waist", Centimeters: 84}, {Site: "hips", Centimeters: 98}},
+	}, "userId")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateByIdInOneTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("UpdateById", ctx, mock.Anything).Return(nil).Once()
+	repoMock.On("DeleteMeasurementsByBodyMetricId", ctx, "metricId", "userId").Return(nil).Once()
+	repoMock.On("CreateMeasurementAndReturnId", ctx, mock.Anything).Return("", testError).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, transactor.withTx)
+	err := service.UpdateById(ctx, "metricId", bodyMetricRequest{
+		Bodyweight:   ptr(80),
+		Measurements: []Measurement{{Site: "waist", Centimeters: 84}},
+	}, "userId")
+
+	// The error is returned from the transaction, so the previous measurements are rolled back
+	assert.ErrorIs(t, err, testError)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestDeleteById(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("DeleteById", ctx, "metricId", "userId").Return(nil).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, transactor.withTx)
+	err := service.DeleteById(ctx, "metricId", "userId")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetBetweenDates(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	repoMock := repoMock{}
+	repoMock.On("GetBetweenDates", ctx, repository.GetBodyMetricsBetweenDatesParams{UserID: "userId", StartDate: "2025-01-01", EndDate: "2025-01-31"}).
+		Return([]BodyMetric{{ID: "a", Measurements: []Measurement{}}, {ID: "b", Measurements: []Measurement{}}}, nil).Once()
+	repoMock.On("GetMeasurementsBetweenDates", ctx, repository.GetBodyMeasurementsBetweenDatesParams{UserID: "userId", StartDate: "2025-01-01", EndDate: "2025-01-31"}).
+		Return([]BodyMeasurement{{BodyMetricID: "b", Measurement: Measurement{Site: "waist", Centimeters: 84}}}, nil).Once()
+
+	service := NewService(&repoMock, nil)
+	result, err := service.GetBetweenDates(ctx, from, to, "userId")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []BodyMetric{
+		{ID: "a", Measurements: []Measurement{}},
+		{ID: "b", Measurements: []Measurement{{Site: "waist", Centimeters: 84}}},
+	}, result)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetTrendIncludesDaysBeforeRange(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	repoMock := repoMock{}
+	repoMock.On("GetBetweenDates", ctx, repository.GetBodyMetricsBetweenDatesParams{UserID: "userId", StartDate: "2025-01-04", EndDate: "2025-01-31"}).
+		Return([]BodyMetric{
+			{MeasuredOn: "2025-01-04", Bodyweight: ptr(80)},
+			{MeasuredOn: "2025-01-10", Bodyweight: ptr(81)},
+		}, nil).Once()
+
+	service := NewService(&repoMock, nil)
+	result, err := service.GetTrend(ctx, from, to, 7, "userId")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []TrendPoint{{Date: "2025-01-10", Bodyweight: ptr(81), BodyweightAverage: ptr(80.5)}}, result)
+	repoMock.AssertExpectations(t)
+}
+
+func TestOnWorkoutCompleted(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("SetWorkoutBodyweight", ctx, mock.MatchedBy(func(input repository.SetWorkoutBodyweightFromBodyMetricsParams) bool {
+		return input.ID == "workoutId" && input.UserID == "userId" && input.UpdatedOn != ""
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock, nil)
+	err := service.OnWorkoutCompleted(ctx, "workoutId", "userId")
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
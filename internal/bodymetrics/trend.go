@@ -0,0 +1,94 @@
+package bodymetrics
+
+import (
+	"math"
+	"time"
+)
+
+// Number of days in the moving average when no window is given
+const DefaultTrendWindow = 7
+
+// TrendPoint is the daily value of a day with a weigh-in together with the
+// moving average over the window ending on that day. Days with several
+// weigh-ins use the average of the day.
+type TrendPoint struct {
+	Date                     string   `json:"date"`
+	Bodyweight               *float64 `json:"bodyweight,omitempty"`
+	BodyweightAverage        *float64 `json:"bodyweight_average,omitempty"`
+	BodyFatPercentage        *float64 `json:"body_fat_percentage,omitempty"`
+	BodyFatPercentageAverage *float64 `json:"body_fat_percentage_average,omitempty"`
+}
+
+type daily struct {
+	sum   float64
+	count int
+}
+
+func (d daily) average() float64 {
+	return d.sum / float64(d.count)
+}
+
+// Trend smooths the metrics with a moving average over the given number of
+// calendar days. Only days from the from date onwards are returned, metrics
+// before it are used to fill the first windows. Metrics are expected to be
+// ordered by date.
+func Trend(metrics []BodyMetric, from time.Time, window int) []TrendPoint {
+	dates := []string{}
+	bodyweight := map[string]daily{}
+	bodyFat := map[string]daily{}
+	for _, v := range metrics {
+		if v.Bodyweight == nil && v.BodyFatPercentage == nil {
+			continue
+		}
+		if len(dates) == 0 || dates[len(dates)-1] != v.MeasuredOn {
+			dates = append(dates, v.MeasuredOn)
+		}
+		if v.Bodyweight != nil {
+			d := bodyweight[v.MeasuredOn]
+			bodyweight[v.MeasuredOn] = daily{d.sum + *v.Bodyweight, d.count + 1}
+		}
+		if v.BodyFatPercentage != nil {
+			d := bodyFat[v.MeasuredOn]
+			bodyFat[v.MeasuredOn] = daily{d.sum + *v.BodyFatPercentage, d.count + 1}
+		}
+	}
+
+	result := []TrendPoint{}
+	for _, date := range dates {
+		day, err := time.Parse(time.DateOnly, date)
+		if err != nil || day.Before(from) {
+			continue
+		}
+
+		point := TrendPoint{Date: date}
+		if d, ok := bodyweight[date]; ok {
+			point.Bodyweight = rounded(d.average())
+			point.BodyweightAverage = movingAverage(bodyweight, day, window)
+		}
+		if d, ok := bodyFat[date]; ok {
+			point.BodyFatPercentage = rounded(d.average())
+			point.BodyFatPercentageAverage = movingAverage(bodyFat, day, window)
+		}
+		result = append(result, point)
+	}
+	return result
+}
+
+// movingAverage averages the daily values of the window ending on the day,
+// days without a value are left out instead of counted as zero
+func movingAverage(values map[string]daily, day time.Time, window int) *float64 {
+	sum := 0.0
+	count := 0
+	for i := 0; i < window; i++ {
+		if d, ok := values[day.AddDate(0, 0, -i).Format(time.DateOnly)]; ok {
+			sum += d.average()
+			count++
+		}
+	}
+	return rounded(sum / float64(count))
+}
+
+func rounded(v float64) *float64 {
+	result := math.Round(v*100) / 100
+	return &result
+}
@@ -0,0 +1,42 @@
+package bodymetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func TestTrend(t *testing.T) {
+	metrics := []BodyMetric{
+		{MeasuredOn: "2025-01-01", Bodyweight: ptr(80)},
+		{MeasuredOn: "2025-01-05", Bodyweight: ptr(82), BodyFatPercentage: ptr(18)},
+		{MeasuredOn: "2025-01-06", Bodyweight: ptr(81)},
+		{MeasuredOn: "2025-01-06", Bodyweight: ptr(82)},
+		{MeasuredOn: "2025-01-08", Bodyweight: ptr(79)},
+		{MeasuredOn: "2025-01-09", BodyFatPercentage: ptr(17)},
+	}
+
+	result := Trend(metrics, time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC), 7)
+
+	assert.Equal(t, []TrendPoint{
+		{Date: "2025-01-05", Bodyweight: ptr(82), BodyweightAverage: ptr(81), BodyFatPercentage: ptr(18), BodyFatPercentageAverage: ptr(18)},
+		{Date: "2025-01-06", Bodyweight: ptr(81.5), BodyweightAverage: ptr(81.17)},
+		{Date: "2025-01-08", Bodyweight: ptr(79), BodyweightAverage: ptr(80.83)},
+		{Date: "2025-01-09", BodyFatPercentage: ptr(17), BodyFatPercentageAverage: ptr(17.5)},
+	}, result)
+}
+
+func TestTrendSkipsMeasurementsOnly(t *testing.T) {
+	metrics := []BodyMetric{
+		{MeasuredOn: "2025-01-01", Measurements: []Measurement{{Site: "waist", Centimeters: 80}}},
+	}
+
+	result := Trend(metrics, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 7)
+
+	assert.Equal(t, []TrendPoint{}, result)
+}
@@ -25,6 +25,10 @@ type Service interface {
 	Close() error
 
 	GetRepository() repository.Querier
+
+	// WithTx runs fn with a repository whose queries share one transaction.
+	// The transaction is committed when fn returns nil and rolled back otherwise.
+	WithTx(ctx context.Context, fn func(repository.Querier) error) error
 }
 
 type service struct {
@@ -61,6 +65,26 @@ func (s *service) GetRepository() repository.Querier {
 	return s.repo
 }
 
+func (s *service) WithTx(ctx context.Context, fn func(repository.Querier) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rolling back after the commit does nothing, it only undoes a failed or panicking fn
+	defer tx.Rollback()
+
+	err = fn(repository.New(tx))
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // Health checks the health of the database connection by pinging the database.
 // It returns a map with keys indicating various health statistics.
 func (s *service) Health() map[string]string {
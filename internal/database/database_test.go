@@ -0,0 +1,123 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"weight-tracker/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestService opens an in-memory database with the users table. A single
+// connection is kept open, every connection would get its own database.
+func newTestService(t *testing.T) (*service, *sql.DB) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE users (
+		id text primary key,
+		username text unique not null,
+		password text not null,
+		created_on text not null,
+		updated_on text not null,
+		email text null,
+		is_verified boolean not null DEFAULT false
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &service{db: db, repo: repository.New(db)}, db
+}
+
+func createUser(ctx context.Context, q repository.Querier, id string) error {
+	_, err := q.CreateUserAndReturnId(ctx, repository.CreateUserAndReturnIdParams{
+		ID:        id,
+		Username:  id,
+		Password:  "hash",
+		CreatedOn: "2024-01-01T00:00:00Z",
+		UpdatedOn: "2024-01-01T00:00:00Z",
+	})
+	return err
+}
+
+func countUsers(t *testing.T, db *sql.DB) int {
+	var count int
+	err := db.QueryRow("SELECT count(*) FROM users").Scan(&count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestWithTxCommits(t *testing.T) {
+	ctx := context.Background()
+	s, db := newTestService(t)
+
+	err := s.WithTx(ctx, func(q repository.Querier) error {
+		err := createUser(ctx, q, "first")
+		if err != nil {
+			return err
+		}
+		return createUser(ctx, q, "second")
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, countUsers(t, db))
+}
+
+func TestWithTxRollsBackOnError(t *testing.T) {
+	ctx := context.Background()
+	s, db := newTestService(t)
+	failure := errors.New("failure")
+
+	err := s.WithTx(ctx, func(q repository.Querier) error {
+		err := createUser(ctx, q, "first")
+		if err != nil {
+			return err
+		}
+		return failure
+	})
+
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 0, countUsers(t, db))
+}
+
+func TestWithTxRollsBackOnFailedQuery(t *testing.T) {
+	ctx := context.Background()
+	s, db := newTestService(t)
+
+	// The second user breaks the unique username, the first is rolled back with it
+	err := s.WithTx(ctx, func(q repository.Querier) error {
+		err := createUser(ctx, q, "first")
+		if err != nil {
+			return err
+		}
+		return createUser(ctx, q, "first")
+	})
+
+	assert.ErrorContains(t, err, "UNIQUE constraint failed")
+	assert.Equal(t, 0, countUsers(t, db))
+}
+
+func TestWithTxRollsBackOnPanic(t *testing.T) {
+	ctx := context.Background()
+	s, db := newTestService(t)
+
+	assert.Panics(t, func() {
+		s.WithTx(ctx, func(q repository.Querier) error {
+			err := createUser(ctx, q, "first")
+			if err != nil {
+				return err
+			}
+			panic("failure")
+		})
+	})
+
+	assert.Equal(t, 0, countUsers(t, db))
+}
@@ -1,10 +1,12 @@
 package exerciseitems
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
-	"time"
 	"weight-tracker/internal/database"
 	"weight-tracker/internal/exercises"
 	"weight-tracker/internal/repository"
@@ -13,29 +15,47 @@ import (
 
 func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
 	handler := handler{
-		service: NewService(
-			NewExerciseItemRepository(s.GetRepository()),
-			exercises.NewExerciseRepository(s.GetRepository()),
-		),
+		service: NewServiceFromDatabase(s),
 	}
 
 	mux.Handle("GET /workouts/{workoutId}/exercise-items", authenticationWrapper(http.HandlerFunc(handler.getByWorkoutIdHandler)))
 	mux.Handle("GET /workouts/{workoutId}/exercise-items/{exerciseItemId}", authenticationWrapper(http.HandlerFunc(handler.getByIdHandler)))
 	mux.Handle("POST /workouts/{workoutId}/exercise-items", authenticationWrapper(http.HandlerFunc(handler.createHandler)))
+	mux.Handle("PUT /workouts/{workoutId}/exercise-items/order", authenticationWrapper(http.HandlerFunc(handler.reorderHandler)))
 	mux.Handle("PUT /workouts/{workoutId}/exercise-items/{exerciseItemId}", authenticationWrapper(http.HandlerFunc(handler.updateHandler)))
 	mux.Handle("DELETE /workouts/{workoutId}/exercise-items/{exerciseItemId}", authenticationWrapper(http.HandlerFunc(handler.deleteHandler)))
 }
 
-type handler struct {
-	service Service
+// NewServiceFromDatabase wires the exercise item service from the database service
+func NewServiceFromDatabase(s database.Service) Service {
+	return NewService(
+		NewExerciseItemRepository(s.GetRepository()),
+		exercises.NewExerciseRepository(s.GetRepository()),
+		func(ctx context.Context, fn func(ExerciseItemRepository) error) error {
+			return s.WithTx(ctx, func(q repository.Querier) error {
+				return fn(NewExerciseItemRepository(q))
+			})
+		},
+	)
 }
 
-type createExerciseItemRequest struct {
-	Type string `json:"type"`
+// NewServiceInTransaction wires the exercise item service on the querier of a
+// running transaction, its writes are committed together with those of the caller
+func NewServiceInTransaction(q repository.Querier) Service {
+	repo := NewExerciseItemRepository(q)
+	return NewService(repo, exercises.NewExerciseRepository(q),
+		func(ctx context.Context, fn func(ExerciseItemRepository) error) error {
+			return fn(repo)
+		},
+	)
 }
 
-type updateExerciseItemRequest struct {
-	Type string `json:"type"`
+type handler struct {
+	service Service
+}
+
+type reorderRequest struct {
+	IDs []string `json:"ids"`
 }
 
 func (h *handler) getByWorkoutIdHandler(w http.ResponseWriter, r *http.Request) {
@@ -90,14 +110,14 @@ func (h *handler) createHandler(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value("sub").(string)
 	workoutId := r.PathValue("workoutId")
 
-	var req createExerciseItemRequest
+	var req Settings
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		slog.Error("Failed to decode request body", "error", err)
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	id, err := h.service.CreateAndReturnId(r.Context(), req.Type, workoutId, userId)
+	id, err := h.service.CreateAndReturnId(r.Context(), req, workoutId, userId)
 	if err != nil {
 		slog.Error("Failed to create exercise item", "error", err)
 		http.Error(w, "Failed to create exercise item", http.StatusBadRequest)
@@ -118,21 +138,20 @@ func (h *handler) updateHandler(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value("sub").(string)
 	itemId := r.PathValue("exerciseItemId")
 
-	var req updateExerciseItemRequest
+	var req Settings
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		slog.Error("Failed to decode request body", "error", err)
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	arg := repository.UpdateExerciseItemTypeParams{
-		ID:        itemId,
-		Type:      req.Type,
-		UserID:    userId,
-		UpdatedOn: timeNow(),
-	}
-	_, err := h.service.UpdateType(r.Context(), arg)
+	err := h.service.UpdateById(r.Context(), itemId, req, userId)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
 		slog.Error("Failed to update exercise item", "error", err)
 		http.Error(w, "Failed to update exercise item", http.StatusBadRequest)
 		return
@@ -142,6 +161,28 @@ func (h *handler) updateHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *handler) reorderHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	workoutId := r.PathValue("workoutId")
+
+	var req reorderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err := h.service.Reorder(r.Context(), workoutId, req.IDs, userId)
+	if err != nil {
+		slog.Warn("Failed to reorder exercise items", "error", err, "workoutId", workoutId)
+		http.Error(w, "Failed to reorder exercise items", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *handler) deleteHandler(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value("sub").(string)
 	itemId := r.PathValue("exerciseItemId")
@@ -160,7 +201,3 @@ func (h *handler) deleteHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusNoContent)
 }
-
-func timeNow() string {
-	return time.Now().UTC().Format(time.RFC3339)
-}
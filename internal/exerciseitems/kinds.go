@@ -0,0 +1,104 @@
+package exerciseitems
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	KindStraight = "straight"
+	KindSuperset = "superset"
+	KindGiant    = "giant"
+	KindCircuit  = "circuit"
+	KindEmom     = "emom"
+	KindAmrap    = "amrap"
+)
+
+var KindNames = []string{KindStraight, KindSuperset, KindGiant, KindCircuit, KindEmom, KindAmrap}
+
+// Items used to be created as "exercise" before kinds had any meaning
+const legacyKindExercise = "exercise"
+
+// Settings is the kind of an exercise item together with how it is run
+type Settings struct {
+	Type            string `json:"type"`
+	Rounds          *int64 `json:"rounds"`
+	IntervalSeconds *int64 `json:"interval_seconds"`
+	TimeCapSeconds  *int64 `json:"time_cap_seconds"`
+}
+
+// Grouped reports whether the exercises of the kind are done back to back in rounds
+func Grouped(kind string) bool {
+	return kind != KindStraight
+}
+
+// NormalizeSettings returns the settings to store. Items without a type are
+// straight sets, each kind only takes the settings it uses.
+func NormalizeSettings(s Settings) (Settings, error) {
+	if s.Type == "" || s.Type == legacyKindExercise {
+		s.Type = KindStraight
+	}
+	if !slices.Contains(KindNames, s.Type) {
+		return Settings{}, fmt.Errorf("unknown exercise item type: %s", s.Type)
+	}
+
+	for _, v := range []*int64{s.Rounds, s.IntervalSeconds, s.TimeCapSeconds} {
+		if v != nil && *v <= 0 {
+			return Settings{}, fmt.Errorf("rounds, interval and time cap must be positive")
+		}
+	}
+
+	switch s.Type {
+	case KindStraight:
+		if s.Rounds != nil || s.IntervalSeconds != nil || s.TimeCapSeconds != nil {
+			return Settings{}, fmt.Errorf("%s items do not take rounds, an interval or a time cap", s.Type)
+		}
+	case KindSuperset, KindGiant:
+		if s.IntervalSeconds != nil || s.TimeCapSeconds != nil {
+			return Settings{}, fmt.Errorf("%s items do not take an interval or a time cap", s.Type)
+		}
+	case KindCircuit:
+		if s.Rounds == nil {
+			return Settings{}, fmt.Errorf("%s items need rounds", s.Type)
+		}
+		if s.IntervalSeconds != nil {
+			return Settings{}, fmt.Errorf("%s items do not take an interval", s.Type)
+		}
+	case KindEmom:
+		// Every minute on the minute, the interval can be changed for e.g. E2MOM
+		if s.Rounds == nil {
+			return Settings{}, fmt.Errorf("%s items need rounds", s.Type)
+		}
+		if s.IntervalSeconds == nil {
+			interval := int64(60)
+			s.IntervalSeconds = &interval
+		}
+		if s.TimeCapSeconds != nil {
+			return Settings{}, fmt.Errorf("%s items do not take a time cap", s.Type)
+		}
+	case KindAmrap:
+		// Rounds are what is being counted, not something to plan
+		if s.TimeCapSeconds == nil {
+			return Settings{}, fmt.Errorf("%s items need a time cap", s.Type)
+		}
+		if s.Rounds != nil || s.IntervalSeconds != nil {
+			return Settings{}, fmt.Errorf("%s items do not take rounds or an interval", s.Type)
+		}
+	}
+	return s, nil
+}
+
+// NullableSettings returns the settings as column values, left out values are stored as NULL
+func NullableSettings(s Settings) (interface{}, interface{}, interface{}) {
+	var rounds, interval, timeCap interface{}
+	if s.Rounds != nil {
+		rounds = *s.Rounds
+	}
+	if s.IntervalSeconds != nil {
+		interval = *s.IntervalSeconds
+	}
+	if s.TimeCapSeconds != nil {
+		timeCap = *s.TimeCapSeconds
+	}
+	return rounds, interval, timeCap
+}
@@ -0,0 +1,54 @@
+package exerciseitems
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSettings(t *testing.T) {
+	rounds := int64(3)
+	interval := int64(90)
+	timeCap := int64(600)
+	zero := int64(0)
+
+	settings, err := NormalizeSettings(Settings{})
+	assert.Nil(t, err)
+	assert.Equal(t, Settings{Type: KindStraight}, settings)
+
+	settings, err = NormalizeSettings(Settings{Type: "exercise"})
+	assert.Nil(t, err)
+	assert.Equal(t, KindStraight, settings.Type)
+
+	settings, err = NormalizeSettings(Settings{Type: KindEmom, Rounds: &rounds})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(60), *settings.IntervalSeconds)
+
+	settings, err = NormalizeSettings(Settings{Type: KindEmom, Rounds: &rounds, IntervalSeconds: &interval})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(90), *settings.IntervalSeconds)
+
+	_, err = NormalizeSettings(Settings{Type: KindSuperset, Rounds: &rounds})
+	assert.Nil(t, err)
+	_, err = NormalizeSettings(Settings{Type: KindCircuit, Rounds: &rounds, TimeCapSeconds: &timeCap})
+	assert.Nil(t, err)
+	_, err = NormalizeSettings(Settings{Type: KindAmrap, TimeCapSeconds: &timeCap})
+	assert.Nil(t, err)
+
+	_, err = NormalizeSettings(Settings{Type: "tabata"})
+	assert.NotNil(t, err)
+	_, err = NormalizeSettings(Settings{Type: KindStraight, Rounds: &rounds})
+	assert.NotNil(t, err)
+	_, err = NormalizeSettings(Settings{Type: KindSuperset, IntervalSeconds: &interval})
+	assert.NotNil(t, err)
+	_, err = NormalizeSettings(Settings{Type: KindCircuit})
+	assert.NotNil(t, err)
+	_, err = NormalizeSettings(Settings{Type: KindEmom})
+	assert.NotNil(t, err)
+	_, err = NormalizeSettings(Settings{Type: KindAmrap})
+	assert.NotNil(t, err)
+	_, err = NormalizeSettings(Settings{Type: KindAmrap, TimeCapSeconds: &timeCap, Rounds: &rounds})
+	assert.NotNil(t, err)
+	_, err = NormalizeSettings(Settings{Type: KindCircuit, Rounds: &zero})
+	assert.NotNil(t, err)
+}
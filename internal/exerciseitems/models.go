@@ -3,20 +3,50 @@ package exerciseitems
 import "weight-tracker/internal/exercises"
 
 type ExerciseItem struct {
-	ID        string `json:"id"`
-	Type      string `json:"type"`
-	UserID    string `json:"user_id"`
-	WorkoutID string `json:"workout_id"`
-	CreatedOn string `json:"created_on"`
-	UpdatedOn string `json:"updated_on"`
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	Rounds          *int64 `json:"rounds,omitempty"`
+	IntervalSeconds *int64 `json:"interval_seconds,omitempty"`
+	TimeCapSeconds  *int64 `json:"time_cap_seconds,omitempty"`
+	UserID          string `json:"user_id"`
+	WorkoutID       string `json:"workout_id"`
+	CreatedOn       string `json:"created_on"`
+	UpdatedOn       string `json:"updated_on"`
 }
 
 type ExerciseItemWithExercises struct {
-	ID        string               `json:"id"`
-	Type      string               `json:"type"`
-	UserID    string               `json:"user_id"`
-	WorkoutID string               `json:"workout_id"`
-	CreatedOn string               `json:"created_on"`
-	UpdatedOn string               `json:"updated_on"`
-	Exercises []exercises.Exercise `json:"exercises"`
+	ID              string               `json:"id"`
+	Type            string               `json:"type"`
+	Rounds          *int64               `json:"rounds,omitempty"`
+	IntervalSeconds *int64               `json:"interval_seconds,omitempty"`
+	TimeCapSeconds  *int64               `json:"time_cap_seconds,omitempty"`
+	UserID          string               `json:"user_id"`
+	WorkoutID       string               `json:"workout_id"`
+	CreatedOn       string               `json:"created_on"`
+	UpdatedOn       string               `json:"updated_on"`
+	Exercises       []exercises.Exercise `json:"exercises"`
+}
+
+func (e ExerciseItemWithExercises) Settings() Settings {
+	return Settings{
+		Type:            e.Type,
+		Rounds:          e.Rounds,
+		IntervalSeconds: e.IntervalSeconds,
+		TimeCapSeconds:  e.TimeCapSeconds,
+	}
+}
+
+func withExercises(item ExerciseItem, exs []exercises.Exercise) ExerciseItemWithExercises {
+	return ExerciseItemWithExercises{
+		ID:              item.ID,
+		Type:            item.Type,
+		Rounds:          item.Rounds,
+		IntervalSeconds: item.IntervalSeconds,
+		TimeCapSeconds:  item.TimeCapSeconds,
+		UserID:          item.UserID,
+		WorkoutID:       item.WorkoutID,
+		CreatedOn:       item.CreatedOn,
+		UpdatedOn:       item.UpdatedOn,
+		Exercises:       exs,
+	}
 }
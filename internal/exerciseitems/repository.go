@@ -2,15 +2,19 @@ package exerciseitems
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"log/slog"
 	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
 )
 
 type ExerciseItemRepository interface {
 	GetById(ctx context.Context, arg repository.GetExerciseItemByIdParams) (ExerciseItem, error)
 	GetByWorkoutId(ctx context.Context, arg repository.GetExerciseItemsByWorkoutIdParams) ([]ExerciseItem, error)
 	CreateAndReturnId(ctx context.Context, arg repository.CreateExerciseItemAndReturnIdParams) (string, error)
-	UpdateType(ctx context.Context, arg repository.UpdateExerciseItemTypeParams) (int64, error)
+	UpdateById(ctx context.Context, arg repository.UpdateExerciseItemParams) error
+	UpdatePosition(ctx context.Context, arg repository.UpdateExerciseItemPositionParams) (int64, error)
 	DeleteById(ctx context.Context, arg repository.DeleteExerciseItemByIdParams) (int64, error)
 }
 
@@ -51,8 +55,21 @@ func (e exerciseItemRepository) GetByWorkoutId(ctx context.Context, arg reposito
 	return result, nil
 }
 
-func (e exerciseItemRepository) UpdateType(ctx context.Context, arg repository.UpdateExerciseItemTypeParams) (int64, error) {
-	return e.repo.UpdateExerciseItemType(ctx, arg)
+func (e exerciseItemRepository) UpdateById(ctx context.Context, arg repository.UpdateExerciseItemParams) error {
+	rows, err := e.repo.UpdateExerciseItem(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update exercise item: %w", err)
+	}
+
+	if rows == 0 {
+		slog.Warn("Tried to update exercise item that did not exist", "exerciseItemId", arg.ID)
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (e exerciseItemRepository) UpdatePosition(ctx context.Context, arg repository.UpdateExerciseItemPositionParams) (int64, error) {
+	return e.repo.UpdateExerciseItemPosition(ctx, arg)
 }
 
 func (e exerciseItemRepository) DeleteById(ctx context.Context, arg repository.DeleteExerciseItemByIdParams) (int64, error) {
@@ -61,11 +78,14 @@ func (e exerciseItemRepository) DeleteById(ctx context.Context, arg repository.D
 
 func newExerciseItem(v repository.ExerciseItem) ExerciseItem {
 	return ExerciseItem{
-		ID:        v.ID,
-		Type:      v.Type,
-		UserID:    v.UserID,
-		WorkoutID: v.WorkoutID,
-		CreatedOn: v.CreatedOn,
-		UpdatedOn: v.UpdatedOn,
+		ID:              v.ID,
+		Type:            v.Type,
+		Rounds:          utils.NullableInt(v.Rounds),
+		IntervalSeconds: utils.NullableInt(v.IntervalSeconds),
+		TimeCapSeconds:  utils.NullableInt(v.TimeCapSeconds),
+		UserID:          v.UserID,
+		WorkoutID:       v.WorkoutID,
+		CreatedOn:       v.CreatedOn,
+		UpdatedOn:       v.UpdatedOn,
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 	"weight-tracker/internal/exercises"
 	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
 
 	"github.com/google/uuid"
 )
@@ -15,34 +16,48 @@ type Service interface {
 	GetByWorkoutId(ctx context.Context, arg repository.GetExerciseItemsByWorkoutIdParams) ([]ExerciseItem, error)
 	GetByIdWithExercises(ctx context.Context, arg repository.GetExerciseItemByIdParams) (ExerciseItemWithExercises, error)
 	GetByWorkoutIdWithExercises(ctx context.Context, arg repository.GetExerciseItemsByWorkoutIdParams) ([]ExerciseItemWithExercises, error)
-	CreateAndReturnId(ctx context.Context, itemType string, workoutId string, userId string) (string, error)
-	UpdateType(ctx context.Context, arg repository.UpdateExerciseItemTypeParams) (int64, error)
+	CreateAndReturnId(ctx context.Context, settings Settings, workoutId string, userId string) (string, error)
+	UpdateById(ctx context.Context, exerciseItemId string, settings Settings, userId string) error
+	Reorder(ctx context.Context, workoutId string, ids []string, userId string) error
 	DeleteById(ctx context.Context, arg repository.DeleteExerciseItemByIdParams) (int64, error)
 }
 
 type exerciseItemService struct {
 	repo         ExerciseItemRepository
 	exerciseRepo exercises.ExerciseRepository
+	withTx       Transactor
 }
 
-func NewService(repo ExerciseItemRepository, exerciseRepo exercises.ExerciseRepository) Service {
-	return &exerciseItemService{repo, exerciseRepo}
+// Transactor runs fn with a repository whose writes are committed together
+type Transactor func(ctx context.Context, fn func(ExerciseItemRepository) error) error
+
+func NewService(repo ExerciseItemRepository, exerciseRepo exercises.ExerciseRepository, withTx Transactor) Service {
+	return &exerciseItemService{repo, exerciseRepo, withTx}
 }
 
-func (s *exerciseItemService) CreateAndReturnId(ctx context.Context, itemType string, workoutId string, userId string) (string, error) {
+func (s *exerciseItemService) CreateAndReturnId(ctx context.Context, settings Settings, workoutId string, userId string) (string, error) {
+	settings, err := NormalizeSettings(settings)
+	if err != nil {
+		return "", err
+	}
+
 	uuid, err := uuid.NewV7()
 	if err != nil {
 		return "", fmt.Errorf("failed to generate UUID: %w", err)
 	}
 
 	now := time.Now().UTC().Format(time.RFC3339)
+	rounds, interval, timeCap := NullableSettings(settings)
 	params := repository.CreateExerciseItemAndReturnIdParams{
-		ID:        uuid.String(),
-		Type:      itemType,
-		UserID:    userId,
-		WorkoutID: workoutId,
-		CreatedOn: now,
-		UpdatedOn: now,
+		ID:              uuid.String(),
+		Type:            settings.Type,
+		Rounds:          rounds,
+		IntervalSeconds: interval,
+		TimeCapSeconds:  timeCap,
+		UserID:          userId,
+		WorkoutID:       workoutId,
+		CreatedOn:       now,
+		UpdatedOn:       now,
 	}
 
 	id, err := s.repo.CreateAndReturnId(ctx, params)
@@ -67,15 +82,7 @@ func (s *exerciseItemService) GetByIdWithExercises(ctx context.Context, arg repo
 		return ExerciseItemWithExercises{}, fmt.Errorf("failed to get exercises: %w", err)
 	}
 
-	return ExerciseItemWithExercises{
-		ID:        item.ID,
-		Type:      item.Type,
-		UserID:    item.UserID,
-		WorkoutID: item.WorkoutID,
-		CreatedOn: item.CreatedOn,
-		UpdatedOn: item.UpdatedOn,
-		Exercises: exs,
-	}, nil
+	return withExercises(item, exs), nil
 }
 
 func (s *exerciseItemService) GetByWorkoutId(ctx context.Context, arg repository.GetExerciseItemsByWorkoutIdParams) ([]ExerciseItem, error) {
@@ -94,22 +101,63 @@ func (s *exerciseItemService) GetByWorkoutIdWithExercises(ctx context.Context, a
 		if err != nil {
 			return nil, fmt.Errorf("failed to get exercises: %w", err)
 		}
-		result = append(result, ExerciseItemWithExercises{
-			ID:        item.ID,
-			Type:      item.Type,
-			UserID:    item.UserID,
-			WorkoutID: item.WorkoutID,
-			CreatedOn: item.CreatedOn,
-			UpdatedOn: item.UpdatedOn,
-			Exercises: exs,
-		})
+		result = append(result, withExercises(item, exs))
 	}
 
 	return result, nil
 }
 
-func (s *exerciseItemService) UpdateType(ctx context.Context, arg repository.UpdateExerciseItemTypeParams) (int64, error) {
-	return s.repo.UpdateType(ctx, arg)
+func (s *exerciseItemService) UpdateById(ctx context.Context, exerciseItemId string, settings Settings, userId string) error {
+	settings, err := NormalizeSettings(settings)
+	if err != nil {
+		return err
+	}
+
+	rounds, interval, timeCap := NullableSettings(settings)
+	return s.repo.UpdateById(ctx, repository.UpdateExerciseItemParams{
+		ID:              exerciseItemId,
+		Type:            settings.Type,
+		Rounds:          rounds,
+		IntervalSeconds: interval,
+		TimeCapSeconds:  timeCap,
+		UpdatedOn:       time.Now().UTC().Format(time.RFC3339),
+		UserID:          userId,
+	})
+}
+
+func (s *exerciseItemService) Reorder(ctx context.Context, workoutId string, ids []string, userId string) error {
+	items, err := s.repo.GetByWorkoutId(ctx, repository.GetExerciseItemsByWorkoutIdParams{
+		WorkoutID: workoutId,
+		UserID:    userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get exercise items: %w", err)
+	}
+
+	existing := []string{}
+	for _, item := range items {
+		existing = append(existing, item.ID)
+	}
+	if err := utils.ValidateOrder(existing, ids); err != nil {
+		return fmt.Errorf("invalid exercise item order: %w", err)
+	}
+
+	// All positions are written together, a failure keeps the previous order
+	now := time.Now().UTC().Format(time.RFC3339)
+	return s.withTx(ctx, func(repo ExerciseItemRepository) error {
+		for i, id := range ids {
+			_, err := repo.UpdatePosition(ctx, repository.UpdateExerciseItemPositionParams{
+				ID:        id,
+				Position:  int64(i),
+				UpdatedOn: now,
+				UserID:    userId,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update exercise item position: %w", err)
+			}
+		}
+		return nil
+	})
 }
 
 func (s *exerciseItemService) DeleteById(ctx context.Context, arg repository.DeleteExerciseItemByIdParams) (int64, error) {
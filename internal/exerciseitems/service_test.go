@@ -8,6 +8,7 @@ import (
 	"weight-tracker/internal/exercisetypes"
 	"weight-tracker/internal/repository"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -30,7 +31,12 @@ func (r *repositoryMock) CreateAndReturnId(ctx context.Context, arg repository.C
 	return args.String(0), args.Error(1)
 }
 
-func (r *repositoryMock) UpdateType(ctx context.Context, arg repository.UpdateExerciseItemTypeParams) (int64, error) {
+func (r *repositoryMock) UpdateById(ctx context.Context, arg repository.UpdateExerciseItemParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *repositoryMock) UpdatePosition(ctx context.Context, arg repository.UpdateExerciseItemPositionParams) (int64, error) {
 	args := r.Called(ctx, arg)
 	return args.Get(0).(int64), args.Error(1)
 }
@@ -59,6 +65,21 @@ func (r *exerciseRepositoryMock) GetByExerciseItemId(ctx context.Context, exerci
 	return args.Get(0).([]exercises.Exercise), args.Error(1)
 }
 
+func (r *exerciseRepositoryMock) GetById(ctx context.Context, arg repository.GetExerciseByIdParams) (exercises.Exercise, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(exercises.Exercise), args.Error(1)
+}
+
+func (r *exerciseRepositoryMock) UpdateById(ctx context.Context, arg repository.UpdateExerciseParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *exerciseRepositoryMock) UpdatePosition(ctx context.Context, arg repository.UpdateExercisePositionParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
 func (r *exerciseRepositoryMock) DeleteById(ctx context.Context, arg repository.DeleteExerciseByIdParams) error {
 	args := r.Called(ctx, arg)
 	return args.Error(0)
@@ -77,12 +98,46 @@ func (r *exerciseRepositoryMock) GetExerciseTypeById(ctx context.Context, arg re
 	return args.Get(0).(*exercisetypes.ExerciseType), args.Error(1)
 }
 
+// transactorStub runs fn on the repository it holds and counts the transactions
+type transactorStub struct {
+	repo ExerciseItemRepository
+	runs int
+}
+
+func (s *transactorStub) withTx(ctx context.Context, fn func(ExerciseItemRepository) error) error {
+	s.runs++
+	return fn(s.repo)
+}
+
 func TestNewService(t *testing.T) {
 	mockRepo := new(repositoryMock)
 	mockExerciseRepo := new(exerciseRepositoryMock)
-	service := NewService(mockRepo, mockExerciseRepo)
+	service := NewService(mockRepo, mockExerciseRepo, nil)
 
 	if service == nil {
 		t.Error("Expected service to be created")
 	}
 }
+
+func TestReorder(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+	workoutId := "workoutId"
+
+	mockRepo := new(repositoryMock)
+	mockRepo.On("GetByWorkoutId", ctx, repository.GetExerciseItemsByWorkoutIdParams{WorkoutID: workoutId, UserID: userId}).
+		Return([]ExerciseItem{{ID: "a"}, {ID: "b"}, {ID: "c"}}, nil).Twice()
+	for i, id := range []string{"c", "a", "b"} {
+		mockRepo.On("UpdatePosition", ctx, mock.MatchedBy(func(input repository.UpdateExerciseItemPositionParams) bool {
+			return input.ID == id && input.Position == int64(i) && input.UserID == userId
+		})).Return(int64(1), nil).Once()
+	}
+
+	transactor := transactorStub{repo: mockRepo}
+	service := NewService(mockRepo, new(exerciseRepositoryMock), transactor.withTx)
+
+	assert.NotNil(t, service.Reorder(ctx, workoutId, []string{"c", "a"}, userId))
+	assert.Nil(t, service.Reorder(ctx, workoutId, []string{"c", "a", "b"}, userId))
+	assert.Equal(t, 1, transactor.runs)
+	mockRepo.AssertExpectations(t)
+}
@@ -1,10 +1,14 @@
 package exercises
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"weight-tracker/internal/database"
+	"weight-tracker/internal/repository"
 	"weight-tracker/internal/utils"
 )
 
@@ -14,12 +18,22 @@ type handler struct {
 
 func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
 	handler := handler{
-		service: NewService(NewExerciseRepository(s.GetRepository())),
+		service: NewService(
+			NewExerciseRepository(s.GetRepository()),
+			func(ctx context.Context, fn func(ExerciseRepository) error) error {
+				return s.WithTx(ctx, func(q repository.Querier) error {
+					return fn(NewExerciseRepository(q))
+				})
+			},
+		),
 	}
 
 	mux.Handle("GET /workouts/{workoutId}/exercise-items/{exerciseItemId}/exercises", authenticationWrapper(http.HandlerFunc(handler.getExercisesByWorkoutIdHandler)))
 	mux.Handle("POST /workouts/{workoutId}/exercise-items/{exerciseItemId}/exercises", authenticationWrapper(http.HandlerFunc(handler.createExerciseHandler)))
 	mux.Handle("DELETE /workouts/{workoutId}/exercise-items/{exerciseItemId}/exercises/{exerciseId}", authenticationWrapper(http.HandlerFunc(handler.deleteExerciseByIdHandler)))
+	mux.Handle("PUT /workouts/{workoutId}/exercise-items/{exerciseItemId}/exercises/order", authenticationWrapper(http.HandlerFunc(handler.reorderExercisesHandler)))
+	mux.Handle("PUT /workouts/{workoutId}/exercise-items/{exerciseItemId}/exercises/{exerciseId}", authenticationWrapper(http.HandlerFunc(handler.updateExerciseByIdHandler)))
+	mux.Handle("PATCH /workouts/{workoutId}/exercise-items/{exerciseItemId}/exercises/{exerciseId}", authenticationWrapper(http.HandlerFunc(handler.patchExerciseByIdHandler)))
 }
 
 func (s *handler) getExercisesByWorkoutIdHandler(w http.ResponseWriter, r *http.Request) {
@@ -100,7 +114,96 @@ func (s *handler) deleteExerciseByIdHandler(w http.ResponseWriter, r *http.Reque
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (s *handler) updateExerciseByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseId := r.PathValue("exerciseId")
+	decoder := json.NewDecoder(r.Body)
+	var t createExerciseRequest
+	err := decoder.Decode(&t)
+	if err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = s.service.UpdateById(r.Context(), exerciseId, t, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		slog.Warn("Failed to update exercise", "error", err, "exerciseId", exerciseId)
+		http.Error(w, "Failed to update exercise", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *handler) patchExerciseByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseId := r.PathValue("exerciseId")
+	decoder := json.NewDecoder(r.Body)
+	var t patchExerciseRequest
+	err := decoder.Decode(&t)
+	if err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = s.service.PatchById(r.Context(), exerciseId, t, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		slog.Warn("Failed to update exercise", "error", err, "exerciseId", exerciseId)
+		http.Error(w, "Failed to update exercise", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *handler) reorderExercisesHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseItemId := r.PathValue("exerciseItemId")
+	decoder := json.NewDecoder(r.Body)
+	var t reorderRequest
+	err := decoder.Decode(&t)
+	if err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = s.service.Reorder(r.Context(), exerciseItemId, t.IDs, userId)
+	if err != nil {
+		slog.Warn("Failed to reorder exercises", "error", err, "exerciseItemId", exerciseItemId)
+		http.Error(w, "Failed to reorder exercises", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
 type createExerciseRequest struct {
 	ExerciseTypeID string `json:"exercise_type_id"`
 	ExerciseItemID string `json:"exercise_item_id"`
 }
+
+// Fields left out of the request keep their current value
+type patchExerciseRequest struct {
+	ExerciseTypeID *string `json:"exercise_type_id"`
+	ExerciseItemID *string `json:"exercise_item_id"`
+}
+
+type reorderRequest struct {
+	IDs []string `json:"ids"`
+}
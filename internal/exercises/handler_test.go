@@ -37,6 +37,21 @@ func (s *serviceMock) CreateAndReturnId(context context.Context, exercise create
 	return args.String(0), args.Error(1)
 }
 
+func (s *serviceMock) UpdateById(context context.Context, id string, exercise createExerciseRequest, userId string) error {
+	args := s.Called(context, id, exercise, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) PatchById(context context.Context, id string, exercise patchExerciseRequest, userId string) error {
+	args := s.Called(context, id, exercise, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) Reorder(context context.Context, exerciseItemId string, ids []string, userId string) error {
+	args := s.Called(context, exerciseItemId, ids, userId)
+	return args.Error(0)
+}
+
 func TestGetExercisesByWorkoutIdHandlerSuccess(t *testing.T) {
 	userId := "userId"
 	workoutId := "workoutId"
@@ -57,7 +72,7 @@ func TestGetExercisesByWorkoutIdHandlerSuccess(t *testing.T) {
 	serviceMock := serviceMock{}
 	serviceMock.On("GetByWorkoutId", ctx, workoutId, userId).
 		Return([]Exercise{
-			{ID: "a", Name: "a", WorkoutID: workoutId, ExerciseTypeID: exerciseTypeId},
+			{ID: "a", Name: "a", WorkoutID: workoutId, ExerciseTypeID: exerciseTypeId, ExerciseItemID: "itemId"},
 		}, nil).
 		Once()
 
@@ -76,7 +91,7 @@ func TestGetExercisesByWorkoutIdHandlerSuccess(t *testing.T) {
 			ctype, "application/json")
 	}
 
-	expected := `{"data":[{"id":"a","name":"a","workout_id":"` + workoutId + `","exercise_type_id":"` + exerciseTypeId + `"}]}`
+	expected := `{"data":[{"id":"a","name":"a","workout_id":"` + workoutId + `","exercise_type_id":"` + exerciseTypeId + `","exercise_item_id":"itemId"}]}`
 	if rr.Body.String() != expected {
 		t.Errorf("handler returned unexpected body: got %v want %v",
 			rr.Body.String(), expected)
@@ -358,3 +373,118 @@ func TestDeleteExerciseByIdHandlerFailsBadRequest(t *testing.T) {
 
 	serviceMock.AssertExpectations(t)
 }
+
+func TestUpdateExerciseByIdHandler(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	exerciseItemId := "exerciseItemId"
+	exerciseId := "exerciseId"
+
+	body := createExerciseRequest{ExerciseTypeID: "squat", ExerciseItemID: exerciseItemId}
+	bodyBytes, _ := json.Marshal(body)
+	req, err := http.NewRequest("PUT", "/workouts/"+workoutId+"/exercise-items/"+exerciseItemId+"/exercises/"+exerciseId, bytes.NewBuffer(bodyBytes))
+	req.SetPathValue("workoutId", workoutId)
+	req.SetPathValue("exerciseItemId", exerciseItemId)
+	req.SetPathValue("exerciseId", exerciseId)
+
+	ctx := context.WithValue(req.Context(), "sub", userId)
+	req = req.WithContext(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("UpdateById", ctx, exerciseId, body, userId).
+		Return(nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.updateExerciseByIdHandler)
+
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestPatchExerciseByIdHandlerNotFound(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	exerciseItemId := "exerciseItemId"
+	exerciseId := "exerciseId"
+
+	req, err := http.NewRequest("PATCH", "/workouts/"+workoutId+"/exercise-items/"+exerciseItemId+"/exercises/"+exerciseId, bytes.NewBufferString(`{"exercise_type_id":"squat"}`))
+	req.SetPathValue("workoutId", workoutId)
+	req.SetPathValue("exerciseItemId", exerciseItemId)
+	req.SetPathValue("exerciseId", exerciseId)
+
+	ctx := context.WithValue(req.Context(), "sub", userId)
+	req = req.WithContext(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("PatchById", ctx, exerciseId, mock.MatchedBy(func(input patchExerciseRequest) bool {
+		return *input.ExerciseTypeID == "squat" && input.ExerciseItemID == nil
+	}), userId).
+		Return(fmt.Errorf("failed to get exercise by id: %w", sql.ErrNoRows)).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.patchExerciseByIdHandler)
+
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestReorderExercisesHandlerInvalidOrder(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	exerciseItemId := "exerciseItemId"
+
+	req, err := http.NewRequest("PUT", "/workouts/"+workoutId+"/exercise-items/"+exerciseItemId+"/exercises/order", bytes.NewBufferString(`{"ids":["a"]}`))
+	req.SetPathValue("workoutId", workoutId)
+	req.SetPathValue("exerciseItemId", exerciseItemId)
+
+	ctx := context.WithValue(req.Context(), "sub", userId)
+	req = req.WithContext(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("Reorder", ctx, exerciseItemId, []string{"a"}, userId).
+		Return(fmt.Errorf("invalid exercise order")).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.reorderExercisesHandler)
+
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+
+	expected := "Failed to reorder exercises\n"
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package exercises
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
 	"weight-tracker/internal/exercisetypes"
@@ -13,12 +14,16 @@ type Exercise struct {
 	Name           string `json:"name"`
 	WorkoutID      string `json:"workout_id"`
 	ExerciseTypeID string `json:"exercise_type_id"`
+	ExerciseItemID string `json:"exercise_item_id"`
 }
 
 type ExerciseRepository interface {
 	GetAll(context context.Context, userId string) ([]Exercise, error)
 	GetByWorkoutId(context context.Context, arg repository.GetExercisesByWorkoutIdParams) ([]Exercise, error)
 	GetByExerciseItemId(context context.Context, exerciseItemId string, userId string) ([]Exercise, error)
+	GetById(context context.Context, arg repository.GetExerciseByIdParams) (Exercise, error)
+	UpdateById(context context.Context, arg repository.UpdateExerciseParams) error
+	UpdatePosition(context context.Context, arg repository.UpdateExercisePositionParams) error
 	DeleteById(context context.Context, arg repository.DeleteExerciseByIdParams) error
 	CreateAndReturnId(context context.Context, exercise repository.CreateExerciseAndReturnIdParams) (string, error)
 	GetExerciseTypeById(context context.Context, arg repository.GetExerciseTypeByIdParams) (*exercisetypes.ExerciseType, error)
@@ -49,7 +54,7 @@ func (e exerciseRepository) GetExerciseTypeById(context context.Context, arg rep
 		return nil, fmt.Errorf("failed to get exercise type by id: %w", err)
 	}
 
-	return &exercisetypes.ExerciseType{ID: exerciseType.ID, Name: exerciseType.Name}, nil
+	return &exercisetypes.ExerciseType{ID: exerciseType.ID, Name: exerciseType.Name, Measurement: exerciseType.Measurement}, nil
 }
 
 func (e exerciseRepository) DeleteById(context context.Context, arg repository.DeleteExerciseByIdParams) error {
@@ -87,11 +92,42 @@ func newExercise(v repository.Exercise) Exercise {
 		ExerciseTypeID: v.ExerciseTypeID,
 		Name:           v.Name,
 		WorkoutID:      v.WorkoutID,
+		ExerciseItemID: v.ExerciseItemID,
 	}
 
 	return exercise
 }
 
+func (e exerciseRepository) GetById(context context.Context, arg repository.GetExerciseByIdParams) (Exercise, error) {
+	exercise, err := e.repo.GetExerciseById(context, arg)
+	if err != nil {
+		return Exercise{}, fmt.Errorf("failed to get exercise by id: %w", err)
+	}
+
+	return newExercise(exercise), nil
+}
+
+func (e exerciseRepository) UpdateById(context context.Context, arg repository.UpdateExerciseParams) error {
+	rows, err := e.repo.UpdateExercise(context, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update exercise: %w", err)
+	}
+
+	if rows == 0 {
+		slog.Warn("Tried to update exercise that did not exist", "exerciseId", arg.ID)
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (e exerciseRepository) UpdatePosition(context context.Context, arg repository.UpdateExercisePositionParams) error {
+	_, err := e.repo.UpdateExercisePosition(context, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update exercise position: %w", err)
+	}
+	return nil
+}
+
 func (e exerciseRepository) GetByWorkoutId(context context.Context, arg repository.GetExercisesByWorkoutIdParams) ([]Exercise, error) {
 	exercises, err := e.repo.GetExercisesByWorkoutId(context, arg)
 	slog.Debug("GetExercisesByWorkoutId returns", "exercises", exercises)
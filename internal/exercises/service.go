@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
 
 	"github.com/google/uuid"
 )
@@ -14,12 +15,19 @@ type Service interface {
 	GetByWorkoutId(context context.Context, workoutId string, userId string) ([]Exercise, error)
 	DeleteById(context context.Context, id string, userId string) error
 	CreateAndReturnId(context context.Context, exercise createExerciseRequest, workoutId string, userId string) (string, error)
+	UpdateById(context context.Context, id string, exercise createExerciseRequest, userId string) error
+	PatchById(context context.Context, id string, exercise patchExerciseRequest, userId string) error
+	Reorder(context context.Context, exerciseItemId string, ids []string, userId string) error
 }
 
 type exerciseService struct {
-	repo ExerciseRepository
+	repo   ExerciseRepository
+	withTx Transactor
 }
 
+// Transactor runs fn with a repository whose writes are committed together
+type Transactor func(ctx context.Context, fn func(ExerciseRepository) error) error
+
 func (e *exerciseService) CreateAndReturnId(context context.Context, exercise createExerciseRequest, workoutId string, userId string) (string, error) {
 	arg := repository.GetExerciseTypeByIdParams{
 		ID:     exercise.ExerciseTypeID,
@@ -57,6 +65,85 @@ func (e *exerciseService) CreateAndReturnId(context context.Context, exercise cr
 	return id, nil
 }
 
+func (e *exerciseService) UpdateById(context context.Context, id string, exercise createExerciseRequest, userId string) error {
+	if exercise.ExerciseTypeID == "" || exercise.ExerciseItemID == "" {
+		return fmt.Errorf("exercise type id and exercise item id are required")
+	}
+
+	arg := repository.GetExerciseTypeByIdParams{
+		ID:     exercise.ExerciseTypeID,
+		UserID: userId,
+	}
+	exerciseType, err := e.repo.GetExerciseTypeById(context, arg)
+	if err != nil {
+		return fmt.Errorf("failed to get exercise type by id: %w", err)
+	}
+
+	// The name follows the exercise type so a corrected type is shown everywhere
+	return e.repo.UpdateById(context, repository.UpdateExerciseParams{
+		ID:             id,
+		Name:           exerciseType.Name,
+		ExerciseTypeID: exerciseType.ID,
+		ExerciseItemID: exercise.ExerciseItemID,
+		UpdatedOn:      time.Now().UTC().Format(time.RFC3339),
+		UserID:         userId,
+	})
+}
+
+func (e *exerciseService) PatchById(context context.Context, id string, exercise patchExerciseRequest, userId string) error {
+	current, err := e.repo.GetById(context, repository.GetExerciseByIdParams{
+		ID:     id,
+		UserID: userId,
+	})
+	if err != nil {
+		return err
+	}
+
+	updated := createExerciseRequest{
+		ExerciseTypeID: current.ExerciseTypeID,
+		ExerciseItemID: current.ExerciseItemID,
+	}
+	if exercise.ExerciseTypeID != nil {
+		updated.ExerciseTypeID = *exercise.ExerciseTypeID
+	}
+	if exercise.ExerciseItemID != nil {
+		updated.ExerciseItemID = *exercise.ExerciseItemID
+	}
+	return e.UpdateById(context, id, updated, userId)
+}
+
+func (e *exerciseService) Reorder(context context.Context, exerciseItemId string, ids []string, userId string) error {
+	exercises, err := e.repo.GetByExerciseItemId(context, exerciseItemId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to get exercises: %w", err)
+	}
+
+	existing := []string{}
+	for _, exercise := range exercises {
+		existing = append(existing, exercise.ID)
+	}
+	if err := utils.ValidateOrder(existing, ids); err != nil {
+		return fmt.Errorf("invalid exercise order: %w", err)
+	}
+
+	// All positions are written together, a failure keeps the previous order
+	now := time.Now().UTC().Format(time.RFC3339)
+	return e.withTx(context, func(repo ExerciseRepository) error {
+		for i, id := range ids {
+			err := repo.UpdatePosition(context, repository.UpdateExercisePositionParams{
+				ID:        id,
+				Position:  int64(i),
+				UpdatedOn: now,
+				UserID:    userId,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (e *exerciseService) GetAll(context context.Context, userId string) ([]Exercise, error) {
 	return e.repo.GetAll(context, userId)
 }
@@ -77,6 +164,6 @@ func (e *exerciseService) DeleteById(context context.Context, id string, userId
 	return e.repo.DeleteById(context, arg)
 }
 
-func NewService(repo ExerciseRepository) Service {
-	return &exerciseService{repo}
+func NewService(repo ExerciseRepository, withTx Transactor) Service {
+	return &exerciseService{repo, withTx}
 }
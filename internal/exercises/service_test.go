@@ -39,11 +39,37 @@ func (r *repoMock) GetByExerciseItemId(context context.Context, exerciseItemId s
 	return args.Get(0).([]Exercise), args.Error(1)
 }
 
+func (r *repoMock) GetById(context context.Context, arg repository.GetExerciseByIdParams) (Exercise, error) {
+	args := r.Called(context, arg)
+	return args.Get(0).(Exercise), args.Error(1)
+}
+
+func (r *repoMock) UpdateById(context context.Context, arg repository.UpdateExerciseParams) error {
+	args := r.Called(context, arg)
+	return args.Error(0)
+}
+
+func (r *repoMock) UpdatePosition(context context.Context, arg repository.UpdateExercisePositionParams) error {
+	args := r.Called(context, arg)
+	return args.Error(0)
+}
+
 func (r *repoMock) GetExerciseTypeById(context context.Context, arg repository.GetExerciseTypeByIdParams) (*exercisetypes.ExerciseType, error) {
 	args := r.Called(context, arg)
 	return args.Get(0).(*exercisetypes.ExerciseType), args.Error(1)
 }
 
+// transactorStub runs fn on the repository it holds and counts the transactions
+type transactorStub struct {
+	repo ExerciseRepository
+	runs int
+}
+
+func (s *transactorStub) withTx(ctx context.Context, fn func(ExerciseRepository) error) error {
+	s.runs++
+	return fn(s.repo)
+}
+
 func TestGetAll(t *testing.T) {
 	userId := "userid"
 	expected := []Exercise{
@@ -59,7 +85,7 @@ func TestGetAll(t *testing.T) {
 		{ID: "b", Name: "b", WorkoutID: "", ExerciseTypeID: ""},
 	}, nil).Once()
 
-	service := NewService(&repoMock)
+	service := NewService(&repoMock, nil)
 
 	result, err := service.GetAll(ctx, userId)
 
@@ -87,7 +113,7 @@ func TestCreateAndReturnId(t *testing.T) {
 		return input.ID == exerciseTypeId && input.UserID == userId
 	})).Return(&exercisetypes.ExerciseType{ID: exerciseTypeId, Name: exerciseTypeName}, nil).Once()
 
-	service := NewService(&repoMock)
+	service := NewService(&repoMock, nil)
 	id, err := service.CreateAndReturnId(context.Background(), createExerciseRequest{
 		ExerciseTypeID: exerciseTypeId,
 	}, workoutId, userId)
@@ -107,7 +133,7 @@ func TestDeleteById(t *testing.T) {
 		return input.UserID == userId && input.ID == exerciseId
 	})).Return(nil).Once()
 
-	service := NewService(&repoMock)
+	service := NewService(&repoMock, nil)
 	err := service.DeleteById(ctx, exerciseId, userId)
 
 	assert.Nil(t, err)
@@ -131,10 +157,84 @@ func TestGetByWorkoutId(t *testing.T) {
 		{ID: "b", Name: "b", WorkoutID: workoutId, ExerciseTypeID: ""},
 	}, nil).Once()
 
-	service := NewService(&repoMock)
+	service := NewService(&repoMock, nil)
 	result, err := service.GetByWorkoutId(ctx, workoutId, userId)
 
 	assert.Nil(t, err)
 	assert.Equal(t, expected, result)
 	repoMock.AssertExpectations(t)
 }
+
+func TestUpdateById(t *testing.T) {
+	userId := "userid"
+	exerciseId := "exerciseId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseTypeById", ctx, repository.GetExerciseTypeByIdParams{ID: "squat", UserID: userId}).
+		Return(&exercisetypes.ExerciseType{ID: "squat", Name: "Squat"}, nil).Once()
+	repoMock.On("UpdateById", ctx, mock.MatchedBy(func(input repository.UpdateExerciseParams) bool {
+		return input.ID == exerciseId && input.Name == "Squat" && input.ExerciseTypeID == "squat" && input.ExerciseItemID == "itemId" && input.UserID == userId && input.UpdatedOn != ""
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock, nil)
+	err := service.UpdateById(ctx, exerciseId, createExerciseRequest{ExerciseTypeID: "squat", ExerciseItemID: "itemId"}, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateByIdMissingFields(t *testing.T) {
+	repoMock := repoMock{}
+	service := NewService(&repoMock, nil)
+
+	err := service.UpdateById(context.Background(), "exerciseId", createExerciseRequest{ExerciseTypeID: "squat"}, "userid")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "UpdateById")
+}
+
+func TestPatchById(t *testing.T) {
+	userId := "userid"
+	exerciseId := "exerciseId"
+	ctx := context.Background()
+	exerciseTypeId := "squat"
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, repository.GetExerciseByIdParams{ID: exerciseId, UserID: userId}).
+		Return(Exercise{ID: exerciseId, Name: "Bench", ExerciseTypeID: "bench", ExerciseItemID: "itemId"}, nil).Once()
+	repoMock.On("GetExerciseTypeById", ctx, repository.GetExerciseTypeByIdParams{ID: exerciseTypeId, UserID: userId}).
+		Return(&exercisetypes.ExerciseType{ID: exerciseTypeId, Name: "Squat"}, nil).Once()
+	repoMock.On("UpdateById", ctx, mock.MatchedBy(func(input repository.UpdateExerciseParams) bool {
+		return input.Name == "Squat" && input.ExerciseTypeID == exerciseTypeId && input.ExerciseItemID == "itemId"
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock, nil)
+	err := service.PatchById(ctx, exerciseId, patchExerciseRequest{ExerciseTypeID: &exerciseTypeId}, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestReorder(t *testing.T) {
+	userId := "userid"
+	exerciseItemId := "itemId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetByExerciseItemId", ctx, exerciseItemId, userId).Return([]Exercise{{ID: "a"}, {ID: "b"}}, nil).Twice()
+	repoMock.On("UpdatePosition", ctx, mock.MatchedBy(func(input repository.UpdateExercisePositionParams) bool {
+		return input.ID == "b" && input.Position == 0
+	})).Return(nil).Once()
+	repoMock.On("UpdatePosition", ctx, mock.MatchedBy(func(input repository.UpdateExercisePositionParams) bool {
+		return input.ID == "a" && input.Position == 1
+	})).Return(nil).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, transactor.withTx)
+
+	assert.NotNil(t, service.Reorder(ctx, exerciseItemId, []string{"a", "c"}, userId))
+	assert.Nil(t, service.Reorder(ctx, exerciseItemId, []string{"b", "a"}, userId))
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
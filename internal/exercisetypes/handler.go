@@ -1,19 +1,34 @@
 package exercisetypes
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"weight-tracker/internal/database"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/progression"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/strength"
+	"weight-tracker/internal/units"
 	"weight-tracker/internal/utils"
 )
 
 func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
+	preferences := preferences.NewServiceFromDatabase(s)
 	handler := handler{
-		service: NewService(exerciseTypeRepository{s.GetRepository()}),
+		service: NewService(exerciseTypeRepository{s.GetRepository()}, preferences,
+			func(ctx context.Context, fn func(ExerciseTypeRepository) error) error {
+				return s.WithTx(ctx, func(q repository.Querier) error {
+					return fn(exerciseTypeRepository{q})
+				})
+			},
+		),
+		units: preferences,
 	}
 
 	mux.Handle("GET /exercise-types", authenticationWrapper(http.HandlerFunc(handler.getAllWorkoutTypesHandler)))
@@ -22,21 +37,99 @@ func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper
 	mux.Handle("GET /exercise-types/{id}/max", authenticationWrapper(http.HandlerFunc(handler.getMaxSet)))
 	mux.Handle("GET /exercise-types/{id}/last", authenticationWrapper(http.HandlerFunc(handler.getLastSet)))
 	mux.Handle("PUT /exercise-types/{id}", authenticationWrapper(http.HandlerFunc(handler.updateExerciseTypeHandler)))
+	mux.Handle("PUT /exercise-types/{id}/metadata", authenticationWrapper(http.HandlerFunc(handler.updateExerciseTypeMetadataHandler)))
+	mux.Handle("GET /exercise-types/{id}/progression", authenticationWrapper(http.HandlerFunc(handler.getProgressionConfig)))
+	mux.Handle("PUT /exercise-types/{id}/progression", authenticationWrapper(http.HandlerFunc(handler.updateProgressionConfig)))
+	mux.Handle("GET /exercise-types/{id}/suggestion", authenticationWrapper(http.HandlerFunc(handler.getSuggestion)))
+	mux.Handle("GET /exercise-types/{id}/e1rm", authenticationWrapper(http.HandlerFunc(handler.getOneRepMaxHistory)))
+	mux.Handle("GET /exercise-types/{id}/history", authenticationWrapper(http.HandlerFunc(handler.getHistory)))
+	mux.Handle("GET /exercise-types/{id}/rest", authenticationWrapper(http.HandlerFunc(handler.getRestTarget)))
+	mux.Handle("PUT /exercise-types/{id}/rest", authenticationWrapper(http.HandlerFunc(handler.updateRestSeconds)))
 }
 
 type handler struct {
 	service Service
+	units   unitPreferences
+}
+
+// Weights are stored in kilograms, requests and responses use the unit of the user
+type unitPreferences interface {
+	GetWeightUnit(ctx context.Context, userId string) (string, error)
+}
+
+// convertConfig converts every weight of the progression config, plates included
+func convertConfig(config progression.Config, unit string, convert func(weight float64, unit string) float64) progression.Config {
+	config.Increment = convert(config.Increment, unit)
+	config.TrainingMax = convert(config.TrainingMax, unit)
+	config.BarWeight = convert(config.BarWeight, unit)
+	if config.Plates != nil {
+		plates := make([]float64, len(config.Plates))
+		for i, v := range config.Plates {
+			plates[i] = convert(v, unit)
+		}
+		config.Plates = plates
+	}
+	return config
 }
 
 type getLastMaxSetResponse struct {
-	Weight float64 `json:"weight"`
-	Reps   int     `json:"reps"`
+	Weight          float64 `json:"weight"`
+	Reps            int     `json:"reps"`
+	DurationSeconds int     `json:"duration_seconds,omitempty"`
+	DistanceMeters  float64 `json:"distance_meters,omitempty"`
+}
+
+type getLastSetResponse struct {
+	Weight          float64                 `json:"weight"`
+	Reps            int                     `json:"reps"`
+	DurationSeconds int                     `json:"duration_seconds,omitempty"`
+	DistanceMeters  float64                 `json:"distance_meters,omitempty"`
+	Suggestion      *progression.Suggestion `json:"suggestion,omitempty"`
+}
+
+type getOneRepMaxHistoryResponse struct {
+	Formula string           `json:"formula"`
+	Bucket  string           `json:"bucket"`
+	Points  []strength.Point `json:"points"`
 }
 
 type updateExerciseTypeRequest struct {
 	Name string `json:"name"`
 }
 
+type updateExerciseTypeMetadataRequest struct {
+	Equipment       string        `json:"equipment"`
+	MovementPattern string        `json:"movement_pattern"`
+	MuscleGroups    []MuscleGroup `json:"muscle_groups"`
+}
+
+func (s *handler) updateExerciseTypeMetadataHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseTypeId := r.PathValue("id")
+	decoder := json.NewDecoder(r.Body)
+	var t updateExerciseTypeMetadataRequest
+	err := decoder.Decode(&t)
+	if err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = s.service.UpdateMetadataById(r.Context(), exerciseTypeId, t, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		slog.Warn("Failed to update exercise type metadata", "error", err, "exerciseTypeId", exerciseTypeId)
+		http.Error(w, "Failed to update exercise type metadata", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (s *handler) updateExerciseTypeHandler(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value("sub").(string)
 	exerciseTypeId := r.PathValue("id")
@@ -82,7 +175,110 @@ func (s *handler) getLastSet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jsonResp, err := utils.CreateResponse(getLastMaxSetResponse{Weight: lastSet.Weight, Reps: lastSet.Reps})
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	response := getLastSetResponse{
+		Weight:          units.FromKilograms(lastSet.Weight, unit),
+		Reps:            lastSet.Reps,
+		DurationSeconds: lastSet.DurationSeconds,
+		DistanceMeters:  lastSet.DistanceMeters,
+	}
+
+	// The last set is still useful without a suggestion, e.g. when the rule is incomplete
+	suggestion, err := s.service.GetSuggestion(r.Context(), exerciseTypeId, userId)
+	if err != nil {
+		slog.Warn("Failed to get suggestion", "error", err, "exerciseTypeId", exerciseTypeId)
+	} else {
+		suggestion.Weight = units.FromKilograms(suggestion.Weight, unit)
+		response.Suggestion = &suggestion
+	}
+
+	jsonResp, err := utils.CreateResponse(response)
+	if err != nil {
+		slog.Warn("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (s *handler) getSuggestion(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseTypeId := r.PathValue("id")
+
+	suggestion, err := s.service.GetSuggestion(r.Context(), exerciseTypeId, userId)
+	if err != nil {
+		if errors.Is(err, progression.ErrNoHistory) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		slog.Warn("Failed to get suggestion", "error", err, "exerciseTypeId", exerciseTypeId)
+		http.Error(w, "Failed to get suggestion", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get suggestion", http.StatusBadRequest)
+		return
+	}
+	suggestion.Weight = units.FromKilograms(suggestion.Weight, unit)
+
+	jsonResp, err := utils.CreateResponse(suggestion)
+	if err != nil {
+		slog.Warn("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (s *handler) getOneRepMaxHistory(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseTypeId := r.PathValue("id")
+
+	formula := r.URL.Query().Get("formula")
+	if formula == "" {
+		formula = strength.FormulaEpley
+	}
+	bucket := r.URL.Query().Get("bucket")
+	if bucket == "" {
+		bucket = strength.BucketWeek
+	}
+
+	if !strength.ValidFormula(formula) || !strength.ValidBucket(bucket) {
+		http.Error(w, "Invalid formula or bucket", http.StatusBadRequest)
+		return
+	}
+
+	points, err := s.service.GetOneRepMaxHistory(r.Context(), exerciseTypeId, formula, bucket, userId)
+	if err != nil {
+		slog.Warn("Failed to get e1rm history", "error", err, "exerciseTypeId", exerciseTypeId)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	for i, v := range points {
+		points[i].E1RM = units.FromKilograms(v.E1RM, unit)
+		points[i].Weight = units.FromKilograms(v.Weight, unit)
+	}
+
+	jsonResp, err := utils.CreateResponse(getOneRepMaxHistoryResponse{Formula: formula, Bucket: bucket, Points: points})
 	if err != nil {
 		slog.Warn("Failed to marshal response", "error", err)
 		http.Error(w, "", http.StatusBadRequest)
@@ -92,6 +288,169 @@ func (s *handler) getLastSet(w http.ResponseWriter, r *http.Request) {
 	utils.ReturnJson(w, jsonResp)
 }
 
+const (
+	defaultHistoryWorkouts = 10
+	maxHistoryWorkouts     = 100
+)
+
+func (s *handler) getHistory(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseTypeId := r.PathValue("id")
+
+	limit := defaultHistoryWorkouts
+	if v := r.URL.Query().Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 || parsed > maxHistoryWorkouts {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	history, err := s.service.GetHistory(r.Context(), exerciseTypeId, limit, userId)
+	if err != nil {
+		slog.Warn("Failed to get exercise type history", "error", err, "exerciseTypeId", exerciseTypeId)
+		http.Error(w, "Failed to get history", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get history", http.StatusBadRequest)
+		return
+	}
+	for _, session := range history {
+		for i, v := range session.Sets {
+			session.Sets[i].Weight = units.FromKilograms(v.Weight, unit)
+		}
+	}
+
+	jsonResp, err := utils.CreateResponse(history)
+	if err != nil {
+		slog.Warn("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (s *handler) getProgressionConfig(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseTypeId := r.PathValue("id")
+
+	config, err := s.service.GetProgressionConfig(r.Context(), exerciseTypeId, userId)
+	if err != nil {
+		slog.Warn("Failed to get progression config", "error", err, "exerciseTypeId", exerciseTypeId)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(convertConfig(config, unit, units.FromKilograms))
+	if err != nil {
+		slog.Warn("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (s *handler) updateProgressionConfig(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseTypeId := r.PathValue("id")
+	decoder := json.NewDecoder(r.Body)
+	var t progression.Config
+	err := decoder.Decode(&t)
+	if err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to update progression config", http.StatusBadRequest)
+		return
+	}
+
+	err = s.service.UpdateProgressionConfig(r.Context(), exerciseTypeId, convertConfig(t, unit, units.ToKilograms), userId)
+	if err != nil {
+		slog.Warn("Failed to update progression config", "error", err, "exerciseTypeId", exerciseTypeId)
+		http.Error(w, "Failed to update progression config", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type updateRestRequest struct {
+	// Left out or null falls back to the default rest of the user
+	RestSeconds *int `json:"rest_seconds"`
+}
+
+func (s *handler) getRestTarget(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseTypeId := r.PathValue("id")
+
+	target, err := s.service.GetRestTarget(r.Context(), exerciseTypeId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		slog.Warn("Failed to get rest target", "error", err, "exerciseTypeId", exerciseTypeId)
+		http.Error(w, "Failed to get rest target", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(target)
+	if err != nil {
+		slog.Warn("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (s *handler) updateRestSeconds(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseTypeId := r.PathValue("id")
+	decoder := json.NewDecoder(r.Body)
+	var t updateRestRequest
+	err := decoder.Decode(&t)
+	if err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = s.service.UpdateRestSeconds(r.Context(), exerciseTypeId, t.RestSeconds, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		slog.Warn("Failed to update rest seconds", "error", err, "exerciseTypeId", exerciseTypeId)
+		http.Error(w, "Failed to update rest seconds", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (s *handler) getMaxSet(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value("sub").(string)
 	exerciseTypeId := r.PathValue("id")
@@ -115,7 +474,19 @@ func (s *handler) getMaxSet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jsonResp, err := utils.CreateResponse(getLastMaxSetResponse{Weight: maxSet.Weight, Reps: maxSet.Reps})
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(getLastMaxSetResponse{
+		Weight:          units.FromKilograms(maxSet.Weight, unit),
+		Reps:            maxSet.Reps,
+		DurationSeconds: maxSet.DurationSeconds,
+		DistanceMeters:  maxSet.DistanceMeters,
+	})
 	if err != nil {
 		slog.Warn("Failed to marshal response", "error", err)
 		http.Error(w, "", http.StatusBadRequest)
@@ -195,5 +566,9 @@ func (s *handler) createExerciseTypeHandler(w http.ResponseWriter, r *http.Reque
 }
 
 type createExerciseTypeRequest struct {
-	Name string `json:"name"`
+	Name            string        `json:"name"`
+	Equipment       string        `json:"equipment"`
+	MovementPattern string        `json:"movement_pattern"`
+	Measurement     string        `json:"measurement"`
+	MuscleGroups    []MuscleGroup `json:"muscle_groups"`
 }
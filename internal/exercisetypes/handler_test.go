@@ -6,9 +6,13 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"weight-tracker/internal/progression"
+	"weight-tracker/internal/strength"
+	"weight-tracker/internal/units"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -44,6 +48,54 @@ func (s *serviceMock) UpdateById(context context.Context, exerciseTypeId string,
 	return args.Error(0)
 }
 
+func (s *serviceMock) GetProgressionConfig(context context.Context, exerciseTypeId string, userId string) (progression.Config, error) {
+	args := s.Called(context, exerciseTypeId, userId)
+	return args.Get(0).(progression.Config), args.Error(1)
+}
+
+func (s *serviceMock) UpdateProgressionConfig(context context.Context, exerciseTypeId string, config progression.Config, userId string) error {
+	args := s.Called(context, exerciseTypeId, config, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) GetSuggestion(context context.Context, exerciseTypeId string, userId string) (progression.Suggestion, error) {
+	args := s.Called(context, exerciseTypeId, userId)
+	return args.Get(0).(progression.Suggestion), args.Error(1)
+}
+
+func (s *serviceMock) GetHistory(context context.Context, exerciseTypeId string, limit int, userId string) ([]HistorySession, error) {
+	args := s.Called(context, exerciseTypeId, limit, userId)
+	return args.Get(0).([]HistorySession), args.Error(1)
+}
+
+func (s *serviceMock) GetOneRepMaxHistory(context context.Context, exerciseTypeId string, formula string, bucket string, userId string) ([]strength.Point, error) {
+	args := s.Called(context, exerciseTypeId, formula, bucket, userId)
+	return args.Get(0).([]strength.Point), args.Error(1)
+}
+
+func (s *serviceMock) UpdateMetadataById(context context.Context, exerciseTypeId string, metadata updateExerciseTypeMetadataRequest, userId string) error {
+	args := s.Called(context, exerciseTypeId, metadata, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) GetRestTarget(context context.Context, exerciseTypeId string, userId string) (RestTarget, error) {
+	args := s.Called(context, exerciseTypeId, userId)
+	return args.Get(0).(RestTarget), args.Error(1)
+}
+
+func (s *serviceMock) UpdateRestSeconds(context context.Context, exerciseTypeId string, restSeconds *int, userId string) error {
+	args := s.Called(context, exerciseTypeId, restSeconds, userId)
+	return args.Error(0)
+}
+
+type unitsStub struct {
+	unit string
+}
+
+func (u unitsStub) GetWeightUnit(ctx context.Context, userId string) (string, error) {
+	return u.unit, nil
+}
+
 func populateContextWithSub(req *http.Request, userId string) *http.Request {
 	ctx := req.Context()
 	ctx = context.WithValue(ctx, "sub", userId)
@@ -79,7 +131,7 @@ func TestCreateExerciseTypeHandler(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.createExerciseTypeHandler)
 
 	handler.ServeHTTP(rr, req)
@@ -129,7 +181,7 @@ func TestCreateExerciseTypeHandlerErr(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.createExerciseTypeHandler)
 
 	handler.ServeHTTP(rr, req)
@@ -162,21 +214,22 @@ func TestGetAllExerciseTypesHandler(t *testing.T) {
 	serviceMock.On("GetAll", req.Context(), userId).
 		Return([]ExerciseType{
 			{
-				ID:   "1",
-				Name: "exerciseName",
+				ID:          "1",
+				Name:        "exerciseName",
+				Measurement: "weight_reps",
 			},
 		}, nil).
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getAllWorkoutTypesHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
 	}
 
-	expected := `{"data":[{"id":"1","name":"exerciseName"}]}`
+	expected := `{"data":[{"id":"1","name":"exerciseName","measurement":"weight_reps"}]}`
 	if rr.Body.String() != expected {
 		t.Errorf("handler returned unexpected body: got %v want %v",
 			rr.Body.String(), expected)
@@ -202,7 +255,7 @@ func TestGetAllExerciseTypesHandlerErr(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getAllWorkoutTypesHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusBadRequest {
@@ -236,7 +289,7 @@ func TestDeleteExerciseTypeByIdHandler(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.deleteExerciseTypeByIdHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusNoContent {
@@ -265,7 +318,7 @@ func TestDeleteExerciseTypeByIdHandlerErr(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.deleteExerciseTypeByIdHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusBadRequest {
@@ -298,9 +351,12 @@ func TestGetLastSet(t *testing.T) {
 	serviceMock.On("GetLastWeightRepsByExerciseTypeId", req.Context(), exerciseTypeId, userId).
 		Return(MaxLastWeightReps{Weight: 100, Reps: 10}, nil).
 		Once()
+	serviceMock.On("GetSuggestion", req.Context(), exerciseTypeId, userId).
+		Return(progression.Suggestion{Rule: progression.RuleLinear, Weight: 102.5, Reps: 10, Reason: "all sets completed"}, nil).
+		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getLastSet)
 
 	handler.ServeHTTP(rr, req)
@@ -308,7 +364,7 @@ func TestGetLastSet(t *testing.T) {
 		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
 	}
 
-	expected := `{"data":{"weight":100,"reps":10}}`
+	expected := `{"data":{"weight":100,"reps":10,"suggestion":{"rule":"linear","weight":102.5,"reps":10,"reason":"all sets completed"}}}`
 	if rr.Body.String() != expected {
 		t.Errorf("handler returned unexpected body: got '%v' want '%v'",
 			rr.Body.String(), expected)
@@ -336,7 +392,7 @@ func TestGetLastSetErr(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getLastSet)
 
 	handler.ServeHTTP(rr, req)
@@ -371,7 +427,7 @@ func TestGetLastSetSqlErr(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getLastSet)
 
 	handler.ServeHTTP(rr, req)
@@ -406,7 +462,7 @@ func TestGetMaxSet(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getMaxSet)
 
 	handler.ServeHTTP(rr, req)
@@ -442,7 +498,7 @@ func TestGetMaxSetErr(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getMaxSet)
 
 	handler.ServeHTTP(rr, req)
@@ -477,7 +533,7 @@ func TestGetMaxSetSqlErr(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getMaxSet)
 
 	handler.ServeHTTP(rr, req)
@@ -524,7 +580,7 @@ func TestUpdateExerciseTypeByIdHandler(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.updateExerciseTypeHandler)
 
 	handler.ServeHTTP(rr, req)
@@ -535,3 +591,562 @@ func TestUpdateExerciseTypeByIdHandler(t *testing.T) {
 
 	serviceMock.AssertExpectations(t)
 }
+
+func TestGetLastSetSuggestionErr(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("GET", "/exercise-types/"+exerciseTypeId+"/last", nil)
+	req.SetPathValue("id", exerciseTypeId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetLastWeightRepsByExerciseTypeId", req.Context(), exerciseTypeId, userId).
+		Return(MaxLastWeightReps{Weight: 100, Reps: 10}, nil).
+		Once()
+	serviceMock.On("GetSuggestion", req.Context(), exerciseTypeId, userId).
+		Return(progression.Suggestion{}, testError).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.getLastSet)
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":{"weight":100,"reps":10}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'",
+			rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetSuggestionHandler(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("GET", "/exercise-types/"+exerciseTypeId+"/suggestion", nil)
+	req.SetPathValue("id", exerciseTypeId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetSuggestion", req.Context(), exerciseTypeId, userId).
+		Return(progression.Suggestion{Rule: progression.RuleDoubleProgression, Weight: 60, Reps: 9, Reason: "add a repetition"}, nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.getSuggestion)
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":{"rule":"double_progression","weight":60,"reps":9,"reason":"add a repetition"}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'",
+			rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetSuggestionHandlerNoHistory(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("GET", "/exercise-types/"+exerciseTypeId+"/suggestion", nil)
+	req.SetPathValue("id", exerciseTypeId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetSuggestion", req.Context(), exerciseTypeId, userId).
+		Return(progression.Suggestion{}, progression.ErrNoHistory).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.getSuggestion)
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestUpdateProgressionConfigHandler(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	config := progression.Config{Rule: progression.RuleLinear, Increment: 5, MinReps: 5, MaxReps: 5, BarWeight: 20}
+	body, _ := json.Marshal(config)
+	req, err := http.NewRequest("PUT", "/exercise-types/"+exerciseTypeId+"/progression", bytes.NewBuffer(body))
+	req.SetPathValue("id", exerciseTypeId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("UpdateProgressionConfig", req.Context(), exerciseTypeId, config, userId).
+		Return(nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.updateProgressionConfig)
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestUpdateProgressionConfigHandlerErr(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	config := progression.Config{Rule: "unknown"}
+	body, _ := json.Marshal(config)
+	req, err := http.NewRequest("PUT", "/exercise-types/"+exerciseTypeId+"/progression", bytes.NewBuffer(body))
+	req.SetPathValue("id", exerciseTypeId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("UpdateProgressionConfig", req.Context(), exerciseTypeId, config, userId).
+		Return(testError).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.updateProgressionConfig)
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetOneRepMaxHistoryHandler(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("GET", "/exercise-types/"+exerciseTypeId+"/e1rm?formula=brzycki&bucket=month", nil)
+	req.SetPathValue("id", exerciseTypeId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetOneRepMaxHistory", req.Context(), exerciseTypeId, strength.FormulaBrzycki, strength.BucketMonth, userId).
+		Return([]strength.Point{{Date: "2025-01-01", E1RM: 112.5, Weight: 100, Reps: 5}}, nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.getOneRepMaxHistory)
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":{"formula":"brzycki","bucket":"month","points":[{"date":"2025-01-01","e1rm":112.5,"weight":100,"reps":5}]}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'",
+			rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetHistoryHandler(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("GET", "/exercise-types/"+exerciseTypeId+"/history?limit=3", nil)
+	req.SetPathValue("id", exerciseTypeId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rpe := 8.5
+	rir := int64(2)
+	serviceMock := serviceMock{}
+	serviceMock.On("GetHistory", req.Context(), exerciseTypeId, 3, userId).
+		Return([]HistorySession{
+			{WorkoutID: "a", WorkoutName: "Push", CompletedOn: "2025-01-06T10:00:00Z", Sets: []HistorySet{
+				{ID: "s1", Type: "warmup", Weight: 60, Reps: 5},
+				{ID: "s2", Type: "working", Weight: 100, Reps: 5, RPE: &rpe, RIR: &rir, Note: "Grindy"},
+			}},
+		}, nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.getHistory)
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":[{"workout_id":"a","workout_name":"Push","completed_on":"2025-01-06T10:00:00Z","sets":[{"id":"s1","type":"warmup","weight":60,"reps":5},{"id":"s2","type":"working","weight":100,"reps":5,"rpe":8.5,"rir":2,"note":"Grindy"}]}]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'",
+			rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetHistoryHandlerInvalidLimit(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("GET", "/exercise-types/"+exerciseTypeId+"/history?limit=0", nil)
+	req.SetPathValue("id", exerciseTypeId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.getHistory)
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+
+	serviceMock.AssertNotCalled(t, "GetHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetOneRepMaxHistoryHandlerDefaults(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("GET", "/exercise-types/"+exerciseTypeId+"/e1rm", nil)
+	req.SetPathValue("id", exerciseTypeId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetOneRepMaxHistory", req.Context(), exerciseTypeId, strength.FormulaEpley, strength.BucketWeek, userId).
+		Return([]strength.Point{}, nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.getOneRepMaxHistory)
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":{"formula":"epley","bucket":"week","points":[]}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'",
+			rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetOneRepMaxHistoryHandlerInvalidFormula(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("GET", "/exercise-types/"+exerciseTypeId+"/e1rm?formula=unknown", nil)
+	req.SetPathValue("id", exerciseTypeId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.getOneRepMaxHistory)
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+
+	serviceMock.AssertNotCalled(t, "GetOneRepMaxHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateExerciseTypeMetadataHandler(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	reqBody := `{"equipment":"barbell","movement_pattern":"hinge","muscle_groups":[{"name":"hamstrings","role":"primary"},{"name":"glutes","role":"secondary"}]}`
+	req, err := http.NewRequest("PUT", "/exercise-types/"+exerciseTypeId+"/metadata", bytes.NewBufferString(reqBody))
+	req.SetPathValue("id", exerciseTypeId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("UpdateMetadataById", req.Context(), exerciseTypeId, updateExerciseTypeMetadataRequest{
+		Equipment:       "barbell",
+		MovementPattern: "hinge",
+		MuscleGroups: []MuscleGroup{
+			{Name: "hamstrings", Role: MuscleGroupRolePrimary},
+			{Name: "glutes", Role: MuscleGroupRoleSecondary},
+		},
+	}, userId).
+		Return(nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.updateExerciseTypeMetadataHandler)
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestUpdateExerciseTypeMetadataHandlerNotFound(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("PUT", "/exercise-types/"+exerciseTypeId+"/metadata", bytes.NewBufferString(`{}`))
+	req.SetPathValue("id", exerciseTypeId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("UpdateMetadataById", req.Context(), exerciseTypeId, updateExerciseTypeMetadataRequest{}, userId).
+		Return(sql.ErrNoRows).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.updateExerciseTypeMetadataHandler)
+
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetProgressionConfigConvertsPounds(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("GET", "/exercise-types/"+exerciseTypeId+"/progression", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", exerciseTypeId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetProgressionConfig", req.Context(), exerciseTypeId, userId).
+		Return(progression.Config{
+			Rule:      progression.RuleLinear,
+			Increment: units.ToKilograms(5, units.Pounds),
+			MinReps:   5,
+			MaxReps:   5,
+			BarWeight: units.ToKilograms(45, units.Pounds),
+			Plates:    []float64{units.ToKilograms(45, units.Pounds), units.ToKilograms(2.5, units.Pounds)},
+		}, nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(s.getProgressionConfig).ServeHTTP(rr, req)
+
+	expected := `{"data":{"rule":"linear","increment":5,"min_reps":5,"max_reps":5,"training_max":0,"percentage":0,"bar_weight":45,"plates":[45,2.5]}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestUpdateProgressionConfigConvertsPounds(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	body := `{"rule":"linear","increment":5,"min_reps":5,"max_reps":5,"bar_weight":45}`
+	req, err := http.NewRequest("PUT", "/exercise-types/"+exerciseTypeId+"/progression", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", exerciseTypeId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("UpdateProgressionConfig", req.Context(), exerciseTypeId, progression.Config{
+		Rule:      progression.RuleLinear,
+		Increment: units.ToKilograms(5, units.Pounds),
+		MinReps:   5,
+		MaxReps:   5,
+		BarWeight: units.ToKilograms(45, units.Pounds),
+	}, userId).
+		Return(nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(s.updateProgressionConfig).ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetRestTargetHandler(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("GET", "/exercise-types/"+exerciseTypeId+"/rest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", exerciseTypeId)
+	req = populateContextWithSub(req, userId)
+
+	last := "2025-01-01T10:00:00Z"
+	next := "2025-01-01T10:02:00Z"
+	serviceMock := serviceMock{}
+	serviceMock.On("GetRestTarget", req.Context(), exerciseTypeId, userId).
+		Return(RestTarget{RestSeconds: 120, Source: RestSourcePreferences, LastPerformedOn: &last, NextSetOn: &next}, nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(s.getRestTarget).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":{"rest_seconds":120,"source":"preferences","last_performed_on":"2025-01-01T10:00:00Z","next_set_on":"2025-01-01T10:02:00Z"}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetRestTargetHandlerNotFound(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("GET", "/exercise-types/"+exerciseTypeId+"/rest", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", exerciseTypeId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetRestTarget", req.Context(), exerciseTypeId, userId).
+		Return(RestTarget{}, fmt.Errorf("failed to get exercise type: %w", sql.ErrNoRows)).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(s.getRestTarget).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestUpdateRestSecondsHandler(t *testing.T) {
+	userId := "userId"
+	exerciseTypeId := "exerciseTypeId"
+
+	req, err := http.NewRequest("PUT", "/exercise-types/"+exerciseTypeId+"/rest", bytes.NewBufferString(`{"rest_seconds":150}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", exerciseTypeId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("UpdateRestSeconds", req.Context(), exerciseTypeId, mock.MatchedBy(func(restSeconds *int) bool {
+		return restSeconds != nil && *restSeconds == 150
+	}), userId).Return(nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(s.updateRestSeconds).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+	serviceMock.AssertExpectations(t)
+}
@@ -0,0 +1,67 @@
+package exercisetypes
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	MuscleGroupRolePrimary   = "primary"
+	MuscleGroupRoleSecondary = "secondary"
+)
+
+var MuscleGroupNames = []string{
+	"chest", "lats", "upper_back", "lower_back", "traps",
+	"front_delts", "side_delts", "rear_delts",
+	"biceps", "triceps", "forearms",
+	"abs", "obliques",
+	"glutes", "quads", "hamstrings", "adductors", "abductors", "calves",
+}
+
+var EquipmentNames = []string{
+	"barbell", "dumbbell", "kettlebell", "machine", "smith_machine", "cable", "band", "bodyweight", "other",
+}
+
+var MovementPatternNames = []string{
+	"horizontal_push", "vertical_push", "horizontal_pull", "vertical_pull",
+	"squat", "hinge", "lunge", "carry", "core", "isolation",
+}
+
+type MuscleGroup struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// MuscleGroupWeight is how much a set counts towards a muscle group. A
+// secondary muscle group gets half a set, the usual convention when counting
+// weekly sets against volume landmarks.
+func MuscleGroupWeight(role string) float64 {
+	if role == MuscleGroupRoleSecondary {
+		return 0.5
+	}
+	return 1
+}
+
+func validateMetadata(equipment string, movementPattern string, muscleGroups []MuscleGroup) error {
+	if equipment != "" && !slices.Contains(EquipmentNames, equipment) {
+		return fmt.Errorf("unknown equipment: %s", equipment)
+	}
+	if movementPattern != "" && !slices.Contains(MovementPatternNames, movementPattern) {
+		return fmt.Errorf("unknown movement pattern: %s", movementPattern)
+	}
+
+	seen := map[string]bool{}
+	for _, v := range muscleGroups {
+		if !slices.Contains(MuscleGroupNames, v.Name) {
+			return fmt.Errorf("unknown muscle group: %s", v.Name)
+		}
+		if v.Role != MuscleGroupRolePrimary && v.Role != MuscleGroupRoleSecondary {
+			return fmt.Errorf("unknown muscle group role: %s", v.Role)
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("muscle group %s added more than once", v.Name)
+		}
+		seen[v.Name] = true
+	}
+	return nil
+}
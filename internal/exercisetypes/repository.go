@@ -6,17 +6,51 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+	"weight-tracker/internal/measurement"
+	"weight-tracker/internal/progression"
 	"weight-tracker/internal/repository"
+	"weight-tracker/internal/strength"
+	"weight-tracker/internal/utils"
 )
 
 type ExerciseType struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID              string        `json:"id"`
+	Name            string        `json:"name"`
+	Equipment       string        `json:"equipment,omitempty"`
+	MovementPattern string        `json:"movement_pattern,omitempty"`
+	Measurement     string        `json:"measurement"`
+	MuscleGroups    []MuscleGroup `json:"muscle_groups,omitempty"`
+	RestSeconds     *int64        `json:"rest_seconds,omitempty"`
 }
 
 type MaxLastWeightReps struct {
-	Weight float64 `json:"weight"`
-	Reps   int     `json:"reps"`
+	Weight          float64 `json:"weight"`
+	Reps            int     `json:"reps"`
+	DurationSeconds int     `json:"duration_seconds,omitempty"`
+	DistanceMeters  float64 `json:"distance_meters,omitempty"`
+}
+
+// HistorySession is a completed workout with every set logged for the exercise type
+type HistorySession struct {
+	WorkoutID   string       `json:"workout_id"`
+	WorkoutName string       `json:"workout_name"`
+	CompletedOn string       `json:"completed_on"`
+	Sets        []HistorySet `json:"sets"`
+}
+
+type HistorySet struct {
+	ID              string   `json:"id"`
+	Type            string   `json:"type"`
+	Weight          float64  `json:"weight"`
+	Reps            int      `json:"reps"`
+	DurationSeconds int      `json:"duration_seconds,omitempty"`
+	DistanceMeters  float64  `json:"distance_meters,omitempty"`
+	RPE             *float64 `json:"rpe,omitempty"`
+	RIR             *int64   `json:"rir,omitempty"`
+	Note            string   `json:"note,omitempty"`
 }
 
 type ExerciseTypeRepository interface {
@@ -25,7 +59,180 @@ type ExerciseTypeRepository interface {
 	GetAll(context context.Context, userId string) ([]ExerciseType, error)
 	GetLastWeightRepsByExerciseTypeId(ctx context.Context, arg repository.GetLastWeightRepsByExerciseTypeIdParams) (MaxLastWeightReps, error)
 	GetMaxWeightRepsByExerciseTypeId(ctx context.Context, arg repository.GetMaxWeightRepsByExerciseTypeIdParams) (MaxLastWeightReps, error)
+	GetMeasurement(ctx context.Context, arg repository.GetExerciseTypeByIdParams) (string, error)
+	GetMeasuredSets(ctx context.Context, arg repository.GetMeasuredSetsByExerciseTypeIdParams) ([]measurement.Set, error)
 	UpdateById(ctx context.Context, arg repository.UpdateExerciseTypeParams) error
+	GetProgressionConfig(ctx context.Context, arg repository.GetProgressionRuleByExerciseTypeIdParams) (progression.Config, error)
+	UpsertProgressionConfig(ctx context.Context, arg repository.UpsertProgressionRuleParams) error
+	GetSessionHistory(ctx context.Context, arg repository.GetSetHistoryByExerciseTypeIdParams) ([]progression.Session, error)
+	GetCompletedSets(ctx context.Context, arg repository.GetCompletedSetsByExerciseTypeIdParams) ([]strength.Set, error)
+	GetHistory(ctx context.Context, arg repository.GetExerciseTypeHistoryParams) ([]HistorySession, error)
+	UpdateMetadata(ctx context.Context, arg repository.UpdateExerciseTypeMetadataParams) error
+	CreateMuscleGroupAndReturnId(ctx context.Context, arg repository.CreateExerciseTypeMuscleGroupAndReturnIdParams) (string, error)
+	DeleteMuscleGroups(ctx context.Context, arg repository.DeleteMuscleGroupsByExerciseTypeIdParams) error
+	GetById(ctx context.Context, arg repository.GetExerciseTypeByIdParams) (ExerciseType, error)
+	UpdateRestSeconds(ctx context.Context, arg repository.UpdateExerciseTypeRestSecondsParams) error
+	GetLastPerformedOn(ctx context.Context, arg repository.GetLastPerformedOnByExerciseTypeIdParams) (time.Time, error)
+}
+
+func (e exerciseTypeRepository) UpdateMetadata(ctx context.Context, arg repository.UpdateExerciseTypeMetadataParams) error {
+	rows, err := e.repo.UpdateExerciseTypeMetadata(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update exercise type metadata: %w", err)
+	}
+
+	if rows == 0 {
+		slog.Warn("Tried to update metadata of exercise type that did not exist", "exerciseTypeId", arg.ID)
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (e exerciseTypeRepository) CreateMuscleGroupAndReturnId(ctx context.Context, arg repository.CreateExerciseTypeMuscleGroupAndReturnIdParams) (string, error) {
+	id, err := e.repo.CreateExerciseTypeMuscleGroupAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create muscle group: %w", err)
+	}
+	return id, nil
+}
+
+func (e exerciseTypeRepository) DeleteMuscleGroups(ctx context.Context, arg repository.DeleteMuscleGroupsByExerciseTypeIdParams) error {
+	_, err := e.repo.DeleteMuscleGroupsByExerciseTypeId(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to delete muscle groups: %w", err)
+	}
+	return nil
+}
+
+func (e exerciseTypeRepository) GetCompletedSets(ctx context.Context, arg repository.GetCompletedSetsByExerciseTypeIdParams) ([]strength.Set, error) {
+	rows, err := e.repo.GetCompletedSetsByExerciseTypeId(ctx, arg)
+	if err != nil {
+		return []strength.Set{}, fmt.Errorf("failed to get completed sets: %w", err)
+	}
+
+	result := []strength.Set{}
+	for _, v := range rows {
+		completedOn, err := time.Parse(time.RFC3339, v.CompletedOn.(string))
+		if err != nil {
+			return []strength.Set{}, fmt.Errorf("failed to parse completed on: %w", err)
+		}
+
+		result = append(result, strength.Set{
+			CompletedOn: completedOn,
+			Weight:      v.Weight,
+			Reps:        int(v.Repetitions),
+			Bodyweight:  v.Bodyweight,
+		})
+	}
+	return result, nil
+}
+
+func (e exerciseTypeRepository) GetProgressionConfig(ctx context.Context, arg repository.GetProgressionRuleByExerciseTypeIdParams) (progression.Config, error) {
+	rule, err := e.repo.GetProgressionRuleByExerciseTypeId(ctx, arg)
+	if err != nil {
+		return progression.Config{}, fmt.Errorf("failed to get progression rule: %w", err)
+	}
+
+	plates := []float64{}
+	for _, v := range strings.Split(rule.Plates, ",") {
+		if v == "" {
+			continue
+		}
+		plate, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return progression.Config{}, fmt.Errorf("failed to parse plates: %w", err)
+		}
+		plates = append(plates, plate)
+	}
+
+	return progression.Config{
+		Rule:        rule.Rule,
+		Increment:   rule.Increment,
+		MinReps:     int(rule.MinReps),
+		MaxReps:     int(rule.MaxReps),
+		TrainingMax: rule.TrainingMax,
+		Percentage:  rule.Percentage,
+		BarWeight:   rule.BarWeight,
+		Plates:      plates,
+	}, nil
+}
+
+func (e exerciseTypeRepository) UpsertProgressionConfig(ctx context.Context, arg repository.UpsertProgressionRuleParams) error {
+	_, err := e.repo.UpsertProgressionRule(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to upsert progression rule: %w", err)
+	}
+	return nil
+}
+
+func (e exerciseTypeRepository) GetSessionHistory(ctx context.Context, arg repository.GetSetHistoryByExerciseTypeIdParams) ([]progression.Session, error) {
+	rows, err := e.repo.GetSetHistoryByExerciseTypeId(ctx, arg)
+	if err != nil {
+		return []progression.Session{}, fmt.Errorf("failed to get set history: %w", err)
+	}
+
+	// Rows are ordered by workout, most recent first
+	result := []progression.Session{}
+	for _, v := range rows {
+		if len(result) == 0 || result[len(result)-1].WorkoutID != v.WorkoutID {
+			session := progression.Session{
+				WorkoutID: v.WorkoutID,
+				Sets:      []progression.Set{},
+			}
+			if v.CompletedOn != nil {
+				session.CompletedOn = v.CompletedOn.(string)
+			}
+			result = append(result, session)
+		}
+
+		last := &result[len(result)-1]
+		last.Sets = append(last.Sets, progression.Set{
+			Weight: v.Weight,
+			Reps:   int(v.Repetitions),
+		})
+	}
+	return result, nil
+}
+
+func (e exerciseTypeRepository) GetHistory(ctx context.Context, arg repository.GetExerciseTypeHistoryParams) ([]HistorySession, error) {
+	rows, err := e.repo.GetExerciseTypeHistory(ctx, arg)
+	if err != nil {
+		return []HistorySession{}, fmt.Errorf("failed to get exercise type history: %w", err)
+	}
+
+	// Rows are ordered by workout, most recent first
+	result := []HistorySession{}
+	for _, v := range rows {
+		if len(result) == 0 || result[len(result)-1].WorkoutID != v.WorkoutID {
+			session := HistorySession{
+				WorkoutID:   v.WorkoutID,
+				WorkoutName: v.WorkoutName,
+				Sets:        []HistorySet{},
+			}
+			if v.CompletedOn != nil {
+				session.CompletedOn = v.CompletedOn.(string)
+			}
+			result = append(result, session)
+		}
+
+		set := HistorySet{
+			ID:              v.ID,
+			Type:            v.Type,
+			Weight:          v.Weight,
+			Reps:            int(v.Repetitions),
+			DurationSeconds: int(utils.IntOrZero(v.DurationSeconds)),
+			DistanceMeters:  utils.FloatOrZero(v.DistanceMeters),
+			RPE:             utils.NullableFloat(v.Rpe),
+			RIR:             utils.NullableInt(v.Rir),
+		}
+		if v.Note != nil {
+			set.Note = v.Note.(string)
+		}
+
+		last := &result[len(result)-1]
+		last.Sets = append(last.Sets, set)
+	}
+	return result, nil
 }
 
 func (e exerciseTypeRepository) UpdateById(ctx context.Context, arg repository.UpdateExerciseTypeParams) error {
@@ -47,7 +254,72 @@ func (e exerciseTypeRepository) GetLastWeightRepsByExerciseTypeId(ctx context.Co
 		return MaxLastWeightReps{}, fmt.Errorf("failed to get last weight reps by exercise type id: %w", err)
 	}
 
-	return MaxLastWeightReps{a.Weight, int(a.Repetitions)}, nil
+	return MaxLastWeightReps{
+		Weight:          a.Weight,
+		Reps:            int(a.Repetitions),
+		DurationSeconds: int(utils.IntOrZero(a.DurationSeconds)),
+		DistanceMeters:  utils.FloatOrZero(a.DistanceMeters),
+	}, nil
+}
+
+func (e exerciseTypeRepository) GetMeasurement(ctx context.Context, arg repository.GetExerciseTypeByIdParams) (string, error) {
+	exerciseType, err := e.repo.GetExerciseTypeById(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to get exercise type: %w", err)
+	}
+	return exerciseType.Measurement, nil
+}
+
+func (e exerciseTypeRepository) GetById(ctx context.Context, arg repository.GetExerciseTypeByIdParams) (ExerciseType, error) {
+	exerciseType, err := e.repo.GetExerciseTypeById(ctx, arg)
+	if err != nil {
+		return ExerciseType{}, fmt.Errorf("failed to get exercise type: %w", err)
+	}
+	return newExerciseType(exerciseType), nil
+}
+
+func (e exerciseTypeRepository) UpdateRestSeconds(ctx context.Context, arg repository.UpdateExerciseTypeRestSecondsParams) error {
+	rows, err := e.repo.UpdateExerciseTypeRestSeconds(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update exercise type rest seconds: %w", err)
+	}
+
+	if rows == 0 {
+		slog.Warn("Tried to update rest seconds of exercise type that did not exist", "exerciseTypeId", arg.ID)
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (e exerciseTypeRepository) GetLastPerformedOn(ctx context.Context, arg repository.GetLastPerformedOnByExerciseTypeIdParams) (time.Time, error) {
+	performedOn, err := e.repo.GetLastPerformedOnByExerciseTypeId(ctx, arg)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get last performed set: %w", err)
+	}
+
+	result, err := time.Parse(time.RFC3339, performedOn)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse performed on: %w", err)
+	}
+	return result, nil
+}
+
+func (e exerciseTypeRepository) GetMeasuredSets(ctx context.Context, arg repository.GetMeasuredSetsByExerciseTypeIdParams) ([]measurement.Set, error) {
+	rows, err := e.repo.GetMeasuredSetsByExerciseTypeId(ctx, arg)
+	if err != nil {
+		return []measurement.Set{}, fmt.Errorf("failed to get measured sets: %w", err)
+	}
+
+	result := []measurement.Set{}
+	for _, v := range rows {
+		result = append(result, measurement.Set{
+			Weight:          v.Weight,
+			Reps:            int(v.Repetitions),
+			DurationSeconds: int(utils.IntOrZero(v.DurationSeconds)),
+			DistanceMeters:  utils.FloatOrZero(v.DistanceMeters),
+		})
+	}
+	return result, nil
 }
 
 func (e exerciseTypeRepository) GetMaxWeightRepsByExerciseTypeId(ctx context.Context, arg repository.GetMaxWeightRepsByExerciseTypeIdParams) (MaxLastWeightReps, error) {
@@ -55,7 +327,7 @@ func (e exerciseTypeRepository) GetMaxWeightRepsByExerciseTypeId(ctx context.Con
 	if err != nil {
 		// Return 0/0 if no rows found
 		if errors.Is(err, sql.ErrNoRows) {
-			return MaxLastWeightReps{0, 0}, nil
+			return MaxLastWeightReps{}, nil
 		}
 		return MaxLastWeightReps{}, fmt.Errorf("failed to get max weight reps by exercise type id: %w", err)
 	}
@@ -64,7 +336,7 @@ func (e exerciseTypeRepository) GetMaxWeightRepsByExerciseTypeId(ctx context.Con
 	if r, ok := a.Repetitions.(int64); ok {
 		reps = int(r)
 	}
-	return MaxLastWeightReps{a.Weight, reps}, nil
+	return MaxLastWeightReps{Weight: a.Weight, Reps: reps}, nil
 }
 
 func (e exerciseTypeRepository) GetAll(context context.Context, userId string) ([]ExerciseType, error) {
@@ -73,18 +345,42 @@ func (e exerciseTypeRepository) GetAll(context context.Context, userId string) (
 		return []ExerciseType{}, fmt.Errorf("failed to get all exercise types: %w", err)
 	}
 
+	muscleGroups, err := e.repo.GetMuscleGroupsByUserId(context, userId)
+	if err != nil {
+		return []ExerciseType{}, fmt.Errorf("failed to get muscle groups: %w", err)
+	}
+
+	muscleGroupsByExerciseType := map[string][]MuscleGroup{}
+	for _, v := range muscleGroups {
+		muscleGroupsByExerciseType[v.ExerciseTypeID] = append(muscleGroupsByExerciseType[v.ExerciseTypeID], MuscleGroup{
+			Name: v.MuscleGroup,
+			Role: v.Role,
+		})
+	}
+
 	result := []ExerciseType{}
 	for _, v := range exerciseTypes {
-		result = append(result, newExerciseType(v))
+		exerciseType := newExerciseType(v)
+		exerciseType.MuscleGroups = muscleGroupsByExerciseType[v.ID]
+		result = append(result, exerciseType)
 	}
 	return result, nil
 }
 
 func newExerciseType(v repository.ExerciseType) ExerciseType {
-	return ExerciseType{
-		ID:   v.ID,
-		Name: v.Name,
+	exerciseType := ExerciseType{
+		ID:          v.ID,
+		Name:        v.Name,
+		Measurement: v.Measurement,
+		RestSeconds: utils.NullableInt(v.RestSeconds),
+	}
+	if v.Equipment != nil {
+		exerciseType.Equipment = v.Equipment.(string)
+	}
+	if v.MovementPattern != nil {
+		exerciseType.MovementPattern = v.MovementPattern.(string)
 	}
+	return exerciseType
 }
 
 func (e exerciseTypeRepository) DeleteById(context context.Context, arg repository.DeleteExerciseTypeByIdParams) error {
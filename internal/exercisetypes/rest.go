@@ -0,0 +1,33 @@
+package exercisetypes
+
+import (
+	"context"
+	"fmt"
+	"weight-tracker/internal/preferences"
+)
+
+const (
+	RestSourceExerciseType = "exercise_type"
+	RestSourcePreferences  = "preferences"
+)
+
+// RestTarget is the rest to take before the next set of an exercise type. The
+// next set is due the rest after the last performed set of the exercise type.
+type RestTarget struct {
+	RestSeconds     int64   `json:"rest_seconds"`
+	Source          string  `json:"source"`
+	LastPerformedOn *string `json:"last_performed_on,omitempty"`
+	NextSetOn       *string `json:"next_set_on,omitempty"`
+}
+
+// Exercise types without a rest fall back to the default rest of the user
+type restPreferences interface {
+	Get(ctx context.Context, userId string) (preferences.Preferences, error)
+}
+
+func validateRestSeconds(restSeconds *int) error {
+	if restSeconds != nil && (*restSeconds <= 0 || *restSeconds > preferences.MaxRestSeconds) {
+		return fmt.Errorf("rest seconds must be between 1 and %d", preferences.MaxRestSeconds)
+	}
+	return nil
+}
@@ -2,19 +2,29 @@ package exercisetypes
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"weight-tracker/internal/measurement"
+	"weight-tracker/internal/progression"
 	"weight-tracker/internal/repository"
+	"weight-tracker/internal/strength"
+	"weight-tracker/internal/utils"
 
 	"github.com/google/uuid"
 )
 
-func NewService(repo ExerciseTypeRepository) Service {
-	return &exerciseTypeService{repo}
+func NewService(repo ExerciseTypeRepository, preferences restPreferences, withTx Transactor) Service {
+	return &exerciseTypeService{repo, preferences, withTx}
 }
 
+// Transactor runs fn with a repository whose writes are committed together
+type Transactor func(ctx context.Context, fn func(ExerciseTypeRepository) error) error
+
 type Service interface {
 	GetAll(context context.Context, userId string) ([]ExerciseType, error)
 	DeleteById(context context.Context, exerciseTypeId string, userId string) error
@@ -22,6 +32,138 @@ type Service interface {
 	GetLastWeightRepsByExerciseTypeId(context context.Context, exerciseTypeId string, userId string) (MaxLastWeightReps, error)
 	GetMaxWeightRepsByExerciseTypeId(context context.Context, exerciseTypeId string, userId string) (MaxLastWeightReps, error)
 	UpdateById(context context.Context, exerciseTypeId string, updateExerciseTypeRequest updateExerciseTypeRequest, userId string) error
+	UpdateMetadataById(context context.Context, exerciseTypeId string, metadata updateExerciseTypeMetadataRequest, userId string) error
+	GetProgressionConfig(context context.Context, exerciseTypeId string, userId string) (progression.Config, error)
+	UpdateProgressionConfig(context context.Context, exerciseTypeId string, config progression.Config, userId string) error
+	GetSuggestion(context context.Context, exerciseTypeId string, userId string) (progression.Suggestion, error)
+	GetOneRepMaxHistory(context context.Context, exerciseTypeId string, formula string, bucket string, userId string) ([]strength.Point, error)
+	GetHistory(context context.Context, exerciseTypeId string, limit int, userId string) ([]HistorySession, error)
+	GetRestTarget(context context.Context, exerciseTypeId string, userId string) (RestTarget, error)
+	UpdateRestSeconds(context context.Context, exerciseTypeId string, restSeconds *int, userId string) error
+}
+
+// Number of sets looked at when suggesting the next session, enough to cover the last few workouts
+const suggestionHistoryLimit = 50
+
+func (s *exerciseTypeService) GetProgressionConfig(context context.Context, exerciseTypeId string, userId string) (progression.Config, error) {
+	arg := repository.GetProgressionRuleByExerciseTypeIdParams{
+		ExerciseTypeID: exerciseTypeId,
+		UserID:         userId,
+	}
+
+	config, err := s.repo.GetProgressionConfig(context, arg)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return progression.DefaultConfig(), nil
+		}
+		return progression.Config{}, fmt.Errorf("failed to get progression config: %w", err)
+	}
+	return config, nil
+}
+
+func (s *exerciseTypeService) UpdateProgressionConfig(context context.Context, exerciseTypeId string, config progression.Config, userId string) error {
+	err := config.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid progression config: %w", err)
+	}
+
+	uuid, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	if len(config.Plates) == 0 {
+		config.Plates = progression.DefaultPlates
+	}
+
+	plates := []string{}
+	for _, plate := range config.Plates {
+		plates = append(plates, strconv.FormatFloat(plate, 'f', -1, 64))
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	arg := repository.UpsertProgressionRuleParams{
+		ID:             uuid.String(),
+		Rule:           config.Rule,
+		Increment:      config.Increment,
+		MinReps:        int64(config.MinReps),
+		MaxReps:        int64(config.MaxReps),
+		TrainingMax:    config.TrainingMax,
+		Percentage:     config.Percentage,
+		BarWeight:      config.BarWeight,
+		Plates:         strings.Join(plates, ","),
+		CreatedOn:      now,
+		UpdatedOn:      now,
+		UserID:         userId,
+		ExerciseTypeID: exerciseTypeId,
+	}
+
+	err = s.repo.UpsertProgressionConfig(context, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update progression config: %w", err)
+	}
+	return nil
+}
+
+func (s *exerciseTypeService) GetSuggestion(context context.Context, exerciseTypeId string, userId string) (progression.Suggestion, error) {
+	kind, err := s.repo.GetMeasurement(context, repository.GetExerciseTypeByIdParams{
+		ID:     exerciseTypeId,
+		UserID: userId,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return progression.Suggestion{}, err
+	}
+	// Progression rules work on weight and reps, timed and distance sets have nothing to progress
+	if kind != "" && kind != measurement.WeightReps && kind != measurement.BodyweightReps {
+		return progression.Suggestion{}, fmt.Errorf("no progression suggestions for %s exercise types", kind)
+	}
+
+	config, err := s.GetProgressionConfig(context, exerciseTypeId, userId)
+	if err != nil {
+		return progression.Suggestion{}, err
+	}
+
+	arg := repository.GetSetHistoryByExerciseTypeIdParams{
+		ID:     exerciseTypeId,
+		UserID: userId,
+		Limit:  suggestionHistoryLimit,
+	}
+	history, err := s.repo.GetSessionHistory(context, arg)
+	if err != nil {
+		return progression.Suggestion{}, fmt.Errorf("failed to get session history: %w", err)
+	}
+
+	return progression.Suggest(config, history)
+}
+
+func (s *exerciseTypeService) GetOneRepMaxHistory(context context.Context, exerciseTypeId string, formula string, bucket string, userId string) ([]strength.Point, error) {
+	arg := repository.GetCompletedSetsByExerciseTypeIdParams{
+		ID:     exerciseTypeId,
+		UserID: userId,
+	}
+	sets, err := s.repo.GetCompletedSets(context, arg)
+	if err != nil {
+		return []strength.Point{}, fmt.Errorf("failed to get completed sets: %w", err)
+	}
+
+	userPreferences, err := s.preferences.Get(context, userId)
+	if err != nil {
+		return []strength.Point{}, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	return strength.History(sets, formula, bucket, userPreferences.Location(), userPreferences.FirstDayOfWeek())
+}
+
+func (s *exerciseTypeService) GetHistory(context context.Context, exerciseTypeId string, limit int, userId string) ([]HistorySession, error) {
+	arg := repository.GetExerciseTypeHistoryParams{
+		ID:     exerciseTypeId,
+		UserID: userId,
+		Limit:  int64(limit),
+	}
+	history, err := s.repo.GetHistory(context, arg)
+	if err != nil {
+		return []HistorySession{}, fmt.Errorf("failed to get history: %w", err)
+	}
+	return history, nil
 }
 
 func (s *exerciseTypeService) UpdateById(context context.Context, exerciseTypeId string, updateExerciseTypeRequest updateExerciseTypeRequest, userId string) error {
@@ -47,12 +189,42 @@ func (s *exerciseTypeService) GetLastWeightRepsByExerciseTypeId(context context.
 }
 
 func (s *exerciseTypeService) GetMaxWeightRepsByExerciseTypeId(context context.Context, exerciseTypeId string, userId string) (MaxLastWeightReps, error) {
-	arg := repository.GetMaxWeightRepsByExerciseTypeIdParams{
+	kind, err := s.repo.GetMeasurement(context, repository.GetExerciseTypeByIdParams{
 		ID:     exerciseTypeId,
 		UserID: userId,
+	})
+	if err != nil {
+		// Return 0/0 if the exercise type does not exist, same as when there are no sets
+		if errors.Is(err, sql.ErrNoRows) {
+			return MaxLastWeightReps{}, nil
+		}
+		return MaxLastWeightReps{}, err
 	}
 
-	return s.repo.GetMaxWeightRepsByExerciseTypeId(context, arg)
+	if kind == measurement.WeightReps {
+		arg := repository.GetMaxWeightRepsByExerciseTypeIdParams{
+			ID:     exerciseTypeId,
+			UserID: userId,
+		}
+		return s.repo.GetMaxWeightRepsByExerciseTypeId(context, arg)
+	}
+
+	// Other kinds are not ranked by weight, pick the best set by its kind instead
+	sets, err := s.repo.GetMeasuredSets(context, repository.GetMeasuredSetsByExerciseTypeIdParams{
+		ID:     exerciseTypeId,
+		UserID: userId,
+	})
+	if err != nil {
+		return MaxLastWeightReps{}, err
+	}
+
+	best := measurement.Best(kind, sets)
+	return MaxLastWeightReps{
+		Weight:          best.Weight,
+		Reps:            best.Reps,
+		DurationSeconds: best.DurationSeconds,
+		DistanceMeters:  best.DistanceMeters,
+	}, nil
 }
 
 func (s *exerciseTypeService) CreateAndReturnId(context context.Context, exerciseType createExerciseTypeRequest, userId string) (string, error) {
@@ -61,27 +233,115 @@ func (s *exerciseTypeService) CreateAndReturnId(context context.Context, exercis
 		return "", fmt.Errorf("failed to generate UUID: %w", err)
 	}
 
+	err = validateMetadata(exerciseType.Equipment, exerciseType.MovementPattern, exerciseType.MuscleGroups)
+	if err != nil {
+		return "", fmt.Errorf("invalid exercise type metadata: %w", err)
+	}
+
+	kind, err := measurement.Normalize(exerciseType.Measurement)
+	if err != nil {
+		return "", err
+	}
+
 	toCreate := repository.CreateExerciseTypeAndReturnIdParams{
-		ID:        uuid.String(),
-		Name:      strings.TrimSpace(exerciseType.Name),
-		CreatedOn: time.Now().UTC().Format(time.RFC3339),
-		UpdatedOn: time.Now().UTC().Format(time.RFC3339),
-		UserID:    userId,
+		ID:              uuid.String(),
+		Name:            strings.TrimSpace(exerciseType.Name),
+		Equipment:       utils.NullableString(exerciseType.Equipment),
+		MovementPattern: utils.NullableString(exerciseType.MovementPattern),
+		Measurement:     kind,
+		CreatedOn:       time.Now().UTC().Format(time.RFC3339),
+		UpdatedOn:       time.Now().UTC().Format(time.RFC3339),
+		UserID:          userId,
 	}
 
-	id, err := s.repo.CreateAndReturnId(context, toCreate)
+	// The exercise type and its muscle groups are created together
+	var id string
+	err = s.withTx(context, func(repo ExerciseTypeRepository) error {
+		var err error
+		id, err = repo.CreateAndReturnId(context, toCreate)
+		if err != nil {
+			return fmt.Errorf("failed to create exercise type: %w", err)
+		}
+
+		return createMuscleGroups(context, repo, id, exerciseType.MuscleGroups, userId)
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to create exercise type: %w", err)
+		return "", err
 	}
 	return id, nil
 }
 
+func (s *exerciseTypeService) UpdateMetadataById(context context.Context, exerciseTypeId string, metadata updateExerciseTypeMetadataRequest, userId string) error {
+	err := validateMetadata(metadata.Equipment, metadata.MovementPattern, metadata.MuscleGroups)
+	if err != nil {
+		return fmt.Errorf("invalid exercise type metadata: %w", err)
+	}
+
+	arg := repository.UpdateExerciseTypeMetadataParams{
+		Equipment:       utils.NullableString(metadata.Equipment),
+		MovementPattern: utils.NullableString(metadata.MovementPattern),
+		UpdatedOn:       time.Now().UTC().Format(time.RFC3339),
+		ID:              exerciseTypeId,
+		UserID:          userId,
+	}
+	// The muscle groups are replaced as a whole, a failure keeps the previous ones
+	return s.withTx(context, func(repo ExerciseTypeRepository) error {
+		err := repo.UpdateMetadata(context, arg)
+		if err != nil {
+			return fmt.Errorf("failed to update exercise type metadata: %w", err)
+		}
+
+		err = repo.DeleteMuscleGroups(context, repository.DeleteMuscleGroupsByExerciseTypeIdParams{
+			ExerciseTypeID: exerciseTypeId,
+			UserID:         userId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete muscle groups: %w", err)
+		}
+
+		return createMuscleGroups(context, repo, exerciseTypeId, metadata.MuscleGroups, userId)
+	})
+}
+
+func createMuscleGroups(context context.Context, repo ExerciseTypeRepository, exerciseTypeId string, muscleGroups []MuscleGroup, userId string) error {
+	for _, v := range muscleGroups {
+		uuid, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("failed to generate UUID: %w", err)
+		}
+
+		_, err = repo.CreateMuscleGroupAndReturnId(context, repository.CreateExerciseTypeMuscleGroupAndReturnIdParams{
+			ID:             uuid.String(),
+			MuscleGroup:    v.Name,
+			Role:           v.Role,
+			CreatedOn:      time.Now().UTC().Format(time.RFC3339),
+			UpdatedOn:      time.Now().UTC().Format(time.RFC3339),
+			UserID:         userId,
+			ExerciseTypeID: exerciseTypeId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create muscle group: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *exerciseTypeService) DeleteById(context context.Context, exerciseTypeId string, userId string) error {
 	arg := repository.DeleteExerciseTypeByIdParams{
 		ID:     exerciseTypeId,
 		UserID: userId,
 	}
-	return s.repo.DeleteById(context, arg)
+	return s.withTx(context, func(repo ExerciseTypeRepository) error {
+		err := repo.DeleteById(context, arg)
+		if err != nil {
+			return err
+		}
+
+		return repo.DeleteMuscleGroups(context, repository.DeleteMuscleGroupsByExerciseTypeIdParams{
+			ExerciseTypeID: exerciseTypeId,
+			UserID:         userId,
+		})
+	})
 }
 
 func (s *exerciseTypeService) GetAll(context context.Context, userId string) ([]ExerciseType, error) {
@@ -97,6 +357,68 @@ func (s *exerciseTypeService) GetAll(context context.Context, userId string) ([]
 	return exerciseTypes, nil
 }
 
+// GetRestTarget returns the rest of the exercise type, or the default rest of
+// the user when the exercise type has none, together with when the next set is due
+func (s *exerciseTypeService) GetRestTarget(context context.Context, exerciseTypeId string, userId string) (RestTarget, error) {
+	exerciseType, err := s.repo.GetById(context, repository.GetExerciseTypeByIdParams{
+		ID:     exerciseTypeId,
+		UserID: userId,
+	})
+	if err != nil {
+		return RestTarget{}, err
+	}
+
+	target := RestTarget{Source: RestSourceExerciseType}
+	if exerciseType.RestSeconds != nil {
+		target.RestSeconds = *exerciseType.RestSeconds
+	} else {
+		userPreferences, err := s.preferences.Get(context, userId)
+		if err != nil {
+			return RestTarget{}, fmt.Errorf("failed to get preferences: %w", err)
+		}
+		target.RestSeconds = userPreferences.DefaultRestSeconds
+		target.Source = RestSourcePreferences
+	}
+
+	lastPerformedOn, err := s.repo.GetLastPerformedOn(context, repository.GetLastPerformedOnByExerciseTypeIdParams{
+		ExerciseTypeID: exerciseTypeId,
+		UserID:         userId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return target, nil
+		}
+		return RestTarget{}, err
+	}
+
+	last := lastPerformedOn.Format(time.RFC3339)
+	next := lastPerformedOn.Add(time.Duration(target.RestSeconds) * time.Second).Format(time.RFC3339)
+	target.LastPerformedOn = &last
+	target.NextSetOn = &next
+	return target, nil
+}
+
+// UpdateRestSeconds sets the rest of the exercise type, no rest falls back to the default rest of the user
+func (s *exerciseTypeService) UpdateRestSeconds(context context.Context, exerciseTypeId string, restSeconds *int, userId string) error {
+	err := validateRestSeconds(restSeconds)
+	if err != nil {
+		return err
+	}
+
+	var rest interface{}
+	if restSeconds != nil {
+		rest = int64(*restSeconds)
+	}
+	return s.repo.UpdateRestSeconds(context, repository.UpdateExerciseTypeRestSecondsParams{
+		RestSeconds: rest,
+		UpdatedOn:   time.Now().UTC().Format(time.RFC3339),
+		ID:          exerciseTypeId,
+		UserID:      userId,
+	})
+}
+
 type exerciseTypeService struct {
-	repo ExerciseTypeRepository
+	repo        ExerciseTypeRepository
+	preferences restPreferences
+	withTx      Transactor
 }
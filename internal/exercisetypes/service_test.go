@@ -2,8 +2,14 @@ package exercisetypes
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
+	"weight-tracker/internal/measurement"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/progression"
 	"weight-tracker/internal/repository"
+	"weight-tracker/internal/strength"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -38,11 +44,95 @@ func (m *repoMock) GetMaxWeightRepsByExerciseTypeId(ctx context.Context, arg rep
 	return args.Get(0).(MaxLastWeightReps), args.Error(1)
 }
 
+func (m *repoMock) GetMeasurement(ctx context.Context, arg repository.GetExerciseTypeByIdParams) (string, error) {
+	args := m.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (m *repoMock) GetMeasuredSets(ctx context.Context, arg repository.GetMeasuredSetsByExerciseTypeIdParams) ([]measurement.Set, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]measurement.Set), args.Error(1)
+}
+
 func (m *repoMock) UpdateById(ctx context.Context, arg repository.UpdateExerciseTypeParams) error {
 	args := m.Called(ctx, arg)
 	return args.Error(0)
 }
 
+func (m *repoMock) GetProgressionConfig(ctx context.Context, arg repository.GetProgressionRuleByExerciseTypeIdParams) (progression.Config, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(progression.Config), args.Error(1)
+}
+
+func (m *repoMock) UpsertProgressionConfig(ctx context.Context, arg repository.UpsertProgressionRuleParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *repoMock) GetSessionHistory(ctx context.Context, arg repository.GetSetHistoryByExerciseTypeIdParams) ([]progression.Session, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]progression.Session), args.Error(1)
+}
+
+func (m *repoMock) GetHistory(ctx context.Context, arg repository.GetExerciseTypeHistoryParams) ([]HistorySession, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]HistorySession), args.Error(1)
+}
+
+func (m *repoMock) GetCompletedSets(ctx context.Context, arg repository.GetCompletedSetsByExerciseTypeIdParams) ([]strength.Set, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]strength.Set), args.Error(1)
+}
+
+func (m *repoMock) UpdateMetadata(ctx context.Context, arg repository.UpdateExerciseTypeMetadataParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *repoMock) CreateMuscleGroupAndReturnId(ctx context.Context, arg repository.CreateExerciseTypeMuscleGroupAndReturnIdParams) (string, error) {
+	args := m.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (m *repoMock) DeleteMuscleGroups(ctx context.Context, arg repository.DeleteMuscleGroupsByExerciseTypeIdParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *repoMock) GetById(ctx context.Context, arg repository.GetExerciseTypeByIdParams) (ExerciseType, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(ExerciseType), args.Error(1)
+}
+
+func (m *repoMock) UpdateRestSeconds(ctx context.Context, arg repository.UpdateExerciseTypeRestSecondsParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *repoMock) GetLastPerformedOn(ctx context.Context, arg repository.GetLastPerformedOnByExerciseTypeIdParams) (time.Time, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+// transactorStub runs fn on the repository it holds and counts the transactions
+type transactorStub struct {
+	repo ExerciseTypeRepository
+	runs int
+}
+
+func (s *transactorStub) withTx(ctx context.Context, fn func(ExerciseTypeRepository) error) error {
+	s.runs++
+	return fn(s.repo)
+}
+
+type preferencesStub struct {
+	preferences preferences.Preferences
+}
+
+func (p preferencesStub) Get(ctx context.Context, userId string) (preferences.Preferences, error) {
+	return p.preferences, nil
+}
+
 func TestGetAll(t *testing.T) {
 	userId := "userid"
 
@@ -59,7 +149,7 @@ func TestGetAll(t *testing.T) {
 		{ID: "a", Name: "a"},
 	}, nil).Once()
 
-	service := NewService(&repoMock)
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
 
 	result, err := service.GetAll(ctx, userId)
 
@@ -78,11 +168,17 @@ func TestDeleteById(t *testing.T) {
 	repoMock.On("DeleteById", ctx, mock.MatchedBy(func(input repository.DeleteExerciseTypeByIdParams) bool {
 		return input.ID == exerciseTypeId && input.UserID == userId
 	})).Return(nil).Once()
+	repoMock.On("DeleteMuscleGroups", ctx, repository.DeleteMuscleGroupsByExerciseTypeIdParams{
+		ExerciseTypeID: exerciseTypeId,
+		UserID:         userId,
+	}).Return(nil).Once()
 
-	service := NewService(&repoMock)
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, transactor.withTx)
 	err := service.DeleteById(ctx, exerciseTypeId, userId)
 
 	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
 	repoMock.AssertExpectations(t)
 }
 
@@ -96,12 +192,14 @@ func TestCreateAndReturnId(t *testing.T) {
 		return input.Name == exerciseTypeName && input.CreatedOn != "" && input.UpdatedOn != "" && input.UserID == userId
 	})).Return("asdf", nil).Once()
 
-	service := NewService(&repoMock)
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, transactor.withTx)
 	_, err := service.CreateAndReturnId(context.Background(), createExerciseTypeRequest{
 		Name: exerciseTypeName,
 	}, userId)
 
 	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
 	repoMock.AssertExpectations(t)
 }
 
@@ -115,12 +213,478 @@ func TestCreateTrimWhitespaceAndReturnId(t *testing.T) {
 		return input.Name == "exerciseTypeId" && input.CreatedOn != "" && input.UpdatedOn != "" && input.UserID == userId
 	})).Return("asdf", nil).Once()
 
-	service := NewService(&repoMock)
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, transactor.withTx)
 	_, err := service.CreateAndReturnId(context.Background(), createExerciseTypeRequest{
 		Name: exerciseTypeName,
 	}, userId)
 
 	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+
+func TestGetProgressionConfigDefault(t *testing.T) {
+	userId := "userid"
+	exerciseTypeId := "exerciseTypeId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetProgressionConfig", ctx, repository.GetProgressionRuleByExerciseTypeIdParams{
+		ExerciseTypeID: exerciseTypeId,
+		UserID:         userId,
+	}).Return(progression.Config{}, sql.ErrNoRows).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	result, err := service.GetProgressionConfig(ctx, exerciseTypeId, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, progression.DefaultConfig(), result)
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateProgressionConfig(t *testing.T) {
+	userId := "userid"
+	exerciseTypeId := "exerciseTypeId"
+	ctx := context.Background()
+
+	config := progression.Config{
+		Rule:      progression.RuleDoubleProgression,
+		Increment: 2.5,
+		MinReps:   8,
+		MaxReps:   12,
+		BarWeight: 20,
+		Plates:    []float64{20, 10, 1.25},
+	}
+
+	repoMock := repoMock{}
+	repoMock.On("UpsertProgressionConfig", ctx, mock.MatchedBy(func(input repository.UpsertProgressionRuleParams) bool {
+		return input.ID != "" && input.Rule == progression.RuleDoubleProgression && input.MinReps == 8 && input.MaxReps == 12 &&
+			input.Plates == "20,10,1.25" && input.UserID == userId && input.ExerciseTypeID == exerciseTypeId
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	err := service.UpdateProgressionConfig(ctx, exerciseTypeId, config, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateProgressionConfigInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	err := service.UpdateProgressionConfig(ctx, "exerciseTypeId", progression.Config{Rule: "unknown", MinReps: 5, MaxReps: 5}, "userid")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "UpsertProgressionConfig", mock.Anything, mock.Anything)
+}
+
+func TestGetSuggestion(t *testing.T) {
+	userId := "userid"
+	exerciseTypeId := "exerciseTypeId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, repository.GetExerciseTypeByIdParams{ID: exerciseTypeId, UserID: userId}).Return(measurement.WeightReps, nil).Once()
+	repoMock.On("GetProgressionConfig", ctx, mock.Anything).Return(progression.DefaultConfig(), nil).Once()
+	repoMock.On("GetSessionHistory", ctx, mock.MatchedBy(func(input repository.GetSetHistoryByExerciseTypeIdParams) bool {
+		return input.ID == exerciseTypeId && input.UserID == userId && input.Limit == suggestionHistoryLimit
+	})).Return([]progression.Session{
+		{WorkoutID: "b", Sets: []progression.Set{{Weight: 100, Reps: 5}, {Weight: 100, Reps: 5}}},
+		{WorkoutID: "a", Sets: []progression.Set{{Weight: 97.5, Reps: 5}}},
+	}, nil).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	result, err := service.GetSuggestion(ctx, exerciseTypeId, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, progression.RuleLinear, result.Rule)
+	assert.Equal(t, 102.5, result.Weight)
+	assert.Equal(t, 5, result.Reps)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetSuggestionUnsupportedMeasurement(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.Duration, nil).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	_, err := service.GetSuggestion(ctx, "exerciseTypeId", "userid")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "GetSessionHistory", mock.Anything, mock.Anything)
+}
+
+func TestGetOneRepMaxHistory(t *testing.T) {
+	userId := "userid"
+	exerciseTypeId := "exerciseTypeId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetCompletedSets", ctx, repository.GetCompletedSetsByExerciseTypeIdParams{
+		ID:     exerciseTypeId,
+		UserID: userId,
+	}).Return([]strength.Set{
+		{CompletedOn: time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC), Weight: 100, Reps: 5},
+		{CompletedOn: time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC), Weight: 105, Reps: 3},
+		{CompletedOn: time.Date(2025, 1, 14, 10, 0, 0, 0, time.UTC), Weight: 110, Reps: 1},
+	}, nil).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	result, err := service.GetOneRepMaxHistory(ctx, exerciseTypeId, strength.FormulaEpley, strength.BucketWeek, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []strength.Point{
+		{Date: "2025-01-06", E1RM: 116.67, Weight: 100, Reps: 5},
+		{Date: "2025-01-13", E1RM: 110, Weight: 110, Reps: 1},
+	}, result)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetOneRepMaxHistoryInCalendarOfUser(t *testing.T) {
+	userId := "userid"
+	exerciseTypeId := "exerciseTypeId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetCompletedSets", ctx, mock.Anything).Return([]strength.Set{
+		// Saturday evening in New York, already sunday in UTC
+		{CompletedOn: time.Date(2025, 1, 12, 1, 0, 0, 0, time.UTC), Weight: 100, Reps: 5},
+		{CompletedOn: time.Date(2025, 1, 12, 15, 0, 0, 0, time.UTC), Weight: 110, Reps: 1},
+	}, nil).Once()
+
+	userPreferences := preferences.Defaults()
+	userPreferences.Timezone = "America/New_York"
+	userPreferences.WeekStart = "sunday"
+	service := NewService(&repoMock, preferencesStub{userPreferences}, nil)
+	result, err := service.GetOneRepMaxHistory(ctx, exerciseTypeId, strength.FormulaEpley, strength.BucketWeek, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []strength.Point{
+		{Date: "2025-01-05", E1RM: 116.67, Weight: 100, Reps: 5},
+		{Date: "2025-01-12", E1RM: 110, Weight: 110, Reps: 1},
+	}, result)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetHistory(t *testing.T) {
+	userId := "userid"
+	exerciseTypeId := "exerciseTypeId"
+	ctx := context.Background()
+	rpe := 8.5
+
+	repoMock := repoMock{}
+	repoMock.On("GetHistory", ctx, repository.GetExerciseTypeHistoryParams{
+		ID:     exerciseTypeId,
+		UserID: userId,
+		Limit:  5,
+	}).Return([]HistorySession{
+		{WorkoutID: "a", WorkoutName: "Push", CompletedOn: "2025-01-06T10:00:00Z", Sets: []HistorySet{
+			{ID: "s1", Type: "working", Weight: 100, Reps: 5, RPE: &rpe, Note: "Grindy"},
+		}},
+	}, nil).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	result, err := service.GetHistory(ctx, exerciseTypeId, 5, userId)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, 8.5, *result[0].Sets[0].RPE)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetHistoryError(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetHistory", ctx, mock.Anything).Return([]HistorySession{}, testError).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	_, err := service.GetHistory(ctx, "exerciseTypeId", 5, "userid")
+
+	assert.ErrorIs(t, err, testError)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateWithMetadataAndReturnId(t *testing.T) {
+	userId := "userid"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateExerciseTypeAndReturnIdParams) bool {
+		return input.Name == "Bench press" && input.Equipment == "barbell" && input.MovementPattern == "horizontal_push"
+	})).Return("exerciseTypeId", nil).Once()
+	repoMock.On("CreateMuscleGroupAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateExerciseTypeMuscleGroupAndReturnIdParams) bool {
+		return input.MuscleGroup == "chest" && input.Role == MuscleGroupRolePrimary && input.ExerciseTypeID == "exerciseTypeId" && input.UserID == userId
+	})).Return("a", nil).Once()
+	repoMock.On("CreateMuscleGroupAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateExerciseTypeMuscleGroupAndReturnIdParams) bool {
+		return input.MuscleGroup == "triceps" && input.Role == MuscleGroupRoleSecondary && input.ExerciseTypeID == "exerciseTypeId" && input.UserID == userId
+	})).Return("b", nil).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, transactor.withTx)
+	id, err := service.CreateAndReturnId(ctx, createExerciseTypeRequest{
+		Name:            "Bench press",
+		Equipment:       "barbell",
+		MovementPattern: "horizontal_push",
+		MuscleGroups: []MuscleGroup{
+			{Name: "chest", Role: MuscleGroupRolePrimary},
+			{Name: "triceps", Role: MuscleGroupRoleSecondary},
+		},
+	}, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "exerciseTypeId", id)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateDefaultsToWeightReps(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateExerciseTypeAndReturnIdParams) bool {
+		return input.Measurement == measurement.WeightReps
+	})).Return("exerciseTypeId", nil).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, transactor.withTx)
+	_, err := service.CreateAndReturnId(ctx, createExerciseTypeRequest{Name: "Bench press"}, "userid")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateWithMeasurement(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateExerciseTypeAndReturnIdParams) bool {
+		return input.Measurement == measurement.DistanceDuration
+	})).Return("exerciseTypeId", nil).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, transactor.withTx)
+	_, err := service.CreateAndReturnId(ctx, createExerciseTypeRequest{Name: "Run", Measurement: measurement.DistanceDuration}, "userid")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
 	repoMock.AssertExpectations(t)
 }
 
+func TestCreateWithUnknownMeasurement(t *testing.T) {
+	repoMock := repoMock{}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	_, err := service.CreateAndReturnId(context.Background(), createExerciseTypeRequest{Name: "Run", Measurement: "calories"}, "userid")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "CreateAndReturnId", mock.Anything, mock.Anything)
+}
+
+func TestGetMaxWeightRepsUsesQueryForWeightReps(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, repository.GetExerciseTypeByIdParams{ID: "a", UserID: "userid"}).Return(measurement.WeightReps, nil).Once()
+	repoMock.On("GetMaxWeightRepsByExerciseTypeId", ctx, repository.GetMaxWeightRepsByExerciseTypeIdParams{ID: "a", UserID: "userid"}).Return(MaxLastWeightReps{Weight: 100, Reps: 5}, nil).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	result, err := service.GetMaxWeightRepsByExerciseTypeId(ctx, "a", "userid")
+
+	assert.Nil(t, err)
+	assert.Equal(t, MaxLastWeightReps{Weight: 100, Reps: 5}, result)
+	repoMock.AssertNotCalled(t, "GetMeasuredSets", mock.Anything, mock.Anything)
+}
+
+func TestGetMaxWeightRepsPicksBestSetByMeasurement(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, repository.GetExerciseTypeByIdParams{ID: "a", UserID: "userid"}).Return(measurement.DistanceDuration, nil).Once()
+	repoMock.On("GetMeasuredSets", ctx, repository.GetMeasuredSetsByExerciseTypeIdParams{ID: "a", UserID: "userid"}).Return([]measurement.Set{
+		{DistanceMeters: 5000, DurationSeconds: 1500},
+		{DistanceMeters: 5000, DurationSeconds: 1450},
+		{DistanceMeters: 3000, DurationSeconds: 800},
+	}, nil).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	result, err := service.GetMaxWeightRepsByExerciseTypeId(ctx, "a", "userid")
+
+	assert.Nil(t, err)
+	assert.Equal(t, MaxLastWeightReps{DistanceMeters: 5000, DurationSeconds: 1450}, result)
+	repoMock.AssertNotCalled(t, "GetMaxWeightRepsByExerciseTypeId", mock.Anything, mock.Anything)
+}
+
+func TestGetMaxWeightRepsMissingExerciseType(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return("", sql.ErrNoRows).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	result, err := service.GetMaxWeightRepsByExerciseTypeId(ctx, "a", "userid")
+
+	assert.Nil(t, err)
+	assert.Equal(t, MaxLastWeightReps{}, result)
+}
+
+func TestCreateWithInvalidMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	_, err := service.CreateAndReturnId(ctx, createExerciseTypeRequest{
+		Name:         "Bench press",
+		MuscleGroups: []MuscleGroup{{Name: "chest", Role: "main"}},
+	}, "userid")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "CreateAndReturnId", mock.Anything, mock.Anything)
+}
+
+func TestUpdateMetadataById(t *testing.T) {
+	userId := "userid"
+	exerciseTypeId := "exerciseTypeId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("UpdateMetadata", ctx, mock.MatchedBy(func(input repository.UpdateExerciseTypeMetadataParams) bool {
+		return input.ID == exerciseTypeId && input.Equipment == nil && input.MovementPattern == "squat" && input.UserID == userId
+	})).Return(nil).Once()
+	repoMock.On("DeleteMuscleGroups", ctx, repository.DeleteMuscleGroupsByExerciseTypeIdParams{
+		ExerciseTypeID: exerciseTypeId,
+		UserID:         userId,
+	}).Return(nil).Once()
+	repoMock.On("CreateMuscleGroupAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateExerciseTypeMuscleGroupAndReturnIdParams) bool {
+		return input.MuscleGroup == "quads" && input.Role == MuscleGroupRolePrimary
+	})).Return("a", nil).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, transactor.withTx)
+	err := service.UpdateMetadataById(ctx, exerciseTypeId, updateExerciseTypeMetadataRequest{
+		MovementPattern: "squat",
+		MuscleGroups:    []MuscleGroup{{Name: "quads", Role: MuscleGroupRolePrimary}},
+	}, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateMetadataByIdReplacesMuscleGroupsInOneTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("UpdateMetadata", ctx, mock.Anything).Return(nil).Once()
+	repoMock.On("DeleteMuscleGroups", ctx, mock.Anything).Return(nil).Once()
+	repoMock.On("CreateMuscleGroupAndReturnId", ctx, mock.Anything).Return("", testError).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, transactor.withTx)
+	err := service.UpdateMetadataById(ctx, "exerciseTypeId", updateExerciseTypeMetadataRequest{
+		MuscleGroups: []MuscleGroup{{Name: "quads", Role: MuscleGroupRolePrimary}},
+	}, "userid")
+
+	// The error is returned from the transaction, so the deleted muscle groups are rolled back
+	assert.ErrorIs(t, err, testError)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestValidateMetadata(t *testing.T) {
+	assert.Nil(t, validateMetadata("", "", []MuscleGroup{}))
+	assert.NotNil(t, validateMetadata("spaceship", "", []MuscleGroup{}))
+	assert.NotNil(t, validateMetadata("", "jump", []MuscleGroup{}))
+	assert.NotNil(t, validateMetadata("", "", []MuscleGroup{{Name: "wings", Role: MuscleGroupRolePrimary}}))
+	assert.NotNil(t, validateMetadata("", "", []MuscleGroup{
+		{Name: "chest", Role: MuscleGroupRolePrimary},
+		{Name: "chest", Role: MuscleGroupRoleSecondary},
+	}))
+}
+
+func TestGetRestTargetOfExerciseType(t *testing.T) {
+	ctx := context.Background()
+	rest := int64(180)
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, repository.GetExerciseTypeByIdParams{ID: "exerciseTypeId", UserID: "userId"}).Return(ExerciseType{ID: "exerciseTypeId", RestSeconds: &rest}, nil).Once()
+	repoMock.On("GetLastPerformedOn", ctx, repository.GetLastPerformedOnByExerciseTypeIdParams{ExerciseTypeID: "exerciseTypeId", UserID: "userId"}).Return(time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), nil).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	target, err := service.GetRestTarget(ctx, "exerciseTypeId", "userId")
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(180), target.RestSeconds)
+	assert.Equal(t, RestSourceExerciseType, target.Source)
+	assert.Equal(t, "2025-01-01T10:00:00Z", *target.LastPerformedOn)
+	assert.Equal(t, "2025-01-01T10:03:00Z", *target.NextSetOn)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetRestTargetFromPreferences(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(ExerciseType{ID: "exerciseTypeId"}, nil).Once()
+	repoMock.On("GetLastPerformedOn", ctx, mock.Anything).Return(time.Time{}, sql.ErrNoRows).Once()
+
+	userPreferences := preferences.Defaults()
+	userPreferences.DefaultRestSeconds = 90
+	service := NewService(&repoMock, preferencesStub{userPreferences}, nil)
+	target, err := service.GetRestTarget(ctx, "exerciseTypeId", "userId")
+
+	assert.Nil(t, err)
+	assert.Equal(t, RestTarget{RestSeconds: 90, Source: RestSourcePreferences}, target)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetRestTargetMissingExerciseType(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(ExerciseType{}, sql.ErrNoRows).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+	_, err := service.GetRestTarget(ctx, "exerciseTypeId", "userId")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	repoMock.AssertNotCalled(t, "GetLastPerformedOn", mock.Anything, mock.Anything)
+}
+
+func TestUpdateRestSeconds(t *testing.T) {
+	ctx := context.Background()
+	rest := 120
+
+	repoMock := repoMock{}
+	repoMock.On("UpdateRestSeconds", ctx, mock.MatchedBy(func(input repository.UpdateExerciseTypeRestSecondsParams) bool {
+		return input.RestSeconds == int64(120) && input.ID == "exerciseTypeId" && input.UserID == "userId" && input.UpdatedOn != ""
+	})).Return(nil).Once()
+	repoMock.On("UpdateRestSeconds", ctx, mock.MatchedBy(func(input repository.UpdateExerciseTypeRestSecondsParams) bool {
+		return input.RestSeconds == nil
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+
+	assert.Nil(t, service.UpdateRestSeconds(ctx, "exerciseTypeId", &rest, "userId"))
+	assert.Nil(t, service.UpdateRestSeconds(ctx, "exerciseTypeId", nil, "userId"))
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateRestSecondsInvalid(t *testing.T) {
+	ctx := context.Background()
+	zero := 0
+	tooLong := preferences.MaxRestSeconds + 1
+
+	repoMock := repoMock{}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()}, nil)
+
+	assert.NotNil(t, service.UpdateRestSeconds(ctx, "exerciseTypeId", &zero, "userId"))
+	assert.NotNil(t, service.UpdateRestSeconds(ctx, "exerciseTypeId", &tooLong, "userId"))
+	repoMock.AssertNotCalled(t, "UpdateRestSeconds", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"weight-tracker/internal/database"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/units"
+)
+
+type handler struct {
+	service Service
+	units   unitPreferences
+}
+
+// Weights are stored in kilograms, the export uses the unit of the user
+type unitPreferences interface {
+	GetWeightUnit(ctx context.Context, userId string) (string, error)
+}
+
+func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
+	handler := handler{
+		service: NewService(func(ctx context.Context, fn func(ExportRepository) error) error {
+			return s.WithTx(ctx, func(q repository.Querier) error {
+				return fn(NewExportRepository(q))
+			})
+		}),
+		units: preferences.NewServiceFromDatabase(s),
+	}
+
+	mux.Handle("GET /me/export", authenticationWrapper(http.HandlerFunc(handler.exportHandler)))
+}
+
+var contentTypes = map[string]string{
+	FormatCSV:  "text/csv; charset=utf-8",
+	FormatJSON: "application/json",
+}
+
+func convertWorkout(workout Workout, unit string) Workout {
+	if workout.Bodyweight != nil {
+		bodyweight := units.FromKilograms(*workout.Bodyweight, unit)
+		workout.Bodyweight = &bodyweight
+	}
+	for _, item := range workout.ExerciseItems {
+		for _, exercise := range item.Exercises {
+			for i, set := range exercise.Sets {
+				exercise.Sets[i].Weight = units.FromKilograms(set.Weight, unit)
+			}
+		}
+	}
+	return workout
+}
+
+// exportHandler streams the workouts of the user as they are read. Until the
+// first workout is written an error is returned as usual, after that the
+// response has started and the export is cut short.
+func (h *handler) exportHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = FormatCSV
+	}
+	contentType, ok := contentTypes[format]
+	if !ok {
+		http.Error(w, "Invalid format", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to export workouts", http.StatusBadRequest)
+		return
+	}
+
+	writer, err := newWorkoutWriter(format, w, unit)
+	if err != nil {
+		slog.Warn("Failed to create export writer", "error", err)
+		http.Error(w, "Failed to export workouts", http.StatusBadRequest)
+		return
+	}
+
+	started := false
+	begin := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="workouts.%s"`, format))
+		return writer.begin()
+	}
+
+	controller := http.NewResponseController(w)
+	err = h.service.Walk(r.Context(), userId, func(workout Workout) error {
+		err := begin()
+		if err != nil {
+			return err
+		}
+		err = writer.write(convertWorkout(workout, unit))
+		if err != nil {
+			return err
+		}
+		// Not every writer can flush, the rest of the export is written either way
+		_ = controller.Flush()
+		return nil
+	})
+	if err != nil && !started {
+		slog.Warn("Failed to export workouts", "error", err)
+		http.Error(w, "Failed to export workouts", http.StatusBadRequest)
+		return
+	}
+	if err == nil {
+		err = begin()
+	}
+	if err == nil {
+		err = writer.end()
+	}
+	if err != nil {
+		slog.Error("Failed to write export", "error", err)
+	}
+}
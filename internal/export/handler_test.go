@@ -0,0 +1,157 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"weight-tracker/internal/exerciseitems"
+	"weight-tracker/internal/exercises"
+	"weight-tracker/internal/sets"
+	"weight-tracker/internal/units"
+	"weight-tracker/internal/workouts"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func populateContextWithSub(req *http.Request, userId string) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, "sub", userId)
+	return req.WithContext(ctx)
+}
+
+// serviceStub walks the workouts it holds and fails with err afterwards
+type serviceStub struct {
+	workouts []Workout
+	err      error
+}
+
+func (s serviceStub) Walk(ctx context.Context, userId string, fn func(Workout) error) error {
+	for _, v := range s.workouts {
+		err := fn(v)
+		if err != nil {
+			return err
+		}
+	}
+	return s.err
+}
+
+type unitsStub struct {
+	unit string
+}
+
+func (u unitsStub) GetWeightUnit(ctx context.Context, userId string) (string, error) {
+	return u.unit, nil
+}
+
+func exportedWorkout() Workout {
+	rpe := 8.5
+	bodyweight := 80.0
+	return Workout{
+		Workout: workouts.Workout{ID: "w1", Name: "Push", CompletedOn: "2025-01-01T18:00:00Z", CreatedOn: "2025-01-01T17:00:00Z", UpdatedOn: "2025-01-01T18:00:00Z", Bodyweight: &bodyweight},
+		ExerciseItems: []ExerciseItem{{
+			ExerciseItem: exerciseitems.ExerciseItem{ID: "i1", Type: "single", UserID: "userId", WorkoutID: "w1"},
+			Exercises: []Exercise{{
+				Exercise: exercises.Exercise{ID: "e1", Name: "Bench, flat", WorkoutID: "w1", ExerciseTypeID: "t1", ExerciseItemID: "i1"},
+				Sets: []sets.Set{
+					{ID: "s1", Repetitions: 5, Weight: 100, Type: "working", RPE: &rpe, ExerciseID: "e1"},
+					{ID: "s2", Repetitions: 3, Weight: 110, Type: "working", Note: "paused", ExerciseID: "e1"},
+				},
+			}},
+		}},
+	}
+}
+
+func TestExportHandlerCsv(t *testing.T) {
+	req, err := http.NewRequest("GET", "/me/export", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	rr := httptest.NewRecorder()
+	h := handler{service: serviceStub{workouts: []Workout{exportedWorkout()}}, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.exportHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="workouts.csv"`, rr.Header().Get("Content-Disposition"))
+
+	expected := "workout_id,workout_name,started_on,completed_on,bodyweight,exercise_item_id,exercise_item_type,exercise_id,exercise_name,exercise_type_id," +
+		"set_id,set_type,round,repetitions,weight,weight_unit,duration_seconds,distance_meters,rpe,rir,performed_on,rest_seconds,note\n" +
+		"w1,Push,,2025-01-01T18:00:00Z,80,i1,single,e1,\"Bench, flat\",t1,s1,working,,5,100,kg,,,8.5,,,,\n" +
+		"w1,Push,,2025-01-01T18:00:00Z,80,i1,single,e1,\"Bench, flat\",t1,s2,working,,3,110,kg,,,,,,,paused\n"
+	assert.Equal(t, expected, rr.Body.String())
+}
+
+func TestExportHandlerJsonConvertsPounds(t *testing.T) {
+	req, err := http.NewRequest("GET", "/me/export?format=json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	workout := exportedWorkout()
+	workout.ExerciseItems[0].Exercises[0].Sets = workout.ExerciseItems[0].Exercises[0].Sets[:1]
+	empty := Workout{Workout: workouts.Workout{ID: "w2", Name: "Rest"}, ExerciseItems: []ExerciseItem{}}
+
+	rr := httptest.NewRecorder()
+	h := handler{service: serviceStub{workouts: []Workout{workout, empty}}, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(h.exportHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	expected := `{"weight_unit":"lb","data":[` +
+		`{"id":"w1","name":"Push","completed_on":"2025-01-01T18:00:00Z","created_on":"2025-01-01T17:00:00Z","updated_on":"2025-01-01T18:00:00Z","note":"","bodyweight":176.37,` +
+		`"exercise_items":[{"id":"i1","type":"single","user_id":"userId","workout_id":"w1","created_on":"","updated_on":"",` +
+		`"exercises":[{"id":"e1","name":"Bench, flat","workout_id":"w1","exercise_type_id":"t1","exercise_item_id":"i1",` +
+		`"sets":[{"id":"s1","repetitions":5,"weight":220.46,"type":"working","rpe":8.5,"exercise_id":"e1"}]}]}]},` +
+		`{"id":"w2","name":"Rest","completed_on":null,"created_on":"","updated_on":"","note":"","exercise_items":[]}]}`
+	assert.Equal(t, expected, rr.Body.String())
+}
+
+func TestExportHandlerWithoutWorkouts(t *testing.T) {
+	req, err := http.NewRequest("GET", "/me/export?format=json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	rr := httptest.NewRecorder()
+	h := handler{service: serviceStub{}, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.exportHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"weight_unit":"kg","data":[]}`, rr.Body.String())
+}
+
+func TestExportHandlerInvalidFormat(t *testing.T) {
+	req, err := http.NewRequest("GET", "/me/export?format=xml", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	rr := httptest.NewRecorder()
+	h := handler{service: serviceStub{}, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.exportHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestExportHandlerFailsBeforeFirstWorkout(t *testing.T) {
+	req, err := http.NewRequest("GET", "/me/export", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	rr := httptest.NewRecorder()
+	h := handler{service: serviceStub{err: errors.New("database is locked")}, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.exportHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "Failed to export workouts\n", rr.Body.String())
+}
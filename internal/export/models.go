@@ -0,0 +1,32 @@
+package export
+
+import (
+	"weight-tracker/internal/exerciseitems"
+	"weight-tracker/internal/exercises"
+	"weight-tracker/internal/sets"
+	"weight-tracker/internal/workouts"
+)
+
+const (
+	// FormatCSV is one row per set with the workout, exercise item and exercise it belongs to
+	FormatCSV = "csv"
+	// FormatJSON is every workout with its exercise items, exercises and sets nested
+	FormatJSON = "json"
+)
+
+// Workout is a workout with everything logged in it. The exercise items mirror
+// exerciseitems.ExerciseItemWithExercises with the sets of every exercise.
+type Workout struct {
+	workouts.Workout
+	ExerciseItems []ExerciseItem `json:"exercise_items"`
+}
+
+type ExerciseItem struct {
+	exerciseitems.ExerciseItem
+	Exercises []Exercise `json:"exercises"`
+}
+
+type Exercise struct {
+	exercises.Exercise
+	Sets []sets.Set `json:"sets"`
+}
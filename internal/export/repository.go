@@ -0,0 +1,51 @@
+package export
+
+import (
+	"context"
+	"weight-tracker/internal/exerciseitems"
+	"weight-tracker/internal/exercises"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/sets"
+	"weight-tracker/internal/workouts"
+)
+
+type ExportRepository interface {
+	GetWorkouts(ctx context.Context, arg repository.GetAllWorkoutsParams) ([]workouts.Workout, error)
+	GetExerciseItems(ctx context.Context, arg repository.GetExerciseItemsByWorkoutIdParams) ([]exerciseitems.ExerciseItem, error)
+	GetExercises(ctx context.Context, arg repository.GetExercisesByWorkoutIdParams) ([]exercises.Exercise, error)
+	GetSets(ctx context.Context, arg repository.GetSetsByWorkoutIdParams) ([]sets.Set, error)
+}
+
+// NewExportRepository reads through the repositories of the workouts, exercise
+// items, exercises and sets so the export has the same fields as their endpoints
+func NewExportRepository(repo repository.Querier) ExportRepository {
+	return exportRepository{
+		workouts:      workouts.NewWorkoutsRepository(repo),
+		exerciseItems: exerciseitems.NewExerciseItemRepository(repo),
+		exercises:     exercises.NewExerciseRepository(repo),
+		sets:          sets.NewSetsRepository(repo),
+	}
+}
+
+type exportRepository struct {
+	workouts      workouts.WorkoutsRepository
+	exerciseItems exerciseitems.ExerciseItemRepository
+	exercises     exercises.ExerciseRepository
+	sets          sets.SetsRepository
+}
+
+func (e exportRepository) GetWorkouts(ctx context.Context, arg repository.GetAllWorkoutsParams) ([]workouts.Workout, error) {
+	return e.workouts.GetAll(ctx, arg)
+}
+
+func (e exportRepository) GetExerciseItems(ctx context.Context, arg repository.GetExerciseItemsByWorkoutIdParams) ([]exerciseitems.ExerciseItem, error) {
+	return e.exerciseItems.GetByWorkoutId(ctx, arg)
+}
+
+func (e exportRepository) GetExercises(ctx context.Context, arg repository.GetExercisesByWorkoutIdParams) ([]exercises.Exercise, error) {
+	return e.exercises.GetByWorkoutId(ctx, arg)
+}
+
+func (e exportRepository) GetSets(ctx context.Context, arg repository.GetSetsByWorkoutIdParams) ([]sets.Set, error) {
+	return e.sets.GetByWorkoutId(ctx, arg)
+}
@@ -0,0 +1,123 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"weight-tracker/internal/exerciseitems"
+	"weight-tracker/internal/exercises"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/sets"
+	"weight-tracker/internal/workouts"
+)
+
+type Service interface {
+	// Walk calls fn with every workout of the user, newest first. Workouts are
+	// read a page at a time, so the history is never loaded all at once. All
+	// pages are read in one transaction, so a workout created or deleted during
+	// the export can not shift the pages.
+	Walk(ctx context.Context, userId string, fn func(Workout) error) error
+}
+
+// Transactor runs fn with a repository whose queries share one transaction
+type Transactor func(ctx context.Context, fn func(ExportRepository) error) error
+
+type exportService struct {
+	withTx Transactor
+}
+
+func NewService(withTx Transactor) Service {
+	return &exportService{withTx: withTx}
+}
+
+// Number of workouts read from the database at a time
+const pageSize = 50
+
+func (s *exportService) Walk(ctx context.Context, userId string, fn func(Workout) error) error {
+	return s.withTx(ctx, func(repo ExportRepository) error {
+		return walk(ctx, repo, userId, fn)
+	})
+}
+
+func walk(ctx context.Context, repo ExportRepository, userId string, fn func(Workout) error) error {
+	for offset := 0; ; offset += pageSize {
+		page, err := repo.GetWorkouts(ctx, repository.GetAllWorkoutsParams{
+			UserID: userId,
+			Offset: int64(offset),
+			Limit:  pageSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, v := range page {
+			workout, err := load(ctx, repo, v, userId)
+			if err != nil {
+				return fmt.Errorf("failed to load workout %s: %w", v.ID, err)
+			}
+			err = fn(workout)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(page) < pageSize {
+			return nil
+		}
+	}
+}
+
+func load(ctx context.Context, repo ExportRepository, workout workouts.Workout, userId string) (Workout, error) {
+	items, err := repo.GetExerciseItems(ctx, repository.GetExerciseItemsByWorkoutIdParams{
+		WorkoutID: workout.ID,
+		UserID:    userId,
+	})
+	if err != nil {
+		return Workout{}, err
+	}
+
+	exs, err := repo.GetExercises(ctx, repository.GetExercisesByWorkoutIdParams{
+		WorkoutID: workout.ID,
+		UserID:    userId,
+	})
+	if err != nil {
+		return Workout{}, err
+	}
+
+	workoutSets, err := repo.GetSets(ctx, repository.GetSetsByWorkoutIdParams{
+		WorkoutID: workout.ID,
+		UserID:    userId,
+	})
+	if err != nil {
+		return Workout{}, err
+	}
+
+	return nest(workout, items, exs, workoutSets), nil
+}
+
+// nest puts the sets under their exercises and the exercises under their
+// exercise items, keeping the order they were read in
+func nest(workout workouts.Workout, items []exerciseitems.ExerciseItem, exs []exercises.Exercise, workoutSets []sets.Set) Workout {
+	setsByExercise := map[string][]sets.Set{}
+	for _, v := range workoutSets {
+		setsByExercise[v.ExerciseID] = append(setsByExercise[v.ExerciseID], v)
+	}
+
+	exercisesByItem := map[string][]Exercise{}
+	for _, v := range exs {
+		exerciseSets := setsByExercise[v.ID]
+		if exerciseSets == nil {
+			exerciseSets = []sets.Set{}
+		}
+		exercisesByItem[v.ExerciseItemID] = append(exercisesByItem[v.ExerciseItemID], Exercise{Exercise: v, Sets: exerciseSets})
+	}
+
+	result := Workout{Workout: workout, ExerciseItems: []ExerciseItem{}}
+	for _, v := range items {
+		itemExercises := exercisesByItem[v.ID]
+		if itemExercises == nil {
+			itemExercises = []Exercise{}
+		}
+		result.ExerciseItems = append(result.ExerciseItems, ExerciseItem{ExerciseItem: v, Exercises: itemExercises})
+	}
+	return result
+}
@@ -0,0 +1,168 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"weight-tracker/internal/exerciseitems"
+	"weight-tracker/internal/exercises"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/sets"
+	"weight-tracker/internal/workouts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type repoMock struct {
+	mock.Mock
+}
+
+func (m *repoMock) GetWorkouts(ctx context.Context, arg repository.GetAllWorkoutsParams) ([]workouts.Workout, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]workouts.Workout), args.Error(1)
+}
+
+func (m *repoMock) GetExerciseItems(ctx context.Context, arg repository.GetExerciseItemsByWorkoutIdParams) ([]exerciseitems.ExerciseItem, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]exerciseitems.ExerciseItem), args.Error(1)
+}
+
+func (m *repoMock) GetExercises(ctx context.Context, arg repository.GetExercisesByWorkoutIdParams) ([]exercises.Exercise, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]exercises.Exercise), args.Error(1)
+}
+
+func (m *repoMock) GetSets(ctx context.Context, arg repository.GetSetsByWorkoutIdParams) ([]sets.Set, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]sets.Set), args.Error(1)
+}
+
+// transactorStub runs fn on the repository it holds and counts the transactions
+type transactorStub struct {
+	repo ExportRepository
+	runs int
+}
+
+func (s *transactorStub) withTx(ctx context.Context, fn func(ExportRepository) error) error {
+	s.runs++
+	return fn(s.repo)
+}
+
+func expectEmptyWorkout(repoMock *repoMock, ctx context.Context, workoutId string, userId string) {
+	repoMock.On("GetExerciseItems", ctx, repository.GetExerciseItemsByWorkoutIdParams{WorkoutID: workoutId, UserID: userId}).
+		Return([]exerciseitems.ExerciseItem{}, nil).Once()
+	repoMock.On("GetExercises", ctx, repository.GetExercisesByWorkoutIdParams{WorkoutID: workoutId, UserID: userId}).
+		Return([]exercises.Exercise{}, nil).Once()
+	repoMock.On("GetSets", ctx, repository.GetSetsByWorkoutIdParams{WorkoutID: workoutId, UserID: userId}).
+		Return([]sets.Set{}, nil).Once()
+}
+
+func TestWalkNestsSetsUnderExercisesAndItems(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetWorkouts", ctx, repository.GetAllWorkoutsParams{UserID: userId, Offset: 0, Limit: pageSize}).
+		Return([]workouts.Workout{{ID: "w1", Name: "Push"}}, nil).Once()
+	repoMock.On("GetExerciseItems", ctx, repository.GetExerciseItemsByWorkoutIdParams{WorkoutID: "w1", UserID: userId}).
+		Return([]exerciseitems.ExerciseItem{{ID: "i1", Type: "superset"}, {ID: "i2", Type: "single"}}, nil).Once()
+	repoMock.On("GetExercises", ctx, repository.GetExercisesByWorkoutIdParams{WorkoutID: "w1", UserID: userId}).
+		Return([]exercises.Exercise{
+			{ID: "e1", Name: "Bench", ExerciseItemID: "i1"},
+			{ID: "e2", Name: "Row", ExerciseItemID: "i1"},
+		}, nil).Once()
+	repoMock.On("GetSets", ctx, repository.GetSetsByWorkoutIdParams{WorkoutID: "w1", UserID: userId}).
+		Return([]sets.Set{
+			{ID: "s1", Weight: 100, Repetitions: 5, ExerciseID: "e1"},
+			{ID: "s2", Weight: 60, Repetitions: 8, ExerciseID: "e2"},
+			{ID: "s3", Weight: 100, Repetitions: 4, ExerciseID: "e1"},
+		}, nil).Once()
+
+	result := []Workout{}
+	transactor := transactorStub{repo: &repoMock}
+	err := NewService(transactor.withTx).Walk(ctx, userId, func(workout Workout) error {
+		result = append(result, workout)
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Workout{{
+		Workout: workouts.Workout{ID: "w1", Name: "Push"},
+		ExerciseItems: []ExerciseItem{
+			{
+				ExerciseItem: exerciseitems.ExerciseItem{ID: "i1", Type: "superset"},
+				Exercises: []Exercise{
+					{
+						Exercise: exercises.Exercise{ID: "e1", Name: "Bench", ExerciseItemID: "i1"},
+						Sets: []sets.Set{
+							{ID: "s1", Weight: 100, Repetitions: 5, ExerciseID: "e1"},
+							{ID: "s3", Weight: 100, Repetitions: 4, ExerciseID: "e1"},
+						},
+					},
+					{
+						Exercise: exercises.Exercise{ID: "e2", Name: "Row", ExerciseItemID: "i1"},
+						Sets:     []sets.Set{{ID: "s2", Weight: 60, Repetitions: 8, ExerciseID: "e2"}},
+					},
+				},
+			},
+			{
+				ExerciseItem: exerciseitems.ExerciseItem{ID: "i2", Type: "single"},
+				Exercises:    []Exercise{},
+			},
+		},
+	}}, result)
+	repoMock.AssertExpectations(t)
+}
+
+func TestWalkReadsWorkoutsInPages(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	firstPage := []workouts.Workout{}
+	for i := 0; i < pageSize; i++ {
+		firstPage = append(firstPage, workouts.Workout{ID: "a"})
+	}
+
+	repoMock := repoMock{}
+	repoMock.On("GetWorkouts", ctx, repository.GetAllWorkoutsParams{UserID: userId, Offset: 0, Limit: pageSize}).
+		Return(firstPage, nil).Once()
+	repoMock.On("GetWorkouts", ctx, repository.GetAllWorkoutsParams{UserID: userId, Offset: pageSize, Limit: pageSize}).
+		Return([]workouts.Workout{{ID: "b"}}, nil).Once()
+	for i := 0; i < pageSize; i++ {
+		expectEmptyWorkout(&repoMock, ctx, "a", userId)
+	}
+	expectEmptyWorkout(&repoMock, ctx, "b", userId)
+
+	walked := 0
+	transactor := transactorStub{repo: &repoMock}
+	err := NewService(transactor.withTx).Walk(ctx, userId, func(workout Workout) error {
+		walked++
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, pageSize+1, walked)
+	// Every page is read in the same transaction
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestWalkStopsOnError(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetWorkouts", ctx, repository.GetAllWorkoutsParams{UserID: userId, Offset: 0, Limit: pageSize}).
+		Return([]workouts.Workout{{ID: "a"}, {ID: "b"}}, nil).Once()
+	expectEmptyWorkout(&repoMock, ctx, "a", userId)
+
+	failed := errors.New("client went away")
+	transactor := transactorStub{repo: &repoMock}
+	err := NewService(transactor.withTx).Walk(ctx, userId, func(workout Workout) error {
+		return failed
+	})
+
+	assert.ErrorIs(t, err, failed)
+	repoMock.AssertExpectations(t)
+}
@@ -0,0 +1,139 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// workoutWriter writes the export a workout at a time. Begin is called before
+// the first workout and end after the last one.
+type workoutWriter interface {
+	begin() error
+	write(workout Workout) error
+	end() error
+}
+
+func newWorkoutWriter(format string, w io.Writer, unit string) (workoutWriter, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w), unit: unit}, nil
+	case FormatJSON:
+		return &jsonWriter{w: w, unit: unit}, nil
+	}
+	return nil, fmt.Errorf("unknown export format: %s", format)
+}
+
+var csvHeader = []string{
+	"workout_id", "workout_name", "started_on", "completed_on", "bodyweight",
+	"exercise_item_id", "exercise_item_type",
+	"exercise_id", "exercise_name", "exercise_type_id",
+	"set_id", "set_type", "round", "repetitions", "weight", "weight_unit",
+	"duration_seconds", "distance_meters", "rpe", "rir", "performed_on", "rest_seconds", "note",
+}
+
+type csvWriter struct {
+	w    *csv.Writer
+	unit string
+}
+
+func (c *csvWriter) begin() error {
+	return c.w.Write(csvHeader)
+}
+
+// write adds a row for every set of the workout, a workout without sets has no rows
+func (c *csvWriter) write(workout Workout) error {
+	completedOn, _ := workout.CompletedOn.(string)
+	for _, item := range workout.ExerciseItems {
+		for _, exercise := range item.Exercises {
+			for _, set := range exercise.Sets {
+				err := c.w.Write([]string{
+					workout.ID, workout.Name, stringOrEmpty(workout.StartedOn), completedOn, floatOrEmpty(workout.Bodyweight),
+					item.ID, item.Type,
+					exercise.ID, exercise.Name, exercise.ExerciseTypeID,
+					set.ID, set.Type, intOrEmpty(set.Round), strconv.FormatInt(set.Repetitions, 10), formatFloat(set.Weight), c.unit,
+					intOrEmpty(set.DurationSeconds), floatOrEmpty(set.DistanceMeters), floatOrEmpty(set.RPE), intOrEmpty(set.RIR),
+					stringOrEmpty(set.PerformedOn), intOrEmpty(set.RestSeconds), set.Note,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) end() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonWriter writes the workouts as the data array of a response, with the
+// unit the weights are in
+type jsonWriter struct {
+	w       io.Writer
+	unit    string
+	written int
+}
+
+func (j *jsonWriter) begin() error {
+	unit, err := json.Marshal(j.unit)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(j.w, `{"weight_unit":%s,"data":[`, unit)
+	return err
+}
+
+func (j *jsonWriter) write(workout Workout) error {
+	data, err := json.Marshal(workout)
+	if err != nil {
+		return err
+	}
+	if j.written > 0 {
+		_, err = io.WriteString(j.w, ",")
+		if err != nil {
+			return err
+		}
+	}
+	_, err = j.w.Write(data)
+	if err != nil {
+		return err
+	}
+	j.written++
+	return nil
+}
+
+func (j *jsonWriter) end() error {
+	_, err := io.WriteString(j.w, "]}")
+	return err
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func floatOrEmpty(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return formatFloat(*v)
+}
+
+func intOrEmpty(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+func stringOrEmpty(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -0,0 +1,173 @@
+package goals
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"weight-tracker/internal/database"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/units"
+	"weight-tracker/internal/utils"
+)
+
+type handler struct {
+	service Service
+	units   unitPreferences
+}
+
+// Weights are stored in kilograms, requests and responses use the unit of the user
+type unitPreferences interface {
+	GetWeightUnit(ctx context.Context, userId string) (string, error)
+}
+
+func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
+	handler := handler{
+		service: NewServiceFromDatabase(s),
+		units:   preferences.NewServiceFromDatabase(s),
+	}
+
+	mux.Handle("GET /goals", authenticationWrapper(http.HandlerFunc(handler.getGoalsHandler)))
+	mux.Handle("GET /goals/{id}", authenticationWrapper(http.HandlerFunc(handler.getGoalByIdHandler)))
+	mux.Handle("POST /goals", authenticationWrapper(http.HandlerFunc(handler.createGoalHandler)))
+	mux.Handle("DELETE /goals/{id}", authenticationWrapper(http.HandlerFunc(handler.deleteGoalByIdHandler)))
+}
+
+// NewServiceFromDatabase wires the goals service from the database service
+func NewServiceFromDatabase(s database.Service) Service {
+	return NewService(NewGoalsRepository(s.GetRepository()))
+}
+
+// convertGoal converts the weights of the goal to the unit, frequency goals
+// count workouts and are left as they are
+func convertGoal(goal Goal, unit string) Goal {
+	if goal.Kind == KindFrequency {
+		return goal
+	}
+	if goal.TargetWeight != nil {
+		weight := units.FromKilograms(*goal.TargetWeight, unit)
+		goal.TargetWeight = &weight
+	}
+	goal.TargetValue = units.FromKilograms(goal.TargetValue, unit)
+	goal.StartValue = units.FromKilograms(goal.StartValue, unit)
+	goal.CurrentValue = units.FromKilograms(goal.CurrentValue, unit)
+	return goal
+}
+
+func (h *handler) getGoalsHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	goals, err := h.service.GetAll(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get goals", "error", err)
+		http.Error(w, "Failed to get goals", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get goals", http.StatusBadRequest)
+		return
+	}
+	for i, v := range goals {
+		goals[i] = convertGoal(v, unit)
+	}
+
+	jsonResp, err := utils.CreateResponse(goals)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) getGoalByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	id := r.PathValue("id")
+
+	goal, err := h.service.GetById(r.Context(), id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		slog.Warn("Failed to get goal", "error", err, "goalId", id)
+		http.Error(w, "Failed to get goal", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get goal", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(convertGoal(goal, unit))
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) createGoalHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	var req createGoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to create goal", http.StatusBadRequest)
+		return
+	}
+	if req.TargetWeight != nil {
+		weight := units.ToKilograms(*req.TargetWeight, unit)
+		req.TargetWeight = &weight
+	}
+	if req.TargetBodyweight != nil {
+		bodyweight := units.ToKilograms(*req.TargetBodyweight, unit)
+		req.TargetBodyweight = &bodyweight
+	}
+
+	id, err := h.service.CreateAndReturnId(r.Context(), req, userId)
+	if err != nil {
+		slog.Warn("Failed to create goal", "error", err)
+		http.Error(w, "Failed to create goal", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	jsonResp, err := utils.CreateIdResponse(id)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) deleteGoalByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	id := r.PathValue("id")
+
+	err := h.service.DeleteById(r.Context(), id, userId)
+	if err != nil {
+		slog.Error("Failed to delete goal", "error", err, "goalId", id)
+		http.Error(w, "Failed to delete goal", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
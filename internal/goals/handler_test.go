@@ -0,0 +1,141 @@
+package goals
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"weight-tracker/internal/units"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func populateContextWithSub(req *http.Request, userId string) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, "sub", userId)
+	return req.WithContext(ctx)
+}
+
+type serviceMock struct {
+	mock.Mock
+}
+
+func (s *serviceMock) GetAll(ctx context.Context, userId string) ([]Goal, error) {
+	args := s.Called(ctx, userId)
+	return args.Get(0).([]Goal), args.Error(1)
+}
+
+func (s *serviceMock) GetById(ctx context.Context, goalId string, userId string) (Goal, error) {
+	args := s.Called(ctx, goalId, userId)
+	return args.Get(0).(Goal), args.Error(1)
+}
+
+func (s *serviceMock) CreateAndReturnId(ctx context.Context, t createGoalRequest, userId string) (string, error) {
+	args := s.Called(ctx, t, userId)
+	return args.String(0), args.Error(1)
+}
+
+func (s *serviceMock) DeleteById(ctx context.Context, goalId string, userId string) error {
+	args := s.Called(ctx, goalId, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error {
+	args := s.Called(ctx, workoutId, userId)
+	return args.Error(0)
+}
+
+type unitsStub struct {
+	unit string
+}
+
+func (u unitsStub) GetWeightUnit(ctx context.Context, userId string) (string, error) {
+	return u.unit, nil
+}
+
+func TestGetGoalsHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/goals", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetAll", req.Context(), userId).Return([]Goal{
+		{ID: "a", Kind: KindBodyweight, TargetValue: 80, StartValue: 90, CurrentValue: 85, PercentComplete: 50, Deadline: "2025-03-01", CreatedOn: "c", UpdatedOn: "u"},
+		{ID: "b", Kind: KindFrequency, TargetValue: 4, CurrentValue: 2, PercentComplete: 50, Deadline: "2025-03-01", CreatedOn: "c", UpdatedOn: "u"},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(h.getGoalsHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":[{"id":"a","kind":"bodyweight","target_value":176.37,"start_value":198.42,"current_value":187.39,"percent_complete":50,"deadline":"2025-03-01","on_track":false,"created_on":"c","updated_on":"u"},` +
+		`{"id":"b","kind":"frequency","target_value":4,"start_value":0,"current_value":2,"percent_complete":50,"deadline":"2025-03-01","on_track":false,"created_on":"c","updated_on":"u"}]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetGoalByIdHandlerNotFound(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/goals/nope", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", "nope")
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetById", req.Context(), "nope", userId).Return(Goal{}, sql.ErrNoRows).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.getGoalByIdHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestCreateGoalHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+
+	body := []byte(`{"kind":"strength","exercise_type_id":"bench","target_weight":225,"target_repetitions":5,"deadline":"2099-01-31"}`)
+	req, err := http.NewRequest("POST", "/goals", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("CreateAndReturnId", req.Context(), mock.MatchedBy(func(input createGoalRequest) bool {
+		return input.Kind == KindStrength && *input.ExerciseTypeID == "bench" &&
+			units.FromKilograms(*input.TargetWeight, units.Kilograms) == 102.06 && *input.TargetRepetitions == 5
+	}), userId).Return("goalId", nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(h.createGoalHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	expected := `{"id":"goalId"}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
@@ -0,0 +1,103 @@
+package goals
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	// KindStrength is lifting a weight for a number of reps on an exercise type
+	KindStrength = "strength"
+	// KindBodyweight is reaching a bodyweight, either by losing or gaining weight
+	KindBodyweight = "bodyweight"
+	// KindFrequency is completing a number of workouts within a week
+	KindFrequency = "frequency"
+)
+
+var KindNames = []string{KindStrength, KindBodyweight, KindFrequency}
+
+// Goal is a target to reach before the deadline. Progress is measured in the
+// target value of the kind: the estimated one rep max of the target weight × reps,
+// the bodyweight or the workouts completed in the last seven days. Weights are
+// stored in kilograms.
+type Goal struct {
+	ID                string   `json:"id"`
+	Kind              string   `json:"kind"`
+	ExerciseTypeID    *string  `json:"exercise_type_id,omitempty"`
+	TargetWeight      *float64 `json:"target_weight,omitempty"`
+	TargetRepetitions *int64   `json:"target_repetitions,omitempty"`
+	TargetValue       float64  `json:"target_value"`
+	StartValue        float64  `json:"start_value"`
+	CurrentValue      float64  `json:"current_value"`
+	PercentComplete   float64  `json:"percent_complete"`
+	Deadline          string   `json:"deadline"`
+	AchievedOn        *string  `json:"achieved_on,omitempty"`
+	ProjectedOn       *string  `json:"projected_on,omitempty"`
+	OnTrack           bool     `json:"on_track"`
+	CreatedOn         string   `json:"created_on"`
+	UpdatedOn         string   `json:"updated_on"`
+}
+
+// ProgressPoint is the value of a goal when it was evaluated
+type ProgressPoint struct {
+	Value      float64
+	RecordedOn time.Time
+}
+
+// Set is a completed set of the exercise type of a strength goal
+type Set struct {
+	Weight float64
+	Reps   int
+}
+
+type createGoalRequest struct {
+	Kind              string   `json:"kind"`
+	ExerciseTypeID    *string  `json:"exercise_type_id"`
+	TargetWeight      *float64 `json:"target_weight"`
+	TargetRepetitions *int     `json:"target_repetitions"`
+	TargetBodyweight  *float64 `json:"target_bodyweight"`
+	TargetWorkouts    *int     `json:"target_workouts"`
+	Deadline          string   `json:"deadline"`
+}
+
+// Upper limit for the workouts of a frequency goal, two workouts a day
+const maxTargetWorkouts = 14
+
+// validateGoalRequest checks the targets required by the kind of goal and
+// returns the deadline
+func validateGoalRequest(t createGoalRequest, now time.Time) (time.Time, error) {
+	if !slices.Contains(KindNames, t.Kind) {
+		return time.Time{}, fmt.Errorf("unknown goal kind: %s", t.Kind)
+	}
+
+	deadline, err := time.Parse(time.DateOnly, t.Deadline)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid deadline: %w", err)
+	}
+	if deadline.Before(now.UTC().Truncate(24 * time.Hour)) {
+		return time.Time{}, fmt.Errorf("deadline must not be in the past")
+	}
+
+	switch t.Kind {
+	case KindStrength:
+		if t.ExerciseTypeID == nil || *t.ExerciseTypeID == "" {
+			return time.Time{}, fmt.Errorf("exercise type is required for a strength goal")
+		}
+		if t.TargetWeight == nil || *t.TargetWeight <= 0 {
+			return time.Time{}, fmt.Errorf("target weight must be positive")
+		}
+		if t.TargetRepetitions == nil || *t.TargetRepetitions <= 0 {
+			return time.Time{}, fmt.Errorf("target repetitions must be positive")
+		}
+	case KindBodyweight:
+		if t.TargetBodyweight == nil || *t.TargetBodyweight <= 0 {
+			return time.Time{}, fmt.Errorf("target bodyweight must be positive")
+		}
+	case KindFrequency:
+		if t.TargetWorkouts == nil || *t.TargetWorkouts <= 0 || *t.TargetWorkouts > maxTargetWorkouts {
+			return time.Time{}, fmt.Errorf("target workouts must be between 1 and %d", maxTargetWorkouts)
+		}
+	}
+	return deadline, nil
+}
@@ -0,0 +1,115 @@
+package goals
+
+import (
+	"math"
+	"time"
+	"weight-tracker/internal/strength"
+)
+
+// estimatedOneRepMax rounds the Epley estimate. The target of a strength goal
+// is the estimate of the target weight × reps, so sets at other rep counts
+// count towards it.
+func estimatedOneRepMax(weight float64, reps int) float64 {
+	e1rm, _ := strength.EstimateOneRepMax(strength.FormulaEpley, weight, reps)
+	return math.Round(e1rm*100) / 100
+}
+
+// strengthProgress returns the best estimated one rep max of the sets. The
+// weight of the set is used as entered, bodyweight is not added, so it
+// compares with the target weight. A set of the target weight × reps always
+// reaches the target.
+func strengthProgress(sets []Set) float64 {
+	best := 0.0
+	for _, set := range sets {
+		best = math.Max(best, estimatedOneRepMax(set.Weight, set.Reps))
+	}
+	return best
+}
+
+// reached reports whether the value reached the target coming from the start,
+// a target below the start is reached by going down
+func reached(start float64, target float64, value float64) bool {
+	if target < start {
+		return value <= target
+	}
+	return value >= target
+}
+
+// percentComplete is how far the value got from the start towards the target,
+// between 0 and 100 and rounded to two decimals
+func percentComplete(start float64, target float64, value float64) float64 {
+	if reached(start, target, value) {
+		return 100
+	}
+	percent := (value - start) / (target - start) * 100
+	return math.Round(math.Max(0, math.Min(100, percent))*100) / 100
+}
+
+// projectedOn fits a line through the progress points and returns when it
+// reaches the target. Without at least two points spread over time, or when
+// the goal is moving away from the target, there is no projection.
+func projectedOn(points []ProgressPoint, target float64) *time.Time {
+	if len(points) < 2 {
+		return nil
+	}
+
+	first := points[0].RecordedOn
+	var sumX, sumY, sumXY, sumXX float64
+	for _, v := range points {
+		x := v.RecordedOn.Sub(first).Seconds()
+		sumX += x
+		sumY += v.Value
+		sumXY += x * v.Value
+		sumXX += x * x
+	}
+
+	n := float64(len(points))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return nil
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+	intercept := (sumY - slope*sumX) / n
+
+	last := points[len(points)-1]
+	if slope == 0 || (target-last.Value)/slope < 0 {
+		return nil
+	}
+
+	seconds := (target - intercept) / slope
+	projected := first.Add(time.Duration(seconds * float64(time.Second)))
+	if projected.Before(last.RecordedOn) {
+		projected = last.RecordedOn
+	}
+	return &projected
+}
+
+// evaluate fills in the progress of the goal from its progress points,
+// the latest point being the current value
+func evaluate(goal Goal, points []ProgressPoint) Goal {
+	if len(points) > 0 {
+		goal.CurrentValue = points[len(points)-1].Value
+	} else {
+		goal.CurrentValue = goal.StartValue
+	}
+
+	goal.PercentComplete = percentComplete(goal.StartValue, goal.TargetValue, goal.CurrentValue)
+	if goal.AchievedOn != nil {
+		achievedOn, err := time.Parse(time.RFC3339, *goal.AchievedOn)
+		if err == nil {
+			date := achievedOn.UTC().Format(time.DateOnly)
+			goal.PercentComplete = 100
+			goal.ProjectedOn = &date
+			goal.OnTrack = date <= goal.Deadline
+			return goal
+		}
+	}
+
+	projected := projectedOn(points, goal.TargetValue)
+	if projected != nil {
+		date := projected.UTC().Format(time.DateOnly)
+		goal.ProjectedOn = &date
+		goal.OnTrack = date <= goal.Deadline
+	}
+	return goal
+}
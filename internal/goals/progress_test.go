@@ -0,0 +1,84 @@
+package goals
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrengthProgress(t *testing.T) {
+	sets := []Set{{Weight: 100, Reps: 5}, {Weight: 110, Reps: 1}, {Weight: 0, Reps: 10}}
+
+	assert.Equal(t, 116.67, strengthProgress(sets))
+	assert.Equal(t, 0.0, strengthProgress([]Set{}))
+}
+
+func TestStrengthTargetReachedBySetOfTarget(t *testing.T) {
+	target := estimatedOneRepMax(120, 5)
+
+	assert.True(t, reached(0, target, strengthProgress([]Set{{Weight: 120, Reps: 5}})))
+	assert.False(t, reached(0, target, strengthProgress([]Set{{Weight: 120, Reps: 4}})))
+}
+
+func TestPercentComplete(t *testing.T) {
+	assert.Equal(t, 50.0, percentComplete(0, 4, 2))
+	assert.Equal(t, 100.0, percentComplete(0, 4, 5))
+	// Losing weight from 90 to 80
+	assert.Equal(t, 25.0, percentComplete(90, 80, 87.5))
+	assert.Equal(t, 0.0, percentComplete(90, 80, 92))
+	assert.Equal(t, 100.0, percentComplete(90, 80, 79))
+}
+
+func TestProjectedOn(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := []ProgressPoint{
+		{Value: 100, RecordedOn: start},
+		{Value: 102, RecordedOn: start.AddDate(0, 0, 7)},
+		{Value: 104, RecordedOn: start.AddDate(0, 0, 14)},
+	}
+
+	projected := projectedOn(points, 110)
+
+	assert.NotNil(t, projected)
+	assert.Equal(t, start.AddDate(0, 0, 35), *projected)
+}
+
+func TestProjectedOnMovingAway(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := []ProgressPoint{
+		{Value: 85, RecordedOn: start},
+		{Value: 86, RecordedOn: start.AddDate(0, 0, 7)},
+	}
+
+	assert.Nil(t, projectedOn(points, 80))
+	assert.Nil(t, projectedOn(points[:1], 80))
+}
+
+func TestEvaluate(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	goal := Goal{Kind: KindBodyweight, StartValue: 90, TargetValue: 80, Deadline: "2025-03-01"}
+	points := []ProgressPoint{
+		{Value: 90, RecordedOn: start},
+		{Value: 89, RecordedOn: start.AddDate(0, 0, 7)},
+		{Value: 88, RecordedOn: start.AddDate(0, 0, 14)},
+	}
+
+	result := evaluate(goal, points)
+
+	assert.Equal(t, 88.0, result.CurrentValue)
+	assert.Equal(t, 20.0, result.PercentComplete)
+	assert.Equal(t, "2025-03-12", *result.ProjectedOn)
+	assert.False(t, result.OnTrack)
+}
+
+func TestEvaluateAchieved(t *testing.T) {
+	achievedOn := "2025-02-10T18:00:00Z"
+	goal := Goal{Kind: KindFrequency, TargetValue: 3, Deadline: "2025-03-01", AchievedOn: &achievedOn}
+
+	result := evaluate(goal, []ProgressPoint{{Value: 2, RecordedOn: time.Date(2025, 2, 17, 0, 0, 0, 0, time.UTC)}})
+
+	assert.Equal(t, 100.0, result.PercentComplete)
+	assert.Equal(t, "2025-02-10", *result.ProjectedOn)
+	assert.True(t, result.OnTrack)
+}
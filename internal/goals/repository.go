@@ -0,0 +1,206 @@
+package goals
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
+)
+
+type GoalsRepository interface {
+	GetAll(ctx context.Context, userId string) ([]Goal, error)
+	GetById(ctx context.Context, arg repository.GetGoalByIdParams) (Goal, error)
+	GetActive(ctx context.Context, userId string) ([]Goal, error)
+	CreateAndReturnId(ctx context.Context, arg repository.CreateGoalAndReturnIdParams) (string, error)
+	SetAchievedOn(ctx context.Context, arg repository.UpdateGoalAchievedOnParams) error
+	DeleteById(ctx context.Context, goalId string, userId string) error
+	GetProgress(ctx context.Context, goalId string, userId string) ([]ProgressPoint, error)
+	CreateProgressAndReturnId(ctx context.Context, arg repository.CreateGoalProgressAndReturnIdParams) (string, error)
+	GetCompletedSets(ctx context.Context, exerciseTypeId string, userId string) ([]Set, error)
+	GetLatestBodyweight(ctx context.Context, userId string) (float64, error)
+	CountWorkouts(ctx context.Context, arg repository.GetStatisticsBetweenDatesParams) (int, error)
+	GetWorkoutCompletedOn(ctx context.Context, workoutId string, userId string) (time.Time, error)
+}
+
+func NewGoalsRepository(repo repository.Querier) GoalsRepository {
+	return goalsRepository{repo: repo}
+}
+
+type goalsRepository struct {
+	repo repository.Querier
+}
+
+func (g goalsRepository) GetAll(ctx context.Context, userId string) ([]Goal, error) {
+	goals, err := g.repo.GetAllGoals(ctx, userId)
+	if err != nil {
+		return []Goal{}, fmt.Errorf("failed to get goals: %w", err)
+	}
+
+	result := []Goal{}
+	for _, v := range goals {
+		result = append(result, newGoal(v))
+	}
+	return result, nil
+}
+
+func (g goalsRepository) GetById(ctx context.Context, arg repository.GetGoalByIdParams) (Goal, error) {
+	goal, err := g.repo.GetGoalById(ctx, arg)
+	if err != nil {
+		return Goal{}, fmt.Errorf("failed to get goal by id: %w", err)
+	}
+	return newGoal(goal), nil
+}
+
+func (g goalsRepository) GetActive(ctx context.Context, userId string) ([]Goal, error) {
+	goals, err := g.repo.GetActiveGoals(ctx, userId)
+	if err != nil {
+		return []Goal{}, fmt.Errorf("failed to get active goals: %w", err)
+	}
+
+	result := []Goal{}
+	for _, v := range goals {
+		result = append(result, newGoal(v))
+	}
+	return result, nil
+}
+
+func (g goalsRepository) CreateAndReturnId(ctx context.Context, arg repository.CreateGoalAndReturnIdParams) (string, error) {
+	id, err := g.repo.CreateGoalAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create goal: %w", err)
+	}
+	return id, nil
+}
+
+func (g goalsRepository) SetAchievedOn(ctx context.Context, arg repository.UpdateGoalAchievedOnParams) error {
+	rows, err := g.repo.UpdateGoalAchievedOn(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update goal: %w", err)
+	}
+
+	if rows == 0 {
+		slog.Warn("Tried to update goal that did not exist", "goalId", arg.ID)
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (g goalsRepository) DeleteById(ctx context.Context, goalId string, userId string) error {
+	_, err := g.repo.DeleteGoalProgressByGoalId(ctx, repository.DeleteGoalProgressByGoalIdParams{
+		GoalID: goalId,
+		UserID: userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete goal progress: %w", err)
+	}
+
+	rows, err := g.repo.DeleteGoalById(ctx, repository.DeleteGoalByIdParams{
+		ID:     goalId,
+		UserID: userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+
+	if rows == 0 {
+		slog.Warn("Tried to delete goal that did not exist", "goalId", goalId)
+	}
+	return nil
+}
+
+func (g goalsRepository) GetProgress(ctx context.Context, goalId string, userId string) ([]ProgressPoint, error) {
+	progress, err := g.repo.GetGoalProgressByGoalId(ctx, repository.GetGoalProgressByGoalIdParams{
+		GoalID: goalId,
+		UserID: userId,
+	})
+	if err != nil {
+		return []ProgressPoint{}, fmt.Errorf("failed to get goal progress: %w", err)
+	}
+
+	result := []ProgressPoint{}
+	for _, v := range progress {
+		recordedOn, err := time.Parse(time.RFC3339, v.RecordedOn)
+		if err != nil {
+			return []ProgressPoint{}, fmt.Errorf("failed to parse recorded on: %w", err)
+		}
+		result = append(result, ProgressPoint{Value: v.Value, RecordedOn: recordedOn})
+	}
+	return result, nil
+}
+
+func (g goalsRepository) CreateProgressAndReturnId(ctx context.Context, arg repository.CreateGoalProgressAndReturnIdParams) (string, error) {
+	id, err := g.repo.CreateGoalProgressAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create goal progress: %w", err)
+	}
+	return id, nil
+}
+
+func (g goalsRepository) GetCompletedSets(ctx context.Context, exerciseTypeId string, userId string) ([]Set, error) {
+	sets, err := g.repo.GetCompletedSetsByExerciseTypeId(ctx, repository.GetCompletedSetsByExerciseTypeIdParams{
+		ID:     exerciseTypeId,
+		UserID: userId,
+	})
+	if err != nil {
+		return []Set{}, fmt.Errorf("failed to get completed sets: %w", err)
+	}
+
+	result := []Set{}
+	for _, v := range sets {
+		result = append(result, Set{Weight: v.Weight, Reps: int(v.Repetitions)})
+	}
+	return result, nil
+}
+
+func (g goalsRepository) GetLatestBodyweight(ctx context.Context, userId string) (float64, error) {
+	bodyweight, err := g.repo.GetLatestBodyweight(ctx, userId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest bodyweight: %w", err)
+	}
+	return bodyweight, nil
+}
+
+func (g goalsRepository) CountWorkouts(ctx context.Context, arg repository.GetStatisticsBetweenDatesParams) (int, error) {
+	count, err := g.repo.GetStatisticsBetweenDates(ctx, arg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count workouts: %w", err)
+	}
+	return int(count), nil
+}
+
+func (g goalsRepository) GetWorkoutCompletedOn(ctx context.Context, workoutId string, userId string) (time.Time, error) {
+	completedOn, err := g.repo.GetWorkoutCompletedOn(ctx, repository.GetWorkoutCompletedOnParams{
+		ID:     workoutId,
+		UserID: userId,
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get workout completion: %w", err)
+	}
+	return time.Parse(time.RFC3339, completedOn)
+}
+
+func newGoal(v repository.Goal) Goal {
+	goal := Goal{
+		ID:                v.ID,
+		Kind:              v.Kind,
+		TargetWeight:      utils.NullableFloat(v.TargetWeight),
+		TargetRepetitions: utils.NullableInt(v.TargetRepetitions),
+		TargetValue:       v.TargetValue,
+		StartValue:        v.StartValue,
+		Deadline:          v.Deadline,
+		CreatedOn:         v.CreatedOn,
+		UpdatedOn:         v.UpdatedOn,
+	}
+	if v.ExerciseTypeID != nil {
+		exerciseTypeId := v.ExerciseTypeID.(string)
+		goal.ExerciseTypeID = &exerciseTypeId
+	}
+	if v.AchievedOn != nil {
+		achievedOn := v.AchievedOn.(string)
+		goal.AchievedOn = &achievedOn
+	}
+	return goal
+}
@@ -0,0 +1,219 @@
+package goals
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"weight-tracker/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	GetAll(ctx context.Context, userId string) ([]Goal, error)
+	GetById(ctx context.Context, goalId string, userId string) (Goal, error)
+	CreateAndReturnId(ctx context.Context, t createGoalRequest, userId string) (string, error)
+	DeleteById(ctx context.Context, goalId string, userId string) error
+	OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error
+}
+
+type goalsService struct {
+	repo GoalsRepository
+}
+
+func NewService(repo GoalsRepository) Service {
+	return &goalsService{repo: repo}
+}
+
+// Number of days counted towards a frequency goal
+const frequencyDays = 7
+
+func (s *goalsService) GetAll(ctx context.Context, userId string) ([]Goal, error) {
+	goals, err := s.repo.GetAll(ctx, userId)
+	if err != nil {
+		return []Goal{}, err
+	}
+
+	for i, v := range goals {
+		points, err := s.repo.GetProgress(ctx, v.ID, userId)
+		if err != nil {
+			return []Goal{}, err
+		}
+		goals[i] = evaluate(v, points)
+	}
+	return goals, nil
+}
+
+func (s *goalsService) GetById(ctx context.Context, goalId string, userId string) (Goal, error) {
+	goal, err := s.repo.GetById(ctx, repository.GetGoalByIdParams{
+		ID:     goalId,
+		UserID: userId,
+	})
+	if err != nil {
+		return Goal{}, err
+	}
+
+	points, err := s.repo.GetProgress(ctx, goalId, userId)
+	if err != nil {
+		return Goal{}, err
+	}
+	return evaluate(goal, points), nil
+}
+
+// CreateAndReturnId creates the goal and evaluates it right away, so its first
+// progress point is where the user stands when setting it
+func (s *goalsService) CreateAndReturnId(ctx context.Context, t createGoalRequest, userId string) (string, error) {
+	now := time.Now().UTC()
+	deadline, err := validateGoalRequest(t, now)
+	if err != nil {
+		return "", err
+	}
+
+	goal := Goal{Kind: t.Kind}
+	var targetWeight, targetRepetitions, exerciseTypeId interface{}
+	switch t.Kind {
+	case KindStrength:
+		targetWeight = *t.TargetWeight
+		targetRepetitions = int64(*t.TargetRepetitions)
+		exerciseTypeId = *t.ExerciseTypeID
+		goal.ExerciseTypeID = t.ExerciseTypeID
+		goal.TargetValue = estimatedOneRepMax(*t.TargetWeight, *t.TargetRepetitions)
+	case KindBodyweight:
+		goal.StartValue, err = s.repo.GetLatestBodyweight(ctx, userId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", fmt.Errorf("a bodyweight must be logged before setting a bodyweight goal")
+			}
+			return "", err
+		}
+		goal.TargetValue = *t.TargetBodyweight
+	case KindFrequency:
+		goal.TargetValue = float64(*t.TargetWorkouts)
+	}
+
+	uuid, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	id, err := s.repo.CreateAndReturnId(ctx, repository.CreateGoalAndReturnIdParams{
+		ID:                uuid.String(),
+		Kind:              goal.Kind,
+		TargetWeight:      targetWeight,
+		TargetRepetitions: targetRepetitions,
+		TargetValue:       goal.TargetValue,
+		StartValue:        goal.StartValue,
+		Deadline:          deadline.Format(time.DateOnly),
+		CreatedOn:         now.Format(time.RFC3339),
+		UpdatedOn:         now.Format(time.RFC3339),
+		UserID:            userId,
+		ExerciseTypeID:    exerciseTypeId,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	goal.ID = id
+	err = s.recordProgress(ctx, goal, now, userId)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (s *goalsService) DeleteById(ctx context.Context, goalId string, userId string) error {
+	return s.repo.DeleteById(ctx, goalId, userId)
+}
+
+// OnWorkoutCompleted records the progress of every goal that is not achieved
+// yet and marks the ones the workout achieved. Progress is recorded at the
+// completion of the workout, so imported history keeps its own dates.
+func (s *goalsService) OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error {
+	goals, err := s.repo.GetActive(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if len(goals) == 0 {
+		return nil
+	}
+
+	completedOn, err := s.repo.GetWorkoutCompletedOn(ctx, workoutId, userId)
+	if err != nil {
+		return err
+	}
+
+	for _, goal := range goals {
+		err = s.recordProgress(ctx, goal, completedOn.UTC(), userId)
+		if err != nil {
+			return fmt.Errorf("failed to record progress of goal %s: %w", goal.ID, err)
+		}
+	}
+	return nil
+}
+
+// recordProgress records the progress of the goal as it stood on the given time
+func (s *goalsService) recordProgress(ctx context.Context, goal Goal, on time.Time, userId string) error {
+	value, err := s.currentValue(ctx, goal, on, userId)
+	if err != nil {
+		return err
+	}
+
+	uuid, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	now := time.Now().UTC()
+	_, err = s.repo.CreateProgressAndReturnId(ctx, repository.CreateGoalProgressAndReturnIdParams{
+		ID:         uuid.String(),
+		Value:      value,
+		RecordedOn: on.Format(time.RFC3339),
+		CreatedOn:  now.Format(time.RFC3339),
+		UpdatedOn:  now.Format(time.RFC3339),
+		UserID:     userId,
+		GoalID:     goal.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !reached(goal.StartValue, goal.TargetValue, value) {
+		return nil
+	}
+	return s.repo.SetAchievedOn(ctx, repository.UpdateGoalAchievedOnParams{
+		AchievedOn: on.Format(time.RFC3339),
+		UpdatedOn:  now.Format(time.RFC3339),
+		ID:         goal.ID,
+		UserID:     userId,
+	})
+}
+
+// currentValue measures the goal in the unit of its target value
+func (s *goalsService) currentValue(ctx context.Context, goal Goal, on time.Time, userId string) (float64, error) {
+	switch goal.Kind {
+	case KindStrength:
+		if goal.ExerciseTypeID == nil {
+			return 0, fmt.Errorf("strength goal without exercise type")
+		}
+		sets, err := s.repo.GetCompletedSets(ctx, *goal.ExerciseTypeID, userId)
+		if err != nil {
+			return 0, err
+		}
+		return strengthProgress(sets), nil
+	case KindBodyweight:
+		return s.repo.GetLatestBodyweight(ctx, userId)
+	case KindFrequency:
+		// The end is exclusive and completions are stored to the second, a
+		// second later counts the workout completed on the time itself
+		end := on.Add(time.Second)
+		workouts, err := s.repo.CountWorkouts(ctx, repository.GetStatisticsBetweenDatesParams{
+			UserID:    userId,
+			StartDate: end.AddDate(0, 0, -frequencyDays).Format(time.RFC3339),
+			EndDate:   end.Format(time.RFC3339),
+		})
+		return float64(workouts), err
+	}
+	return 0, fmt.Errorf("unknown goal kind: %s", goal.Kind)
+}
@@ -0,0 +1,257 @@
+package goals
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+	"weight-tracker/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type repoMock struct {
+	mock.Mock
+}
+
+func (m *repoMock) GetAll(ctx context.Context, userId string) ([]Goal, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]Goal), args.Error(1)
+}
+
+func (m *repoMock) GetById(ctx context.Context, arg repository.GetGoalByIdParams) (Goal, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(Goal), args.Error(1)
+}
+
+func (m *repoMock) GetActive(ctx context.Context, userId string) ([]Goal, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]Goal), args.Error(1)
+}
+
+func (m *repoMock) CreateAndReturnId(ctx context.Context, arg repository.CreateGoalAndReturnIdParams) (string, error) {
+	args := m.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (m *repoMock) SetAchievedOn(ctx context.Context, arg repository.UpdateGoalAchievedOnParams) error {
+	args := m.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (m *repoMock) DeleteById(ctx context.Context, goalId string, userId string) error {
+	args := m.Called(ctx, goalId, userId)
+	return args.Error(0)
+}
+
+func (m *repoMock) GetProgress(ctx context.Context, goalId string, userId string) ([]ProgressPoint, error) {
+	args := m.Called(ctx, goalId, userId)
+	return args.Get(0).([]ProgressPoint), args.Error(1)
+}
+
+func (m *repoMock) CreateProgressAndReturnId(ctx context.Context, arg repository.CreateGoalProgressAndReturnIdParams) (string, error) {
+	args := m.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (m *repoMock) GetCompletedSets(ctx context.Context, exerciseTypeId string, userId string) ([]Set, error) {
+	args := m.Called(ctx, exerciseTypeId, userId)
+	return args.Get(0).([]Set), args.Error(1)
+}
+
+func (m *repoMock) GetLatestBodyweight(ctx context.Context, userId string) (float64, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *repoMock) CountWorkouts(ctx context.Context, arg repository.GetStatisticsBetweenDatesParams) (int, error) {
+	args := m.Called(ctx, arg)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *repoMock) GetWorkoutCompletedOn(ctx context.Context, workoutId string, userId string) (time.Time, error) {
+	args := m.Called(ctx, workoutId, userId)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestCreateStrengthGoal(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateGoalAndReturnIdParams) bool {
+		return input.Kind == KindStrength && input.TargetWeight == 100.0 && input.TargetRepetitions == int64(5) &&
+			input.TargetValue == 116.67 && input.StartValue == 0 && input.ExerciseTypeID == "bench" &&
+			input.Deadline == "2099-01-31" && input.UserID == userId
+	})).Return("goalId", nil).Once()
+	repoMock.On("GetCompletedSets", ctx, "bench", userId).Return([]Set{{Weight: 90, Reps: 5}}, nil).Once()
+	repoMock.On("CreateProgressAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateGoalProgressAndReturnIdParams) bool {
+		return input.GoalID == "goalId" && input.Value == 105 && input.UserID == userId
+	})).Return("progressId", nil).Once()
+
+	service := NewService(&repoMock)
+	id, err := service.CreateAndReturnId(ctx, createGoalRequest{
+		Kind:              KindStrength,
+		ExerciseTypeID:    ptr("bench"),
+		TargetWeight:      ptr(100.0),
+		TargetRepetitions: ptr(5),
+		Deadline:          "2099-01-31",
+	}, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "goalId", id)
+	repoMock.AssertExpectations(t)
+	repoMock.AssertNotCalled(t, "SetAchievedOn", mock.Anything, mock.Anything)
+}
+
+func TestCreateBodyweightGoalWithoutBodyweight(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetLatestBodyweight", ctx, "userId").Return(0.0, sql.ErrNoRows).Once()
+
+	service := NewService(&repoMock)
+	_, err := service.CreateAndReturnId(ctx, createGoalRequest{
+		Kind:             KindBodyweight,
+		TargetBodyweight: ptr(80.0),
+		Deadline:         "2099-01-31",
+	}, "userId")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "CreateAndReturnId", mock.Anything, mock.Anything)
+}
+
+func TestCreateGoalValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		request createGoalRequest
+	}{
+		{"unknown kind", createGoalRequest{Kind: "distance", Deadline: "2099-01-31"}},
+		{"invalid deadline", createGoalRequest{Kind: KindFrequency, TargetWorkouts: ptr(3), Deadline: "tomorrow"}},
+		{"past deadline", createGoalRequest{Kind: KindFrequency, TargetWorkouts: ptr(3), Deadline: "2020-01-31"}},
+		{"strength without exercise type", createGoalRequest{Kind: KindStrength, TargetWeight: ptr(100.0), TargetRepetitions: ptr(5), Deadline: "2099-01-31"}},
+		{"strength without reps", createGoalRequest{Kind: KindStrength, ExerciseTypeID: ptr("bench"), TargetWeight: ptr(100.0), Deadline: "2099-01-31"}},
+		{"negative bodyweight", createGoalRequest{Kind: KindBodyweight, TargetBodyweight: ptr(-80.0), Deadline: "2099-01-31"}},
+		{"too many workouts", createGoalRequest{Kind: KindFrequency, TargetWorkouts: ptr(15), Deadline: "2099-01-31"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repoMock := repoMock{}
+			service := NewService(&repoMock)
+			_, err := service.CreateAndReturnId(context.Background(), test.request, "userId")
+
+			assert.NotNil(t, err)
+			repoMock.AssertNotCalled(t, "CreateAndReturnId", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOnWorkoutCompletedAchievesGoals(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetActive", ctx, userId).Return([]Goal{
+		{ID: "frequency", Kind: KindFrequency, TargetValue: 3},
+		{ID: "bodyweight", Kind: KindBodyweight, StartValue: 90, TargetValue: 80},
+	}, nil).Once()
+	repoMock.On("GetWorkoutCompletedOn", ctx, "workoutId", userId).Return(time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC), nil).Once()
+	repoMock.On("CountWorkouts", ctx, mock.MatchedBy(func(input repository.GetStatisticsBetweenDatesParams) bool {
+		start, err := time.Parse(time.RFC3339, input.StartDate.(string))
+		if err != nil {
+			return false
+		}
+		end, err := time.Parse(time.RFC3339, input.EndDate.(string))
+		if err != nil {
+			return false
+		}
+		return input.UserID == userId && end.Sub(start) == 7*24*time.Hour
+	})).Return(3, nil).Once()
+	repoMock.On("GetLatestBodyweight", ctx, userId).Return(85.0, nil).Once()
+	repoMock.On("CreateProgressAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateGoalProgressAndReturnIdParams) bool {
+		return input.GoalID == "frequency" && input.Value == 3 && input.RecordedOn == "2024-03-01T18:00:00Z"
+	})).Return("progressId", nil).Once()
+	repoMock.On("CreateProgressAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateGoalProgressAndReturnIdParams) bool {
+		return input.GoalID == "bodyweight" && input.Value == 85
+	})).Return("progressId", nil).Once()
+	repoMock.On("SetAchievedOn", ctx, mock.MatchedBy(func(input repository.UpdateGoalAchievedOnParams) bool {
+		return input.ID == "frequency" && input.UserID == userId && input.AchievedOn == "2024-03-01T18:00:00Z"
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock)
+	err := service.OnWorkoutCompleted(ctx, "workoutId", userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestOnWorkoutCompletedCountsTheCompletedWorkout(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	// The third workout is completed on the same second the goal is evaluated,
+	// the exclusive end of the count has to lie after it
+	repoMock := repoMock{}
+	repoMock.On("GetActive", ctx, userId).Return([]Goal{{ID: "frequency", Kind: KindFrequency, TargetValue: 3}}, nil).Once()
+	repoMock.On("GetWorkoutCompletedOn", ctx, "third", userId).Return(time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC), nil).Once()
+	repoMock.On("CountWorkouts", ctx, repository.GetStatisticsBetweenDatesParams{
+		UserID:    userId,
+		StartDate: "2024-02-23T18:00:01Z",
+		EndDate:   "2024-03-01T18:00:01Z",
+	}).Return(3, nil).Once()
+	repoMock.On("CreateProgressAndReturnId", ctx, mock.Anything).Return("progressId", nil).Once()
+	repoMock.On("SetAchievedOn", ctx, mock.MatchedBy(func(input repository.UpdateGoalAchievedOnParams) bool {
+		return input.ID == "frequency" && input.AchievedOn == "2024-03-01T18:00:00Z"
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock)
+	err := service.OnWorkoutCompleted(ctx, "third", userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestOnWorkoutCompletedWithoutActiveGoals(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetActive", ctx, userId).Return([]Goal{}, nil).Once()
+
+	service := NewService(&repoMock)
+	err := service.OnWorkoutCompleted(ctx, "workoutId", userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+	repoMock.AssertNotCalled(t, "GetWorkoutCompletedOn", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetById(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, repository.GetGoalByIdParams{ID: "goalId", UserID: userId}).Return(Goal{
+		ID: "goalId", Kind: KindFrequency, TargetValue: 4, Deadline: "2025-03-01",
+	}, nil).Once()
+	repoMock.On("GetProgress", ctx, "goalId", userId).Return([]ProgressPoint{
+		{Value: 1, RecordedOn: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Value: 2, RecordedOn: time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)},
+	}, nil).Once()
+
+	service := NewService(&repoMock)
+	goal, err := service.GetById(ctx, "goalId", userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, goal.CurrentValue)
+	assert.Equal(t, 50.0, goal.PercentComplete)
+	assert.Equal(t, "2025-01-22", *goal.ProjectedOn)
+	assert.True(t, goal.OnTrack)
+	repoMock.AssertExpectations(t)
+}
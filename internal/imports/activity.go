@@ -0,0 +1,282 @@
+package imports
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// Mean radius of the earth, used to measure the distance between track points
+const earthRadiusMeters = 6371008.8
+
+// Sport names of the formats and the exercise type names they are logged as,
+// sports that are not listed are logged as cardio
+var sportNames = map[string]string{
+	"running":  "Running",
+	"run":      "Running",
+	"cycling":  "Cycling",
+	"biking":   "Cycling",
+	"ride":     "Cycling",
+	"walking":  "Walking",
+	"walk":     "Walking",
+	"hiking":   "Hiking",
+	"hike":     "Hiking",
+	"swimming": "Swimming",
+	"swim":     "Swimming",
+	"rowing":   "Rowing",
+}
+
+func sportName(sport string) string {
+	if name, ok := sportNames[strings.ToLower(strings.TrimSpace(sport))]; ok {
+		return name
+	}
+	return "Cardio"
+}
+
+// parseActivity detects the format of the activity file and reads its summary
+func parseActivity(file io.Reader) (Activity, error) {
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return Activity{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var activity Activity
+	switch {
+	case len(content) >= 12 && string(content[8:12]) == ".FIT":
+		activity, err = parseFIT(content)
+	case rootElement(content) == "TrainingCenterDatabase":
+		activity, err = parseTCX(content)
+	case rootElement(content) == "gpx":
+		activity, err = parseGPX(content)
+	default:
+		return Activity{}, fmt.Errorf("unrecognized file, expected a FIT, TCX or GPX activity")
+	}
+	if err != nil {
+		return Activity{}, err
+	}
+
+	if activity.StartedOn.IsZero() {
+		return Activity{}, fmt.Errorf("activity has no start time")
+	}
+	if activity.ActiveSeconds <= 0 {
+		activity.ActiveSeconds = activity.ElapsedSeconds
+	}
+	if activity.ActiveSeconds <= 0 {
+		return Activity{}, fmt.Errorf("activity has no duration")
+	}
+	activity.ElapsedSeconds = max(activity.ElapsedSeconds, activity.ActiveSeconds)
+	activity.DistanceMeters = math.Round(activity.DistanceMeters)
+	if activity.Name == "" {
+		activity.Name = activity.Sport
+	}
+	return activity, nil
+}
+
+// rootElement returns the local name of the first element of an XML document,
+// or nothing when the content is not XML
+func rootElement(content []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return ""
+		}
+		if element, ok := token.(xml.StartElement); ok {
+			return element.Name.Local
+		}
+	}
+}
+
+type tcxFile struct {
+	Activities []struct {
+		Sport string `xml:"Sport,attr"`
+		Laps  []struct {
+			StartTime        string   `xml:"StartTime,attr"`
+			TotalTimeSeconds float64  `xml:"TotalTimeSeconds"`
+			DistanceMeters   float64  `xml:"DistanceMeters"`
+			AverageHeartRate *float64 `xml:"AverageHeartRateBpm>Value"`
+			Trackpoints      []struct {
+				Time      string   `xml:"Time"`
+				HeartRate *float64 `xml:"HeartRateBpm>Value"`
+			} `xml:"Track>Trackpoint"`
+		} `xml:"Lap"`
+	} `xml:"Activities>Activity"`
+}
+
+// parseTCX reads the first activity of a Training Center file. Its laps hold
+// the totals, the heart rate of the track points is used when laps have none.
+func parseTCX(content []byte) (Activity, error) {
+	var file tcxFile
+	err := xml.Unmarshal(content, &file)
+	if err != nil {
+		return Activity{}, fmt.Errorf("failed to decode TCX file: %w", err)
+	}
+	if len(file.Activities) == 0 || len(file.Activities[0].Laps) == 0 {
+		return Activity{}, fmt.Errorf("TCX file has no laps")
+	}
+
+	activity := Activity{Format: FormatTCX, Sport: sportName(file.Activities[0].Sport)}
+	var end time.Time
+	var activeSeconds float64
+	lapHeartRate := heartRate{}
+	pointHeartRate := heartRate{}
+	for _, lap := range file.Activities[0].Laps {
+		startedOn, err := parseActivityTime(lap.StartTime)
+		if err != nil {
+			return Activity{}, err
+		}
+		if activity.StartedOn.IsZero() || startedOn.Before(activity.StartedOn) {
+			activity.StartedOn = startedOn
+		}
+		end = later(end, startedOn.Add(time.Duration(lap.TotalTimeSeconds*float64(time.Second))))
+
+		activeSeconds += lap.TotalTimeSeconds
+		activity.DistanceMeters += lap.DistanceMeters
+		if lap.AverageHeartRate != nil {
+			lapHeartRate.add(*lap.AverageHeartRate, lap.TotalTimeSeconds)
+		}
+		for _, point := range lap.Trackpoints {
+			if point.Time != "" {
+				at, err := parseActivityTime(point.Time)
+				if err != nil {
+					return Activity{}, err
+				}
+				end = later(end, at)
+			}
+			if point.HeartRate != nil {
+				pointHeartRate.add(*point.HeartRate, 1)
+			}
+		}
+	}
+
+	activity.ActiveSeconds = int64(math.Round(activeSeconds))
+	activity.ElapsedSeconds = int64(end.Sub(activity.StartedOn).Seconds())
+	activity.AverageHeartRate = lapHeartRate.average()
+	if activity.AverageHeartRate == nil {
+		activity.AverageHeartRate = pointHeartRate.average()
+	}
+	return activity, nil
+}
+
+type gpxFile struct {
+	Tracks []struct {
+		Name     string `xml:"name"`
+		Type     string `xml:"type"`
+		Segments []struct {
+			Points []struct {
+				Lat       float64  `xml:"lat,attr"`
+				Lon       float64  `xml:"lon,attr"`
+				Time      string   `xml:"time"`
+				HeartRate *float64 `xml:"extensions>TrackPointExtension>hr"`
+			} `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// parseGPX reads the tracks of a GPX file. The distance is measured between
+// the points of a segment and a new segment starts after a pause, so the
+// active time is the time within the segments.
+func parseGPX(content []byte) (Activity, error) {
+	var file gpxFile
+	err := xml.Unmarshal(content, &file)
+	if err != nil {
+		return Activity{}, fmt.Errorf("failed to decode GPX file: %w", err)
+	}
+	if len(file.Tracks) == 0 {
+		return Activity{}, fmt.Errorf("GPX file has no tracks")
+	}
+
+	activity := Activity{Format: FormatGPX, Sport: sportName(file.Tracks[0].Type), Name: strings.TrimSpace(file.Tracks[0].Name)}
+	var end time.Time
+	rate := heartRate{}
+	for _, track := range file.Tracks {
+		for _, segment := range track.Segments {
+			var first, last time.Time
+			for i, point := range segment.Points {
+				if i > 0 {
+					previous := segment.Points[i-1]
+					activity.DistanceMeters += haversine(previous.Lat, previous.Lon, point.Lat, point.Lon)
+				}
+				if point.HeartRate != nil {
+					rate.add(*point.HeartRate, 1)
+				}
+				if point.Time == "" {
+					continue
+				}
+				at, err := parseActivityTime(point.Time)
+				if err != nil {
+					return Activity{}, err
+				}
+				if first.IsZero() {
+					first = at
+				}
+				last = at
+			}
+			if first.IsZero() {
+				continue
+			}
+			if activity.StartedOn.IsZero() || first.Before(activity.StartedOn) {
+				activity.StartedOn = first
+			}
+			end = later(end, last)
+			activity.ActiveSeconds += int64(last.Sub(first).Seconds())
+		}
+	}
+
+	if !activity.StartedOn.IsZero() {
+		activity.ElapsedSeconds = int64(end.Sub(activity.StartedOn).Seconds())
+	}
+	activity.AverageHeartRate = rate.average()
+	return activity, nil
+}
+
+func parseActivityTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time: %s", value)
+	}
+	return t.UTC(), nil
+}
+
+func later(a time.Time, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// haversine returns the distance in meters between two coordinates in degrees
+func haversine(lat1 float64, lon1 float64, lat2 float64, lon2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// heartRate averages heart rate samples by their weight, e.g. the length of a lap
+type heartRate struct {
+	sum    float64
+	weight float64
+}
+
+func (h *heartRate) add(bpm float64, weight float64) {
+	if bpm <= 0 || weight <= 0 {
+		return
+	}
+	h.sum += bpm * weight
+	h.weight += weight
+}
+
+func (h heartRate) average() *int64 {
+	if h.weight == 0 {
+		return nil
+	}
+	average := int64(math.Round(h.sum / h.weight))
+	return &average
+}
@@ -0,0 +1,117 @@
+package imports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tcxActivity = `<?xml version="1.0" encoding="UTF-8"?>
+<TrainingCenterDatabase xmlns="http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2">
+  <Activities>
+    <Activity Sport="Biking">
+      <Id>2024-01-15T07:00:00Z</Id>
+      <Lap StartTime="2024-01-15T07:00:00Z">
+        <TotalTimeSeconds>1800</TotalTimeSeconds>
+        <DistanceMeters>15000.4</DistanceMeters>
+        <AverageHeartRateBpm><Value>140</Value></AverageHeartRateBpm>
+        <Track>
+          <Trackpoint><Time>2024-01-15T07:00:00Z</Time><HeartRateBpm><Value>120</Value></HeartRateBpm></Trackpoint>
+          <Trackpoint><Time>2024-01-15T07:30:00Z</Time><HeartRateBpm><Value>150</Value></HeartRateBpm></Trackpoint>
+        </Track>
+      </Lap>
+      <Lap StartTime="2024-01-15T07:35:00Z">
+        <TotalTimeSeconds>600</TotalTimeSeconds>
+        <DistanceMeters>5000</DistanceMeters>
+        <AverageHeartRateBpm><Value>160</Value></AverageHeartRateBpm>
+      </Lap>
+    </Activity>
+  </Activities>
+</TrainingCenterDatabase>`
+
+const gpxActivity = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="Garmin Connect" xmlns="http://www.topografix.com/GPX/1/1" xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v1">
+  <trk>
+    <name>Morning Run</name>
+    <type>running</type>
+    <trkseg>
+      <trkpt lat="52.0" lon="4.0"><time>2024-01-15T07:00:00Z</time><extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>140</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions></trkpt>
+      <trkpt lat="52.009" lon="4.0"><time>2024-01-15T07:05:00Z</time><extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>150</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions></trkpt>
+    </trkseg>
+    <trkseg>
+      <trkpt lat="52.009" lon="4.0"><time>2024-01-15T07:10:00Z</time><extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>160</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions></trkpt>
+      <trkpt lat="52.018" lon="4.0"><time>2024-01-15T07:15:00Z</time><extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>170</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions></trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+func TestParseActivityTCX(t *testing.T) {
+	activity, err := parseActivity(strings.NewReader(tcxActivity))
+
+	heartRate := int64(145)
+	assert.Nil(t, err)
+	assert.Equal(t, Activity{
+		Format:    FormatTCX,
+		Sport:     "Cycling",
+		Name:      "Cycling",
+		StartedOn: time.Date(2024, 1, 15, 7, 0, 0, 0, time.UTC),
+		// The pause between the laps counts for the elapsed time only
+		ElapsedSeconds:   2700,
+		ActiveSeconds:    2400,
+		DistanceMeters:   20000,
+		AverageHeartRate: &heartRate,
+	}, activity)
+}
+
+func TestParseActivityGPX(t *testing.T) {
+	activity, err := parseActivity(strings.NewReader(gpxActivity))
+
+	heartRate := int64(155)
+	assert.Nil(t, err)
+	assert.Equal(t, Activity{
+		Format:           FormatGPX,
+		Sport:            "Running",
+		Name:             "Morning Run",
+		StartedOn:        time.Date(2024, 1, 15, 7, 0, 0, 0, time.UTC),
+		ElapsedSeconds:   900,
+		ActiveSeconds:    600,
+		DistanceMeters:   2002,
+		AverageHeartRate: &heartRate,
+	}, activity)
+}
+
+func TestParseActivityWithoutHeartRate(t *testing.T) {
+	file := `<gpx><trk><trkseg>` +
+		`<trkpt lat="52.0" lon="4.0"><time>2024-01-15T07:00:00+01:00</time></trkpt>` +
+		`<trkpt lat="52.0" lon="4.0"><time>2024-01-15T07:20:00+01:00</time></trkpt>` +
+		`</trkseg></trk></gpx>`
+
+	activity, err := parseActivity(strings.NewReader(file))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Cardio", activity.Name)
+	assert.Equal(t, time.Date(2024, 1, 15, 6, 0, 0, 0, time.UTC), activity.StartedOn)
+	assert.Equal(t, int64(1200), activity.ActiveSeconds)
+	assert.Equal(t, 0.0, activity.DistanceMeters)
+	assert.Nil(t, activity.AverageHeartRate)
+}
+
+func TestParseActivityInvalid(t *testing.T) {
+	tests := []struct {
+		file     string
+		expected string
+	}{
+		{"name,weight\nbench,100\n", "unrecognized file"},
+		{"<kml></kml>", "unrecognized file"},
+		{`<gpx><trk><trkseg><trkpt lat="52.0" lon="4.0"></trkpt></trkseg></trk></gpx>`, "activity has no start time"},
+		{`<gpx><trk><trkseg><trkpt lat="52.0" lon="4.0"><time>yesterday</time></trkpt></trkseg></trk></gpx>`, "invalid time: yesterday"},
+		{`<TrainingCenterDatabase><Activities><Activity Sport="Running"><Lap StartTime="2024-01-15T07:00:00Z">` +
+			`<TotalTimeSeconds>0</TotalTimeSeconds></Lap></Activity></Activities></TrainingCenterDatabase>`, "activity has no duration"},
+	}
+	for _, test := range tests {
+		_, err := parseActivity(strings.NewReader(test.file))
+		assert.ErrorContains(t, err, test.expected, test.file)
+	}
+}
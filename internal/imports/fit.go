@@ -0,0 +1,294 @@
+package imports
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// FIT timestamps count the seconds since the last day of 1989
+var fitEpoch = time.Date(1989, 12, 31, 0, 0, 0, 0, time.UTC)
+
+var errTruncatedFIT = errors.New("truncated FIT file")
+
+// Global numbers of the FIT messages and fields that are read
+const (
+	fitSession          = 18
+	fitRecord           = 20
+	fitTimestamp        = 253
+	fitSessionStartTime = 2
+	fitSessionSport     = 5
+	fitSessionElapsed   = 7
+	fitSessionTimer     = 8
+	fitSessionDistance  = 9
+	fitSessionHeartRate = 16
+	fitRecordHeartRate  = 3
+	fitRecordDistance   = 5
+)
+
+// Sports of the FIT profile that have a name, the others are logged as cardio
+var fitSports = map[uint64]string{
+	1:  "running",
+	2:  "cycling",
+	5:  "swimming",
+	11: "walking",
+	15: "rowing",
+	17: "hiking",
+}
+
+type fitField struct {
+	number   byte
+	size     int
+	baseType byte
+}
+
+type fitDefinition struct {
+	global    uint16
+	bigEndian bool
+	fields    []fitField
+	// Developer fields are skipped, only their size is needed
+	developerSize int
+}
+
+// fitMessage holds the unsigned integer fields of a data message by their
+// number, fields without a value are left out
+type fitMessage map[byte]uint64
+
+// parseFIT decodes the messages of a FIT file. The sessions hold the totals,
+// files without one are summed up from their records.
+func parseFIT(content []byte) (Activity, error) {
+	headerSize := int(content[0])
+	if headerSize < 12 || len(content) < headerSize {
+		return Activity{}, fmt.Errorf("invalid FIT header")
+	}
+	end := headerSize + int(binary.LittleEndian.Uint32(content[4:8]))
+	if end > len(content) {
+		return Activity{}, errTruncatedFIT
+	}
+	data := content[headerSize:end]
+
+	definitions := map[byte]fitDefinition{}
+	var sessions, records []fitMessage
+	var lastTimestamp uint64
+	for pos := 0; pos < len(data); {
+		header := data[pos]
+		pos++
+
+		if header&0x80 == 0 && header&0x40 != 0 {
+			definition, size, err := readFITDefinition(data[pos:], header&0x20 != 0)
+			if err != nil {
+				return Activity{}, err
+			}
+			definitions[header&0x0f] = definition
+			pos += size
+			continue
+		}
+
+		// A compressed timestamp header holds the local type and the last five
+		// bits of the timestamp, which only ever moves forward
+		local := header & 0x0f
+		compressed := header&0x80 != 0
+		if compressed {
+			local = (header >> 5) & 0x03
+		}
+		definition, ok := definitions[local]
+		if !ok {
+			return Activity{}, fmt.Errorf("FIT data message without definition")
+		}
+		message, size, err := readFITMessage(data[pos:], definition)
+		if err != nil {
+			return Activity{}, err
+		}
+		pos += size
+
+		if compressed {
+			offset := uint64(header & 0x1f)
+			timestamp := lastTimestamp&^0x1f + offset
+			if offset < lastTimestamp&0x1f {
+				timestamp += 0x20
+			}
+			message[fitTimestamp] = timestamp
+		}
+		if timestamp, ok := message[fitTimestamp]; ok {
+			lastTimestamp = timestamp
+		}
+
+		switch definition.global {
+		case fitSession:
+			sessions = append(sessions, message)
+		case fitRecord:
+			records = append(records, message)
+		}
+	}
+
+	if len(sessions) > 0 {
+		return fitActivityFromSessions(sessions), nil
+	}
+	if len(records) > 0 {
+		return fitActivityFromRecords(records), nil
+	}
+	return Activity{}, fmt.Errorf("FIT file has no sessions or records")
+}
+
+// readFITDefinition reads the definition after its header and returns it with
+// its size in bytes
+func readFITDefinition(data []byte, developer bool) (fitDefinition, int, error) {
+	if len(data) < 5 {
+		return fitDefinition{}, 0, errTruncatedFIT
+	}
+	definition := fitDefinition{bigEndian: data[1] == 1}
+	if definition.bigEndian {
+		definition.global = binary.BigEndian.Uint16(data[2:4])
+	} else {
+		definition.global = binary.LittleEndian.Uint16(data[2:4])
+	}
+
+	count := int(data[4])
+	pos := 5
+	if len(data) < pos+count*3 {
+		return fitDefinition{}, 0, errTruncatedFIT
+	}
+	for i := 0; i < count; i++ {
+		definition.fields = append(definition.fields, fitField{
+			number:   data[pos],
+			size:     int(data[pos+1]),
+			baseType: data[pos+2],
+		})
+		pos += 3
+	}
+
+	if developer {
+		if len(data) < pos+1 {
+			return fitDefinition{}, 0, errTruncatedFIT
+		}
+		count := int(data[pos])
+		pos++
+		if len(data) < pos+count*3 {
+			return fitDefinition{}, 0, errTruncatedFIT
+		}
+		for i := 0; i < count; i++ {
+			definition.developerSize += int(data[pos+1])
+			pos += 3
+		}
+	}
+	return definition, pos, nil
+}
+
+// readFITMessage reads a data message of the definition and returns it with
+// its size in bytes
+func readFITMessage(data []byte, definition fitDefinition) (fitMessage, int, error) {
+	message := fitMessage{}
+	pos := 0
+	for _, field := range definition.fields {
+		if len(data) < pos+field.size {
+			return nil, 0, errTruncatedFIT
+		}
+		if value, ok := fitUnsigned(data[pos:pos+field.size], field.baseType, definition.bigEndian); ok {
+			message[field.number] = value
+		}
+		pos += field.size
+	}
+	if len(data) < pos+definition.developerSize {
+		return nil, 0, errTruncatedFIT
+	}
+	return message, pos + definition.developerSize, nil
+}
+
+// fitUnsigned decodes a field of an unsigned integer or enum base type. Fields
+// of other types and fields holding the invalid value of their type have no value.
+func fitUnsigned(data []byte, baseType byte, bigEndian bool) (uint64, bool) {
+	var order binary.ByteOrder = binary.LittleEndian
+	if bigEndian {
+		order = binary.BigEndian
+	}
+
+	var value, invalid uint64
+	switch baseType & 0x1f {
+	case 0x00, 0x02, 0x0a:
+		if len(data) != 1 {
+			return 0, false
+		}
+		value, invalid = uint64(data[0]), math.MaxUint8
+	case 0x04, 0x0b:
+		if len(data) != 2 {
+			return 0, false
+		}
+		value, invalid = uint64(order.Uint16(data)), math.MaxUint16
+	case 0x06, 0x0c:
+		if len(data) != 4 {
+			return 0, false
+		}
+		value, invalid = uint64(order.Uint32(data)), math.MaxUint32
+	default:
+		return 0, false
+	}
+
+	// The z types use zero as their invalid value
+	zero := baseType&0x1f >= 0x0a
+	if (zero && value == 0) || (!zero && value == invalid) {
+		return 0, false
+	}
+	return value, true
+}
+
+func fitTime(timestamp uint64) time.Time {
+	return fitEpoch.Add(time.Duration(timestamp) * time.Second)
+}
+
+func fitActivityFromSessions(sessions []fitMessage) Activity {
+	activity := Activity{Format: FormatFIT, Sport: sportName(fitSports[sessions[0][fitSessionSport]])}
+	rate := heartRate{}
+	var elapsed, timer float64
+	for _, session := range sessions {
+		start, ok := session[fitSessionStartTime]
+		if !ok {
+			start = session[fitTimestamp]
+		}
+		if startedOn := fitTime(start); start > 0 && (activity.StartedOn.IsZero() || startedOn.Before(activity.StartedOn)) {
+			activity.StartedOn = startedOn
+		}
+
+		// Times are in milliseconds and distances in centimeters
+		elapsed += float64(session[fitSessionElapsed]) / 1000
+		timer += float64(session[fitSessionTimer]) / 1000
+		activity.DistanceMeters += float64(session[fitSessionDistance]) / 100
+		if bpm, ok := session[fitSessionHeartRate]; ok {
+			rate.add(float64(bpm), float64(session[fitSessionTimer]))
+		}
+	}
+
+	activity.ElapsedSeconds = int64(math.Round(elapsed))
+	activity.ActiveSeconds = int64(math.Round(timer))
+	activity.AverageHeartRate = rate.average()
+	return activity
+}
+
+func fitActivityFromRecords(records []fitMessage) Activity {
+	activity := Activity{Format: FormatFIT, Sport: sportName("")}
+	rate := heartRate{}
+	var first, last uint64
+	for _, record := range records {
+		if timestamp, ok := record[fitTimestamp]; ok {
+			if first == 0 {
+				first = timestamp
+			}
+			last = timestamp
+		}
+		// The distance of a record is the distance covered so far
+		if distance, ok := record[fitRecordDistance]; ok {
+			activity.DistanceMeters = max(activity.DistanceMeters, float64(distance)/100)
+		}
+		if bpm, ok := record[fitRecordHeartRate]; ok {
+			rate.add(float64(bpm), 1)
+		}
+	}
+
+	if first > 0 {
+		activity.StartedOn = fitTime(first)
+		activity.ElapsedSeconds = int64(last - first)
+	}
+	activity.AverageHeartRate = rate.average()
+	return activity
+}
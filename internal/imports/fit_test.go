@@ -0,0 +1,142 @@
+package imports
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fitTestField struct {
+	number   byte
+	size     int
+	baseType byte
+	value    uint64
+}
+
+func fitTestDefinition(local byte, global uint16, fields ...fitTestField) []byte {
+	result := []byte{0x40 | local, 0, 0, byte(global), byte(global >> 8), byte(len(fields))}
+	for _, f := range fields {
+		result = append(result, f.number, byte(f.size), f.baseType)
+	}
+	return result
+}
+
+func fitTestData(header byte, fields ...fitTestField) []byte {
+	result := []byte{header}
+	for _, f := range fields {
+		for i := 0; i < f.size; i++ {
+			result = append(result, byte(f.value>>(8*i)))
+		}
+	}
+	return result
+}
+
+// fitTestFile puts the header and a CRC around the records
+func fitTestFile(records ...[]byte) []byte {
+	data := bytes.Join(records, nil)
+	header := []byte{14, 0x20, 0x54, 0x08, 0, 0, 0, 0, '.', 'F', 'I', 'T', 0, 0}
+	binary.LittleEndian.PutUint32(header[4:8], uint32(len(data)))
+	return append(append(header, data...), 0, 0)
+}
+
+func fitTestTimestamp(t time.Time) uint64 {
+	return uint64(t.Unix() - fitEpoch.Unix())
+}
+
+func TestParseFITSession(t *testing.T) {
+	startedOn := time.Date(2024, 1, 15, 18, 30, 0, 0, time.UTC)
+	session := []fitTestField{
+		{number: fitTimestamp, size: 4, baseType: 0x86, value: fitTestTimestamp(startedOn.Add(time.Hour))},
+		{number: fitSessionStartTime, size: 4, baseType: 0x86, value: fitTestTimestamp(startedOn)},
+		{number: fitSessionSport, size: 1, baseType: 0x00, value: 1},
+		{number: fitSessionElapsed, size: 4, baseType: 0x86, value: 3900000},
+		{number: fitSessionTimer, size: 4, baseType: 0x86, value: 3600000},
+		{number: fitSessionDistance, size: 4, baseType: 0x86, value: 1000012},
+		{number: fitSessionHeartRate, size: 1, baseType: 0x02, value: 152},
+		// Invalid values are left out
+		{number: 17, size: 1, baseType: 0x02, value: 0xff},
+	}
+	// The session is defined with a developer field, which is skipped
+	definition := fitTestDefinition(0, fitSession, session...)
+	definition[0] |= 0x20
+	definition = append(definition, 1, 0, 2, 0)
+	data := append(fitTestData(0, session...), 0xaa, 0xbb)
+
+	file := fitTestFile(
+		// A file id with a string field
+		fitTestDefinition(1, 0, fitTestField{number: 8, size: 4, baseType: 0x07}),
+		fitTestData(1, fitTestField{number: 8, size: 4, value: 0x6e6d7247}),
+		definition,
+		data,
+	)
+
+	activity, err := parseActivity(bytes.NewReader(file))
+
+	heartRate := int64(152)
+	assert.Nil(t, err)
+	assert.Equal(t, Activity{
+		Format:           FormatFIT,
+		Sport:            "Running",
+		Name:             "Running",
+		StartedOn:        startedOn,
+		ElapsedSeconds:   3900,
+		ActiveSeconds:    3600,
+		DistanceMeters:   10000,
+		AverageHeartRate: &heartRate,
+	}, activity)
+}
+
+func TestParseFITRecordsWithCompressedTimestamps(t *testing.T) {
+	// The last five bits of the first timestamp are 30, so the next compressed
+	// timestamp rolls over
+	first := fitTestTimestamp(time.Date(2024, 1, 15, 18, 30, 0, 0, time.UTC))&^0x1f + 30
+	heartRate := fitTestField{number: fitRecordHeartRate, size: 1, baseType: 0x02}
+	distance := fitTestField{number: fitRecordDistance, size: 4, baseType: 0x86}
+	timestamp := fitTestField{number: fitTimestamp, size: 4, baseType: 0x86, value: first}
+
+	record := func(bpm uint64, centimeters uint64) []fitTestField {
+		heartRate.value, distance.value = bpm, centimeters
+		return []fitTestField{heartRate, distance}
+	}
+	file := fitTestFile(
+		fitTestDefinition(1, fitRecord, timestamp, heartRate, distance),
+		fitTestData(1, append([]fitTestField{timestamp}, record(140, 0)...)...),
+		fitTestDefinition(0, fitRecord, heartRate, distance),
+		fitTestData(0x80|2, record(150, 2000)...),
+		fitTestData(0x80|10, record(160, 5000)...),
+	)
+
+	activity, err := parseActivity(bytes.NewReader(file))
+
+	bpm := int64(150)
+	assert.Nil(t, err)
+	assert.Equal(t, Activity{
+		Format:           FormatFIT,
+		Sport:            "Cardio",
+		Name:             "Cardio",
+		StartedOn:        fitEpoch.Add(time.Duration(first) * time.Second),
+		ElapsedSeconds:   12,
+		ActiveSeconds:    12,
+		DistanceMeters:   50,
+		AverageHeartRate: &bpm,
+	}, activity)
+}
+
+func TestParseFITInvalid(t *testing.T) {
+	session := fitTestField{number: fitSessionTimer, size: 4, baseType: 0x86, value: 1000}
+	complete := fitTestFile(fitTestDefinition(0, fitSession, session), fitTestData(0, session))
+
+	tests := map[string][]byte{
+		"truncated FIT file":                  complete[:len(complete)-6],
+		"FIT data message without definition": fitTestFile(fitTestData(0, session)),
+		"FIT file has no sessions or records": fitTestFile(),
+		"activity has no start time":          complete,
+	}
+	for expected, file := range tests {
+		_, err := parseActivity(bytes.NewReader(file))
+		assert.ErrorContains(t, err, expected)
+	}
+}
@@ -0,0 +1,160 @@
+package imports
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"weight-tracker/internal/database"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
+	"weight-tracker/internal/workouts"
+)
+
+type handler struct {
+	service Service
+}
+
+func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
+	handler := handler{
+		service: NewService(
+			NewImportRepository(s.GetRepository()),
+			func(ctx context.Context, fn func(ImportRepository) error) error {
+				return s.WithTx(ctx, func(q repository.Querier) error {
+					return fn(NewImportRepository(q))
+				})
+			},
+			preferences.NewServiceFromDatabase(s),
+			workouts.NewCompletionListenersFromDatabase(s)...,
+		),
+	}
+
+	mux.Handle("POST /imports/preview", authenticationWrapper(http.HandlerFunc(handler.previewHandler)))
+	mux.Handle("POST /imports", authenticationWrapper(http.HandlerFunc(handler.importHandler)))
+	mux.Handle("POST /imports/activity", authenticationWrapper(http.HandlerFunc(handler.importActivityHandler)))
+}
+
+// Largest file that can be uploaded, years of history fit in a few megabytes
+const maxUploadBytes = 10 << 20
+
+// readFile reads the file of a multipart form, the other fields of the form
+// can be read from the request afterwards
+func readFile(w http.ResponseWriter, r *http.Request) (multipart.File, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadBytes)
+	err := r.ParseMultipartForm(maxUploadBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse form: %w", err)
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	return file, nil
+}
+
+// readUpload reads the file and the mappings of a multipart form. The
+// mappings are a JSON object from exercise names in the file to exercise type
+// ids, an empty id skips the sets of the name.
+func readUpload(w http.ResponseWriter, r *http.Request) (multipart.File, map[string]string, error) {
+	file, err := readFile(w, r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mappings := map[string]string{}
+	if v := r.FormValue("mappings"); v != "" {
+		err = json.Unmarshal([]byte(v), &mappings)
+		if err != nil {
+			file.Close()
+			return nil, nil, fmt.Errorf("failed to decode mappings: %w", err)
+		}
+	}
+	return file, mappings, nil
+}
+
+func (h *handler) previewHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	file, mappings, err := readUpload(w, r)
+	if err != nil {
+		slog.Warn("Failed to read upload", "error", err)
+		http.Error(w, "Invalid upload", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	preview, err := h.service.Preview(r.Context(), file, mappings, userId)
+	if err != nil {
+		slog.Warn("Failed to preview import", "error", err)
+		http.Error(w, "Failed to preview import", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(preview)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) importHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	file, mappings, err := readUpload(w, r)
+	if err != nil {
+		slog.Warn("Failed to read upload", "error", err)
+		http.Error(w, "Invalid upload", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	result, err := h.service.Import(r.Context(), file, mappings, userId)
+	if err != nil {
+		slog.Warn("Failed to import workouts", "error", err)
+		http.Error(w, "Failed to import workouts", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(result)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) importActivityHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	file, err := readFile(w, r)
+	if err != nil {
+		slog.Warn("Failed to read upload", "error", err)
+		http.Error(w, "Invalid upload", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	result, err := h.service.ImportActivity(r.Context(), file, r.FormValue("exercise_type_id"), userId)
+	if err != nil {
+		slog.Warn("Failed to import activity", "error", err)
+		http.Error(w, "Failed to import activity", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(result)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	utils.ReturnJson(w, jsonResp)
+}
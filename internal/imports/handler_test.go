@@ -0,0 +1,205 @@
+package imports
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func populateContextWithSub(req *http.Request, userId string) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, "sub", userId)
+	return req.WithContext(ctx)
+}
+
+type serviceMock struct {
+	mock.Mock
+}
+
+func (m *serviceMock) Preview(ctx context.Context, file io.Reader, mappings map[string]string, userId string) (Preview, error) {
+	content, _ := io.ReadAll(file)
+	args := m.Called(ctx, string(content), mappings, userId)
+	return args.Get(0).(Preview), args.Error(1)
+}
+
+func (m *serviceMock) Import(ctx context.Context, file io.Reader, mappings map[string]string, userId string) (Result, error) {
+	content, _ := io.ReadAll(file)
+	args := m.Called(ctx, string(content), mappings, userId)
+	return args.Get(0).(Result), args.Error(1)
+}
+
+func (m *serviceMock) ImportActivity(ctx context.Context, file io.Reader, exerciseTypeId string, userId string) (ActivityResult, error) {
+	content, _ := io.ReadAll(file)
+	args := m.Called(ctx, string(content), exerciseTypeId, userId)
+	return args.Get(0).(ActivityResult), args.Error(1)
+}
+
+// newUploadRequest creates a multipart request of the file and, when not
+// empty, the mappings
+func newUploadRequest(t *testing.T, url string, file string, mappings string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "strong.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write([]byte(file))
+	if mappings != "" {
+		writer.WriteField("mappings", mappings)
+	}
+	writer.Close()
+
+	req, err := http.NewRequest("POST", url, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return populateContextWithSub(req, "userId")
+}
+
+func TestPreviewHandler(t *testing.T) {
+	req := newUploadRequest(t, "/imports/preview", strongFile, `{"Squat (Barbell)":"squat"}`)
+
+	id, name := "squat", "Back Squat"
+	serviceMock := serviceMock{}
+	serviceMock.On("Preview", mock.Anything, strongFile, map[string]string{"Squat (Barbell)": "squat"}, "userId").Return(Preview{
+		Source:    SourceStrong,
+		Workouts:  1,
+		Sets:      1,
+		Exercises: []ExerciseMatch{{Name: "Squat (Barbell)", Sets: 1, Match: MatchMapped, ExerciseTypeID: &id, ExerciseTypeName: &name, Score: 1}},
+		Skipped:   []SkippedRow{{Line: 5, Reason: "rest timer row"}},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock}
+	http.HandlerFunc(h.previewHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	expected := `{"data":{"source":"strong","workouts":1,"sets":1,` +
+		`"exercises":[{"name":"Squat (Barbell)","sets":1,"match":"mapped","exercise_type_id":"squat","exercise_type_name":"Back Squat","score":1}],` +
+		`"skipped":[{"line":5,"reason":"rest timer row"}]}}`
+	assert.Equal(t, expected, rr.Body.String())
+	serviceMock.AssertExpectations(t)
+}
+
+func TestImportHandler(t *testing.T) {
+	req := newUploadRequest(t, "/imports", strongFile, "")
+
+	serviceMock := serviceMock{}
+	serviceMock.On("Import", mock.Anything, strongFile, map[string]string{}, "userId").Return(Result{
+		Source:     SourceStrong,
+		WorkoutIDs: []string{"push", "legs"},
+		Sets:       3,
+		Skipped:    []SkippedRow{},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock}
+	http.HandlerFunc(h.importHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, `{"data":{"source":"strong","workout_ids":["push","legs"],"sets":3,"skipped":[]}}`, rr.Body.String())
+	serviceMock.AssertExpectations(t)
+}
+
+func TestImportHandlerFailure(t *testing.T) {
+	req := newUploadRequest(t, "/imports", "name,weight\n", "")
+
+	serviceMock := serviceMock{}
+	serviceMock.On("Import", mock.Anything, "name,weight\n", map[string]string{}, "userId").Return(Result{}, errors.New("unrecognized file")).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock}
+	http.HandlerFunc(h.importHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "Failed to import workouts\n", rr.Body.String())
+}
+
+func TestImportHandlerInvalidUpload(t *testing.T) {
+	tests := map[string]*http.Request{
+		"invalid mappings": newUploadRequest(t, "/imports", strongFile, "[1, 2]"),
+	}
+	req, err := http.NewRequest("POST", "/imports", bytes.NewBufferString(strongFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests["not multipart"] = populateContextWithSub(req, "userId")
+
+	for name, req := range tests {
+		serviceMock := serviceMock{}
+
+		rr := httptest.NewRecorder()
+		h := handler{service: &serviceMock}
+		http.HandlerFunc(h.importHandler).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, name)
+		assert.Equal(t, "Invalid upload\n", rr.Body.String(), name)
+		serviceMock.AssertNotCalled(t, "Import")
+	}
+}
+
+func TestImportActivityHandler(t *testing.T) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "ride.tcx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write([]byte(tcxActivity))
+	writer.WriteField("exercise_type_id", "bike")
+	writer.Close()
+
+	req, err := http.NewRequest("POST", "/imports/activity", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req = populateContextWithSub(req, "userId")
+
+	heartRate := int64(145)
+	serviceMock := serviceMock{}
+	serviceMock.On("ImportActivity", mock.Anything, tcxActivity, "bike", "userId").Return(ActivityResult{
+		Format:           FormatTCX,
+		WorkoutID:        "workout",
+		ExerciseTypeID:   "bike",
+		ExerciseTypeName: "Cycling",
+		StartedOn:        "2024-01-15T07:00:00Z",
+		CompletedOn:      "2024-01-15T07:45:00Z",
+		DurationSeconds:  2400,
+		DistanceMeters:   20000,
+		AverageHeartRate: &heartRate,
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock}
+	http.HandlerFunc(h.importActivityHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	expected := `{"data":{"format":"tcx","workout_id":"workout","exercise_type_id":"bike","exercise_type_name":"Cycling","created_exercise_type":false,` +
+		`"started_on":"2024-01-15T07:00:00Z","completed_on":"2024-01-15T07:45:00Z","duration_seconds":2400,"distance_meters":20000,"average_heart_rate":145}}`
+	assert.Equal(t, expected, rr.Body.String())
+	serviceMock.AssertExpectations(t)
+}
+
+func TestImportActivityHandlerFailure(t *testing.T) {
+	req := newUploadRequest(t, "/imports/activity", "name,weight\n", "")
+
+	serviceMock := serviceMock{}
+	serviceMock.On("ImportActivity", mock.Anything, "name,weight\n", "", "userId").Return(ActivityResult{}, errors.New("unrecognized file")).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock}
+	http.HandlerFunc(h.importActivityHandler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "Failed to import activity\n", rr.Body.String())
+}
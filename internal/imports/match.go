@@ -0,0 +1,125 @@
+package imports
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Lowest similarity at which an exercise type is suggested for a name
+const minScore = 0.6
+
+// Abbreviations the apps and users write for equipment
+var abbreviations = map[string]string{
+	"bb":  "barbell",
+	"db":  "dumbbell",
+	"kb":  "kettlebell",
+	"bw":  "bodyweight",
+	"ohp": "overhead press",
+	"rdl": "romanian deadlift",
+}
+
+// words lowercases the name, splits it on anything but letters and digits,
+// writes out abbreviations and sorts the words, so "Bench Press (Barbell)"
+// and "barbell bench press" have the same words
+func words(name string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	result := []string{}
+	for _, v := range fields {
+		if full, ok := abbreviations[v]; ok {
+			result = append(result, strings.Fields(full)...)
+			continue
+		}
+		result = append(result, v)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// similarity scores two names between 0 and 1. It is the best of the share
+// of words they have in common and how close their sorted words are as text,
+// which forgives small spelling differences.
+func similarity(a []string, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	a, b = slices.Compact(slices.Clone(a)), slices.Compact(slices.Clone(b))
+	common := 0
+	for _, v := range a {
+		if slices.Contains(b, v) {
+			common++
+		}
+	}
+	dice := 2 * float64(common) / float64(len(a)+len(b))
+
+	textA, textB := strings.Join(a, " "), strings.Join(b, " ")
+	longest := max(len([]rune(textA)), len([]rune(textB)))
+	edits := 1 - float64(levenshtein(textA, textB))/float64(longest)
+
+	return math.Round(max(dice, edits)*100) / 100
+}
+
+func levenshtein(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// match finds the exercise type for a name. Types with the same words are an
+// exact match, otherwise the most similar type is a fuzzy match when it
+// scores at least minScore. Ties go to the type listed first.
+func match(name string, exerciseTypes []ExerciseType) (ExerciseMatch, *ExerciseType) {
+	nameWords := words(name)
+	result := ExerciseMatch{Name: name, Match: MatchNone}
+
+	var best *ExerciseType
+	for i, v := range exerciseTypes {
+		typeWords := words(v.Name)
+		if slices.Equal(nameWords, typeWords) {
+			best = &exerciseTypes[i]
+			result.Match = MatchExact
+			result.Score = 1
+			break
+		}
+
+		score := similarity(nameWords, typeWords)
+		if score > result.Score {
+			best = &exerciseTypes[i]
+			result.Score = score
+		}
+	}
+
+	if best == nil {
+		return result, nil
+	}
+	if result.Match != MatchExact {
+		if result.Score < minScore {
+			return result, nil
+		}
+		result.Match = MatchFuzzy
+	}
+	result.ExerciseTypeID = &best.ID
+	result.ExerciseTypeName = &best.Name
+	return result, best
+}
@@ -0,0 +1,56 @@
+package imports
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var matchTypes = []ExerciseType{
+	{ID: "bench", Name: "Barbell Bench Press"},
+	{ID: "incline", Name: "Incline Dumbbell Press"},
+	{ID: "squat", Name: "Back Squat"},
+	{ID: "pullup", Name: "Pull Up"},
+}
+
+func TestMatchExactIgnoresOrderCaseAndPunctuation(t *testing.T) {
+	result, exerciseType := match("Bench Press (Barbell)", matchTypes)
+
+	id, name := "bench", "Barbell Bench Press"
+	assert.Equal(t, ExerciseMatch{Name: "Bench Press (Barbell)", Match: MatchExact, ExerciseTypeID: &id, ExerciseTypeName: &name, Score: 1}, result)
+	assert.Equal(t, "bench", exerciseType.ID)
+}
+
+func TestMatchWritesOutAbbreviations(t *testing.T) {
+	result, _ := match("Incline DB Press", matchTypes)
+
+	assert.Equal(t, MatchExact, result.Match)
+	assert.Equal(t, "incline", *result.ExerciseTypeID)
+}
+
+func TestMatchFuzzy(t *testing.T) {
+	result, exerciseType := match("Pull-ups", matchTypes)
+
+	assert.Equal(t, MatchFuzzy, result.Match)
+	assert.Equal(t, "pullup", exerciseType.ID)
+	assert.Equal(t, 0.88, result.Score)
+
+	result, exerciseType = match("Squat (Barbell)", matchTypes)
+	assert.Equal(t, MatchFuzzy, result.Match)
+	assert.Equal(t, "squat", exerciseType.ID)
+}
+
+func TestMatchNone(t *testing.T) {
+	result, exerciseType := match("Lateral Raise (Cable)", matchTypes)
+
+	assert.Equal(t, MatchNone, result.Match)
+	assert.Nil(t, result.ExerciseTypeID)
+	assert.Nil(t, exerciseType)
+	assert.Less(t, result.Score, minScore)
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("squat", "squat"))
+	assert.Equal(t, 3, levenshtein("kitten", "sitting"))
+	assert.Equal(t, 5, levenshtein("", "squat"))
+}
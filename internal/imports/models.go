@@ -0,0 +1,116 @@
+package imports
+
+import "time"
+
+// Apps whose CSV exports can be imported, recognised by their header
+const (
+	SourceStrong   = "strong"
+	SourceHevy     = "hevy"
+	SourceFitNotes = "fitnotes"
+)
+
+// How the exercise name of a file was matched onto an exercise type
+const (
+	// MatchExact is a name with the same words as the exercise type, in any order
+	MatchExact = "exact"
+	// MatchFuzzy is the closest exercise type when it is similar enough
+	MatchFuzzy = "fuzzy"
+	// MatchMapped is an exercise type chosen in the mappings of the import
+	MatchMapped = "mapped"
+	// MatchIgnored is a name mapped to no exercise type, its rows are skipped
+	MatchIgnored = "ignored"
+	// MatchNone is a name without any similar exercise type, its rows are skipped
+	MatchNone = "none"
+)
+
+// Row is a set read from the file. Weights are in kilograms and workouts are
+// told apart by their key.
+type Row struct {
+	Line            int
+	WorkoutKey      string
+	WorkoutName     string
+	WorkoutNote     string
+	StartedOn       time.Time
+	ActiveSeconds   int64
+	ExerciseName    string
+	Type            string
+	Repetitions     int64
+	Weight          float64
+	DurationSeconds int64
+	DistanceMeters  float64
+	RPE             *float64
+	Note            string
+}
+
+// SkippedRow is a line of the file that was not imported and why
+type SkippedRow struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// ExerciseMatch is the exercise type the sets of an exercise name are imported into
+type ExerciseMatch struct {
+	Name             string  `json:"name"`
+	Sets             int     `json:"sets"`
+	Match            string  `json:"match"`
+	ExerciseTypeID   *string `json:"exercise_type_id,omitempty"`
+	ExerciseTypeName *string `json:"exercise_type_name,omitempty"`
+	Score            float64 `json:"score"`
+}
+
+// Preview is what importing the file would do with the mappings given
+type Preview struct {
+	Source    string          `json:"source"`
+	Workouts  int             `json:"workouts"`
+	Sets      int             `json:"sets"`
+	Exercises []ExerciseMatch `json:"exercises"`
+	Skipped   []SkippedRow    `json:"skipped"`
+}
+
+// Result is what an import created
+type Result struct {
+	Source     string       `json:"source"`
+	WorkoutIDs []string     `json:"workout_ids"`
+	Sets       int          `json:"sets"`
+	Skipped    []SkippedRow `json:"skipped"`
+}
+
+type ExerciseType struct {
+	ID          string
+	Name        string
+	Measurement string
+}
+
+// Formats of activity files that can be imported, recognised by their content
+const (
+	FormatFIT = "fit"
+	FormatTCX = "tcx"
+	FormatGPX = "gpx"
+)
+
+// Activity is the summary of a cardio session read from an activity file.
+// Elapsed seconds include pauses, active seconds do not.
+type Activity struct {
+	Format           string
+	Sport            string
+	Name             string
+	StartedOn        time.Time
+	ElapsedSeconds   int64
+	ActiveSeconds    int64
+	DistanceMeters   float64
+	AverageHeartRate *int64
+}
+
+// ActivityResult is the workout an activity file was imported into
+type ActivityResult struct {
+	Format              string  `json:"format"`
+	WorkoutID           string  `json:"workout_id"`
+	ExerciseTypeID      string  `json:"exercise_type_id"`
+	ExerciseTypeName    string  `json:"exercise_type_name"`
+	CreatedExerciseType bool    `json:"created_exercise_type"`
+	StartedOn           string  `json:"started_on"`
+	CompletedOn         string  `json:"completed_on"`
+	DurationSeconds     int64   `json:"duration_seconds"`
+	DistanceMeters      float64 `json:"distance_meters"`
+	AverageHeartRate    *int64  `json:"average_heart_rate,omitempty"`
+}
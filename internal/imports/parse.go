@@ -0,0 +1,446 @@
+package imports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"weight-tracker/internal/sets"
+	"weight-tracker/internal/units"
+)
+
+const (
+	metersPerMile = 1609.344
+	metersPerFoot = 0.3048
+	metersPerYard = 0.9144
+)
+
+// Sets are imported within the limits of logged sets, an RPE outside of them
+// is left out and long notes are cut short
+const (
+	minRPE        = 6
+	maxRPE        = 10
+	maxNoteLength = 500
+)
+
+// errRestTimer marks the rest timer rows Strong writes between sets, they are
+// not sets and skipped
+var errRestTimer = errors.New("rest timer row")
+
+// columns looks up the values of a record by the name of their column
+type columns map[string]int
+
+func (c columns) has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := c[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (c columns) get(record []string, name string) string {
+	i, ok := c[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// rowParser turns a record of the file into a set, an error is the reason to skip it
+type rowParser func(c columns, record []string) (Row, error)
+
+// parse detects which app exported the file and reads its sets. Weights
+// without a unit in the file are in the unit of the user and dates without a
+// timezone are in the location of the user.
+func parse(file io.Reader, unit string, location *time.Location) (string, []Row, []SkippedRow, error) {
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = delimiter(content)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	c := columns{}
+	for i, name := range header {
+		c[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	source, parser, err := detect(c, unit, location)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	rows := []Row{}
+	skipped := []SkippedRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return "", nil, nil, fmt.Errorf("failed to read file: %w", err)
+			}
+			skipped = append(skipped, SkippedRow{Line: parseErr.Line, Reason: parseErr.Err.Error()})
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		row, err := parser(c, record)
+		if err != nil {
+			skipped = append(skipped, SkippedRow{Line: line, Reason: err.Error()})
+			continue
+		}
+		row.Line = line
+		rows = append(rows, row)
+	}
+	return source, rows, skipped, nil
+}
+
+// delimiter is a semicolon when the header has more of them than commas, as in
+// exports from locales that write decimals with a comma
+func delimiter(content []byte) rune {
+	header, _, _ := bytes.Cut(content, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+func detect(c columns, unit string, location *time.Location) (string, rowParser, error) {
+	switch {
+	case c.has("title", "start_time", "exercise_title", "set_type"):
+		return SourceHevy, func(c columns, record []string) (Row, error) {
+			return parseHevy(c, record, location)
+		}, nil
+	case c.has("date", "workout name", "exercise name", "set order"):
+		return SourceStrong, func(c columns, record []string) (Row, error) {
+			return parseStrong(c, record, unit, location)
+		}, nil
+	case c.has("date", "exercise", "category", "reps"):
+		return SourceFitNotes, func(c columns, record []string) (Row, error) {
+			return parseFitNotes(c, record, location)
+		}, nil
+	}
+	return "", nil, fmt.Errorf("unrecognized file, expected a CSV export of Strong, Hevy or FitNotes")
+}
+
+// parseStrong reads a row of Strong, weights and distances are in the unit
+// the app was set to, kilometers going with kilograms and miles with pounds
+func parseStrong(c columns, record []string, unit string, location *time.Location) (Row, error) {
+	setOrder := c.get(record, "set order")
+	if strings.EqualFold(setOrder, "rest timer") {
+		return Row{}, errRestTimer
+	}
+
+	date := c.get(record, "date")
+	startedOn, err := parseTime(date, location, "2006-01-02 15:04:05", "2006-01-02 15:04")
+	if err != nil {
+		return Row{}, err
+	}
+
+	row := Row{
+		WorkoutKey:   date + "|" + c.get(record, "workout name"),
+		WorkoutName:  c.get(record, "workout name"),
+		WorkoutNote:  c.get(record, "workout notes"),
+		StartedOn:    startedOn,
+		ExerciseName: c.get(record, "exercise name"),
+		Type:         strongSetType(setOrder),
+		Note:         truncate(c.get(record, "notes")),
+	}
+
+	row.ActiveSeconds, err = parseStrongDuration(c.get(record, "duration"))
+	if err != nil {
+		return Row{}, err
+	}
+
+	weight, err := parseNumber("weight", c.get(record, "weight"))
+	if err != nil {
+		return Row{}, err
+	}
+	row.Weight = units.ToKilograms(weight, unit)
+
+	distance, err := parseNumber("distance", c.get(record, "distance"))
+	if err != nil {
+		return Row{}, err
+	}
+	if unit == units.Pounds {
+		row.DistanceMeters = distance * metersPerMile
+	} else {
+		row.DistanceMeters = distance * 1000
+	}
+
+	err = parseSet(&row, c.get(record, "reps"), c.get(record, "seconds"), c.get(record, "rpe"))
+	if err != nil {
+		return Row{}, err
+	}
+	return row, validateRow(row)
+}
+
+// Strong numbers working sets and marks the others with a letter
+func strongSetType(setOrder string) string {
+	switch strings.ToUpper(setOrder) {
+	case "W":
+		return sets.TypeWarmup
+	case "D":
+		return sets.TypeDrop
+	case "F":
+		return sets.TypeFailure
+	}
+	return sets.TypeWorking
+}
+
+// parseStrongDuration reads durations like "1h 5m" or "45m 30s", a plain
+// number is seconds
+func parseStrongDuration(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return seconds, nil
+	}
+
+	duration, err := time.ParseDuration(strings.ReplaceAll(value, " ", ""))
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid duration: %s", value)
+	}
+	return int64(duration.Seconds()), nil
+}
+
+// parseHevy reads a row of Hevy, which names the unit of weights and
+// distances in the column
+func parseHevy(c columns, record []string, location *time.Location) (Row, error) {
+	layouts := []string{"2 Jan 2006, 15:04", "2 Jan 2006 15:04", "2006-01-02 15:04:05", time.RFC3339}
+	start := c.get(record, "start_time")
+	startedOn, err := parseTime(start, location, layouts...)
+	if err != nil {
+		return Row{}, err
+	}
+
+	row := Row{
+		WorkoutKey:   start + "|" + c.get(record, "title"),
+		WorkoutName:  c.get(record, "title"),
+		WorkoutNote:  c.get(record, "description"),
+		StartedOn:    startedOn,
+		ExerciseName: c.get(record, "exercise_title"),
+		Type:         hevySetType(c.get(record, "set_type")),
+		Note:         truncate(c.get(record, "exercise_notes")),
+	}
+
+	if end := c.get(record, "end_time"); end != "" {
+		endedOn, err := parseTime(end, location, layouts...)
+		if err != nil {
+			return Row{}, err
+		}
+		row.ActiveSeconds = max(0, int64(endedOn.Sub(startedOn).Seconds()))
+	}
+
+	if c.has("weight_lbs") {
+		weight, err := parseNumber("weight", c.get(record, "weight_lbs"))
+		if err != nil {
+			return Row{}, err
+		}
+		row.Weight = units.ToKilograms(weight, units.Pounds)
+	} else {
+		row.Weight, err = parseNumber("weight", c.get(record, "weight_kg"))
+		if err != nil {
+			return Row{}, err
+		}
+	}
+
+	if c.has("distance_miles") {
+		distance, err := parseNumber("distance", c.get(record, "distance_miles"))
+		if err != nil {
+			return Row{}, err
+		}
+		row.DistanceMeters = distance * metersPerMile
+	} else {
+		distance, err := parseNumber("distance", c.get(record, "distance_km"))
+		if err != nil {
+			return Row{}, err
+		}
+		row.DistanceMeters = distance * 1000
+	}
+
+	err = parseSet(&row, c.get(record, "reps"), c.get(record, "duration_seconds"), c.get(record, "rpe"))
+	if err != nil {
+		return Row{}, err
+	}
+	return row, validateRow(row)
+}
+
+func hevySetType(setType string) string {
+	switch strings.ToLower(setType) {
+	case "warmup":
+		return sets.TypeWarmup
+	case "dropset":
+		return sets.TypeDrop
+	case "failure":
+		return sets.TypeFailure
+	}
+	return sets.TypeWorking
+}
+
+// parseFitNotes reads a row of FitNotes. It logs days rather than workouts,
+// every date is one workout without a start time.
+func parseFitNotes(c columns, record []string, location *time.Location) (Row, error) {
+	date := c.get(record, "date")
+	startedOn, err := parseTime(date, location, time.DateOnly)
+	if err != nil {
+		return Row{}, err
+	}
+
+	row := Row{
+		WorkoutKey:   date,
+		WorkoutName:  "Workout",
+		StartedOn:    startedOn,
+		ExerciseName: c.get(record, "exercise"),
+		Type:         sets.TypeWorking,
+		Note:         truncate(c.get(record, "comment")),
+	}
+
+	if c.has("weight (lbs)") {
+		weight, err := parseNumber("weight", c.get(record, "weight (lbs)"))
+		if err != nil {
+			return Row{}, err
+		}
+		row.Weight = units.ToKilograms(weight, units.Pounds)
+	} else {
+		row.Weight, err = parseNumber("weight", c.get(record, "weight (kgs)"))
+		if err != nil {
+			return Row{}, err
+		}
+	}
+
+	distance, err := parseNumber("distance", c.get(record, "distance"))
+	if err != nil {
+		return Row{}, err
+	}
+	switch strings.ToLower(c.get(record, "distance unit")) {
+	case "", "m":
+		row.DistanceMeters = distance
+	case "km":
+		row.DistanceMeters = distance * 1000
+	case "mi":
+		row.DistanceMeters = distance * metersPerMile
+	case "ft":
+		row.DistanceMeters = distance * metersPerFoot
+	case "yd":
+		row.DistanceMeters = distance * metersPerYard
+	default:
+		return Row{}, fmt.Errorf("unknown distance unit: %s", c.get(record, "distance unit"))
+	}
+
+	seconds, err := parseClock(c.get(record, "time"))
+	if err != nil {
+		return Row{}, err
+	}
+	err = parseSet(&row, c.get(record, "reps"), strconv.FormatInt(seconds, 10), "")
+	if err != nil {
+		return Row{}, err
+	}
+	return row, validateRow(row)
+}
+
+// parseClock reads durations like "1:05:30" or "5:30"
+func parseClock(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	seconds := int64(0)
+	for _, part := range strings.Split(value, ":") {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid time: %s", value)
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds, nil
+}
+
+// parseSet reads the repetitions, duration in seconds and RPE the apps share
+func parseSet(row *Row, reps string, seconds string, rpe string) error {
+	repetitions, err := parseNumber("repetitions", reps)
+	if err != nil {
+		return err
+	}
+	row.Repetitions = int64(math.Round(repetitions))
+
+	duration, err := parseNumber("duration", seconds)
+	if err != nil {
+		return err
+	}
+	row.DurationSeconds = int64(math.Round(duration))
+
+	if rpe != "" {
+		value, err := parseNumber("rpe", rpe)
+		if err != nil {
+			return err
+		}
+		value = math.Round(value*2) / 2
+		if value >= minRPE && value <= maxRPE {
+			row.RPE = &value
+		}
+	}
+	return nil
+}
+
+// parseNumber reads a number written with a decimal point or comma, an empty
+// value is zero
+func parseNumber(name string, value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+	if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("invalid %s: %s", name, value)
+	}
+	return number, nil
+}
+
+func parseTime(value string, location *time.Location, layouts ...string) (time.Time, error) {
+	for _, layout := range layouts {
+		t, err := time.ParseInLocation(layout, value, location)
+		if err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %s", value)
+}
+
+func truncate(note string) string {
+	runes := []rune(note)
+	if len(runes) <= maxNoteLength {
+		return note
+	}
+	return string(runes[:maxNoteLength])
+}
+
+func validateRow(row Row) error {
+	if row.ExerciseName == "" {
+		return fmt.Errorf("missing exercise name")
+	}
+	if row.Repetitions < 0 || row.Weight < 0 || row.DurationSeconds < 0 || row.DistanceMeters < 0 {
+		return fmt.Errorf("weight, repetitions, duration and distance can not be negative")
+	}
+	if row.Repetitions == 0 && row.DurationSeconds == 0 && row.DistanceMeters == 0 {
+		return fmt.Errorf("set has no repetitions, duration or distance")
+	}
+	return nil
+}
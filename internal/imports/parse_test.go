@@ -0,0 +1,112 @@
+package imports
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"weight-tracker/internal/sets"
+	"weight-tracker/internal/units"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStrong(t *testing.T) {
+	file := "Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps,Distance,Seconds,Notes,Workout Notes,RPE\n" +
+		"2024-01-15 18:30:00,Push,1h 5m,Bench Press (Barbell),W,60,10,0,0,,Felt good,\n" +
+		"2024-01-15 18:30:00,Push,1h 5m,Bench Press (Barbell),1,100,5,0,0,paused,Felt good,8.5\n" +
+		"2024-01-15 18:30:00,Push,1h 5m,Bench Press (Barbell),Rest Timer,0,0,0,90,,Felt good,\n" +
+		"2024-01-15 18:30:00,Push,1h 5m,Plank,1,0,0,0,60,,Felt good,\n" +
+		"2024-01-15 18:30:00,Push,1h 5m,Dips,1,0,0,0,0,,Felt good,\n" +
+		"yesterday,Push,1h 5m,Dips,1,0,10,0,0,,,\n"
+
+	location, _ := time.LoadLocation("Europe/Amsterdam")
+	source, rows, skipped, err := parse(strings.NewReader(file), units.Kilograms, location)
+
+	rpe := 8.5
+	startedOn := time.Date(2024, 1, 15, 17, 30, 0, 0, time.UTC)
+	key := "2024-01-15 18:30:00|Push"
+	assert.Nil(t, err)
+	assert.Equal(t, SourceStrong, source)
+	assert.Equal(t, []Row{
+		{Line: 2, WorkoutKey: key, WorkoutName: "Push", WorkoutNote: "Felt good", StartedOn: startedOn, ActiveSeconds: 3900, ExerciseName: "Bench Press (Barbell)", Type: sets.TypeWarmup, Repetitions: 10, Weight: 60},
+		{Line: 3, WorkoutKey: key, WorkoutName: "Push", WorkoutNote: "Felt good", StartedOn: startedOn, ActiveSeconds: 3900, ExerciseName: "Bench Press (Barbell)", Type: sets.TypeWorking, Repetitions: 5, Weight: 100, RPE: &rpe, Note: "paused"},
+		{Line: 5, WorkoutKey: key, WorkoutName: "Push", WorkoutNote: "Felt good", StartedOn: startedOn, ActiveSeconds: 3900, ExerciseName: "Plank", Type: sets.TypeWorking, DurationSeconds: 60},
+	}, rows)
+	assert.Equal(t, []SkippedRow{
+		{Line: 4, Reason: "rest timer row"},
+		{Line: 6, Reason: "set has no repetitions, duration or distance"},
+		{Line: 7, Reason: "invalid date: yesterday"},
+	}, skipped)
+}
+
+func TestParseStrongSemicolonsInPounds(t *testing.T) {
+	file := "\ufeffDate;Workout Name;Duration;Exercise Name;Set Order;Weight;Reps;Distance;Seconds;Notes;Workout Notes;RPE\n" +
+		"2024-01-15 18:30:00;Legs;45m;Squat (Barbell);1;225,5;5;0;0;;;\n" +
+		"2024-01-15 18:30:00;Legs;45m;Running;1;0;0;1,5;600;;;\n"
+
+	_, rows, skipped, err := parse(strings.NewReader(file), units.Pounds, time.UTC)
+
+	assert.Nil(t, err)
+	assert.Empty(t, skipped)
+	assert.Len(t, rows, 2)
+	assert.InDelta(t, 102.28, rows[0].Weight, 0.01)
+	assert.Equal(t, int64(2700), rows[0].ActiveSeconds)
+	assert.InDelta(t, 2414.02, rows[1].DistanceMeters, 0.01)
+	assert.Equal(t, int64(600), rows[1].DurationSeconds)
+}
+
+func TestParseHevy(t *testing.T) {
+	file := `"title","start_time","end_time","description","exercise_title","superset_id","exercise_notes","set_index","set_type","weight_lbs","reps","distance_miles","duration_seconds","rpe"` + "\n" +
+		`"Upper","15 Jan 2024, 18:30","15 Jan 2024, 19:45","","Bench Press (Barbell)",,"",0,"warmup",135,10,,,` + "\n" +
+		`"Upper","15 Jan 2024, 18:30","15 Jan 2024, 19:45","","Bench Press (Barbell)",,"",1,"dropset",225,5,,,12` + "\n" +
+		`"Upper","15 Jan 2024, 18:30","15 Jan 2024, 19:45","","Bench Press (Barbell)",,"",2,"normal",-10,5,,,` + "\n"
+
+	source, rows, skipped, err := parse(strings.NewReader(file), units.Kilograms, time.UTC)
+
+	assert.Nil(t, err)
+	assert.Equal(t, SourceHevy, source)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, sets.TypeWarmup, rows[0].Type)
+	assert.InDelta(t, 61.23, rows[0].Weight, 0.01)
+	assert.Equal(t, int64(4500), rows[0].ActiveSeconds)
+	assert.Equal(t, time.Date(2024, 1, 15, 18, 30, 0, 0, time.UTC), rows[0].StartedOn)
+	assert.Equal(t, sets.TypeDrop, rows[1].Type)
+	// An RPE outside of what can be logged is left out
+	assert.Nil(t, rows[1].RPE)
+	assert.Equal(t, []SkippedRow{{Line: 4, Reason: "weight, repetitions, duration and distance can not be negative"}}, skipped)
+}
+
+func TestParseFitNotes(t *testing.T) {
+	file := "Date,Exercise,Category,Weight (kgs),Reps,Distance,Distance Unit,Time,Comment\n" +
+		"2024-01-15,Flat Barbell Bench Press,Chest,100.0,5,,,,\n" +
+		"2024-01-15,Treadmill,Cardio,,,5.0,km,0:25:30,easy\n" +
+		"2024-01-16,Rowing,Cardio,,,500,furlong,,\n"
+
+	source, rows, skipped, err := parse(strings.NewReader(file), units.Pounds, time.UTC)
+
+	assert.Nil(t, err)
+	assert.Equal(t, SourceFitNotes, source)
+	assert.Equal(t, []Row{
+		{Line: 2, WorkoutKey: "2024-01-15", WorkoutName: "Workout", StartedOn: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), ExerciseName: "Flat Barbell Bench Press", Type: sets.TypeWorking, Repetitions: 5, Weight: 100},
+		{Line: 3, WorkoutKey: "2024-01-15", WorkoutName: "Workout", StartedOn: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), ExerciseName: "Treadmill", Type: sets.TypeWorking, DurationSeconds: 1530, DistanceMeters: 5000, Note: "easy"},
+	}, rows)
+	assert.Equal(t, []SkippedRow{{Line: 4, Reason: "unknown distance unit: furlong"}}, skipped)
+}
+
+func TestParseUnrecognizedFile(t *testing.T) {
+	_, _, _, err := parse(strings.NewReader("name,weight\nbench,100\n"), units.Kilograms, time.UTC)
+
+	assert.ErrorContains(t, err, "unrecognized file")
+}
+
+func TestParseStrongDuration(t *testing.T) {
+	tests := map[string]int64{"": 0, "45m": 2700, "1h 5m": 3900, "58s": 58, "1h 2m 3s": 3723, "90": 90}
+	for value, expected := range tests {
+		seconds, err := parseStrongDuration(value)
+		assert.Nil(t, err, value)
+		assert.Equal(t, expected, seconds, value)
+	}
+
+	_, err := parseStrongDuration("an hour")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,77 @@
+package imports
+
+import (
+	"context"
+	"fmt"
+	"weight-tracker/internal/repository"
+)
+
+type ImportRepository interface {
+	GetExerciseTypes(ctx context.Context, userId string) ([]ExerciseType, error)
+	CreateExerciseType(ctx context.Context, arg repository.CreateExerciseTypeAndReturnIdParams) (string, error)
+	CreateWorkout(ctx context.Context, arg repository.CreateCompletedWorkoutAndReturnIdParams) (string, error)
+	CreateExerciseItem(ctx context.Context, arg repository.CreateExerciseItemAndReturnIdParams) (string, error)
+	CreateExercise(ctx context.Context, arg repository.CreateExerciseAndReturnIdParams) (string, error)
+	CreateSet(ctx context.Context, arg repository.CreateSetAndReturnIdParams) (string, error)
+}
+
+func NewImportRepository(repo repository.Querier) ImportRepository {
+	return importRepository{repo: repo}
+}
+
+type importRepository struct {
+	repo repository.Querier
+}
+
+func (i importRepository) GetExerciseTypes(ctx context.Context, userId string) ([]ExerciseType, error) {
+	exerciseTypes, err := i.repo.GetAllExerciseTypes(ctx, userId)
+	if err != nil {
+		return []ExerciseType{}, fmt.Errorf("failed to get exercise types: %w", err)
+	}
+
+	result := []ExerciseType{}
+	for _, v := range exerciseTypes {
+		result = append(result, ExerciseType{ID: v.ID, Name: v.Name, Measurement: v.Measurement})
+	}
+	return result, nil
+}
+
+func (i importRepository) CreateExerciseType(ctx context.Context, arg repository.CreateExerciseTypeAndReturnIdParams) (string, error) {
+	id, err := i.repo.CreateExerciseTypeAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create exercise type: %w", err)
+	}
+	return id, nil
+}
+
+func (i importRepository) CreateWorkout(ctx context.Context, arg repository.CreateCompletedWorkoutAndReturnIdParams) (string, error) {
+	id, err := i.repo.CreateCompletedWorkoutAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create workout: %w", err)
+	}
+	return id, nil
+}
+
+func (i importRepository) CreateExerciseItem(ctx context.Context, arg repository.CreateExerciseItemAndReturnIdParams) (string, error) {
+	id, err := i.repo.CreateExerciseItemAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create exercise item: %w", err)
+	}
+	return id, nil
+}
+
+func (i importRepository) CreateExercise(ctx context.Context, arg repository.CreateExerciseAndReturnIdParams) (string, error) {
+	id, err := i.repo.CreateExerciseAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create exercise: %w", err)
+	}
+	return id, nil
+}
+
+func (i importRepository) CreateSet(ctx context.Context, arg repository.CreateSetAndReturnIdParams) (string, error) {
+	id, err := i.repo.CreateSetAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create set: %w", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,463 @@
+package imports
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+	"weight-tracker/internal/exerciseitems"
+	"weight-tracker/internal/measurement"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/sets"
+	"weight-tracker/internal/utils"
+	"weight-tracker/internal/workouts"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	// Preview reads the file and reports what importing it with the mappings would do
+	Preview(ctx context.Context, file io.Reader, mappings map[string]string, userId string) (Preview, error)
+	// Import creates the workouts of the file in one transaction
+	Import(ctx context.Context, file io.Reader, mappings map[string]string, userId string) (Result, error)
+	// ImportActivity creates a completed workout of a FIT, TCX or GPX activity
+	// file, logged as the exercise type or the one of its sport when not given
+	ImportActivity(ctx context.Context, file io.Reader, exerciseTypeId string, userId string) (ActivityResult, error)
+}
+
+// Transactor runs fn with a repository whose writes are committed together
+type Transactor func(ctx context.Context, fn func(ImportRepository) error) error
+
+// Weights and dates in the file are read in the unit and timezone of the user
+type importPreferences interface {
+	Get(ctx context.Context, userId string) (preferences.Preferences, error)
+}
+
+type importService struct {
+	repo        ImportRepository
+	withTx      Transactor
+	preferences importPreferences
+	listeners   []workouts.CompletionListener
+}
+
+// NewService creates the import service, the listeners are notified of every
+// imported workout like they are of workouts completed by hand
+func NewService(repo ImportRepository, withTx Transactor, preferences importPreferences, listeners ...workouts.CompletionListener) Service {
+	return &importService{repo: repo, withTx: withTx, preferences: preferences, listeners: listeners}
+}
+
+// notifyCompleted notifies the listeners of the imported workouts once they
+// are committed, oldest first so records are found in the order they were set
+func (s *importService) notifyCompleted(ctx context.Context, workoutIds []string, userId string) {
+	for _, workoutId := range workoutIds {
+		// The workouts are already imported, a failing listener should not undo that
+		for _, listener := range s.listeners {
+			err := listener.OnWorkoutCompleted(ctx, workoutId, userId)
+			if err != nil {
+				slog.Error("Completion listener failed", "error", err, "workoutId", workoutId)
+			}
+		}
+	}
+}
+
+type plannedExercise struct {
+	exerciseType ExerciseType
+	rows         []Row
+}
+
+type plannedWorkout struct {
+	name             string
+	note             string
+	startedOn        time.Time
+	completedOn      time.Time
+	activeSeconds    int64
+	averageHeartRate *int64
+	exercises        []plannedExercise
+}
+
+type plan struct {
+	source    string
+	workouts  []plannedWorkout
+	sets      int
+	exercises []ExerciseMatch
+	skipped   []SkippedRow
+}
+
+func (s *importService) Preview(ctx context.Context, file io.Reader, mappings map[string]string, userId string) (Preview, error) {
+	p, err := s.plan(ctx, file, mappings, userId)
+	if err != nil {
+		return Preview{}, err
+	}
+	return Preview{
+		Source:    p.source,
+		Workouts:  len(p.workouts),
+		Sets:      p.sets,
+		Exercises: p.exercises,
+		Skipped:   p.skipped,
+	}, nil
+}
+
+func (s *importService) Import(ctx context.Context, file io.Reader, mappings map[string]string, userId string) (Result, error) {
+	p, err := s.plan(ctx, file, mappings, userId)
+	if err != nil {
+		return Result{}, err
+	}
+	if p.sets == 0 {
+		return Result{}, fmt.Errorf("no sets to import")
+	}
+
+	result := Result{Source: p.source, WorkoutIDs: []string{}, Sets: p.sets, Skipped: p.skipped}
+	err = s.withTx(ctx, func(repo ImportRepository) error {
+		now := time.Now().UTC().Format(time.RFC3339)
+		for _, workout := range p.workouts {
+			id, err := createWorkout(ctx, repo, workout, now, userId)
+			if err != nil {
+				return err
+			}
+			result.WorkoutIDs = append(result.WorkoutIDs, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to import workouts: %w", err)
+	}
+	s.notifyCompleted(ctx, result.WorkoutIDs, userId)
+	return result, nil
+}
+
+func (s *importService) ImportActivity(ctx context.Context, file io.Reader, exerciseTypeId string, userId string) (ActivityResult, error) {
+	activity, err := parseActivity(file)
+	if err != nil {
+		return ActivityResult{}, err
+	}
+
+	// Activities without a distance, e.g. on a trainer, are logged by their duration
+	set := measurement.Set{DurationSeconds: int(activity.ActiveSeconds), DistanceMeters: activity.DistanceMeters}
+	kind := measurement.DistanceDuration
+	if activity.DistanceMeters == 0 {
+		kind = measurement.Duration
+	}
+
+	exerciseTypes, err := s.repo.GetExerciseTypes(ctx, userId)
+	if err != nil {
+		return ActivityResult{}, err
+	}
+
+	exerciseType := &ExerciseType{Name: activity.Sport, Measurement: kind}
+	if exerciseTypeId != "" {
+		i := slices.IndexFunc(exerciseTypes, func(v ExerciseType) bool { return v.ID == exerciseTypeId })
+		if i == -1 {
+			return ActivityResult{}, fmt.Errorf("unknown exercise type: %s", exerciseTypeId)
+		}
+		exerciseType = &exerciseTypes[i]
+		err = measurement.Validate(exerciseType.Measurement, set)
+		if err != nil {
+			return ActivityResult{}, fmt.Errorf("can not log the activity as %s: %w", exerciseType.Name, err)
+		}
+	} else {
+		candidates := []ExerciseType{}
+		for _, v := range exerciseTypes {
+			if v.Measurement == kind {
+				candidates = append(candidates, v)
+			}
+		}
+		if _, best := match(activity.Sport, candidates); best != nil {
+			exerciseType = best
+		}
+	}
+
+	completedOn := activity.StartedOn.Add(time.Duration(activity.ElapsedSeconds) * time.Second)
+	result := ActivityResult{
+		Format:           activity.Format,
+		ExerciseTypeName: exerciseType.Name,
+		StartedOn:        activity.StartedOn.Format(time.RFC3339),
+		CompletedOn:      completedOn.Format(time.RFC3339),
+		DurationSeconds:  activity.ActiveSeconds,
+		DistanceMeters:   activity.DistanceMeters,
+		AverageHeartRate: activity.AverageHeartRate,
+	}
+	err = s.withTx(ctx, func(repo ImportRepository) error {
+		now := time.Now().UTC().Format(time.RFC3339)
+		// A sport without an exercise type gets one, so its sessions are logged together
+		if exerciseType.ID == "" {
+			id, err := newId()
+			if err != nil {
+				return err
+			}
+			_, err = repo.CreateExerciseType(ctx, repository.CreateExerciseTypeAndReturnIdParams{
+				ID:          id,
+				Name:        exerciseType.Name,
+				Measurement: exerciseType.Measurement,
+				CreatedOn:   now,
+				UpdatedOn:   now,
+				UserID:      userId,
+			})
+			if err != nil {
+				return err
+			}
+			exerciseType.ID = id
+			result.CreatedExerciseType = true
+		}
+
+		workoutId, err := createWorkout(ctx, repo, plannedWorkout{
+			name:             activity.Name,
+			startedOn:        activity.StartedOn,
+			completedOn:      completedOn,
+			activeSeconds:    activity.ActiveSeconds,
+			averageHeartRate: activity.AverageHeartRate,
+			exercises: []plannedExercise{{
+				exerciseType: *exerciseType,
+				rows: []Row{{
+					Type:            sets.TypeWorking,
+					DurationSeconds: activity.ActiveSeconds,
+					DistanceMeters:  activity.DistanceMeters,
+				}},
+			}},
+		}, now, userId)
+		if err != nil {
+			return err
+		}
+		result.WorkoutID = workoutId
+		return nil
+	})
+	if err != nil {
+		return ActivityResult{}, fmt.Errorf("failed to import activity: %w", err)
+	}
+	result.ExerciseTypeID = exerciseType.ID
+	s.notifyCompleted(ctx, []string{result.WorkoutID}, userId)
+	return result, nil
+}
+
+// plan parses the file, matches its exercise names onto exercise types and
+// groups the sets that can be imported into workouts
+func (s *importService) plan(ctx context.Context, file io.Reader, mappings map[string]string, userId string) (plan, error) {
+	userPreferences, err := s.preferences.Get(ctx, userId)
+	if err != nil {
+		return plan{}, fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	source, rows, skipped, err := parse(file, userPreferences.WeightUnit, userPreferences.Location())
+	if err != nil {
+		return plan{}, err
+	}
+
+	exerciseTypes, err := s.repo.GetExerciseTypes(ctx, userId)
+	if err != nil {
+		return plan{}, err
+	}
+
+	matches, matched, err := resolve(rows, exerciseTypes, mappings)
+	if err != nil {
+		return plan{}, err
+	}
+
+	result := plan{source: source, exercises: matches, skipped: skipped}
+	workoutIndex := map[string]int{}
+	for _, row := range rows {
+		exerciseType, ok := matched[row.ExerciseName]
+		if !ok {
+			result.skipped = append(result.skipped, SkippedRow{Line: row.Line, Reason: fmt.Sprintf("no exercise type for %s", row.ExerciseName)})
+			continue
+		}
+		err := measurement.Validate(exerciseType.Measurement, measurement.Set{
+			Weight:          row.Weight,
+			Reps:            int(row.Repetitions),
+			DurationSeconds: int(row.DurationSeconds),
+			DistanceMeters:  row.DistanceMeters,
+		})
+		if err != nil {
+			result.skipped = append(result.skipped, SkippedRow{Line: row.Line, Reason: err.Error()})
+			continue
+		}
+
+		i, ok := workoutIndex[row.WorkoutKey]
+		if !ok {
+			i = len(result.workouts)
+			workoutIndex[row.WorkoutKey] = i
+			result.workouts = append(result.workouts, plannedWorkout{
+				name:          row.WorkoutName,
+				note:          row.WorkoutNote,
+				startedOn:     row.StartedOn,
+				completedOn:   row.StartedOn.Add(time.Duration(row.ActiveSeconds) * time.Second),
+				activeSeconds: row.ActiveSeconds,
+			})
+		}
+		result.workouts[i].exercises = addRow(result.workouts[i].exercises, exerciseType, row)
+		result.sets++
+	}
+
+	slices.SortStableFunc(result.workouts, func(a, b plannedWorkout) int {
+		return a.startedOn.Compare(b.startedOn)
+	})
+	slices.SortStableFunc(result.skipped, func(a, b SkippedRow) int {
+		return cmp.Compare(a.Line, b.Line)
+	})
+	return result, nil
+}
+
+// resolve decides the exercise type of every exercise name in the rows. A
+// mapping of the name wins over matching it, mapping it to nothing skips it.
+func resolve(rows []Row, exerciseTypes []ExerciseType, mappings map[string]string) ([]ExerciseMatch, map[string]ExerciseType, error) {
+	byId := map[string]ExerciseType{}
+	for _, v := range exerciseTypes {
+		byId[v.ID] = v
+	}
+
+	matches := []ExerciseMatch{}
+	matched := map[string]ExerciseType{}
+	index := map[string]int{}
+	for _, row := range rows {
+		if i, ok := index[row.ExerciseName]; ok {
+			matches[i].Sets++
+			continue
+		}
+		index[row.ExerciseName] = len(matches)
+
+		result, best := match(row.ExerciseName, exerciseTypes)
+		if id, ok := mappings[row.ExerciseName]; ok {
+			result = ExerciseMatch{Name: row.ExerciseName, Match: MatchIgnored}
+			best = nil
+			if id != "" {
+				exerciseType, ok := byId[id]
+				if !ok {
+					return nil, nil, fmt.Errorf("unknown exercise type for %s: %s", row.ExerciseName, id)
+				}
+				best = &exerciseType
+				result.Match = MatchMapped
+				result.ExerciseTypeID = &exerciseType.ID
+				result.ExerciseTypeName = &exerciseType.Name
+				result.Score = 1
+			}
+		}
+
+		result.Sets = 1
+		matches = append(matches, result)
+		if best != nil {
+			matched[row.ExerciseName] = *best
+		}
+	}
+	return matches, matched, nil
+}
+
+// addRow adds the set to the exercise of its type, the exercises of a workout
+// are kept in the order they first appear
+func addRow(exercises []plannedExercise, exerciseType ExerciseType, row Row) []plannedExercise {
+	for i, v := range exercises {
+		if v.exerciseType.ID == exerciseType.ID {
+			exercises[i].rows = append(exercises[i].rows, row)
+			return exercises
+		}
+	}
+	return append(exercises, plannedExercise{exerciseType: exerciseType, rows: []Row{row}})
+}
+
+// createWorkout creates the workout as completed with an exercise item of a
+// single exercise for every exercise type it has sets of
+func createWorkout(ctx context.Context, repo ImportRepository, workout plannedWorkout, now string, userId string) (string, error) {
+	workoutId, err := newId()
+	if err != nil {
+		return "", err
+	}
+	arg := repository.CreateCompletedWorkoutAndReturnIdParams{
+		ID:            workoutId,
+		Name:          workout.name,
+		Note:          utils.NullableString(workout.note),
+		StartedOn:     workout.startedOn.Format(time.RFC3339),
+		CompletedOn:   workout.completedOn.Format(time.RFC3339),
+		ActiveSeconds: workout.activeSeconds,
+		CreatedOn:     now,
+		UpdatedOn:     now,
+		UserID:        userId,
+	}
+	if workout.averageHeartRate != nil {
+		arg.AverageHeartRate = *workout.averageHeartRate
+	}
+	_, err = repo.CreateWorkout(ctx, arg)
+	if err != nil {
+		return "", err
+	}
+
+	for _, exercise := range workout.exercises {
+		itemId, err := newId()
+		if err != nil {
+			return "", err
+		}
+		_, err = repo.CreateExerciseItem(ctx, repository.CreateExerciseItemAndReturnIdParams{
+			ID:        itemId,
+			Type:      exerciseitems.KindStraight,
+			WorkoutID: workoutId,
+			UserID:    userId,
+			CreatedOn: now,
+			UpdatedOn: now,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		exerciseId, err := newId()
+		if err != nil {
+			return "", err
+		}
+		_, err = repo.CreateExercise(ctx, repository.CreateExerciseAndReturnIdParams{
+			ID:             exerciseId,
+			Name:           exercise.exerciseType.Name,
+			WorkoutID:      workoutId,
+			ExerciseTypeID: exercise.exerciseType.ID,
+			ExerciseItemID: itemId,
+			UserID:         userId,
+			CreatedOn:      now,
+			UpdatedOn:      now,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		for _, row := range exercise.rows {
+			setId, err := newId()
+			if err != nil {
+				return "", err
+			}
+			_, err = repo.CreateSet(ctx, newSetParams(setId, row, exerciseId, now, userId))
+			if err != nil {
+				return "", fmt.Errorf("line %d: %w", row.Line, err)
+			}
+		}
+	}
+	return workoutId, nil
+}
+
+func newSetParams(id string, row Row, exerciseId string, now string, userId string) repository.CreateSetAndReturnIdParams {
+	arg := repository.CreateSetAndReturnIdParams{
+		ID:          id,
+		Repetitions: row.Repetitions,
+		Weight:      row.Weight,
+		Type:        row.Type,
+		Note:        utils.NullableString(row.Note),
+		ExerciseID:  exerciseId,
+		UserID:      userId,
+		CreatedOn:   now,
+		UpdatedOn:   now,
+	}
+	if row.DurationSeconds > 0 {
+		arg.DurationSeconds = row.DurationSeconds
+	}
+	if row.DistanceMeters > 0 {
+		arg.DistanceMeters = row.DistanceMeters
+	}
+	if row.RPE != nil {
+		arg.Rpe = *row.RPE
+	}
+	return arg
+}
+
+func newId() (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+	return id.String(), nil
+}
@@ -0,0 +1,360 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"weight-tracker/internal/measurement"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type repoMock struct {
+	mock.Mock
+}
+
+func (m *repoMock) GetExerciseTypes(ctx context.Context, userId string) ([]ExerciseType, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]ExerciseType), args.Error(1)
+}
+
+func (m *repoMock) CreateExerciseType(ctx context.Context, arg repository.CreateExerciseTypeAndReturnIdParams) (string, error) {
+	args := m.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (m *repoMock) CreateWorkout(ctx context.Context, arg repository.CreateCompletedWorkoutAndReturnIdParams) (string, error) {
+	args := m.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (m *repoMock) CreateExerciseItem(ctx context.Context, arg repository.CreateExerciseItemAndReturnIdParams) (string, error) {
+	args := m.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (m *repoMock) CreateExercise(ctx context.Context, arg repository.CreateExerciseAndReturnIdParams) (string, error) {
+	args := m.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (m *repoMock) CreateSet(ctx context.Context, arg repository.CreateSetAndReturnIdParams) (string, error) {
+	args := m.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+type completionListenerMock struct {
+	mock.Mock
+}
+
+func (l *completionListenerMock) OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error {
+	args := l.Called(ctx, workoutId, userId)
+	return args.Error(0)
+}
+
+type preferencesStub struct{}
+
+func (p preferencesStub) Get(ctx context.Context, userId string) (preferences.Preferences, error) {
+	return preferences.Defaults(), nil
+}
+
+// transactorStub runs fn on the repository it holds and counts the transactions
+type transactorStub struct {
+	repo ImportRepository
+	runs int
+}
+
+func (s *transactorStub) withTx(ctx context.Context, fn func(ImportRepository) error) error {
+	s.runs++
+	return fn(s.repo)
+}
+
+var serviceTypes = []ExerciseType{
+	{ID: "bench", Name: "Barbell Bench Press", Measurement: measurement.WeightReps},
+	{ID: "squat", Name: "Back Squat", Measurement: measurement.WeightReps},
+	{ID: "plank", Name: "Plank", Measurement: measurement.Duration},
+}
+
+const strongFile = "Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps,Distance,Seconds,Notes,Workout Notes,RPE\n" +
+	"2024-01-17 18:00:00,Legs,1h,Squat (Barbell),1,140,5,0,0,,,\n" +
+	"2024-01-17 18:00:00,Legs,1h,Plank,1,0,10,0,0,,,\n" +
+	"2024-01-15 18:30:00,Push,1h 5m,Bench Press (Barbell),1,100,5,0,0,,,\n" +
+	"2024-01-15 18:30:00,Push,1h 5m,Bench Press (Barbell),Rest Timer,0,0,0,90,,,\n" +
+	"2024-01-15 18:30:00,Push,1h 5m,Lateral Raise (Cable),1,10,12,0,0,,,\n" +
+	"2024-01-15 18:30:00,Push,1h 5m,Bench Press (Barbell),2,100,5,0,0,,,\n"
+
+func TestPreview(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseTypes", ctx, userId).Return(serviceTypes, nil).Once()
+
+	preview, err := NewService(&repoMock, nil, preferencesStub{}).Preview(ctx, strings.NewReader(strongFile), map[string]string{}, userId)
+
+	bench, benchName := "bench", "Barbell Bench Press"
+	squat, squatName := "squat", "Back Squat"
+	plank, plankName := "plank", "Plank"
+	assert.Nil(t, err)
+	assert.Equal(t, Preview{
+		Source:   SourceStrong,
+		Workouts: 2,
+		Sets:     3,
+		Exercises: []ExerciseMatch{
+			{Name: "Squat (Barbell)", Sets: 1, Match: MatchFuzzy, ExerciseTypeID: &squat, ExerciseTypeName: &squatName, Score: 0.62},
+			{Name: "Plank", Sets: 1, Match: MatchExact, ExerciseTypeID: &plank, ExerciseTypeName: &plankName, Score: 1},
+			{Name: "Bench Press (Barbell)", Sets: 2, Match: MatchExact, ExerciseTypeID: &bench, ExerciseTypeName: &benchName, Score: 1},
+			{Name: "Lateral Raise (Cable)", Sets: 1, Match: MatchNone, Score: 0.32},
+		},
+		Skipped: []SkippedRow{
+			{Line: 3, Reason: "duration sets need a duration"},
+			{Line: 5, Reason: "rest timer row"},
+			{Line: 6, Reason: "no exercise type for Lateral Raise (Cable)"},
+		},
+	}, preview)
+	repoMock.AssertExpectations(t)
+}
+
+func TestPreviewWithMappings(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseTypes", ctx, userId).Return(serviceTypes, nil).Once()
+
+	preview, err := NewService(&repoMock, nil, preferencesStub{}).Preview(ctx, strings.NewReader(strongFile), map[string]string{
+		"Squat (Barbell)":       "",
+		"Lateral Raise (Cable)": "bench",
+	}, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, preview.Workouts)
+	assert.Equal(t, 3, preview.Sets)
+	assert.Equal(t, MatchIgnored, preview.Exercises[0].Match)
+	assert.Nil(t, preview.Exercises[0].ExerciseTypeID)
+	assert.Equal(t, MatchMapped, preview.Exercises[3].Match)
+	assert.Equal(t, "bench", *preview.Exercises[3].ExerciseTypeID)
+	assert.Equal(t, []SkippedRow{
+		{Line: 2, Reason: "no exercise type for Squat (Barbell)"},
+		{Line: 3, Reason: "duration sets need a duration"},
+		{Line: 5, Reason: "rest timer row"},
+	}, preview.Skipped)
+}
+
+func TestPreviewUnknownMapping(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseTypes", ctx, userId).Return(serviceTypes, nil).Once()
+
+	_, err := NewService(&repoMock, nil, preferencesStub{}).Preview(ctx, strings.NewReader(strongFile), map[string]string{
+		"Plank": "someone-elses-type",
+	}, userId)
+
+	assert.ErrorContains(t, err, "unknown exercise type for Plank")
+}
+
+func TestImportCreatesWorkoutsInOneTransaction(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	txMock := repoMock{}
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseTypes", ctx, userId).Return(serviceTypes, nil).Once()
+
+	// Workouts are created oldest first
+	txMock.On("CreateWorkout", ctx, mock.MatchedBy(func(arg repository.CreateCompletedWorkoutAndReturnIdParams) bool {
+		return arg.Name == "Push" && arg.StartedOn == "2024-01-15T18:30:00Z" && arg.CompletedOn == "2024-01-15T19:35:00Z" &&
+			arg.ActiveSeconds == 3900 && arg.Note == nil && arg.UserID == userId
+	})).Return("push", nil).Once()
+	txMock.On("CreateWorkout", ctx, mock.MatchedBy(func(arg repository.CreateCompletedWorkoutAndReturnIdParams) bool {
+		return arg.Name == "Legs" && arg.StartedOn == "2024-01-17T18:00:00Z"
+	})).Return("legs", nil).Once()
+	txMock.On("CreateExerciseItem", ctx, mock.MatchedBy(func(arg repository.CreateExerciseItemAndReturnIdParams) bool {
+		return arg.Type == "straight" && arg.WorkoutID != "" && arg.UserID == userId
+	})).Return("item", nil).Twice()
+	txMock.On("CreateExercise", ctx, mock.MatchedBy(func(arg repository.CreateExerciseAndReturnIdParams) bool {
+		return arg.ExerciseTypeID == "bench" && arg.Name == "Barbell Bench Press"
+	})).Return("exercise", nil).Once()
+	txMock.On("CreateExercise", ctx, mock.MatchedBy(func(arg repository.CreateExerciseAndReturnIdParams) bool {
+		return arg.ExerciseTypeID == "squat" && arg.Name == "Back Squat"
+	})).Return("exercise", nil).Once()
+	txMock.On("CreateSet", ctx, mock.MatchedBy(func(arg repository.CreateSetAndReturnIdParams) bool {
+		return arg.Weight == 100 && arg.Repetitions == 5 && arg.Type == "working" && arg.DurationSeconds == nil && arg.Rpe == nil
+	})).Return("set", nil).Twice()
+	txMock.On("CreateSet", ctx, mock.MatchedBy(func(arg repository.CreateSetAndReturnIdParams) bool {
+		return arg.Weight == 140 && arg.Repetitions == 5
+	})).Return("set", nil).Once()
+
+	// Imported workouts get their records and goal progress like completed ones
+	listener := completionListenerMock{}
+	listener.On("OnWorkoutCompleted", ctx, mock.Anything, userId).Return(errors.New("failed")).Twice()
+
+	transactor := transactorStub{repo: &txMock}
+	result, err := NewService(&repoMock, transactor.withTx, preferencesStub{}, &listener).Import(ctx, strings.NewReader(strongFile), map[string]string{}, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
+	assert.Len(t, result.WorkoutIDs, 2)
+	assert.Equal(t, SourceStrong, result.Source)
+	assert.Equal(t, 3, result.Sets)
+	assert.Len(t, result.Skipped, 3)
+	txMock.AssertExpectations(t)
+	listener.AssertExpectations(t)
+	assert.Equal(t, result.WorkoutIDs[0], listener.Calls[0].Arguments.String(1))
+	assert.Equal(t, result.WorkoutIDs[1], listener.Calls[1].Arguments.String(1))
+}
+
+func TestImportReturnsErrorOfTransaction(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	txMock := repoMock{}
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseTypes", ctx, userId).Return(serviceTypes, nil).Once()
+
+	txMock.On("CreateWorkout", ctx, mock.Anything).Return("", errors.New("disk full")).Once()
+
+	listener := completionListenerMock{}
+
+	transactor := transactorStub{repo: &txMock}
+	_, err := NewService(&repoMock, transactor.withTx, preferencesStub{}, &listener).Import(ctx, strings.NewReader(strongFile), map[string]string{}, userId)
+
+	assert.ErrorContains(t, err, "disk full")
+	txMock.AssertExpectations(t)
+	listener.AssertNotCalled(t, "OnWorkoutCompleted", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportWithoutSets(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseTypes", ctx, userId).Return([]ExerciseType{}, nil).Once()
+
+	transactor := transactorStub{}
+	_, err := NewService(&repoMock, transactor.withTx, preferencesStub{}).Import(ctx, strings.NewReader(strongFile), map[string]string{}, userId)
+
+	assert.ErrorContains(t, err, "no sets to import")
+	assert.Equal(t, 0, transactor.runs)
+}
+
+func TestImportActivityCreatesExerciseTypeOfSport(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	txMock := repoMock{}
+	repoMock := repoMock{}
+	// A type of the same name that is measured differently is not used
+	repoMock.On("GetExerciseTypes", ctx, userId).Return([]ExerciseType{{ID: "running", Name: "Running", Measurement: measurement.Duration}}, nil).Once()
+
+	txMock.On("CreateExerciseType", ctx, mock.MatchedBy(func(arg repository.CreateExerciseTypeAndReturnIdParams) bool {
+		return arg.Name == "Running" && arg.Measurement == measurement.DistanceDuration && arg.UserID == userId
+	})).Return("id", nil).Once()
+	txMock.On("CreateWorkout", ctx, mock.MatchedBy(func(arg repository.CreateCompletedWorkoutAndReturnIdParams) bool {
+		return arg.Name == "Morning Run" && arg.StartedOn == "2024-01-15T07:00:00Z" && arg.CompletedOn == "2024-01-15T07:15:00Z" &&
+			arg.ActiveSeconds == 600 && arg.AverageHeartRate == int64(155)
+	})).Return("workout", nil).Once()
+	txMock.On("CreateExerciseItem", ctx, mock.Anything).Return("item", nil).Once()
+	txMock.On("CreateExercise", ctx, mock.MatchedBy(func(arg repository.CreateExerciseAndReturnIdParams) bool {
+		return arg.Name == "Running" && arg.ExerciseTypeID != "" && arg.ExerciseTypeID != "running"
+	})).Return("exercise", nil).Once()
+	txMock.On("CreateSet", ctx, mock.MatchedBy(func(arg repository.CreateSetAndReturnIdParams) bool {
+		return arg.DurationSeconds == int64(600) && arg.DistanceMeters == 2002.0 && arg.Repetitions == 0 && arg.Weight == 0
+	})).Return("set", nil).Once()
+
+	listener := completionListenerMock{}
+	listener.On("OnWorkoutCompleted", ctx, mock.Anything, userId).Return(nil).Once()
+
+	transactor := transactorStub{repo: &txMock}
+	result, err := NewService(&repoMock, transactor.withTx, preferencesStub{}, &listener).ImportActivity(ctx, strings.NewReader(gpxActivity), "", userId)
+
+	heartRate := int64(155)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
+	assert.NotEmpty(t, result.WorkoutID)
+	assert.NotEmpty(t, result.ExerciseTypeID)
+	assert.Equal(t, ActivityResult{
+		Format:              FormatGPX,
+		WorkoutID:           result.WorkoutID,
+		ExerciseTypeID:      result.ExerciseTypeID,
+		ExerciseTypeName:    "Running",
+		CreatedExerciseType: true,
+		StartedOn:           "2024-01-15T07:00:00Z",
+		CompletedOn:         "2024-01-15T07:15:00Z",
+		DurationSeconds:     600,
+		DistanceMeters:      2002,
+		AverageHeartRate:    &heartRate,
+	}, result)
+	txMock.AssertExpectations(t)
+	listener.AssertCalled(t, "OnWorkoutCompleted", ctx, result.WorkoutID, userId)
+}
+
+func TestImportActivityMatchesExerciseTypeOfSport(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	txMock := repoMock{}
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseTypes", ctx, userId).Return([]ExerciseType{
+		{ID: "run", Name: "Run", Measurement: measurement.DistanceDuration},
+		{ID: "bike", Name: "Cycling", Measurement: measurement.DistanceDuration},
+	}, nil).Once()
+
+	txMock.On("CreateWorkout", ctx, mock.Anything).Return("workout", nil).Once()
+	txMock.On("CreateExerciseItem", ctx, mock.Anything).Return("item", nil).Once()
+	txMock.On("CreateExercise", ctx, mock.MatchedBy(func(arg repository.CreateExerciseAndReturnIdParams) bool {
+		return arg.ExerciseTypeID == "bike" && arg.Name == "Cycling"
+	})).Return("exercise", nil).Once()
+	txMock.On("CreateSet", ctx, mock.Anything).Return("set", nil).Once()
+
+	transactor := transactorStub{repo: &txMock}
+	result, err := NewService(&repoMock, transactor.withTx, preferencesStub{}).ImportActivity(ctx, strings.NewReader(tcxActivity), "", userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "bike", result.ExerciseTypeID)
+	assert.False(t, result.CreatedExerciseType)
+	txMock.AssertExpectations(t)
+	txMock.AssertNotCalled(t, "CreateExerciseType", mock.Anything, mock.Anything)
+}
+
+func TestImportActivityAsExerciseType(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	exerciseTypes := []ExerciseType{
+		{ID: "bench", Name: "Bench Press", Measurement: measurement.WeightReps},
+		{ID: "commute", Name: "Commute", Measurement: measurement.DistanceDuration},
+	}
+
+	txMock := repoMock{}
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseTypes", ctx, userId).Return(exerciseTypes, nil).Times(3)
+
+	txMock.On("CreateWorkout", ctx, mock.Anything).Return("workout", nil).Once()
+	txMock.On("CreateExerciseItem", ctx, mock.Anything).Return("item", nil).Once()
+	txMock.On("CreateExercise", ctx, mock.MatchedBy(func(arg repository.CreateExerciseAndReturnIdParams) bool {
+		return arg.ExerciseTypeID == "commute" && arg.Name == "Commute"
+	})).Return("exercise", nil).Once()
+	txMock.On("CreateSet", ctx, mock.Anything).Return("set", nil).Once()
+
+	transactor := transactorStub{repo: &txMock}
+	service := NewService(&repoMock, transactor.withTx, preferencesStub{})
+	result, err := service.ImportActivity(ctx, strings.NewReader(tcxActivity), "commute", userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "commute", result.ExerciseTypeID)
+	txMock.AssertExpectations(t)
+
+	_, err = service.ImportActivity(ctx, strings.NewReader(tcxActivity), "bench", userId)
+	assert.ErrorContains(t, err, "can not log the activity as Bench Press")
+
+	_, err = service.ImportActivity(ctx, strings.NewReader(tcxActivity), "someone-elses-type", userId)
+	assert.ErrorContains(t, err, "unknown exercise type: someone-elses-type")
+	assert.Equal(t, 1, transactor.runs)
+}
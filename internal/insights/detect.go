@@ -0,0 +1,130 @@
+package insights
+
+import (
+	"math"
+	"time"
+	"weight-tracker/internal/strength"
+)
+
+// Number of exposures without improvement before a plateau is flagged when none is given
+const DefaultExposures = 4
+
+// Drop of the recent estimated one rep max below the best, as a fraction, from
+// which a plateau is flagged as a deload
+const deloadDrop = 0.10
+
+// Acute workload is the last week, chronic workload the weekly average of the last four
+const (
+	acuteDays   = 7
+	chronicDays = 28
+)
+
+// Acute to chronic workload ratio above which the acute week is a spike
+const workloadSpikeRatio = 1.5
+
+// exposures groups the sets into one exposure per completed workout with the
+// best estimated one rep max and the tonnage. Sets are expected to be ordered
+// by completion time.
+func exposures(sets []strength.Set) []Exposure {
+	result := []Exposure{}
+	for _, set := range sets {
+		if set.Reps <= 0 {
+			continue
+		}
+		load := set.Weight + set.Bodyweight
+		e1rm, _ := strength.EstimateOneRepMax(strength.FormulaEpley, load, set.Reps)
+
+		if len(result) == 0 || !result[len(result)-1].CompletedOn.Equal(set.CompletedOn) {
+			result = append(result, Exposure{CompletedOn: set.CompletedOn})
+		}
+		exposure := &result[len(result)-1]
+		exposure.E1RM = math.Max(exposure.E1RM, math.Round(e1rm*100)/100)
+		exposure.Tonnage += load * float64(set.Reps)
+	}
+	return result
+}
+
+// detectStall flags an exercise type when neither its estimated one rep max
+// nor its tonnage beat their best for at least the given number of exposures.
+// Only strict improvements count, matching the best again is still a stall.
+func detectStall(series []Exposure, window int) (Insight, bool) {
+	if len(series) <= window {
+		return Insight{}, false
+	}
+
+	bestE1RM, bestTonnage := 0, 0
+	for i, v := range series {
+		if v.E1RM > series[bestE1RM].E1RM {
+			bestE1RM = i
+		}
+		if v.Tonnage > series[bestTonnage].Tonnage {
+			bestTonnage = i
+		}
+	}
+
+	since := len(series) - 1 - max(bestE1RM, bestTonnage)
+	if since < window {
+		return Insight{}, false
+	}
+
+	recent := 0.0
+	for _, v := range series[len(series)-window:] {
+		recent += v.E1RM
+	}
+	recent = math.Round(recent/float64(window)*100) / 100
+
+	insight := Insight{
+		Kind:       KindPlateau,
+		Exposures:  since,
+		BestE1RM:   series[bestE1RM].E1RM,
+		RecentE1RM: recent,
+	}
+	if insight.BestE1RM > 0 && (insight.BestE1RM-recent)/insight.BestE1RM >= deloadDrop {
+		insight.Kind = KindDeload
+	}
+	return insight, true
+}
+
+// workloadEnd is the end of the last day counted in the workload, the weeks
+// being whole days up to and including today
+func workloadEnd(now time.Time) time.Time {
+	return now.UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+}
+
+// detectWorkloadSpike compares the tonnage of the last week with the weekly
+// average of the last four, both ending at the given end. Without workouts
+// before the last week there is no chronic workload to compare with.
+func detectWorkloadSpike(workloads []Workload, end time.Time) (Insight, bool) {
+	acuteStart := end.AddDate(0, 0, -acuteDays)
+	chronicStart := end.AddDate(0, 0, -chronicDays)
+
+	acute, chronic := 0.0, 0.0
+	history := false
+	for _, v := range workloads {
+		if v.CompletedOn.Before(chronicStart) || !v.CompletedOn.Before(end) {
+			continue
+		}
+		chronic += v.Tonnage
+		if v.CompletedOn.Before(acuteStart) {
+			history = true
+			continue
+		}
+		acute += v.Tonnage
+	}
+
+	chronic = chronic / (chronicDays / acuteDays)
+	if !history || chronic == 0 {
+		return Insight{}, false
+	}
+
+	ratio := math.Round(acute/chronic*100) / 100
+	if ratio <= workloadSpikeRatio {
+		return Insight{}, false
+	}
+	return Insight{
+		Kind:            KindWorkloadSpike,
+		AcuteWorkload:   math.Round(acute*100) / 100,
+		ChronicWorkload: math.Round(chronic*100) / 100,
+		WorkloadRatio:   ratio,
+	}, true
+}
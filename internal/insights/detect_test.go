@@ -0,0 +1,114 @@
+package insights
+
+import (
+	"testing"
+	"time"
+	"weight-tracker/internal/strength"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func series(e1rms ...float64) []Exposure {
+	start := time.Date(2025, 1, 1, 18, 0, 0, 0, time.UTC)
+	result := []Exposure{}
+	for i, v := range e1rms {
+		result = append(result, Exposure{CompletedOn: start.AddDate(0, 0, 3*i), E1RM: v, Tonnage: v * 10})
+	}
+	return result
+}
+
+func TestExposures(t *testing.T) {
+	first := time.Date(2025, 1, 1, 18, 0, 0, 0, time.UTC)
+	second := time.Date(2025, 1, 4, 18, 0, 0, 0, time.UTC)
+	sets := []strength.Set{
+		{CompletedOn: first, Weight: 100, Reps: 5},
+		{CompletedOn: first, Weight: 90, Reps: 8},
+		{CompletedOn: second, Weight: 20, Reps: 10, Bodyweight: 80},
+		{CompletedOn: second, Weight: 0, Reps: 0},
+	}
+
+	result := exposures(sets)
+
+	assert.Equal(t, []Exposure{
+		{CompletedOn: first, E1RM: 116.67, Tonnage: 1220},
+		{CompletedOn: second, E1RM: 133.33, Tonnage: 1000},
+	}, result)
+}
+
+func TestDetectStallPlateau(t *testing.T) {
+	insight, ok := detectStall(series(100, 105, 105, 103, 104, 102), 4)
+
+	assert.True(t, ok)
+	assert.Equal(t, Insight{Kind: KindPlateau, Exposures: 4, BestE1RM: 105, RecentE1RM: 103.5}, insight)
+}
+
+func TestDetectStallDeload(t *testing.T) {
+	insight, ok := detectStall(series(100, 110, 98, 96, 95, 94), 4)
+
+	assert.True(t, ok)
+	assert.Equal(t, KindDeload, insight.Kind)
+	assert.Equal(t, 95.75, insight.RecentE1RM)
+}
+
+func TestDetectStallImproving(t *testing.T) {
+	_, ok := detectStall(series(100, 105, 103, 104, 102, 106), 4)
+	assert.False(t, ok)
+
+	// Too few exposures to tell
+	_, ok = detectStall(series(100, 99, 98, 97), 4)
+	assert.False(t, ok)
+}
+
+func TestDetectStallVolumeImproving(t *testing.T) {
+	exposures := series(100, 105, 104, 103, 102, 101)
+	exposures[5].Tonnage = 2000
+
+	_, ok := detectStall(exposures, 4)
+
+	assert.False(t, ok)
+}
+
+func TestDetectWorkloadSpike(t *testing.T) {
+	end := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	workloads := []Workload{
+		{CompletedOn: end.AddDate(0, 0, -30), Tonnage: 5000},
+		{CompletedOn: end.AddDate(0, 0, -20), Tonnage: 1000},
+		{CompletedOn: end.AddDate(0, 0, -13), Tonnage: 1000},
+		{CompletedOn: end.AddDate(0, 0, -2), Tonnage: 3000},
+		{CompletedOn: end.AddDate(0, 0, -1), Tonnage: 3000},
+	}
+
+	insight, ok := detectWorkloadSpike(workloads, end)
+
+	assert.True(t, ok)
+	assert.Equal(t, Insight{Kind: KindWorkloadSpike, AcuteWorkload: 6000, ChronicWorkload: 2000, WorkloadRatio: 3}, insight)
+}
+
+func TestDetectWorkloadSpikeWithoutHistory(t *testing.T) {
+	end := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	workloads := []Workload{
+		{CompletedOn: end.AddDate(0, 0, -2), Tonnage: 3000},
+	}
+
+	_, ok := detectWorkloadSpike(workloads, end)
+
+	assert.False(t, ok)
+}
+
+func TestDetectWorkloadSpikeSteady(t *testing.T) {
+	end := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	workloads := []Workload{}
+	for week := range 4 {
+		workloads = append(workloads, Workload{CompletedOn: end.AddDate(0, 0, -7*week-3), Tonnage: 2000})
+	}
+
+	_, ok := detectWorkloadSpike(workloads, end)
+
+	assert.False(t, ok)
+}
+
+func TestWorkloadEnd(t *testing.T) {
+	now := time.Date(2025, 1, 31, 23, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), workloadEnd(now))
+}
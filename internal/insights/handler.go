@@ -0,0 +1,82 @@
+package insights
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+	"weight-tracker/internal/database"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/units"
+	"weight-tracker/internal/utils"
+)
+
+type handler struct {
+	service Service
+	units   unitPreferences
+}
+
+// Weights are stored in kilograms, responses use the unit of the user
+type unitPreferences interface {
+	GetWeightUnit(ctx context.Context, userId string) (string, error)
+}
+
+func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
+	handler := handler{
+		service: NewService(NewInsightsRepository(s.GetRepository()), time.Now),
+		units:   preferences.NewServiceFromDatabase(s),
+	}
+
+	mux.Handle("GET /insights", authenticationWrapper(http.HandlerFunc(handler.getInsightsHandler)))
+}
+
+// Upper limit for the number of exposures without improvement
+const maxExposures = 20
+
+func convertInsight(insight Insight, unit string) Insight {
+	insight.BestE1RM = units.FromKilograms(insight.BestE1RM, unit)
+	insight.RecentE1RM = units.FromKilograms(insight.RecentE1RM, unit)
+	insight.AcuteWorkload = units.FromKilograms(insight.AcuteWorkload, unit)
+	insight.ChronicWorkload = units.FromKilograms(insight.ChronicWorkload, unit)
+	return insight
+}
+
+func (h *handler) getInsightsHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	window := DefaultExposures
+	if v := r.URL.Query().Get("exposures"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 2 || parsed > maxExposures {
+			http.Error(w, "Invalid number of exposures", http.StatusBadRequest)
+			return
+		}
+		window = parsed
+	}
+
+	insights, err := h.service.GetInsights(r.Context(), window, userId)
+	if err != nil {
+		slog.Warn("Failed to get insights", "error", err)
+		http.Error(w, "Failed to get insights", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get insights", http.StatusBadRequest)
+		return
+	}
+	for i, v := range insights {
+		insights[i] = convertInsight(v, unit)
+	}
+
+	jsonResp, err := utils.CreateResponse(insights)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
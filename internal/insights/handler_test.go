@@ -0,0 +1,84 @@
+package insights
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"weight-tracker/internal/units"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func populateContextWithSub(req *http.Request, userId string) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, "sub", userId)
+	return req.WithContext(ctx)
+}
+
+type serviceMock struct {
+	mock.Mock
+}
+
+func (s *serviceMock) GetInsights(ctx context.Context, window int, userId string) ([]Insight, error) {
+	args := s.Called(ctx, window, userId)
+	return args.Get(0).([]Insight), args.Error(1)
+}
+
+type unitsStub struct {
+	unit string
+}
+
+func (u unitsStub) GetWeightUnit(ctx context.Context, userId string) (string, error) {
+	return u.unit, nil
+}
+
+func TestGetInsightsHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/insights?exposures=5", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetInsights", req.Context(), 5, userId).Return([]Insight{
+		{Kind: KindWorkloadSpike, AcuteWorkload: 6000, ChronicWorkload: 2000, WorkloadRatio: 3},
+		{Kind: KindDeload, ExerciseTypeID: "bench", ExerciseTypeName: "Bench", Exposures: 5, BestE1RM: 100, RecentE1RM: 85},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(h.getInsightsHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":[{"kind":"workload_spike","acute_workload":13227.74,"chronic_workload":4409.25,"workload_ratio":3},` +
+		`{"kind":"deload","exercise_type_id":"bench","exercise_type_name":"Bench","exposures":5,"best_e1rm":220.46,"recent_e1rm":187.39}]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetInsightsHandlerInvalidExposures(t *testing.T) {
+	req, err := http.NewRequest("GET", "/insights?exposures=21", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	serviceMock := serviceMock{}
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.getInsightsHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+	serviceMock.AssertNotCalled(t, "GetInsights", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,47 @@
+package insights
+
+import "time"
+
+const (
+	// KindPlateau is an exercise type where neither the estimated one rep max
+	// nor the volume improved across the last exposures
+	KindPlateau = "plateau"
+	// KindDeload is a plateau where the estimated one rep max also dropped well
+	// below the best, a sign that fatigue is building up
+	KindDeload = "deload"
+	// KindWorkloadSpike is a week with a lot more volume than the weeks before it
+	KindWorkloadSpike = "workload_spike"
+)
+
+// Insight flags something in the training history worth acting on. Exercise
+// type fields are set for plateaus and deloads, workload fields for spikes.
+// Weights are in kilograms.
+type Insight struct {
+	Kind             string  `json:"kind"`
+	ExerciseTypeID   string  `json:"exercise_type_id,omitempty"`
+	ExerciseTypeName string  `json:"exercise_type_name,omitempty"`
+	Exposures        int     `json:"exposures,omitempty"`
+	BestE1RM         float64 `json:"best_e1rm,omitempty"`
+	RecentE1RM       float64 `json:"recent_e1rm,omitempty"`
+	AcuteWorkload    float64 `json:"acute_workload,omitempty"`
+	ChronicWorkload  float64 `json:"chronic_workload,omitempty"`
+	WorkloadRatio    float64 `json:"workload_ratio,omitempty"`
+}
+
+type ExerciseType struct {
+	ID   string
+	Name string
+}
+
+// Exposure is the work done on an exercise type in one completed workout
+type Exposure struct {
+	CompletedOn time.Time
+	E1RM        float64
+	Tonnage     float64
+}
+
+// Workload is the tonnage of a completed workout
+type Workload struct {
+	CompletedOn time.Time
+	Tonnage     float64
+}
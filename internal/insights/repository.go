@@ -0,0 +1,76 @@
+package insights
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/strength"
+)
+
+type InsightsRepository interface {
+	GetExerciseTypes(ctx context.Context, userId string) ([]ExerciseType, error)
+	GetCompletedSets(ctx context.Context, arg repository.GetCompletedSetsByExerciseTypeIdParams) ([]strength.Set, error)
+	GetWorkloads(ctx context.Context, arg repository.GetWorkoutTonnageBetweenDatesParams) ([]Workload, error)
+}
+
+func NewInsightsRepository(repo repository.Querier) InsightsRepository {
+	return insightsRepository{repo: repo}
+}
+
+type insightsRepository struct {
+	repo repository.Querier
+}
+
+func (i insightsRepository) GetExerciseTypes(ctx context.Context, userId string) ([]ExerciseType, error) {
+	exerciseTypes, err := i.repo.GetAllExerciseTypes(ctx, userId)
+	if err != nil {
+		return []ExerciseType{}, fmt.Errorf("failed to get exercise types: %w", err)
+	}
+
+	result := []ExerciseType{}
+	for _, v := range exerciseTypes {
+		result = append(result, ExerciseType{ID: v.ID, Name: v.Name})
+	}
+	return result, nil
+}
+
+func (i insightsRepository) GetCompletedSets(ctx context.Context, arg repository.GetCompletedSetsByExerciseTypeIdParams) ([]strength.Set, error) {
+	rows, err := i.repo.GetCompletedSetsByExerciseTypeId(ctx, arg)
+	if err != nil {
+		return []strength.Set{}, fmt.Errorf("failed to get completed sets: %w", err)
+	}
+
+	result := []strength.Set{}
+	for _, v := range rows {
+		completedOn, err := time.Parse(time.RFC3339, v.CompletedOn.(string))
+		if err != nil {
+			return []strength.Set{}, fmt.Errorf("failed to parse completed on: %w", err)
+		}
+
+		result = append(result, strength.Set{
+			CompletedOn: completedOn,
+			Weight:      v.Weight,
+			Reps:        int(v.Repetitions),
+			Bodyweight:  v.Bodyweight,
+		})
+	}
+	return result, nil
+}
+
+func (i insightsRepository) GetWorkloads(ctx context.Context, arg repository.GetWorkoutTonnageBetweenDatesParams) ([]Workload, error) {
+	rows, err := i.repo.GetWorkoutTonnageBetweenDates(ctx, arg)
+	if err != nil {
+		return []Workload{}, fmt.Errorf("failed to get workout tonnage: %w", err)
+	}
+
+	result := []Workload{}
+	for _, v := range rows {
+		completedOn, err := time.Parse(time.RFC3339, v.CompletedOn)
+		if err != nil {
+			return []Workload{}, fmt.Errorf("failed to parse completed on: %w", err)
+		}
+		result = append(result, Workload{CompletedOn: completedOn, Tonnage: v.Tonnage})
+	}
+	return result, nil
+}
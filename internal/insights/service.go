@@ -0,0 +1,70 @@
+package insights
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"weight-tracker/internal/repository"
+)
+
+type Service interface {
+	GetInsights(ctx context.Context, window int, userId string) ([]Insight, error)
+}
+
+type insightsService struct {
+	repo InsightsRepository
+	// Clock the workload weeks are computed from
+	now func() time.Time
+}
+
+func NewService(repo InsightsRepository, now func() time.Time) Service {
+	return &insightsService{repo: repo, now: now}
+}
+
+// GetInsights looks for workload spikes over the last weeks and for exercise
+// types that did not improve for the given number of exposures. A workload
+// spike comes first, exercise types follow in the order they are listed in.
+func (s *insightsService) GetInsights(ctx context.Context, window int, userId string) ([]Insight, error) {
+	if window <= 0 {
+		return []Insight{}, fmt.Errorf("exposures must be positive")
+	}
+
+	result := []Insight{}
+
+	end := workloadEnd(s.now())
+	workloads, err := s.repo.GetWorkloads(ctx, repository.GetWorkoutTonnageBetweenDatesParams{
+		UserID:    userId,
+		StartDate: end.AddDate(0, 0, -chronicDays).Format(time.RFC3339),
+		EndDate:   end.Format(time.RFC3339),
+	})
+	if err != nil {
+		return []Insight{}, err
+	}
+	if insight, ok := detectWorkloadSpike(workloads, end); ok {
+		result = append(result, insight)
+	}
+
+	exerciseTypes, err := s.repo.GetExerciseTypes(ctx, userId)
+	if err != nil {
+		return []Insight{}, err
+	}
+
+	for _, exerciseType := range exerciseTypes {
+		sets, err := s.repo.GetCompletedSets(ctx, repository.GetCompletedSetsByExerciseTypeIdParams{
+			ID:     exerciseType.ID,
+			UserID: userId,
+		})
+		if err != nil {
+			return []Insight{}, err
+		}
+
+		insight, ok := detectStall(exposures(sets), window)
+		if !ok {
+			continue
+		}
+		insight.ExerciseTypeID = exerciseType.ID
+		insight.ExerciseTypeName = exerciseType.Name
+		result = append(result, insight)
+	}
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package insights
+
+import (
+	"context"
+	"testing"
+	"time"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/strength"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type repoMock struct {
+	mock.Mock
+}
+
+func (m *repoMock) GetExerciseTypes(ctx context.Context, userId string) ([]ExerciseType, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]ExerciseType), args.Error(1)
+}
+
+func (m *repoMock) GetCompletedSets(ctx context.Context, arg repository.GetCompletedSetsByExerciseTypeIdParams) ([]strength.Set, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]strength.Set), args.Error(1)
+}
+
+func (m *repoMock) GetWorkloads(ctx context.Context, arg repository.GetWorkoutTonnageBetweenDatesParams) ([]Workload, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]Workload), args.Error(1)
+}
+
+func TestGetInsights(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+	now := func() time.Time { return time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC) }
+
+	sets := []strength.Set{}
+	for i, weight := range []float64{100, 105, 100, 100, 100} {
+		sets = append(sets, strength.Set{CompletedOn: time.Date(2025, 1, 1+3*i, 18, 0, 0, 0, time.UTC), Weight: weight, Reps: 5})
+	}
+
+	repoMock := repoMock{}
+	repoMock.On("GetWorkloads", ctx, repository.GetWorkoutTonnageBetweenDatesParams{
+		UserID:    userId,
+		StartDate: "2025-01-04T00:00:00Z",
+		EndDate:   "2025-02-01T00:00:00Z",
+	}).Return([]Workload{}, nil).Once()
+	repoMock.On("GetExerciseTypes", ctx, userId).Return([]ExerciseType{
+		{ID: "bench", Name: "Bench"},
+		{ID: "squat", Name: "Squat"},
+	}, nil).Once()
+	repoMock.On("GetCompletedSets", ctx, repository.GetCompletedSetsByExerciseTypeIdParams{ID: "bench", UserID: userId}).Return(sets, nil).Once()
+	repoMock.On("GetCompletedSets", ctx, repository.GetCompletedSetsByExerciseTypeIdParams{ID: "squat", UserID: userId}).Return([]strength.Set{}, nil).Once()
+
+	service := NewService(&repoMock, now)
+	result, err := service.GetInsights(ctx, 3, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Insight{{
+		Kind:             KindPlateau,
+		ExerciseTypeID:   "bench",
+		ExerciseTypeName: "Bench",
+		Exposures:        3,
+		BestE1RM:         122.5,
+		RecentE1RM:       116.67,
+	}}, result)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetInsightsInvalidWindow(t *testing.T) {
+	repoMock := repoMock{}
+	service := NewService(&repoMock, time.Now)
+	_, err := service.GetInsights(context.Background(), 0, "userId")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "GetWorkloads", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,124 @@
+package measurement
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Kinds decide which fields of a set are logged for an exercise type
+const (
+	WeightReps       = "weight_reps"
+	BodyweightReps   = "bodyweight_reps"
+	Duration         = "duration"
+	DistanceDuration = "distance_duration"
+	WeightDistance   = "weight_distance"
+)
+
+var Names = []string{WeightReps, BodyweightReps, Duration, DistanceDuration, WeightDistance}
+
+// Set holds every field a set can be measured in, fields a kind does not use are zero
+type Set struct {
+	Weight          float64
+	Reps            int
+	DurationSeconds int
+	DistanceMeters  float64
+}
+
+// Normalize returns the kind to store, exercise types without one are weight and reps
+func Normalize(kind string) (string, error) {
+	if kind == "" {
+		return WeightReps, nil
+	}
+	if !slices.Contains(Names, kind) {
+		return "", fmt.Errorf("unknown measurement: %s", kind)
+	}
+	return kind, nil
+}
+
+// Validate checks that the set has the fields its kind needs and none it does not use
+func Validate(kind string, set Set) error {
+	if set.Weight < 0 || set.Reps < 0 || set.DurationSeconds < 0 || set.DistanceMeters < 0 {
+		return fmt.Errorf("weight, repetitions, duration and distance can not be negative")
+	}
+
+	switch kind {
+	case WeightReps:
+		if set.DurationSeconds != 0 || set.DistanceMeters != 0 {
+			return fmt.Errorf("%s sets do not take a duration or distance", kind)
+		}
+	case BodyweightReps:
+		// Weight is the added load, e.g. a weighted pull up
+		if set.Reps == 0 {
+			return fmt.Errorf("%s sets need repetitions", kind)
+		}
+		if set.DurationSeconds != 0 || set.DistanceMeters != 0 {
+			return fmt.Errorf("%s sets do not take a duration or distance", kind)
+		}
+	case Duration:
+		if set.DurationSeconds == 0 {
+			return fmt.Errorf("%s sets need a duration", kind)
+		}
+		if set.Reps != 0 || set.DistanceMeters != 0 {
+			return fmt.Errorf("%s sets do not take repetitions or a distance", kind)
+		}
+	case DistanceDuration:
+		if set.DistanceMeters == 0 || set.DurationSeconds == 0 {
+			return fmt.Errorf("%s sets need a distance and a duration", kind)
+		}
+		if set.Weight != 0 || set.Reps != 0 {
+			return fmt.Errorf("%s sets do not take weight or repetitions", kind)
+		}
+	case WeightDistance:
+		if set.Weight == 0 || set.DistanceMeters == 0 {
+			return fmt.Errorf("%s sets need weight and a distance", kind)
+		}
+		if set.Reps != 0 {
+			return fmt.Errorf("%s sets do not take repetitions", kind)
+		}
+	default:
+		return fmt.Errorf("unknown measurement: %s", kind)
+	}
+	return nil
+}
+
+// Better reports whether a is a better set than b for the kind
+func Better(kind string, a Set, b Set) bool {
+	switch kind {
+	case BodyweightReps:
+		if a.Reps != b.Reps {
+			return a.Reps > b.Reps
+		}
+		return a.Weight > b.Weight
+	case Duration:
+		if a.DurationSeconds != b.DurationSeconds {
+			return a.DurationSeconds > b.DurationSeconds
+		}
+		return a.Weight > b.Weight
+	case DistanceDuration:
+		// Longer distance wins, the same distance in less time is faster
+		if a.DistanceMeters != b.DistanceMeters {
+			return a.DistanceMeters > b.DistanceMeters
+		}
+		return a.DurationSeconds < b.DurationSeconds
+	case WeightDistance:
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
+		}
+		return a.DistanceMeters > b.DistanceMeters
+	}
+	if a.Weight != b.Weight {
+		return a.Weight > b.Weight
+	}
+	return a.Reps > b.Reps
+}
+
+// Best returns the best set for the kind, or a zero set when there are none
+func Best(kind string, sets []Set) Set {
+	best := Set{}
+	for i, set := range sets {
+		if i == 0 || Better(kind, set, best) {
+			best = set
+		}
+	}
+	return best
+}
@@ -0,0 +1,58 @@
+package measurement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	kind, err := Normalize("")
+	assert.Nil(t, err)
+	assert.Equal(t, WeightReps, kind)
+
+	kind, err = Normalize(DistanceDuration)
+	assert.Nil(t, err)
+	assert.Equal(t, DistanceDuration, kind)
+
+	_, err = Normalize("calories")
+	assert.NotNil(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Validate(WeightReps, Set{Weight: 100, Reps: 5}))
+	assert.NotNil(t, Validate(WeightReps, Set{Weight: 100, Reps: 5, DurationSeconds: 30}))
+
+	assert.Nil(t, Validate(BodyweightReps, Set{Reps: 10}))
+	assert.Nil(t, Validate(BodyweightReps, Set{Weight: 10, Reps: 10}))
+	assert.NotNil(t, Validate(BodyweightReps, Set{}))
+
+	assert.Nil(t, Validate(Duration, Set{DurationSeconds: 60}))
+	assert.NotNil(t, Validate(Duration, Set{}))
+	assert.NotNil(t, Validate(Duration, Set{DurationSeconds: 60, Reps: 5}))
+
+	assert.Nil(t, Validate(DistanceDuration, Set{DistanceMeters: 5000, DurationSeconds: 1500}))
+	assert.NotNil(t, Validate(DistanceDuration, Set{DistanceMeters: 5000}))
+	assert.NotNil(t, Validate(DistanceDuration, Set{DistanceMeters: 5000, DurationSeconds: 1500, Weight: 10}))
+
+	assert.Nil(t, Validate(WeightDistance, Set{Weight: 40, DistanceMeters: 20}))
+	assert.Nil(t, Validate(WeightDistance, Set{Weight: 40, DistanceMeters: 20, DurationSeconds: 30}))
+	assert.NotNil(t, Validate(WeightDistance, Set{Weight: 40}))
+
+	assert.NotNil(t, Validate(WeightReps, Set{Weight: -1}))
+	assert.NotNil(t, Validate("calories", Set{}))
+}
+
+func TestBest(t *testing.T) {
+	assert.Equal(t, Set{}, Best(WeightReps, []Set{}))
+
+	assert.Equal(t, Set{Weight: 100, Reps: 5}, Best(WeightReps, []Set{{Weight: 90, Reps: 8}, {Weight: 100, Reps: 3}, {Weight: 100, Reps: 5}}))
+	assert.Equal(t, Set{Weight: 10, Reps: 12}, Best(BodyweightReps, []Set{{Weight: 20, Reps: 8}, {Reps: 12}, {Weight: 10, Reps: 12}}))
+	assert.Equal(t, Set{DurationSeconds: 90}, Best(Duration, []Set{{DurationSeconds: 60}, {DurationSeconds: 90}}))
+	assert.Equal(t, Set{DistanceMeters: 5000, DurationSeconds: 1400}, Best(DistanceDuration, []Set{
+		{DistanceMeters: 3000, DurationSeconds: 800},
+		{DistanceMeters: 5000, DurationSeconds: 1500},
+		{DistanceMeters: 5000, DurationSeconds: 1400},
+	}))
+	assert.Equal(t, Set{Weight: 60, DistanceMeters: 30}, Best(WeightDistance, []Set{{Weight: 40, DistanceMeters: 50}, {Weight: 60, DistanceMeters: 20}, {Weight: 60, DistanceMeters: 30}}))
+}
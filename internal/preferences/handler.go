@@ -0,0 +1,75 @@
+package preferences
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"weight-tracker/internal/database"
+	"weight-tracker/internal/utils"
+)
+
+type handler struct {
+	service Service
+}
+
+func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
+	handler := handler{
+		service: NewServiceFromDatabase(s),
+	}
+
+	mux.Handle("GET /me/preferences", authenticationWrapper(http.HandlerFunc(handler.getPreferencesHandler)))
+	mux.Handle("PUT /me/preferences", authenticationWrapper(http.HandlerFunc(handler.updatePreferencesHandler)))
+}
+
+// NewServiceFromDatabase wires the preferences service from the database service
+func NewServiceFromDatabase(s database.Service) Service {
+	return NewService(NewPreferencesRepository(s.GetRepository()))
+}
+
+func (h *handler) getPreferencesHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	preferences, err := h.service.Get(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get preferences", "error", err)
+		http.Error(w, "Failed to get preferences", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(preferences)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+// updatePreferencesHandler saves the preferences, fields left out of the
+// request keep their current value
+func (h *handler) updatePreferencesHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	preferences, err := h.service.Get(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get preferences", "error", err)
+		http.Error(w, "Failed to update preferences", http.StatusBadRequest)
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&preferences); err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.Update(r.Context(), preferences, userId)
+	if err != nil {
+		slog.Warn("Failed to update preferences", "error", err)
+		http.Error(w, "Failed to update preferences", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,89 @@
+package preferences
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func populateContextWithSub(req *http.Request, userId string) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, "sub", userId)
+	return req.WithContext(ctx)
+}
+
+type serviceMock struct {
+	mock.Mock
+}
+
+func (s *serviceMock) Get(ctx context.Context, userId string) (Preferences, error) {
+	args := s.Called(ctx, userId)
+	return args.Get(0).(Preferences), args.Error(1)
+}
+
+func (s *serviceMock) Update(ctx context.Context, t Preferences, userId string) error {
+	args := s.Called(ctx, t, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) GetWeightUnit(ctx context.Context, userId string) (string, error) {
+	args := s.Called(ctx, userId)
+	return args.String(0), args.Error(1)
+}
+
+func TestGetPreferencesHandler(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/me/preferences", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("Get", req.Context(), userId).Return(Defaults(), nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock}
+	http.HandlerFunc(h.getPreferencesHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":{"weight_unit":"kg","week_start":"monday","timezone":"UTC","default_rest_seconds":120,"weekly_target":3}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestUpdatePreferencesHandlerKeepsMissingFields(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("PUT", "/me/preferences", bytes.NewBufferString(`{"weight_unit":"lb"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	updated := Defaults()
+	updated.WeightUnit = "lb"
+
+	serviceMock := serviceMock{}
+	serviceMock.On("Get", req.Context(), userId).Return(Defaults(), nil).Once()
+	serviceMock.On("Update", req.Context(), updated, userId).Return(nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock}
+	http.HandlerFunc(h.updatePreferencesHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+	serviceMock.AssertExpectations(t)
+}
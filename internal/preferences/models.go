@@ -0,0 +1,75 @@
+package preferences
+
+import (
+	"fmt"
+	"slices"
+	"time"
+	"weight-tracker/internal/units"
+)
+
+var WeekStartNames = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// Preferences of a user, users that never saved any get the defaults
+type Preferences struct {
+	WeightUnit         string `json:"weight_unit"`
+	WeekStart          string `json:"week_start"`
+	Timezone           string `json:"timezone"`
+	DefaultRestSeconds int64  `json:"default_rest_seconds"`
+	// Number of workouts a week the user aims for
+	WeeklyTarget int64 `json:"weekly_target"`
+}
+
+func Defaults() Preferences {
+	return Preferences{
+		WeightUnit:         units.Kilograms,
+		WeekStart:          "monday",
+		Timezone:           "UTC",
+		DefaultRestSeconds: 120,
+		WeeklyTarget:       3,
+	}
+}
+
+// Location returns the timezone of the user, falling back to UTC for
+// timezones that are no longer known
+func (p Preferences) Location() *time.Location {
+	location, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// FirstDayOfWeek returns the weekday weeks of the user start on
+func (p Preferences) FirstDayOfWeek() time.Weekday {
+	index := slices.Index(WeekStartNames, p.WeekStart)
+	if index < 0 {
+		return time.Monday
+	}
+	// Week start names begin on monday while weekdays begin on sunday
+	return time.Weekday((index + 1) % 7)
+}
+
+// Upper limit for rest targets, one hour
+const MaxRestSeconds = 3600
+
+// Upper limit for the weekly target, two workouts a day
+const maxWeeklyTarget = 14
+
+func validatePreferences(p Preferences) error {
+	if !units.ValidWeight(p.WeightUnit) {
+		return fmt.Errorf("unknown weight unit: %s", p.WeightUnit)
+	}
+	if !slices.Contains(WeekStartNames, p.WeekStart) {
+		return fmt.Errorf("unknown week start: %s", p.WeekStart)
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil || p.Timezone == "" {
+		return fmt.Errorf("unknown timezone: %s", p.Timezone)
+	}
+	if p.DefaultRestSeconds <= 0 || p.DefaultRestSeconds > MaxRestSeconds {
+		return fmt.Errorf("default rest seconds must be between 1 and %d", MaxRestSeconds)
+	}
+	if p.WeeklyTarget <= 0 || p.WeeklyTarget > maxWeeklyTarget {
+		return fmt.Errorf("weekly target must be between 1 and %d", maxWeeklyTarget)
+	}
+	return nil
+}
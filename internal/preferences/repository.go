@@ -0,0 +1,43 @@
+package preferences
+
+import (
+	"context"
+	"fmt"
+	"weight-tracker/internal/repository"
+)
+
+type PreferencesRepository interface {
+	GetByUserId(ctx context.Context, userId string) (Preferences, error)
+	Upsert(ctx context.Context, arg repository.UpsertPreferencesParams) error
+}
+
+func NewPreferencesRepository(repo repository.Querier) PreferencesRepository {
+	return preferencesRepository{repo: repo}
+}
+
+type preferencesRepository struct {
+	repo repository.Querier
+}
+
+func (p preferencesRepository) GetByUserId(ctx context.Context, userId string) (Preferences, error) {
+	preferences, err := p.repo.GetPreferencesByUserId(ctx, userId)
+	if err != nil {
+		return Preferences{}, fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	return Preferences{
+		WeightUnit:         preferences.WeightUnit,
+		WeekStart:          preferences.WeekStart,
+		Timezone:           preferences.Timezone,
+		DefaultRestSeconds: preferences.DefaultRestSeconds,
+		WeeklyTarget:       preferences.WeeklyTarget,
+	}, nil
+}
+
+func (p preferencesRepository) Upsert(ctx context.Context, arg repository.UpsertPreferencesParams) error {
+	_, err := p.repo.UpsertPreferences(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return nil
+}
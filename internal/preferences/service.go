@@ -0,0 +1,62 @@
+package preferences
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+	"weight-tracker/internal/repository"
+)
+
+type Service interface {
+	Get(ctx context.Context, userId string) (Preferences, error)
+	Update(ctx context.Context, t Preferences, userId string) error
+	GetWeightUnit(ctx context.Context, userId string) (string, error)
+}
+
+type preferencesService struct {
+	repo PreferencesRepository
+}
+
+func NewService(repo PreferencesRepository) Service {
+	return &preferencesService{repo: repo}
+}
+
+func (s *preferencesService) Get(ctx context.Context, userId string) (Preferences, error) {
+	preferences, err := s.repo.GetByUserId(ctx, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Defaults(), nil
+		}
+		return Preferences{}, err
+	}
+	return preferences, nil
+}
+
+func (s *preferencesService) Update(ctx context.Context, t Preferences, userId string) error {
+	err := validatePreferences(t)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	return s.repo.Upsert(ctx, repository.UpsertPreferencesParams{
+		UserID:             userId,
+		WeightUnit:         t.WeightUnit,
+		WeekStart:          t.WeekStart,
+		Timezone:           t.Timezone,
+		DefaultRestSeconds: t.DefaultRestSeconds,
+		WeeklyTarget:       t.WeeklyTarget,
+		CreatedOn:          now,
+		UpdatedOn:          now,
+	})
+}
+
+// GetWeightUnit returns the unit the user enters and reads weights in
+func (s *preferencesService) GetWeightUnit(ctx context.Context, userId string) (string, error) {
+	preferences, err := s.Get(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+	return preferences.WeightUnit, nil
+}
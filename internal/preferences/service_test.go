@@ -0,0 +1,123 @@
+package preferences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+	"weight-tracker/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type repoMock struct {
+	mock.Mock
+}
+
+func (r *repoMock) GetByUserId(ctx context.Context, userId string) (Preferences, error) {
+	args := r.Called(ctx, userId)
+	return args.Get(0).(Preferences), args.Error(1)
+}
+
+func (r *repoMock) Upsert(ctx context.Context, arg repository.UpsertPreferencesParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func TestGetReturnsDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetByUserId", ctx, "userId").Return(Preferences{}, fmt.Errorf("failed to get preferences: %w", sql.ErrNoRows)).Once()
+
+	service := NewService(&repoMock)
+	preferences, err := service.Get(ctx, "userId")
+
+	assert.Nil(t, err)
+	assert.Equal(t, Defaults(), preferences)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetWeightUnit(t *testing.T) {
+	ctx := context.Background()
+
+	stored := Defaults()
+	stored.WeightUnit = "lb"
+	repoMock := repoMock{}
+	repoMock.On("GetByUserId", ctx, "userId").Return(stored, nil).Once()
+
+	service := NewService(&repoMock)
+	unit, err := service.GetWeightUnit(ctx, "userId")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "lb", unit)
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("Upsert", ctx, mock.MatchedBy(func(input repository.UpsertPreferencesParams) bool {
+		return input.UserID == "userId" && input.WeightUnit == "lb" && input.WeekStart == "sunday" &&
+			input.Timezone == "America/New_York" && input.DefaultRestSeconds == 90 && input.WeeklyTarget == 4
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock)
+	err := service.Update(ctx, Preferences{
+		WeightUnit:         "lb",
+		WeekStart:          "sunday",
+		Timezone:           "America/New_York",
+		DefaultRestSeconds: 90,
+		WeeklyTarget:       4,
+	}, "userId")
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *Preferences)
+	}{
+		{"unknown weight unit", func(p *Preferences) { p.WeightUnit = "stone" }},
+		{"unknown week start", func(p *Preferences) { p.WeekStart = "someday" }},
+		{"unknown timezone", func(p *Preferences) { p.Timezone = "Mars/Olympus" }},
+		{"empty timezone", func(p *Preferences) { p.Timezone = "" }},
+		{"no rest", func(p *Preferences) { p.DefaultRestSeconds = 0 }},
+		{"too much rest", func(p *Preferences) { p.DefaultRestSeconds = 3601 }},
+		{"no weekly target", func(p *Preferences) { p.WeeklyTarget = 0 }},
+		{"too high weekly target", func(p *Preferences) { p.WeeklyTarget = 15 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preferences := Defaults()
+			tt.modify(&preferences)
+
+			repoMock := repoMock{}
+			service := NewService(&repoMock)
+			err := service.Update(context.Background(), preferences, "userId")
+
+			assert.NotNil(t, err)
+			repoMock.AssertNotCalled(t, "Upsert")
+		})
+	}
+}
+
+func TestCalendarSettings(t *testing.T) {
+	preferences := Defaults()
+	assert.Equal(t, time.UTC, preferences.Location())
+	assert.Equal(t, time.Monday, preferences.FirstDayOfWeek())
+
+	preferences.Timezone = "Europe/Amsterdam"
+	preferences.WeekStart = "sunday"
+	assert.Equal(t, "Europe/Amsterdam", preferences.Location().String())
+	assert.Equal(t, time.Sunday, preferences.FirstDayOfWeek())
+
+	preferences.WeekStart = "saturday"
+	assert.Equal(t, time.Saturday, preferences.FirstDayOfWeek())
+}
@@ -0,0 +1,213 @@
+package programs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"weight-tracker/internal/database"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/templates"
+	"weight-tracker/internal/utils"
+)
+
+type handler struct {
+	service Service
+}
+
+type createProgramRequest struct {
+	Name  string               `json:"name"`
+	Weeks []programWeekRequest `json:"weeks"`
+}
+
+type programWeekRequest struct {
+	Days []programDayRequest `json:"days"`
+}
+
+type programDayRequest struct {
+	TemplateID string `json:"template_id"`
+}
+
+func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
+	handler := handler{
+		service: NewServiceFromDatabase(s),
+	}
+
+	mux.Handle("GET /programs", authenticationWrapper(http.HandlerFunc(handler.getAllProgramsHandler)))
+	mux.Handle("GET /programs/today", authenticationWrapper(http.HandlerFunc(handler.getTodaysSessionHandler)))
+	mux.Handle("POST /programs/today/start", authenticationWrapper(http.HandlerFunc(handler.startTodaysSessionHandler)))
+	mux.Handle("GET /programs/{id}", authenticationWrapper(http.HandlerFunc(handler.getProgramByIdHandler)))
+	mux.Handle("POST /programs", authenticationWrapper(http.HandlerFunc(handler.createProgramHandler)))
+	mux.Handle("POST /programs/{id}/enroll", authenticationWrapper(http.HandlerFunc(handler.enrollHandler)))
+	mux.Handle("DELETE /programs/{id}", authenticationWrapper(http.HandlerFunc(handler.deleteProgramByIdHandler)))
+}
+
+// NewServiceFromDatabase wires the program service and its dependencies from the database service
+func NewServiceFromDatabase(s database.Service) Service {
+	return NewService(
+		NewProgramRepository(s.GetRepository()),
+		templates.NewServiceFromDatabase(s),
+		func(ctx context.Context, fn func(Repositories) error) error {
+			return s.WithTx(ctx, func(q repository.Querier) error {
+				return fn(Repositories{
+					Programs:  NewProgramRepository(q),
+					Templates: templates.NewServiceInTransaction(q),
+				})
+			})
+		},
+	)
+}
+
+func (h *handler) getAllProgramsHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	programs, err := h.service.GetAll(r.Context(), userId)
+	if err != nil {
+		slog.Error("Failed to get programs", "error", err)
+		http.Error(w, "Failed to get programs", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(programs)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) getProgramByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	id := r.PathValue("id")
+
+	program, err := h.service.GetById(r.Context(), id, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		slog.Error("Failed to get program", "error", err, "programId", id)
+		http.Error(w, "Failed to get program", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(program)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) createProgramHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	var req createProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	id, err := h.service.CreateAndReturnId(r.Context(), req, userId)
+	if err != nil {
+		slog.Error("Failed to create program", "error", err)
+		http.Error(w, "Failed to create program", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	jsonResp, err := utils.CreateIdResponse(id)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) deleteProgramByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	id := r.PathValue("id")
+
+	err := h.service.DeleteById(r.Context(), id, userId)
+	if err != nil {
+		slog.Error("Failed to delete program", "error", err, "programId", id)
+		http.Error(w, "Failed to delete program", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) enrollHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	id := r.PathValue("id")
+
+	enrollmentId, err := h.service.EnrollAndReturnId(r.Context(), id, userId)
+	if err != nil {
+		slog.Error("Failed to enroll in program", "error", err, "programId", id)
+		http.Error(w, "Failed to enroll in program", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	jsonResp, err := utils.CreateIdResponse(enrollmentId)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) getTodaysSessionHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	session, err := h.service.GetTodaysSession(r.Context(), userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		slog.Error("Failed to get todays session", "error", err)
+		http.Error(w, "Failed to get todays session", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(session)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) startTodaysSessionHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	workoutId, err := h.service.StartTodaysSessionAndReturnId(r.Context(), userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		slog.Error("Failed to start todays session", "error", err)
+		http.Error(w, "Failed to start todays session", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	jsonResp, err := utils.CreateIdResponse(workoutId)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
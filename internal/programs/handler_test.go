@@ -0,0 +1,242 @@
+package programs
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func populateContextWithSub(req *http.Request, userId string) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, "sub", userId)
+	return req.WithContext(ctx)
+}
+
+type serviceMock struct {
+	mock.Mock
+}
+
+func (s *serviceMock) GetAll(ctx context.Context, userId string) ([]Program, error) {
+	args := s.Called(ctx, userId)
+	return args.Get(0).([]Program), args.Error(1)
+}
+
+func (s *serviceMock) GetById(ctx context.Context, programId string, userId string) (ProgramWithWeeks, error) {
+	args := s.Called(ctx, programId, userId)
+	return args.Get(0).(ProgramWithWeeks), args.Error(1)
+}
+
+func (s *serviceMock) CreateAndReturnId(ctx context.Context, t createProgramRequest, userId string) (string, error) {
+	args := s.Called(ctx, t, userId)
+	return args.String(0), args.Error(1)
+}
+
+func (s *serviceMock) DeleteById(ctx context.Context, programId string, userId string) error {
+	args := s.Called(ctx, programId, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) EnrollAndReturnId(ctx context.Context, programId string, userId string) (string, error) {
+	args := s.Called(ctx, programId, userId)
+	return args.String(0), args.Error(1)
+}
+
+func (s *serviceMock) GetTodaysSession(ctx context.Context, userId string) (Session, error) {
+	args := s.Called(ctx, userId)
+	return args.Get(0).(Session), args.Error(1)
+}
+
+func (s *serviceMock) StartTodaysSessionAndReturnId(ctx context.Context, userId string) (string, error) {
+	args := s.Called(ctx, userId)
+	return args.String(0), args.Error(1)
+}
+
+func (s *serviceMock) OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error {
+	args := s.Called(ctx, workoutId, userId)
+	return args.Error(0)
+}
+
+func TestCreateProgramHandler(t *testing.T) {
+	userId := "userId"
+	request := createProgramRequest{
+		Name:  "PPL",
+		Weeks: []programWeekRequest{{Days: []programDayRequest{{TemplateID: "push"}}}},
+	}
+	body, _ := json.Marshal(request)
+
+	req, err := http.NewRequest("POST", "/programs", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("CreateAndReturnId", req.Context(), request, userId).Return("programId", nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.createProgramHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	expected := `{"id":"programId"}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestEnrollHandler(t *testing.T) {
+	userId := "userId"
+	programId := "programId"
+
+	req, err := http.NewRequest("POST", "/programs/"+programId+"/enroll", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", programId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("EnrollAndReturnId", req.Context(), programId, userId).Return("enrollmentId", nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.enrollHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetTodaysSessionHandlerNoActiveProgram(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/programs/today", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetTodaysSession", req.Context(), userId).Return(Session{}, sql.ErrNoRows).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.getTodaysSessionHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetTodaysSessionHandler(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/programs/today", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetTodaysSession", req.Context(), userId).Return(Session{
+		ProgramName:       "PPL",
+		Week:              1,
+		Day:               2,
+		CompletedSessions: 1,
+		TotalSessions:     6,
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.getTodaysSessionHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	var resp struct {
+		Data Session `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Data.Day != 2 || resp.Data.TotalSessions != 6 {
+		t.Errorf("handler returned unexpected body: got %v", rr.Body.String())
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestStartTodaysSessionHandler(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("POST", "/programs/today/start", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("StartTodaysSessionAndReturnId", req.Context(), userId).Return("workoutId", nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.startTodaysSessionHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	expected := `{"id":"workoutId"}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestDeleteProgramByIdHandlerServiceErr(t *testing.T) {
+	userId := "userId"
+	programId := "programId"
+
+	req, err := http.NewRequest("DELETE", "/programs/"+programId, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", programId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("DeleteById", req.Context(), programId, userId).Return(testError).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.deleteProgramByIdHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
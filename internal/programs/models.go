@@ -0,0 +1,58 @@
+package programs
+
+import "weight-tracker/internal/templates"
+
+type Program struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedOn string `json:"created_on"`
+	UpdatedOn string `json:"updated_on"`
+}
+
+type ProgramWithWeeks struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	CreatedOn string        `json:"created_on"`
+	UpdatedOn string        `json:"updated_on"`
+	Weeks     []ProgramWeek `json:"weeks"`
+}
+
+type ProgramWeek struct {
+	Week int64        `json:"week"`
+	Days []ProgramDay `json:"days"`
+}
+
+type ProgramDay struct {
+	ID         string `json:"id"`
+	Week       int64  `json:"week"`
+	Day        int64  `json:"day"`
+	TemplateID string `json:"template_id"`
+}
+
+type Enrollment struct {
+	ID        string `json:"id"`
+	ProgramID string `json:"program_id"`
+	StartedOn string `json:"started_on"`
+	EndedOn   string `json:"ended_on"`
+}
+
+type Progress struct {
+	ID           string `json:"id"`
+	ProgramDayID string `json:"program_day_id"`
+	WorkoutID    string `json:"workout_id"`
+	CompletedOn  string `json:"completed_on"`
+}
+
+// Session is the next scheduled day of the active enrollment
+type Session struct {
+	EnrollmentID      string                              `json:"enrollment_id"`
+	ProgramID         string                              `json:"program_id"`
+	ProgramName       string                              `json:"program_name"`
+	ProgramDayID      string                              `json:"program_day_id"`
+	Week              int64                               `json:"week"`
+	Day               int64                               `json:"day"`
+	WorkoutID         string                              `json:"workout_id"`
+	CompletedSessions int                                 `json:"completed_sessions"`
+	TotalSessions     int                                 `json:"total_sessions"`
+	Template          templates.TemplateWithExerciseItems `json:"template"`
+}
@@ -0,0 +1,228 @@
+package programs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"weight-tracker/internal/repository"
+)
+
+type ProgramRepository interface {
+	GetAll(ctx context.Context, userId string) ([]Program, error)
+	GetById(ctx context.Context, arg repository.GetProgramByIdParams) (Program, error)
+	CreateAndReturnId(ctx context.Context, arg repository.CreateProgramAndReturnIdParams) (string, error)
+	DeleteById(ctx context.Context, programId string, userId string) error
+	CreateDayAndReturnId(ctx context.Context, arg repository.CreateProgramDayAndReturnIdParams) (string, error)
+	GetDaysByProgramId(ctx context.Context, programId string, userId string) ([]ProgramDay, error)
+	CreateEnrollmentAndReturnId(ctx context.Context, arg repository.CreateProgramEnrollmentAndReturnIdParams) (string, error)
+	GetActiveEnrollment(ctx context.Context, userId string) (Enrollment, error)
+	EndActiveEnrollments(ctx context.Context, arg repository.EndActiveProgramEnrollmentsParams) error
+	EndEnrollmentById(ctx context.Context, arg repository.EndProgramEnrollmentByIdParams) error
+	CreateProgressAndReturnId(ctx context.Context, arg repository.CreateProgramProgressAndReturnIdParams) (string, error)
+	GetProgressByEnrollmentId(ctx context.Context, enrollmentId string, userId string) ([]Progress, error)
+	CompleteProgressByWorkoutId(ctx context.Context, arg repository.CompleteProgramProgressByWorkoutIdParams) (int64, error)
+	DeleteUnfinishedProgressByDayId(ctx context.Context, arg repository.DeleteUnfinishedProgramProgressByDayIdParams) error
+}
+
+func NewProgramRepository(repo repository.Querier) ProgramRepository {
+	return programRepository{repo: repo}
+}
+
+type programRepository struct {
+	repo repository.Querier
+}
+
+func (p programRepository) GetAll(ctx context.Context, userId string) ([]Program, error) {
+	programs, err := p.repo.GetAllPrograms(ctx, userId)
+	if err != nil {
+		return []Program{}, fmt.Errorf("failed to get all programs: %w", err)
+	}
+
+	result := []Program{}
+	for _, v := range programs {
+		result = append(result, newProgram(v))
+	}
+	return result, nil
+}
+
+func (p programRepository) GetById(ctx context.Context, arg repository.GetProgramByIdParams) (Program, error) {
+	program, err := p.repo.GetProgramById(ctx, arg)
+	if err != nil {
+		return Program{}, fmt.Errorf("failed to get program by id: %w", err)
+	}
+	return newProgram(program), nil
+}
+
+func (p programRepository) CreateAndReturnId(ctx context.Context, arg repository.CreateProgramAndReturnIdParams) (string, error) {
+	id, err := p.repo.CreateProgramAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create program: %w", err)
+	}
+	return id, nil
+}
+
+func (p programRepository) DeleteById(ctx context.Context, programId string, userId string) error {
+	_, err := p.repo.DeleteProgramProgressByProgramId(ctx, repository.DeleteProgramProgressByProgramIdParams{
+		ProgramID: programId,
+		UserID:    userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete program progress: %w", err)
+	}
+
+	_, err = p.repo.DeleteProgramEnrollmentsByProgramId(ctx, repository.DeleteProgramEnrollmentsByProgramIdParams{
+		ProgramID: programId,
+		UserID:    userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete program enrollments: %w", err)
+	}
+
+	_, err = p.repo.DeleteProgramDaysByProgramId(ctx, repository.DeleteProgramDaysByProgramIdParams{
+		ProgramID: programId,
+		UserID:    userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete program days: %w", err)
+	}
+
+	rows, err := p.repo.DeleteProgramById(ctx, repository.DeleteProgramByIdParams{
+		ID:     programId,
+		UserID: userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete program: %w", err)
+	}
+
+	if rows == 0 {
+		slog.Warn("Tried to delete program that did not exist", "programId", programId)
+	}
+	return nil
+}
+
+func (p programRepository) CreateDayAndReturnId(ctx context.Context, arg repository.CreateProgramDayAndReturnIdParams) (string, error) {
+	id, err := p.repo.CreateProgramDayAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create program day: %w", err)
+	}
+	return id, nil
+}
+
+func (p programRepository) GetDaysByProgramId(ctx context.Context, programId string, userId string) ([]ProgramDay, error) {
+	days, err := p.repo.GetProgramDaysByProgramId(ctx, repository.GetProgramDaysByProgramIdParams{
+		ProgramID: programId,
+		UserID:    userId,
+	})
+	if err != nil {
+		return []ProgramDay{}, fmt.Errorf("failed to get program days: %w", err)
+	}
+
+	result := []ProgramDay{}
+	for _, v := range days {
+		result = append(result, ProgramDay{
+			ID:         v.ID,
+			Week:       v.Week,
+			Day:        v.Day,
+			TemplateID: v.TemplateID,
+		})
+	}
+	return result, nil
+}
+
+func (p programRepository) CreateEnrollmentAndReturnId(ctx context.Context, arg repository.CreateProgramEnrollmentAndReturnIdParams) (string, error) {
+	id, err := p.repo.CreateProgramEnrollmentAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create program enrollment: %w", err)
+	}
+	return id, nil
+}
+
+func (p programRepository) GetActiveEnrollment(ctx context.Context, userId string) (Enrollment, error) {
+	enrollment, err := p.repo.GetActiveProgramEnrollment(ctx, userId)
+	if err != nil {
+		return Enrollment{}, fmt.Errorf("failed to get active program enrollment: %w", err)
+	}
+
+	result := Enrollment{
+		ID:        enrollment.ID,
+		ProgramID: enrollment.ProgramID,
+		StartedOn: enrollment.StartedOn,
+	}
+	if enrollment.EndedOn != nil {
+		result.EndedOn = enrollment.EndedOn.(string)
+	}
+	return result, nil
+}
+
+func (p programRepository) EndActiveEnrollments(ctx context.Context, arg repository.EndActiveProgramEnrollmentsParams) error {
+	_, err := p.repo.EndActiveProgramEnrollments(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to end active program enrollments: %w", err)
+	}
+	return nil
+}
+
+func (p programRepository) EndEnrollmentById(ctx context.Context, arg repository.EndProgramEnrollmentByIdParams) error {
+	_, err := p.repo.EndProgramEnrollmentById(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to end program enrollment: %w", err)
+	}
+	return nil
+}
+
+func (p programRepository) CreateProgressAndReturnId(ctx context.Context, arg repository.CreateProgramProgressAndReturnIdParams) (string, error) {
+	id, err := p.repo.CreateProgramProgressAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create program progress: %w", err)
+	}
+	return id, nil
+}
+
+func (p programRepository) GetProgressByEnrollmentId(ctx context.Context, enrollmentId string, userId string) ([]Progress, error) {
+	progress, err := p.repo.GetProgramProgressByEnrollmentId(ctx, repository.GetProgramProgressByEnrollmentIdParams{
+		ProgramEnrollmentID: enrollmentId,
+		UserID:              userId,
+	})
+	if err != nil {
+		return []Progress{}, fmt.Errorf("failed to get program progress: %w", err)
+	}
+
+	result := []Progress{}
+	for _, v := range progress {
+		item := Progress{
+			ID:           v.ID,
+			ProgramDayID: v.ProgramDayID,
+			WorkoutID:    v.WorkoutID,
+		}
+		if v.CompletedOn != nil {
+			item.CompletedOn = v.CompletedOn.(string)
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func (p programRepository) CompleteProgressByWorkoutId(ctx context.Context, arg repository.CompleteProgramProgressByWorkoutIdParams) (int64, error) {
+	rows, err := p.repo.CompleteProgramProgressByWorkoutId(ctx, arg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to complete program progress: %w", err)
+	}
+	return rows, nil
+}
+
+func (p programRepository) DeleteUnfinishedProgressByDayId(ctx context.Context, arg repository.DeleteUnfinishedProgramProgressByDayIdParams) error {
+	_, err := p.repo.DeleteUnfinishedProgramProgressByDayId(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to delete unfinished program progress: %w", err)
+	}
+	return nil
+}
+
+func newProgram(v repository.Program) Program {
+	return Program{
+		ID:        v.ID,
+		Name:      v.Name,
+		CreatedOn: v.CreatedOn,
+		UpdatedOn: v.UpdatedOn,
+	}
+}
@@ -0,0 +1,403 @@
+package programs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/templates"
+
+	"github.com/google/uuid"
+)
+
+// TemplateService is the part of templates.Service used to plan and start sessions
+type TemplateService interface {
+	GetById(ctx context.Context, templateId string, userId string) (templates.TemplateWithExerciseItems, error)
+	StartWorkoutAndReturnId(ctx context.Context, templateId string, userId string) (string, error)
+}
+
+type Service interface {
+	GetAll(ctx context.Context, userId string) ([]Program, error)
+	GetById(ctx context.Context, programId string, userId string) (ProgramWithWeeks, error)
+	CreateAndReturnId(ctx context.Context, t createProgramRequest, userId string) (string, error)
+	DeleteById(ctx context.Context, programId string, userId string) error
+	EnrollAndReturnId(ctx context.Context, programId string, userId string) (string, error)
+	GetTodaysSession(ctx context.Context, userId string) (Session, error)
+	StartTodaysSessionAndReturnId(ctx context.Context, userId string) (string, error)
+	OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error
+}
+
+// Repositories are the repositories and services a program is written with
+type Repositories struct {
+	Programs  ProgramRepository
+	Templates TemplateService
+}
+
+// Transactor runs fn with repositories whose queries share one transaction
+type Transactor func(ctx context.Context, fn func(Repositories) error) error
+
+type programService struct {
+	repo        ProgramRepository
+	templateSvc TemplateService
+	withTx      Transactor
+}
+
+func NewService(repo ProgramRepository, templateSvc TemplateService, withTx Transactor) Service {
+	return &programService{repo, templateSvc, withTx}
+}
+
+func (s *programService) GetAll(ctx context.Context, userId string) ([]Program, error) {
+	return s.repo.GetAll(ctx, userId)
+}
+
+func (s *programService) GetById(ctx context.Context, programId string, userId string) (ProgramWithWeeks, error) {
+	program, err := s.repo.GetById(ctx, repository.GetProgramByIdParams{
+		ID:     programId,
+		UserID: userId,
+	})
+	if err != nil {
+		return ProgramWithWeeks{}, fmt.Errorf("failed to get program: %w", err)
+	}
+
+	days, err := s.repo.GetDaysByProgramId(ctx, programId, userId)
+	if err != nil {
+		return ProgramWithWeeks{}, fmt.Errorf("failed to get program days: %w", err)
+	}
+
+	// Days are ordered by week and day, so a new week starts whenever the week number changes
+	weeks := []ProgramWeek{}
+	for _, day := range days {
+		if len(weeks) == 0 || weeks[len(weeks)-1].Week != day.Week {
+			weeks = append(weeks, ProgramWeek{Week: day.Week, Days: []ProgramDay{}})
+		}
+		weeks[len(weeks)-1].Days = append(weeks[len(weeks)-1].Days, day)
+	}
+
+	return ProgramWithWeeks{
+		ID:        program.ID,
+		Name:      program.Name,
+		CreatedOn: program.CreatedOn,
+		UpdatedOn: program.UpdatedOn,
+		Weeks:     weeks,
+	}, nil
+}
+
+func (s *programService) CreateAndReturnId(ctx context.Context, t createProgramRequest, userId string) (string, error) {
+	if t.Name == "" {
+		return "", fmt.Errorf("program name is required")
+	}
+
+	dayCount := 0
+	for _, week := range t.Weeks {
+		for _, day := range week.Days {
+			_, err := s.templateSvc.GetById(ctx, day.TemplateID, userId)
+			if err != nil {
+				return "", fmt.Errorf("failed to get template for program day: %w", err)
+			}
+			dayCount++
+		}
+	}
+
+	if dayCount == 0 {
+		return "", fmt.Errorf("program must have at least one day")
+	}
+
+	programUuid, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	var programId string
+	err = s.withTx(ctx, func(repos Repositories) error {
+		now := time.Now().UTC().Format(time.RFC3339)
+		var err error
+		programId, err = repos.Programs.CreateAndReturnId(ctx, repository.CreateProgramAndReturnIdParams{
+			ID:        programUuid.String(),
+			Name:      t.Name,
+			CreatedOn: now,
+			UpdatedOn: now,
+			UserID:    userId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create program: %w", err)
+		}
+
+		for weekIndex, week := range t.Weeks {
+			for dayIndex, day := range week.Days {
+				dayUuid, err := uuid.NewV7()
+				if err != nil {
+					return fmt.Errorf("failed to generate UUID for program day: %w", err)
+				}
+
+				_, err = repos.Programs.CreateDayAndReturnId(ctx, repository.CreateProgramDayAndReturnIdParams{
+					ID:         dayUuid.String(),
+					Week:       int64(weekIndex + 1),
+					Day:        int64(dayIndex + 1),
+					CreatedOn:  now,
+					UpdatedOn:  now,
+					UserID:     userId,
+					ProgramID:  programId,
+					TemplateID: day.TemplateID,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to create program day: %w", err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return programId, nil
+}
+
+func (s *programService) DeleteById(ctx context.Context, programId string, userId string) error {
+	// The progress, enrollments and days go together with the program
+	return s.withTx(ctx, func(repos Repositories) error {
+		return repos.Programs.DeleteById(ctx, programId, userId)
+	})
+}
+
+func (s *programService) EnrollAndReturnId(ctx context.Context, programId string, userId string) (string, error) {
+	_, err := s.repo.GetById(ctx, repository.GetProgramByIdParams{
+		ID:     programId,
+		UserID: userId,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get program: %w", err)
+	}
+
+	enrollmentUuid, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	var id string
+	err = s.withTx(ctx, func(repos Repositories) error {
+		now := time.Now().UTC().Format(time.RFC3339)
+
+		// A user follows one program at a time, enrolling ends the current one
+		err := repos.Programs.EndActiveEnrollments(ctx, repository.EndActiveProgramEnrollmentsParams{
+			EndedOn:   now,
+			UpdatedOn: now,
+			UserID:    userId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to end active enrollments: %w", err)
+		}
+
+		id, err = repos.Programs.CreateEnrollmentAndReturnId(ctx, repository.CreateProgramEnrollmentAndReturnIdParams{
+			ID:        enrollmentUuid.String(),
+			StartedOn: now,
+			CreatedOn: now,
+			UpdatedOn: now,
+			UserID:    userId,
+			ProgramID: programId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create enrollment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (s *programService) GetTodaysSession(ctx context.Context, userId string) (Session, error) {
+	enrollment, err := s.repo.GetActiveEnrollment(ctx, userId)
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to get active enrollment: %w", err)
+	}
+
+	program, err := s.repo.GetById(ctx, repository.GetProgramByIdParams{
+		ID:     enrollment.ProgramID,
+		UserID: userId,
+	})
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to get program: %w", err)
+	}
+
+	days, progress, err := s.getDaysAndProgress(ctx, enrollment, userId)
+	if err != nil {
+		return Session{}, err
+	}
+
+	next, workoutId, completed := nextSession(days, progress)
+	if next == nil {
+		return Session{}, fmt.Errorf("program has no remaining sessions: %w", sql.ErrNoRows)
+	}
+
+	template, err := s.templateSvc.GetById(ctx, next.TemplateID, userId)
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to get template for session: %w", err)
+	}
+
+	return Session{
+		EnrollmentID:      enrollment.ID,
+		ProgramID:         program.ID,
+		ProgramName:       program.Name,
+		ProgramDayID:      next.ID,
+		Week:              next.Week,
+		Day:               next.Day,
+		WorkoutID:         workoutId,
+		CompletedSessions: completed,
+		TotalSessions:     len(days),
+		Template:          template,
+	}, nil
+}
+
+func (s *programService) StartTodaysSessionAndReturnId(ctx context.Context, userId string) (string, error) {
+	session, err := s.GetTodaysSession(ctx, userId)
+	if err != nil {
+		return "", err
+	}
+
+	// The session has already been started, continue with the same workout
+	if session.WorkoutID != "" {
+		return session.WorkoutID, nil
+	}
+
+	progressUuid, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	// The workout and the progress pointing at it are created together, a
+	// failure leaves neither behind
+	var workoutId string
+	err = s.withTx(ctx, func(repos Repositories) error {
+		var err error
+		workoutId, err = repos.Templates.StartWorkoutAndReturnId(ctx, session.Template.ID, userId)
+		if err != nil {
+			return fmt.Errorf("failed to start workout: %w", err)
+		}
+
+		// A session of the day whose workout was deleted is replaced by the new one
+		err = repos.Programs.DeleteUnfinishedProgressByDayId(ctx, repository.DeleteUnfinishedProgramProgressByDayIdParams{
+			ProgramEnrollmentID: session.EnrollmentID,
+			ProgramDayID:        session.ProgramDayID,
+			UserID:              userId,
+		})
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC().Format(time.RFC3339)
+		_, err = repos.Programs.CreateProgressAndReturnId(ctx, repository.CreateProgramProgressAndReturnIdParams{
+			ID:                  progressUuid.String(),
+			CreatedOn:           now,
+			UpdatedOn:           now,
+			UserID:              userId,
+			ProgramID:           session.ProgramID,
+			ProgramEnrollmentID: session.EnrollmentID,
+			ProgramDayID:        session.ProgramDayID,
+			WorkoutID:           workoutId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create program progress: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return workoutId, nil
+}
+
+func (s *programService) OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	rows, err := s.repo.CompleteProgressByWorkoutId(ctx, repository.CompleteProgramProgressByWorkoutIdParams{
+		CompletedOn: now,
+		UpdatedOn:   now,
+		WorkoutID:   workoutId,
+		UserID:      userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete program progress: %w", err)
+	}
+
+	// The workout was not started from a program
+	if rows == 0 {
+		return nil
+	}
+
+	enrollment, err := s.repo.GetActiveEnrollment(ctx, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to get active enrollment: %w", err)
+	}
+
+	days, progress, err := s.getDaysAndProgress(ctx, enrollment, userId)
+	if err != nil {
+		return err
+	}
+
+	next, _, _ := nextSession(days, progress)
+	if next != nil {
+		return nil
+	}
+
+	err = s.repo.EndEnrollmentById(ctx, repository.EndProgramEnrollmentByIdParams{
+		EndedOn:   now,
+		UpdatedOn: now,
+		ID:        enrollment.ID,
+		UserID:    userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to end finished enrollment: %w", err)
+	}
+	return nil
+}
+
+func (s *programService) getDaysAndProgress(ctx context.Context, enrollment Enrollment, userId string) ([]ProgramDay, []Progress, error) {
+	days, err := s.repo.GetDaysByProgramId(ctx, enrollment.ProgramID, userId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get program days: %w", err)
+	}
+
+	progress, err := s.repo.GetProgressByEnrollmentId(ctx, enrollment.ID, userId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get program progress: %w", err)
+	}
+	return days, progress, nil
+}
+
+// nextSession returns the first day without a completed workout, the workout
+// already started for that day if any, and the number of completed days
+func nextSession(days []ProgramDay, progress []Progress) (*ProgramDay, string, int) {
+	completedDays := map[string]bool{}
+	startedDays := map[string]string{}
+	for _, p := range progress {
+		if p.CompletedOn != "" {
+			completedDays[p.ProgramDayID] = true
+		} else {
+			startedDays[p.ProgramDayID] = p.WorkoutID
+		}
+	}
+
+	completed := 0
+	var next *ProgramDay
+	for i := range days {
+		if completedDays[days[i].ID] {
+			completed++
+			continue
+		}
+		if next == nil {
+			next = &days[i]
+		}
+	}
+
+	if next == nil {
+		return nil, "", completed
+	}
+	return next, startedDays[next.ID], completed
+}
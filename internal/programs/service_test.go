@@ -0,0 +1,428 @@
+package programs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/templates"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testError = errors.New("Testerror")
+
+type repoMock struct {
+	mock.Mock
+}
+
+func (r *repoMock) GetAll(ctx context.Context, userId string) ([]Program, error) {
+	args := r.Called(ctx, userId)
+	return args.Get(0).([]Program), args.Error(1)
+}
+
+func (r *repoMock) GetById(ctx context.Context, arg repository.GetProgramByIdParams) (Program, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(Program), args.Error(1)
+}
+
+func (r *repoMock) CreateAndReturnId(ctx context.Context, arg repository.CreateProgramAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) DeleteById(ctx context.Context, programId string, userId string) error {
+	args := r.Called(ctx, programId, userId)
+	return args.Error(0)
+}
+
+func (r *repoMock) CreateDayAndReturnId(ctx context.Context, arg repository.CreateProgramDayAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) GetDaysByProgramId(ctx context.Context, programId string, userId string) ([]ProgramDay, error) {
+	args := r.Called(ctx, programId, userId)
+	return args.Get(0).([]ProgramDay), args.Error(1)
+}
+
+func (r *repoMock) CreateEnrollmentAndReturnId(ctx context.Context, arg repository.CreateProgramEnrollmentAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) GetActiveEnrollment(ctx context.Context, userId string) (Enrollment, error) {
+	args := r.Called(ctx, userId)
+	return args.Get(0).(Enrollment), args.Error(1)
+}
+
+func (r *repoMock) EndActiveEnrollments(ctx context.Context, arg repository.EndActiveProgramEnrollmentsParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *repoMock) EndEnrollmentById(ctx context.Context, arg repository.EndProgramEnrollmentByIdParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *repoMock) CreateProgressAndReturnId(ctx context.Context, arg repository.CreateProgramProgressAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) GetProgressByEnrollmentId(ctx context.Context, enrollmentId string, userId string) ([]Progress, error) {
+	args := r.Called(ctx, enrollmentId, userId)
+	return args.Get(0).([]Progress), args.Error(1)
+}
+
+func (r *repoMock) CompleteProgressByWorkoutId(ctx context.Context, arg repository.CompleteProgramProgressByWorkoutIdParams) (int64, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (r *repoMock) DeleteUnfinishedProgressByDayId(ctx context.Context, arg repository.DeleteUnfinishedProgramProgressByDayIdParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+type transactorStub struct {
+	repos Repositories
+	runs  int
+}
+
+func (s *transactorStub) withTx(ctx context.Context, fn func(Repositories) error) error {
+	s.runs++
+	return fn(s.repos)
+}
+
+type templateServiceMock struct {
+	mock.Mock
+}
+
+func (t *templateServiceMock) GetById(ctx context.Context, templateId string, userId string) (templates.TemplateWithExerciseItems, error) {
+	args := t.Called(ctx, templateId, userId)
+	return args.Get(0).(templates.TemplateWithExerciseItems), args.Error(1)
+}
+
+func (t *templateServiceMock) StartWorkoutAndReturnId(ctx context.Context, templateId string, userId string) (string, error) {
+	args := t.Called(ctx, templateId, userId)
+	return args.String(0), args.Error(1)
+}
+
+var testDays = []ProgramDay{
+	{ID: "day1", Week: 1, Day: 1, TemplateID: "push"},
+	{ID: "day2", Week: 1, Day: 2, TemplateID: "pull"},
+	{ID: "day3", Week: 2, Day: 1, TemplateID: "legs"},
+}
+
+func TestNextSession(t *testing.T) {
+	next, workoutId, completed := nextSession(testDays, []Progress{})
+	assert.Equal(t, "day1", next.ID)
+	assert.Equal(t, "", workoutId)
+	assert.Equal(t, 0, completed)
+
+	next, workoutId, completed = nextSession(testDays, []Progress{
+		{ProgramDayID: "day1", WorkoutID: "w1", CompletedOn: "2025-01-01T00:00:00Z"},
+		{ProgramDayID: "day2", WorkoutID: "w2"},
+	})
+	assert.Equal(t, "day2", next.ID)
+	assert.Equal(t, "w2", workoutId)
+	assert.Equal(t, 1, completed)
+
+	next, _, completed = nextSession(testDays, []Progress{
+		{ProgramDayID: "day1", CompletedOn: "2025-01-01T00:00:00Z"},
+		{ProgramDayID: "day2", CompletedOn: "2025-01-02T00:00:00Z"},
+		{ProgramDayID: "day3", CompletedOn: "2025-01-08T00:00:00Z"},
+	})
+	assert.Nil(t, next)
+	assert.Equal(t, 3, completed)
+}
+
+func TestGetById(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, repository.GetProgramByIdParams{ID: "programId", UserID: userId}).Return(Program{ID: "programId", Name: "PPL"}, nil).Once()
+	repoMock.On("GetDaysByProgramId", ctx, "programId", userId).Return(testDays, nil).Once()
+
+	service := NewService(&repoMock, nil, nil)
+
+	program, err := service.GetById(ctx, "programId", userId)
+
+	assert.Nil(t, err)
+	assert.Len(t, program.Weeks, 2)
+	assert.Len(t, program.Weeks[0].Days, 2)
+	assert.Equal(t, int64(2), program.Weeks[1].Week)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateAndReturnId(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+
+	templateMock := templateServiceMock{}
+	templateMock.On("GetById", ctx, "push", userId).Return(templates.TemplateWithExerciseItems{ID: "push"}, nil).Twice()
+	templateMock.On("GetById", ctx, "pull", userId).Return(templates.TemplateWithExerciseItems{ID: "pull"}, nil).Once()
+
+	repoMock := repoMock{}
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateProgramAndReturnIdParams) bool {
+		return input.Name == "531" && input.UserID == userId
+	})).Return("programId", nil).Once()
+	repoMock.On("CreateDayAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateProgramDayAndReturnIdParams) bool {
+		return input.Week == 1 && input.Day == 1 && input.TemplateID == "push" && input.ProgramID == "programId"
+	})).Return("day1", nil).Once()
+	repoMock.On("CreateDayAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateProgramDayAndReturnIdParams) bool {
+		return input.Week == 1 && input.Day == 2 && input.TemplateID == "pull"
+	})).Return("day2", nil).Once()
+	repoMock.On("CreateDayAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateProgramDayAndReturnIdParams) bool {
+		return input.Week == 2 && input.Day == 1 && input.TemplateID == "push"
+	})).Return("day3", nil).Once()
+
+	transactor := transactorStub{repos: Repositories{Programs: &repoMock, Templates: &templateMock}}
+	service := NewService(&repoMock, &templateMock, transactor.withTx)
+
+	id, err := service.CreateAndReturnId(ctx, createProgramRequest{
+		Name: "531",
+		Weeks: []programWeekRequest{
+			{Days: []programDayRequest{{TemplateID: "push"}, {TemplateID: "pull"}}},
+			{Days: []programDayRequest{{TemplateID: "push"}}},
+		},
+	}, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "programId", id)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+	templateMock.AssertExpectations(t)
+}
+
+func TestCreateAndReturnIdWithoutDays(t *testing.T) {
+	service := NewService(&repoMock{}, &templateServiceMock{}, nil)
+
+	_, err := service.CreateAndReturnId(context.Background(), createProgramRequest{Name: "531"}, "userId")
+
+	assert.NotNil(t, err)
+}
+
+func TestCreateAndReturnIdUnknownTemplate(t *testing.T) {
+	ctx := context.Background()
+
+	templateMock := templateServiceMock{}
+	templateMock.On("GetById", ctx, "missing", "userId").Return(templates.TemplateWithExerciseItems{}, sql.ErrNoRows).Once()
+
+	transactor := transactorStub{}
+	service := NewService(&repoMock{}, &templateMock, transactor.withTx)
+
+	_, err := service.CreateAndReturnId(ctx, createProgramRequest{
+		Name:  "531",
+		Weeks: []programWeekRequest{{Days: []programDayRequest{{TemplateID: "missing"}}}},
+	}, "userId")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Equal(t, 0, transactor.runs)
+	templateMock.AssertExpectations(t)
+}
+
+func TestEnrollAndReturnId(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(Program{ID: "programId"}, nil).Once()
+	repoMock.On("EndActiveEnrollments", ctx, mock.MatchedBy(func(input repository.EndActiveProgramEnrollmentsParams) bool {
+		return input.UserID == userId && input.EndedOn != ""
+	})).Return(nil).Once()
+	repoMock.On("CreateEnrollmentAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateProgramEnrollmentAndReturnIdParams) bool {
+		return input.ProgramID == "programId" && input.UserID == userId
+	})).Return("enrollmentId", nil).Once()
+
+	transactor := transactorStub{repos: Repositories{Programs: &repoMock}}
+	service := NewService(&repoMock, nil, transactor.withTx)
+
+	id, err := service.EnrollAndReturnId(ctx, "programId", userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "enrollmentId", id)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetTodaysSessionNoEnrollment(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetActiveEnrollment", ctx, "userId").Return(Enrollment{}, sql.ErrNoRows).Once()
+
+	service := NewService(&repoMock, nil, nil)
+
+	_, err := service.GetTodaysSession(ctx, "userId")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	repoMock.AssertExpectations(t)
+}
+
+func TestStartTodaysSessionAndReturnId(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+
+	repoMock := repoMock{}
+	repoMock.On("GetActiveEnrollment", ctx, userId).Return(Enrollment{ID: "enrollmentId", ProgramID: "programId"}, nil).Once()
+	repoMock.On("GetById", ctx, mock.Anything).Return(Program{ID: "programId", Name: "PPL"}, nil).Once()
+	repoMock.On("GetDaysByProgramId", ctx, "programId", userId).Return(testDays, nil).Once()
+	repoMock.On("GetProgressByEnrollmentId", ctx, "enrollmentId", userId).Return([]Progress{
+		{ProgramDayID: "day1", WorkoutID: "w1", CompletedOn: "2025-01-01T00:00:00Z"},
+	}, nil).Once()
+	repoMock.On("DeleteUnfinishedProgressByDayId", ctx, repository.DeleteUnfinishedProgramProgressByDayIdParams{
+		ProgramEnrollmentID: "enrollmentId",
+		ProgramDayID:        "day2",
+		UserID:              userId,
+	}).Return(nil).Once()
+	repoMock.On("CreateProgressAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateProgramProgressAndReturnIdParams) bool {
+		return input.ProgramDayID == "day2" && input.WorkoutID == "workoutId" && input.ProgramEnrollmentID == "enrollmentId" && input.ProgramID == "programId"
+	})).Return("progressId", nil).Once()
+
+	templateMock := templateServiceMock{}
+	templateMock.On("GetById", ctx, "pull", userId).Return(templates.TemplateWithExerciseItems{ID: "pull"}, nil).Once()
+	templateMock.On("StartWorkoutAndReturnId", ctx, "pull", userId).Return("workoutId", nil).Once()
+
+	transactor := transactorStub{repos: Repositories{Programs: &repoMock, Templates: &templateMock}}
+	service := NewService(&repoMock, &templateMock, transactor.withTx)
+
+	id, err := service.StartTodaysSessionAndReturnId(ctx, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "workoutId", id)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+	templateMock.AssertExpectations(t)
+}
+
+func TestStartTodaysSessionAndReturnIdProgressErr(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+
+	repoMock := repoMock{}
+	repoMock.On("GetActiveEnrollment", ctx, userId).Return(Enrollment{ID: "enrollmentId", ProgramID: "programId"}, nil).Once()
+	repoMock.On("GetById", ctx, mock.Anything).Return(Program{ID: "programId", Name: "PPL"}, nil).Once()
+	repoMock.On("GetDaysByProgramId", ctx, "programId", userId).Return(testDays, nil).Once()
+	repoMock.On("GetProgressByEnrollmentId", ctx, "enrollmentId", userId).Return([]Progress{}, nil).Once()
+	repoMock.On("DeleteUnfinishedProgressByDayId", ctx, mock.Anything).Return(nil).Once()
+	repoMock.On("CreateProgressAndReturnId", ctx, mock.Anything).Return("", testError).Once()
+
+	templateMock := templateServiceMock{}
+	templateMock.On("GetById", ctx, "push", userId).Return(templates.TemplateWithExerciseItems{ID: "push"}, nil).Once()
+	templateMock.On("StartWorkoutAndReturnId", ctx, "push", userId).Return("workoutId", nil).Once()
+
+	// The workout is started in the transaction of the progress, the error rolls both back
+	transactor := transactorStub{repos: Repositories{Programs: &repoMock, Templates: &templateMock}}
+	service := NewService(&repoMock, &templateMock, transactor.withTx)
+
+	_, err := service.StartTodaysSessionAndReturnId(ctx, userId)
+
+	assert.ErrorIs(t, err, testError)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+	templateMock.AssertExpectations(t)
+}
+
+func TestStartTodaysSessionAndReturnIdAlreadyStarted(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+
+	repoMock := repoMock{}
+	repoMock.On("GetActiveEnrollment", ctx, userId).Return(Enrollment{ID: "enrollmentId", ProgramID: "programId"}, nil).Once()
+	repoMock.On("GetById", ctx, mock.Anything).Return(Program{ID: "programId", Name: "PPL"}, nil).Once()
+	repoMock.On("GetDaysByProgramId", ctx, "programId", userId).Return(testDays, nil).Once()
+	repoMock.On("GetProgressByEnrollmentId", ctx, "enrollmentId", userId).Return([]Progress{
+		{ProgramDayID: "day1", WorkoutID: "w1"},
+	}, nil).Once()
+
+	templateMock := templateServiceMock{}
+	templateMock.On("GetById", ctx, "push", userId).Return(templates.TemplateWithExerciseItems{ID: "push"}, nil).Once()
+
+	transactor := transactorStub{}
+	service := NewService(&repoMock, &templateMock, transactor.withTx)
+
+	id, err := service.StartTodaysSessionAndReturnId(ctx, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "w1", id)
+	assert.Equal(t, 0, transactor.runs)
+	repoMock.AssertExpectations(t)
+	templateMock.AssertExpectations(t)
+}
+
+func TestDeleteById(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("DeleteById", ctx, "programId", "userId").Return(nil).Once()
+
+	transactor := transactorStub{repos: Repositories{Programs: &repoMock}}
+	service := NewService(nil, nil, transactor.withTx)
+
+	err := service.DeleteById(ctx, "programId", "userId")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestOnWorkoutCompletedNotInProgram(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("CompleteProgressByWorkoutId", ctx, mock.MatchedBy(func(input repository.CompleteProgramProgressByWorkoutIdParams) bool {
+		return input.WorkoutID == "workoutId" && input.UserID == "userId"
+	})).Return(int64(0), nil).Once()
+
+	service := NewService(&repoMock, nil, nil)
+
+	err := service.OnWorkoutCompleted(ctx, "workoutId", "userId")
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestOnWorkoutCompletedEndsFinishedEnrollment(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+
+	repoMock := repoMock{}
+	repoMock.On("CompleteProgressByWorkoutId", ctx, mock.Anything).Return(int64(1), nil).Once()
+	repoMock.On("GetActiveEnrollment", ctx, userId).Return(Enrollment{ID: "enrollmentId", ProgramID: "programId"}, nil).Once()
+	repoMock.On("GetDaysByProgramId", ctx, "programId", userId).Return(testDays, nil).Once()
+	repoMock.On("GetProgressByEnrollmentId", ctx, "enrollmentId", userId).Return([]Progress{
+		{ProgramDayID: "day1", CompletedOn: "2025-01-01T00:00:00Z"},
+		{ProgramDayID: "day2", CompletedOn: "2025-01-02T00:00:00Z"},
+		{ProgramDayID: "day3", CompletedOn: "2025-01-08T00:00:00Z"},
+	}, nil).Once()
+	repoMock.On("EndEnrollmentById", ctx, mock.MatchedBy(func(input repository.EndProgramEnrollmentByIdParams) bool {
+		return input.ID == "enrollmentId" && input.UserID == userId
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock, nil, nil)
+
+	err := service.OnWorkoutCompleted(ctx, "workoutId", userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestOnWorkoutCompletedRepoErr(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("CompleteProgressByWorkoutId", ctx, mock.Anything).Return(int64(0), testError).Once()
+
+	service := NewService(&repoMock, nil, nil)
+
+	err := service.OnWorkoutCompleted(ctx, "workoutId", "userId")
+
+	assert.ErrorIs(t, err, testError)
+	repoMock.AssertExpectations(t)
+}
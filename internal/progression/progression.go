@@ -0,0 +1,223 @@
+package progression
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	RuleLinear             = "linear"
+	RuleDoubleProgression  = "double_progression"
+	RulePercentTrainingMax = "percent_training_max"
+)
+
+var ErrNoHistory = errors.New("no history to base a suggestion on")
+
+var DefaultPlates = []float64{25, 20, 15, 10, 5, 2.5, 1.25}
+
+// Config describes how an exercise type should progress between sessions
+type Config struct {
+	Rule        string    `json:"rule"`
+	Increment   float64   `json:"increment"`
+	MinReps     int       `json:"min_reps"`
+	MaxReps     int       `json:"max_reps"`
+	TrainingMax float64   `json:"training_max"`
+	Percentage  float64   `json:"percentage"`
+	BarWeight   float64   `json:"bar_weight"`
+	Plates      []float64 `json:"plates"`
+}
+
+type Set struct {
+	Weight float64 `json:"weight"`
+	Reps   int     `json:"reps"`
+}
+
+// Session holds the sets performed for an exercise type in one completed workout
+type Session struct {
+	WorkoutID   string `json:"workout_id"`
+	CompletedOn string `json:"completed_on"`
+	Sets        []Set  `json:"sets"`
+}
+
+type Suggestion struct {
+	Rule   string  `json:"rule"`
+	Weight float64 `json:"weight"`
+	Reps   int     `json:"reps"`
+	Reason string  `json:"reason"`
+}
+
+// Rule suggests the next session from the configuration and the history,
+// most recent session first
+type Rule interface {
+	Suggest(config Config, history []Session) (Suggestion, error)
+}
+
+var rules = map[string]Rule{
+	RuleLinear:             linearRule{},
+	RuleDoubleProgression:  doubleProgressionRule{},
+	RulePercentTrainingMax: percentTrainingMaxRule{},
+}
+
+// Register makes a rule available under the given name
+func Register(name string, rule Rule) {
+	rules[name] = rule
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Rule:      RuleLinear,
+		Increment: 2.5,
+		MinReps:   5,
+		MaxReps:   5,
+		BarWeight: 20,
+		Plates:    DefaultPlates,
+	}
+}
+
+func (c Config) Validate() error {
+	if _, ok := rules[c.Rule]; !ok {
+		return fmt.Errorf("unknown progression rule: %s", c.Rule)
+	}
+	if c.Increment < 0 || c.TrainingMax < 0 || c.Percentage < 0 || c.BarWeight < 0 {
+		return fmt.Errorf("increment, training max, percentage and bar weight must not be negative")
+	}
+	if c.MinReps <= 0 || c.MaxReps < c.MinReps {
+		return fmt.Errorf("rep range must be positive with min reps not above max reps")
+	}
+	for _, plate := range c.Plates {
+		if plate <= 0 {
+			return fmt.Errorf("plates must be positive")
+		}
+	}
+	return nil
+}
+
+func Suggest(config Config, history []Session) (Suggestion, error) {
+	rule, ok := rules[config.Rule]
+	if !ok {
+		return Suggestion{}, fmt.Errorf("unknown progression rule: %s", config.Rule)
+	}
+
+	suggestion, err := rule.Suggest(config, history)
+	if err != nil {
+		return Suggestion{}, err
+	}
+	suggestion.Rule = config.Rule
+	return suggestion, nil
+}
+
+// RoundToPlates rounds the weight to the nearest load that can be put on the
+// bar, assuming plates come in pairs and are multiples of the smallest plate
+func RoundToPlates(weight float64, barWeight float64, plates []float64) float64 {
+	if len(plates) == 0 {
+		return weight
+	}
+	if weight <= barWeight {
+		return barWeight
+	}
+
+	sorted := append([]float64{}, plates...)
+	sort.Float64s(sorted)
+	step := sorted[0] * 2
+
+	return barWeight + math.Round((weight-barWeight)/step)*step
+}
+
+// topSets returns the heaviest weight of the session and the sets done with it
+func topSets(session Session) (float64, []Set) {
+	top := 0.0
+	for _, set := range session.Sets {
+		top = math.Max(top, set.Weight)
+	}
+
+	result := []Set{}
+	for _, set := range session.Sets {
+		if set.Weight == top {
+			result = append(result, set)
+		}
+	}
+	return top, result
+}
+
+func minReps(sets []Set) int {
+	result := math.MaxInt
+	for _, set := range sets {
+		result = min(result, set.Reps)
+	}
+	return result
+}
+
+func lastSession(history []Session) (Session, error) {
+	if len(history) == 0 || len(history[0].Sets) == 0 {
+		return Session{}, ErrNoHistory
+	}
+	return history[0], nil
+}
+
+// linearRule adds the increment every time all top sets reach the target reps
+type linearRule struct{}
+
+func (linearRule) Suggest(config Config, history []Session) (Suggestion, error) {
+	last, err := lastSession(history)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	weight, sets := topSets(last)
+	if minReps(sets) >= config.MaxReps {
+		return Suggestion{
+			Weight: weight + config.Increment,
+			Reps:   config.MaxReps,
+			Reason: "All sets reached the target reps, increase the weight",
+		}, nil
+	}
+
+	return Suggestion{
+		Weight: weight,
+		Reps:   config.MaxReps,
+		Reason: "Target reps were missed, repeat the weight",
+	}, nil
+}
+
+// doubleProgressionRule adds reps up to the top of the range before adding weight
+type doubleProgressionRule struct{}
+
+func (doubleProgressionRule) Suggest(config Config, history []Session) (Suggestion, error) {
+	last, err := lastSession(history)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	weight, sets := topSets(last)
+	reps := minReps(sets)
+	if reps >= config.MaxReps {
+		return Suggestion{
+			Weight: weight + config.Increment,
+			Reps:   config.MinReps,
+			Reason: "Top of the rep range reached, increase the weight and start over at the bottom",
+		}, nil
+	}
+
+	return Suggestion{
+		Weight: weight,
+		Reps:   max(config.MinReps, reps+1),
+		Reason: "Add a rep before increasing the weight",
+	}, nil
+}
+
+// percentTrainingMaxRule prescribes a percentage of the training max
+type percentTrainingMaxRule struct{}
+
+func (percentTrainingMaxRule) Suggest(config Config, history []Session) (Suggestion, error) {
+	if config.TrainingMax <= 0 || config.Percentage <= 0 {
+		return Suggestion{}, fmt.Errorf("training max and percentage are required")
+	}
+
+	return Suggestion{
+		Weight: RoundToPlates(config.TrainingMax*config.Percentage/100, config.BarWeight, config.Plates),
+		Reps:   config.MaxReps,
+		Reason: fmt.Sprintf("%g%% of the training max rounded to available plates", config.Percentage),
+	}, nil
+}
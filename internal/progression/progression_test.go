@@ -0,0 +1,102 @@
+package progression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinearIncreasesWhenAllSetsReachTarget(t *testing.T) {
+	config := DefaultConfig()
+	history := []Session{
+		{WorkoutID: "b", Sets: []Set{{Weight: 60, Reps: 10}, {Weight: 100, Reps: 5}, {Weight: 100, Reps: 5}}},
+		{WorkoutID: "a", Sets: []Set{{Weight: 97.5, Reps: 5}}},
+	}
+
+	result, err := Suggest(config, history)
+
+	assert.Nil(t, err)
+	assert.Equal(t, RuleLinear, result.Rule)
+	assert.Equal(t, 102.5, result.Weight)
+	assert.Equal(t, 5, result.Reps)
+}
+
+func TestLinearRepeatsWhenRepsMissed(t *testing.T) {
+	config := DefaultConfig()
+	history := []Session{
+		{WorkoutID: "a", Sets: []Set{{Weight: 100, Reps: 5}, {Weight: 100, Reps: 3}}},
+	}
+
+	result, err := Suggest(config, history)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 100.0, result.Weight)
+	assert.Equal(t, 5, result.Reps)
+}
+
+func TestLinearNoHistory(t *testing.T) {
+	_, err := Suggest(DefaultConfig(), []Session{})
+
+	assert.ErrorIs(t, err, ErrNoHistory)
+}
+
+func TestDoubleProgressionAddsRep(t *testing.T) {
+	config := Config{Rule: RuleDoubleProgression, Increment: 2.5, MinReps: 8, MaxReps: 12}
+	history := []Session{
+		{WorkoutID: "a", Sets: []Set{{Weight: 40, Reps: 10}, {Weight: 40, Reps: 9}}},
+	}
+
+	result, err := Suggest(config, history)
+
+	assert.Nil(t, err)
+	assert.Equal(t, RuleDoubleProgression, result.Rule)
+	assert.Equal(t, 40.0, result.Weight)
+	assert.Equal(t, 10, result.Reps)
+}
+
+func TestDoubleProgressionIncreasesWeightAtTopOfRange(t *testing.T) {
+	config := Config{Rule: RuleDoubleProgression, Increment: 2.5, MinReps: 8, MaxReps: 12}
+	history := []Session{
+		{WorkoutID: "a", Sets: []Set{{Weight: 40, Reps: 12}, {Weight: 40, Reps: 12}}},
+	}
+
+	result, err := Suggest(config, history)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 42.5, result.Weight)
+	assert.Equal(t, 8, result.Reps)
+}
+
+func TestPercentTrainingMaxRoundsToPlates(t *testing.T) {
+	config := Config{Rule: RulePercentTrainingMax, MinReps: 5, MaxReps: 5, TrainingMax: 143, Percentage: 85, BarWeight: 20, Plates: DefaultPlates}
+
+	result, err := Suggest(config, []Session{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, RulePercentTrainingMax, result.Rule)
+	assert.Equal(t, 122.5, result.Weight)
+	assert.Equal(t, 5, result.Reps)
+}
+
+func TestPercentTrainingMaxRequiresTrainingMax(t *testing.T) {
+	config := Config{Rule: RulePercentTrainingMax, MinReps: 5, MaxReps: 5, Percentage: 85}
+
+	_, err := Suggest(config, []Session{})
+
+	assert.NotNil(t, err)
+}
+
+func TestRoundToPlates(t *testing.T) {
+	assert.Equal(t, 20.0, RoundToPlates(15, 20, DefaultPlates))
+	assert.Equal(t, 102.5, RoundToPlates(101.9, 20, DefaultPlates))
+	assert.Equal(t, 100.0, RoundToPlates(101, 20, []float64{20, 5}))
+	assert.Equal(t, 101.3, RoundToPlates(101.3, 20, []float64{}))
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, DefaultConfig().Validate())
+	assert.NotNil(t, Config{Rule: "unknown", MinReps: 5, MaxReps: 5}.Validate())
+	assert.NotNil(t, Config{Rule: RuleLinear, MinReps: 8, MaxReps: 5}.Validate())
+	assert.NotNil(t, Config{Rule: RuleLinear, MinReps: 5, MaxReps: 5, Increment: -1}.Validate())
+	assert.NotNil(t, Config{Rule: RuleLinear, MinReps: 5, MaxReps: 5, Plates: []float64{0}}.Validate())
+}
@@ -0,0 +1,105 @@
+package records
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"weight-tracker/internal/database"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/units"
+	"weight-tracker/internal/utils"
+)
+
+type handler struct {
+	service Service
+	units   unitPreferences
+}
+
+// Weights are stored in kilograms, requests and responses use the unit of the user
+type unitPreferences interface {
+	GetWeightUnit(ctx context.Context, userId string) (string, error)
+}
+
+func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
+	handler := handler{
+		service: NewServiceFromDatabase(s),
+		units:   preferences.NewServiceFromDatabase(s),
+	}
+
+	mux.Handle("GET /records", authenticationWrapper(http.HandlerFunc(handler.getAllRecordsHandler)))
+	mux.Handle("GET /workouts/{id}/records", authenticationWrapper(http.HandlerFunc(handler.getRecordsByWorkoutIdHandler)))
+}
+
+// NewServiceFromDatabase wires the record service from the database service
+func NewServiceFromDatabase(s database.Service) Service {
+	return NewService(NewRecordRepository(s.GetRepository()))
+}
+
+// convertRecord converts the weights of the record to the unit, the value of a
+// reps record counts repetitions and is left as it is
+func convertRecord(record Record, unit string) Record {
+	record.Weight = units.FromKilograms(record.Weight, unit)
+	if record.Kind != KindMaxRepsAtWeight {
+		record.Value = units.FromKilograms(record.Value, unit)
+	}
+	return record
+}
+
+func (h *handler) getAllRecordsHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	records, err := h.service.GetAll(r.Context(), userId)
+	if err != nil {
+		slog.Error("Failed to get records", "error", err)
+		http.Error(w, "Failed to get records", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get records", http.StatusBadRequest)
+		return
+	}
+	for i, v := range records {
+		records[i] = convertRecord(v, unit)
+	}
+
+	jsonResp, err := utils.CreateResponse(records)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) getRecordsByWorkoutIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	workoutId := r.PathValue("id")
+
+	records, err := h.service.GetByWorkoutId(r.Context(), workoutId, userId)
+	if err != nil {
+		slog.Error("Failed to get records for workout", "error", err, "workoutId", workoutId)
+		http.Error(w, "Failed to get records", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get records", http.StatusBadRequest)
+		return
+	}
+	for i, v := range records {
+		records[i] = convertRecord(v, unit)
+	}
+
+	jsonResp, err := utils.CreateResponse(records)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
@@ -0,0 +1,188 @@
+package records
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"weight-tracker/internal/units"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func populateContextWithSub(req *http.Request, userId string) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, "sub", userId)
+	return req.WithContext(ctx)
+}
+
+type serviceMock struct {
+	mock.Mock
+}
+
+func (s *serviceMock) GetAll(ctx context.Context, userId string) ([]Record, error) {
+	args := s.Called(ctx, userId)
+	return args.Get(0).([]Record), args.Error(1)
+}
+
+func (s *serviceMock) GetByWorkoutId(ctx context.Context, workoutId string, userId string) ([]Record, error) {
+	args := s.Called(ctx, workoutId, userId)
+	return args.Get(0).([]Record), args.Error(1)
+}
+
+func (s *serviceMock) OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error {
+	args := s.Called(ctx, workoutId, userId)
+	return args.Error(0)
+}
+
+type unitsStub struct {
+	unit string
+}
+
+func (u unitsStub) GetWeightUnit(ctx context.Context, userId string) (string, error) {
+	return u.unit, nil
+}
+
+func TestGetAllRecordsHandler(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/records", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetAll", req.Context(), userId).Return([]Record{
+		{ID: "a", Kind: KindMaxWeight, Value: 100, Weight: 100, Reps: 1, AchievedOn: "2025-01-01T10:00:00Z", ExerciseTypeID: "bench", ExerciseTypeName: "Bench", WorkoutID: "w"},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.getAllRecordsHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":[{"id":"a","kind":"max_weight","value":100,"weight":100,"reps":1,"achieved_on":"2025-01-01T10:00:00Z","exercise_type_id":"bench","exercise_type_name":"Bench","workout_id":"w"}]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetAllRecordsHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/records", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetAll", req.Context(), userId).Return([]Record{
+		{ID: "a", Kind: KindMaxWeight, Value: 100, Weight: 100, Reps: 1, AchievedOn: "2025-01-01T10:00:00Z", ExerciseTypeID: "bench", ExerciseTypeName: "Bench", WorkoutID: "w"},
+		{ID: "b", Kind: KindMaxRepsAtWeight, Value: 5, Weight: 100, Reps: 5, AchievedOn: "2025-01-01T10:00:00Z", ExerciseTypeID: "bench", ExerciseTypeName: "Bench", WorkoutID: "w"},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(h.getAllRecordsHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":[{"id":"a","kind":"max_weight","value":220.46,"weight":220.46,"reps":1,"achieved_on":"2025-01-01T10:00:00Z","exercise_type_id":"bench","exercise_type_name":"Bench","workout_id":"w"},` +
+		`{"id":"b","kind":"max_reps_at_weight","value":5,"weight":220.46,"reps":5,"achieved_on":"2025-01-01T10:00:00Z","exercise_type_id":"bench","exercise_type_name":"Bench","workout_id":"w"}]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetRecordsByWorkoutIdHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+
+	req, err := http.NewRequest("GET", "/workouts/"+workoutId+"/records", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", workoutId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetByWorkoutId", req.Context(), workoutId, userId).Return([]Record{
+		{ID: "a", Kind: KindSessionVolume, Value: 1000, AchievedOn: "2025-01-01T10:00:00Z", ExerciseTypeID: "bench", ExerciseTypeName: "Bench", WorkoutID: workoutId},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(h.getRecordsByWorkoutIdHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":[{"id":"a","kind":"session_volume","value":2204.62,"weight":0,"reps":0,"achieved_on":"2025-01-01T10:00:00Z","exercise_type_id":"bench","exercise_type_name":"Bench","workout_id":"workoutId"}]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetRecordsByWorkoutIdHandler(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+
+	req, err := http.NewRequest("GET", "/workouts/"+workoutId+"/records", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", workoutId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetByWorkoutId", req.Context(), workoutId, userId).Return([]Record{}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(h.getRecordsByWorkoutIdHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":[]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetRecordsByWorkoutIdHandlerErr(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+
+	req, err := http.NewRequest("GET", "/workouts/"+workoutId+"/records", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", workoutId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetByWorkoutId", req.Context(), workoutId, userId).Return([]Record{}, testError).Once()
+
+	rr := httptest.NewRecorder()
+	h := handler{service: &serviceMock}
+	http.HandlerFunc(h.getRecordsByWorkoutIdHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+	serviceMock.AssertExpectations(t)
+}
@@ -0,0 +1,35 @@
+package records
+
+const (
+	KindMaxWeight       = "max_weight"
+	KindMaxRepsAtWeight = "max_reps_at_weight"
+	KindE1RM            = "e1rm"
+	KindSessionVolume   = "session_volume"
+)
+
+type Record struct {
+	ID               string  `json:"id"`
+	Kind             string  `json:"kind"`
+	Value            float64 `json:"value"`
+	Weight           float64 `json:"weight"`
+	Reps             int     `json:"reps"`
+	AchievedOn       string  `json:"achieved_on"`
+	ExerciseTypeID   string  `json:"exercise_type_id"`
+	ExerciseTypeName string  `json:"exercise_type_name"`
+	WorkoutID        string  `json:"workout_id"`
+}
+
+// Set is a set performed in a workout, used when looking for new records
+type Set struct {
+	WorkoutID      string
+	ExerciseTypeID string
+	Weight         float64
+	Reps           int
+	// Bodyweight of the workout for bodyweight exercises, zero for other exercises
+	Bodyweight float64
+}
+
+// Load is the weight that was moved, the added weight on top of the bodyweight
+func (s Set) Load() float64 {
+	return s.Weight + s.Bodyweight
+}
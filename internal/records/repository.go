@@ -0,0 +1,138 @@
+package records
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"weight-tracker/internal/repository"
+)
+
+type RecordRepository interface {
+	GetAll(ctx context.Context, userId string) ([]Record, error)
+	GetByWorkoutId(ctx context.Context, arg repository.GetRecordsByWorkoutIdParams) ([]Record, error)
+	GetSetsByWorkoutId(ctx context.Context, arg repository.GetSetsForRecordsByWorkoutIdParams) ([]Set, error)
+	GetPreviousSetsByExerciseTypeId(ctx context.Context, arg repository.GetPreviousSetsForRecordsByExerciseTypeIdParams) ([]Set, error)
+	GetWorkoutCompletedOn(ctx context.Context, workoutId string, userId string) (time.Time, error)
+	CreateAndReturnId(ctx context.Context, arg repository.CreateRecordAndReturnIdParams) (string, error)
+	DeleteByWorkoutId(ctx context.Context, arg repository.DeleteRecordsByWorkoutIdParams) error
+}
+
+func NewRecordRepository(repo repository.Querier) RecordRepository {
+	return recordRepository{repo: repo}
+}
+
+type recordRepository struct {
+	repo repository.Querier
+}
+
+func (r recordRepository) GetAll(ctx context.Context, userId string) ([]Record, error) {
+	records, err := r.repo.GetAllRecords(ctx, userId)
+	if err != nil {
+		return []Record{}, fmt.Errorf("failed to get all records: %w", err)
+	}
+
+	result := []Record{}
+	for _, v := range records {
+		result = append(result, Record{
+			ID:               v.ID,
+			Kind:             v.Kind,
+			Value:            v.Value,
+			Weight:           v.Weight,
+			Reps:             int(v.Repetitions),
+			AchievedOn:       v.AchievedOn,
+			ExerciseTypeID:   v.ExerciseTypeID,
+			ExerciseTypeName: v.ExerciseTypeName,
+			WorkoutID:        v.WorkoutID,
+		})
+	}
+	return result, nil
+}
+
+func (r recordRepository) GetByWorkoutId(ctx context.Context, arg repository.GetRecordsByWorkoutIdParams) ([]Record, error) {
+	records, err := r.repo.GetRecordsByWorkoutId(ctx, arg)
+	if err != nil {
+		return []Record{}, fmt.Errorf("failed to get records by workout id: %w", err)
+	}
+
+	result := []Record{}
+	for _, v := range records {
+		result = append(result, Record{
+			ID:               v.ID,
+			Kind:             v.Kind,
+			Value:            v.Value,
+			Weight:           v.Weight,
+			Reps:             int(v.Repetitions),
+			AchievedOn:       v.AchievedOn,
+			ExerciseTypeID:   v.ExerciseTypeID,
+			ExerciseTypeName: v.ExerciseTypeName,
+			WorkoutID:        v.WorkoutID,
+		})
+	}
+	return result, nil
+}
+
+func (r recordRepository) GetSetsByWorkoutId(ctx context.Context, arg repository.GetSetsForRecordsByWorkoutIdParams) ([]Set, error) {
+	sets, err := r.repo.GetSetsForRecordsByWorkoutId(ctx, arg)
+	if err != nil {
+		return []Set{}, fmt.Errorf("failed to get sets by workout id: %w", err)
+	}
+
+	result := []Set{}
+	for _, v := range sets {
+		result = append(result, Set{
+			ExerciseTypeID: v.ExerciseTypeID,
+			Weight:         v.Weight,
+			Reps:           int(v.Repetitions),
+			Bodyweight:     v.Bodyweight,
+		})
+	}
+	return result, nil
+}
+
+// GetPreviousSetsByExerciseTypeId returns the sets of the exercise type in the
+// workouts completed before the given workout, ordered by workout
+func (r recordRepository) GetPreviousSetsByExerciseTypeId(ctx context.Context, arg repository.GetPreviousSetsForRecordsByExerciseTypeIdParams) ([]Set, error) {
+	sets, err := r.repo.GetPreviousSetsForRecordsByExerciseTypeId(ctx, arg)
+	if err != nil {
+		return []Set{}, fmt.Errorf("failed to get previous sets: %w", err)
+	}
+
+	result := []Set{}
+	for _, v := range sets {
+		result = append(result, Set{
+			WorkoutID:      v.WorkoutID,
+			ExerciseTypeID: v.ExerciseTypeID,
+			Weight:         v.Weight,
+			Reps:           int(v.Repetitions),
+			Bodyweight:     v.Bodyweight,
+		})
+	}
+	return result, nil
+}
+
+func (r recordRepository) GetWorkoutCompletedOn(ctx context.Context, workoutId string, userId string) (time.Time, error) {
+	completedOn, err := r.repo.GetWorkoutCompletedOn(ctx, repository.GetWorkoutCompletedOnParams{
+		ID:     workoutId,
+		UserID: userId,
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get workout completion: %w", err)
+	}
+	return time.Parse(time.RFC3339, completedOn)
+}
+
+func (r recordRepository) CreateAndReturnId(ctx context.Context, arg repository.CreateRecordAndReturnIdParams) (string, error) {
+	id, err := r.repo.CreateRecordAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create record: %w", err)
+	}
+	return id, nil
+}
+
+func (r recordRepository) DeleteByWorkoutId(ctx context.Context, arg repository.DeleteRecordsByWorkoutIdParams) error {
+	_, err := r.repo.DeleteRecordsByWorkoutId(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to delete records by workout id: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package records
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/strength"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	GetAll(ctx context.Context, userId string) ([]Record, error)
+	GetByWorkoutId(ctx context.Context, workoutId string, userId string) ([]Record, error)
+	OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error
+}
+
+type recordService struct {
+	repo RecordRepository
+}
+
+func NewService(repo RecordRepository) Service {
+	return &recordService{repo: repo}
+}
+
+func (s *recordService) GetAll(ctx context.Context, userId string) ([]Record, error) {
+	return s.repo.GetAll(ctx, userId)
+}
+
+func (s *recordService) GetByWorkoutId(ctx context.Context, workoutId string, userId string) ([]Record, error) {
+	return s.repo.GetByWorkoutId(ctx, repository.GetRecordsByWorkoutIdParams{
+		WorkoutID: workoutId,
+		UserID:    userId,
+	})
+}
+
+// OnWorkoutCompleted compares the sets of the workout with the sets of the
+// workouts completed before it and stores the records that were beaten. Records
+// from an earlier completion of the same workout are replaced, so completing it
+// again after a reopen does not produce duplicates.
+func (s *recordService) OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error {
+	err := s.repo.DeleteByWorkoutId(ctx, repository.DeleteRecordsByWorkoutIdParams{
+		WorkoutID: workoutId,
+		UserID:    userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete previous records for workout: %w", err)
+	}
+
+	sets, err := s.repo.GetSetsByWorkoutId(ctx, repository.GetSetsForRecordsByWorkoutIdParams{
+		WorkoutID: workoutId,
+		UserID:    userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get sets for workout: %w", err)
+	}
+
+	exerciseTypeIds := []string{}
+	setsByExerciseType := map[string][]Set{}
+	for _, set := range sets {
+		if _, ok := setsByExerciseType[set.ExerciseTypeID]; !ok {
+			exerciseTypeIds = append(exerciseTypeIds, set.ExerciseTypeID)
+		}
+		setsByExerciseType[set.ExerciseTypeID] = append(setsByExerciseType[set.ExerciseTypeID], set)
+	}
+
+	if len(exerciseTypeIds) == 0 {
+		return nil
+	}
+
+	completedOn, err := s.repo.GetWorkoutCompletedOn(ctx, workoutId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to get workout completion: %w", err)
+	}
+	achievedOn := completedOn.UTC().Format(time.RFC3339)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, exerciseTypeId := range exerciseTypeIds {
+		previousSets, err := s.repo.GetPreviousSetsByExerciseTypeId(ctx, repository.GetPreviousSetsForRecordsByExerciseTypeIdParams{
+			ExerciseTypeID: exerciseTypeId,
+			WorkoutID:      workoutId,
+			UserID:         userId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get previous sets: %w", err)
+		}
+
+		for _, record := range detect(setsByExerciseType[exerciseTypeId], history(previousSets)) {
+			uuid, err := uuid.NewV7()
+			if err != nil {
+				return fmt.Errorf("failed to generate UUID: %w", err)
+			}
+
+			_, err = s.repo.CreateAndReturnId(ctx, repository.CreateRecordAndReturnIdParams{
+				ID:             uuid.String(),
+				Kind:           record.Kind,
+				Value:          record.Value,
+				Weight:         record.Weight,
+				Repetitions:    int64(record.Reps),
+				AchievedOn:     achievedOn,
+				CreatedOn:      now,
+				UpdatedOn:      now,
+				UserID:         userId,
+				ExerciseTypeID: exerciseTypeId,
+				WorkoutID:      workoutId,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create record: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// history replays the previous sets workout by workout and returns the records
+// they set, so workouts from before records were tracked count as well
+func history(sets []Set) []Record {
+	result := []Record{}
+	for start := 0; start < len(sets); {
+		end := start
+		for end < len(sets) && sets[end].WorkoutID == sets[start].WorkoutID {
+			end++
+		}
+		result = append(result, detect(sets[start:end], result)...)
+		start = end
+	}
+	return result
+}
+
+// detect returns the records beaten by the sets of one exercise type in a workout
+func detect(sets []Set, previous []Record) []Record {
+	valid := []Set{}
+	for _, set := range sets {
+		if set.Load() > 0 && set.Reps > 0 {
+			valid = append(valid, set)
+		}
+	}
+	if len(valid) == 0 {
+		return []Record{}
+	}
+
+	result := []Record{}
+
+	heaviest := valid[0]
+	for _, set := range valid {
+		if set.Weight > heaviest.Weight || (set.Weight == heaviest.Weight && set.Reps > heaviest.Reps) {
+			heaviest = set
+		}
+	}
+	if heaviest.Weight > best(previous, KindMaxWeight) {
+		result = append(result, Record{Kind: KindMaxWeight, Value: heaviest.Weight, Weight: heaviest.Weight, Reps: heaviest.Reps})
+	}
+
+	result = append(result, repRecords(valid, previous)...)
+
+	bestE1RM := 0.0
+	bestE1RMSet := valid[0]
+	for _, set := range valid {
+		e1rm, _ := strength.EstimateOneRepMax(strength.FormulaEpley, set.Load(), set.Reps)
+		e1rm = math.Round(e1rm*100) / 100
+		if e1rm > bestE1RM {
+			bestE1RM = e1rm
+			bestE1RMSet = set
+		}
+	}
+	if bestE1RM > best(previous, KindE1RM) {
+		result = append(result, Record{Kind: KindE1RM, Value: bestE1RM, Weight: bestE1RMSet.Weight, Reps: bestE1RMSet.Reps})
+	}
+
+	volume := 0.0
+	for _, set := range valid {
+		volume += set.Load() * float64(set.Reps)
+	}
+	if volume > best(previous, KindSessionVolume) {
+		result = append(result, Record{Kind: KindSessionVolume, Value: volume})
+	}
+
+	return result
+}
+
+// repRecords returns the weights where more reps were done than ever before.
+// Reps at a weight only count when no heavier weight has been done for as many reps.
+func repRecords(sets []Set, previous []Record) []Record {
+	mostReps := map[float64]int{}
+	for _, set := range sets {
+		mostReps[set.Weight] = max(mostReps[set.Weight], set.Reps)
+	}
+
+	weights := []float64{}
+	for weight := range mostReps {
+		weights = append(weights, weight)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(weights)))
+
+	beaten := []Record{}
+	for _, record := range previous {
+		if record.Kind == KindMaxRepsAtWeight {
+			beaten = append(beaten, record)
+		}
+	}
+
+	result := []Record{}
+	for _, weight := range weights {
+		reps := mostReps[weight]
+		dominated := false
+		for _, record := range beaten {
+			if record.Weight >= weight && record.Reps >= reps {
+				dominated = true
+				break
+			}
+		}
+		if dominated {
+			continue
+		}
+
+		record := Record{Kind: KindMaxRepsAtWeight, Value: float64(reps), Weight: weight, Reps: reps}
+		beaten = append(beaten, record)
+		result = append(result, record)
+	}
+	return result
+}
+
+func best(records []Record, kind string) float64 {
+	result := 0.0
+	for _, record := range records {
+		if record.Kind == kind {
+			result = math.Max(result, record.Value)
+		}
+	}
+	return result
+}
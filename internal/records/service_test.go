@@ -0,0 +1,179 @@
+package records
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"weight-tracker/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testError = errors.New("Testerror")
+
+type repoMock struct {
+	mock.Mock
+}
+
+func (r *repoMock) GetAll(ctx context.Context, userId string) ([]Record, error) {
+	args := r.Called(ctx, userId)
+	return args.Get(0).([]Record), args.Error(1)
+}
+
+func (r *repoMock) GetByWorkoutId(ctx context.Context, arg repository.GetRecordsByWorkoutIdParams) ([]Record, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]Record), args.Error(1)
+}
+
+func (r *repoMock) GetSetsByWorkoutId(ctx context.Context, arg repository.GetSetsForRecordsByWorkoutIdParams) ([]Set, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]Set), args.Error(1)
+}
+
+func (r *repoMock) GetPreviousSetsByExerciseTypeId(ctx context.Context, arg repository.GetPreviousSetsForRecordsByExerciseTypeIdParams) ([]Set, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]Set), args.Error(1)
+}
+
+func (r *repoMock) GetWorkoutCompletedOn(ctx context.Context, workoutId string, userId string) (time.Time, error) {
+	args := r.Called(ctx, workoutId, userId)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (r *repoMock) CreateAndReturnId(ctx context.Context, arg repository.CreateRecordAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) DeleteByWorkoutId(ctx context.Context, arg repository.DeleteRecordsByWorkoutIdParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func TestDetectWithoutPreviousRecords(t *testing.T) {
+	sets := []Set{
+		{Weight: 100, Reps: 5},
+		{Weight: 100, Reps: 4},
+		{Weight: 80, Reps: 8},
+	}
+
+	result := detect(sets, []Record{})
+
+	assert.Equal(t, []Record{
+		{Kind: KindMaxWeight, Value: 100, Weight: 100, Reps: 5},
+		{Kind: KindMaxRepsAtWeight, Value: 5, Weight: 100, Reps: 5},
+		{Kind: KindMaxRepsAtWeight, Value: 8, Weight: 80, Reps: 8},
+		{Kind: KindE1RM, Value: 116.67, Weight: 100, Reps: 5},
+		{Kind: KindSessionVolume, Value: 1540},
+	}, result)
+}
+
+func TestDetectOnlyBeatenRecords(t *testing.T) {
+	sets := []Set{
+		{Weight: 100, Reps: 6},
+		{Weight: 90, Reps: 5},
+	}
+	previous := []Record{
+		{Kind: KindMaxWeight, Value: 110, Weight: 110, Reps: 1},
+		{Kind: KindMaxRepsAtWeight, Value: 5, Weight: 100, Reps: 5},
+		{Kind: KindE1RM, Value: 125, Weight: 110, Reps: 4},
+		{Kind: KindSessionVolume, Value: 2000},
+	}
+
+	result := detect(sets, previous)
+
+	assert.Equal(t, []Record{
+		{Kind: KindMaxRepsAtWeight, Value: 6, Weight: 100, Reps: 6},
+	}, result)
+}
+
+func TestDetectCountsBodyweight(t *testing.T) {
+	sets := []Set{
+		{Weight: 10, Reps: 5, Bodyweight: 80},
+		{Weight: 0, Reps: 8, Bodyweight: 80},
+	}
+
+	result := detect(sets, []Record{})
+
+	assert.Equal(t, []Record{
+		{Kind: KindMaxWeight, Value: 10, Weight: 10, Reps: 5},
+		{Kind: KindMaxRepsAtWeight, Value: 5, Weight: 10, Reps: 5},
+		{Kind: KindMaxRepsAtWeight, Value: 8, Weight: 0, Reps: 8},
+		{Kind: KindE1RM, Value: 105, Weight: 10, Reps: 5},
+		{Kind: KindSessionVolume, Value: 1090},
+	}, result)
+}
+
+func TestDetectIgnoresEmptySets(t *testing.T) {
+	result := detect([]Set{{Weight: 0, Reps: 10}, {Weight: 50, Reps: 0}}, []Record{})
+
+	assert.Equal(t, []Record{}, result)
+}
+
+func TestHistory(t *testing.T) {
+	sets := []Set{
+		{WorkoutID: "first", Weight: 100, Reps: 5},
+		{WorkoutID: "second", Weight: 90, Reps: 5},
+		{WorkoutID: "second", Weight: 110, Reps: 1},
+	}
+
+	result := history(sets)
+
+	assert.Equal(t, []Record{
+		{Kind: KindMaxWeight, Value: 100, Weight: 100, Reps: 5},
+		{Kind: KindMaxRepsAtWeight, Value: 5, Weight: 100, Reps: 5},
+		{Kind: KindE1RM, Value: 116.67, Weight: 100, Reps: 5},
+		{Kind: KindSessionVolume, Value: 500},
+		{Kind: KindMaxWeight, Value: 110, Weight: 110, Reps: 1},
+		{Kind: KindMaxRepsAtWeight, Value: 1, Weight: 110, Reps: 1},
+		{Kind: KindSessionVolume, Value: 560},
+	}, result)
+}
+
+func TestOnWorkoutCompleted(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+	workoutId := "workoutId"
+
+	repoMock := repoMock{}
+	repoMock.On("DeleteByWorkoutId", ctx, repository.DeleteRecordsByWorkoutIdParams{WorkoutID: workoutId, UserID: userId}).Return(nil).Once()
+	repoMock.On("GetSetsByWorkoutId", ctx, repository.GetSetsForRecordsByWorkoutIdParams{WorkoutID: workoutId, UserID: userId}).Return([]Set{
+		{ExerciseTypeID: "bench", Weight: 100, Reps: 1},
+	}, nil).Once()
+	repoMock.On("GetWorkoutCompletedOn", ctx, workoutId, userId).Return(time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC), nil).Once()
+	repoMock.On("GetPreviousSetsByExerciseTypeId", ctx, repository.GetPreviousSetsForRecordsByExerciseTypeIdParams{
+		ExerciseTypeID: "bench",
+		WorkoutID:      workoutId,
+		UserID:         userId,
+	}).Return([]Set{
+		{WorkoutID: "earlier", ExerciseTypeID: "bench", Weight: 95, Reps: 3},
+	}, nil).Once()
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateRecordAndReturnIdParams) bool {
+		return input.ID != "" && input.Kind == KindMaxWeight && input.Value == 100 && input.AchievedOn == "2024-03-01T18:00:00Z" &&
+			input.ExerciseTypeID == "bench" && input.WorkoutID == workoutId && input.UserID == userId
+	})).Return("recordId", nil).Once()
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateRecordAndReturnIdParams) bool {
+		return input.Kind == KindMaxRepsAtWeight && input.Weight == 100 && input.Repetitions == 1 && input.AchievedOn == "2024-03-01T18:00:00Z"
+	})).Return("recordId", nil).Once()
+
+	service := NewService(&repoMock)
+	err := service.OnWorkoutCompleted(ctx, workoutId, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestOnWorkoutCompletedDeleteErr(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("DeleteByWorkoutId", ctx, mock.Anything).Return(testError).Once()
+
+	service := NewService(&repoMock)
+	err := service.OnWorkoutCompleted(ctx, "workoutId", "userId")
+
+	assert.ErrorIs(t, err, testError)
+	repoMock.AssertNotCalled(t, "GetSetsByWorkoutId", mock.Anything, mock.Anything)
+}
@@ -11,28 +11,36 @@ import (
 
 const createExerciseItemAndReturnId = `-- name: CreateExerciseItemAndReturnId :one
 INSERT INTO exercise_items (
-  id, type, user_id, workout_id, created_on, updated_on
+  id, type, rounds, interval_seconds, time_cap_seconds, position, user_id, workout_id, created_on, updated_on
 ) VALUES (
-  ?1, ?2, ?3, ?4, ?5, ?6
+  ?1, ?2, ?3, ?4, ?5,
+  (SELECT COALESCE(MAX(position) + 1, 0) FROM exercise_items WHERE workout_id = ?6 AND user_id = ?7),
+  ?7, ?6, ?8, ?9
 )
 RETURNING id
 `
 
 type CreateExerciseItemAndReturnIdParams struct {
-	ID        string `json:"id"`
-	Type      string `json:"type"`
-	UserID    string `json:"user_id"`
-	WorkoutID string `json:"workout_id"`
-	CreatedOn string `json:"created_on"`
-	UpdatedOn string `json:"updated_on"`
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`
+	Rounds          interface{} `json:"rounds"`
+	IntervalSeconds interface{} `json:"interval_seconds"`
+	TimeCapSeconds  interface{} `json:"time_cap_seconds"`
+	WorkoutID       string      `json:"workout_id"`
+	UserID          string      `json:"user_id"`
+	CreatedOn       string      `json:"created_on"`
+	UpdatedOn       string      `json:"updated_on"`
 }
 
 func (q *Queries) CreateExerciseItemAndReturnId(ctx context.Context, arg CreateExerciseItemAndReturnIdParams) (string, error) {
 	row := q.db.QueryRowContext(ctx, createExerciseItemAndReturnId,
 		arg.ID,
 		arg.Type,
-		arg.UserID,
+		arg.Rounds,
+		arg.IntervalSeconds,
+		arg.TimeCapSeconds,
 		arg.WorkoutID,
+		arg.UserID,
 		arg.CreatedOn,
 		arg.UpdatedOn,
 	)
@@ -61,7 +69,7 @@ func (q *Queries) DeleteExerciseItemById(ctx context.Context, arg DeleteExercise
 }
 
 const getExerciseItemById = `-- name: GetExerciseItemById :one
-SELECT id, type, user_id, workout_id, created_on, updated_on, "foreign" FROM exercise_items
+SELECT id, type, user_id, workout_id, created_on, updated_on, "foreign", position, rounds, interval_seconds, time_cap_seconds FROM exercise_items
 WHERE id = ?1
 AND user_id = ?2
 `
@@ -82,15 +90,19 @@ func (q *Queries) GetExerciseItemById(ctx context.Context, arg GetExerciseItemBy
 		&i.CreatedOn,
 		&i.UpdatedOn,
 		&i.Foreign,
+		&i.Position,
+		&i.Rounds,
+		&i.IntervalSeconds,
+		&i.TimeCapSeconds,
 	)
 	return i, err
 }
 
 const getExerciseItemsByWorkoutId = `-- name: GetExerciseItemsByWorkoutId :many
-SELECT id, type, user_id, workout_id, created_on, updated_on, "foreign" FROM exercise_items
+SELECT id, type, user_id, workout_id, created_on, updated_on, "foreign", position, rounds, interval_seconds, time_cap_seconds FROM exercise_items
 WHERE workout_id = ?1
 AND user_id = ?2
-ORDER BY created_on
+ORDER BY position, created_on
 `
 
 type GetExerciseItemsByWorkoutIdParams struct {
@@ -115,6 +127,10 @@ func (q *Queries) GetExerciseItemsByWorkoutId(ctx context.Context, arg GetExerci
 			&i.CreatedOn,
 			&i.UpdatedOn,
 			&i.Foreign,
+			&i.Position,
+			&i.Rounds,
+			&i.IntervalSeconds,
+			&i.TimeCapSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -129,23 +145,60 @@ func (q *Queries) GetExerciseItemsByWorkoutId(ctx context.Context, arg GetExerci
 	return items, nil
 }
 
-const updateExerciseItemType = `-- name: UpdateExerciseItemType :execrows
+const updateExerciseItem = `-- name: UpdateExerciseItem :execrows
 UPDATE exercise_items
-SET type = ?1, updated_on = ?2
+SET type = ?1,
+rounds = ?2,
+interval_seconds = ?3,
+time_cap_seconds = ?4,
+updated_on = ?5
+WHERE id = ?6
+AND user_id = ?7
+`
+
+type UpdateExerciseItemParams struct {
+	Type            string      `json:"type"`
+	Rounds          interface{} `json:"rounds"`
+	IntervalSeconds interface{} `json:"interval_seconds"`
+	TimeCapSeconds  interface{} `json:"time_cap_seconds"`
+	UpdatedOn       string      `json:"updated_on"`
+	ID              string      `json:"id"`
+	UserID          string      `json:"user_id"`
+}
+
+func (q *Queries) UpdateExerciseItem(ctx context.Context, arg UpdateExerciseItemParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateExerciseItem,
+		arg.Type,
+		arg.Rounds,
+		arg.IntervalSeconds,
+		arg.TimeCapSeconds,
+		arg.UpdatedOn,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateExerciseItemPosition = `-- name: UpdateExerciseItemPosition :execrows
+UPDATE exercise_items
+SET position = ?1, updated_on = ?2
 WHERE id = ?3
 AND user_id = ?4
 `
 
-type UpdateExerciseItemTypeParams struct {
-	Type      string `json:"type"`
+type UpdateExerciseItemPositionParams struct {
+	Position  int64  `json:"position"`
 	UpdatedOn string `json:"updated_on"`
 	ID        string `json:"id"`
 	UserID    string `json:"user_id"`
 }
 
-func (q *Queries) UpdateExerciseItemType(ctx context.Context, arg UpdateExerciseItemTypeParams) (int64, error) {
-	result, err := q.db.ExecContext(ctx, updateExerciseItemType,
-		arg.Type,
+func (q *Queries) UpdateExerciseItemPosition(ctx context.Context, arg UpdateExerciseItemPositionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateExerciseItemPosition,
+		arg.Position,
 		arg.UpdatedOn,
 		arg.ID,
 		arg.UserID,
@@ -11,25 +11,31 @@ import (
 
 const createExerciseTypeAndReturnId = `-- name: CreateExerciseTypeAndReturnId :one
 INSERT INTO exercise_types (
-  id, name, created_on, updated_on, user_id
+  id, name, equipment, movement_pattern, measurement, created_on, updated_on, user_id
 ) VALUES (
-  ?1, ?2, ?3, ?4, ?5
+  ?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8
 )
 RETURNING id
 `
 
 type CreateExerciseTypeAndReturnIdParams struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	CreatedOn string `json:"created_on"`
-	UpdatedOn string `json:"updated_on"`
-	UserID    string `json:"user_id"`
+	ID              string      `json:"id"`
+	Name            string      `json:"name"`
+	Equipment       interface{} `json:"equipment"`
+	MovementPattern interface{} `json:"movement_pattern"`
+	Measurement     string      `json:"measurement"`
+	CreatedOn       string      `json:"created_on"`
+	UpdatedOn       string      `json:"updated_on"`
+	UserID          string      `json:"user_id"`
 }
 
 func (q *Queries) CreateExerciseTypeAndReturnId(ctx context.Context, arg CreateExerciseTypeAndReturnIdParams) (string, error) {
 	row := q.db.QueryRowContext(ctx, createExerciseTypeAndReturnId,
 		arg.ID,
 		arg.Name,
+		arg.Equipment,
+		arg.MovementPattern,
+		arg.Measurement,
 		arg.CreatedOn,
 		arg.UpdatedOn,
 		arg.UserID,
@@ -39,6 +45,40 @@ func (q *Queries) CreateExerciseTypeAndReturnId(ctx context.Context, arg CreateE
 	return id, err
 }
 
+const createExerciseTypeMuscleGroupAndReturnId = `-- name: CreateExerciseTypeMuscleGroupAndReturnId :one
+INSERT INTO exercise_type_muscle_groups (
+  id, muscle_group, role, created_on, updated_on, user_id, exercise_type_id
+) VALUES (
+  ?1, ?2, ?3, ?4, ?5, ?6, ?7
+)
+RETURNING id
+`
+
+type CreateExerciseTypeMuscleGroupAndReturnIdParams struct {
+	ID             string `json:"id"`
+	MuscleGroup    string `json:"muscle_group"`
+	Role           string `json:"role"`
+	CreatedOn      string `json:"created_on"`
+	UpdatedOn      string `json:"updated_on"`
+	UserID         string `json:"user_id"`
+	ExerciseTypeID string `json:"exercise_type_id"`
+}
+
+func (q *Queries) CreateExerciseTypeMuscleGroupAndReturnId(ctx context.Context, arg CreateExerciseTypeMuscleGroupAndReturnIdParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, createExerciseTypeMuscleGroupAndReturnId,
+		arg.ID,
+		arg.MuscleGroup,
+		arg.Role,
+		arg.CreatedOn,
+		arg.UpdatedOn,
+		arg.UserID,
+		arg.ExerciseTypeID,
+	)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
 const deleteExerciseTypeById = `-- name: DeleteExerciseTypeById :execrows
 DELETE FROM exercise_types
 WHERE id = ?1
@@ -58,8 +98,27 @@ func (q *Queries) DeleteExerciseTypeById(ctx context.Context, arg DeleteExercise
 	return result.RowsAffected()
 }
 
+const deleteMuscleGroupsByExerciseTypeId = `-- name: DeleteMuscleGroupsByExerciseTypeId :execrows
+DELETE FROM exercise_type_muscle_groups
+WHERE exercise_type_id = ?1
+AND user_id = ?2
+`
+
+type DeleteMuscleGroupsByExerciseTypeIdParams struct {
+	ExerciseTypeID string `json:"exercise_type_id"`
+	UserID         string `json:"user_id"`
+}
+
+func (q *Queries) DeleteMuscleGroupsByExerciseTypeId(ctx context.Context, arg DeleteMuscleGroupsByExerciseTypeIdParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteMuscleGroupsByExerciseTypeId, arg.ExerciseTypeID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getAllExerciseTypes = `-- name: GetAllExerciseTypes :many
-SELECT id, name, created_on, updated_on, user_id FROM exercise_types 
+SELECT id, name, created_on, updated_on, user_id, equipment, movement_pattern, measurement, rest_seconds FROM exercise_types 
 WHERE user_id = ?1
 ORDER by id asc
 `
@@ -79,6 +138,63 @@ func (q *Queries) GetAllExerciseTypes(ctx context.Context, userID string) ([]Exe
 			&i.CreatedOn,
 			&i.UpdatedOn,
 			&i.UserID,
+			&i.Equipment,
+			&i.MovementPattern,
+			&i.Measurement,
+			&i.RestSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCompletedSetsByExerciseTypeId = `-- name: GetCompletedSetsByExerciseTypeId :many
+SELECT w.completed_on, s.weight, s.repetitions,
+CAST(CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END AS REAL) as bodyweight FROM exercises e
+JOIN sets s ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN exercise_types et ON e.exercise_type_id = et.id
+WHERE e.exercise_type_id = ?1
+AND s.user_id = ?2
+AND s.type != 'warmup'
+AND w.completed_on IS NOT NULL
+ORDER BY w.completed_on ASC, s.id ASC
+`
+
+type GetCompletedSetsByExerciseTypeIdParams struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+}
+
+type GetCompletedSetsByExerciseTypeIdRow struct {
+	CompletedOn interface{} `json:"completed_on"`
+	Weight      float64     `json:"weight"`
+	Repetitions int64       `json:"repetitions"`
+	Bodyweight  float64     `json:"bodyweight"`
+}
+
+func (q *Queries) GetCompletedSetsByExerciseTypeId(ctx context.Context, arg GetCompletedSetsByExerciseTypeIdParams) ([]GetCompletedSetsByExerciseTypeIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCompletedSetsByExerciseTypeId, arg.ID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCompletedSetsByExerciseTypeIdRow{}
+	for rows.Next() {
+		var i GetCompletedSetsByExerciseTypeIdRow
+		if err := rows.Scan(
+			&i.CompletedOn,
+			&i.Weight,
+			&i.Repetitions,
+			&i.Bodyweight,
 		); err != nil {
 			return nil, err
 		}
@@ -94,7 +210,7 @@ func (q *Queries) GetAllExerciseTypes(ctx context.Context, userID string) ([]Exe
 }
 
 const getExerciseTypeById = `-- name: GetExerciseTypeById :one
-SELECT id, name, created_on, updated_on, user_id FROM exercise_types 
+SELECT id, name, created_on, updated_on, user_id, equipment, movement_pattern, measurement, rest_seconds FROM exercise_types 
 WHERE id = ?1
 AND user_id = ?2
 `
@@ -113,18 +229,120 @@ func (q *Queries) GetExerciseTypeById(ctx context.Context, arg GetExerciseTypeBy
 		&i.CreatedOn,
 		&i.UpdatedOn,
 		&i.UserID,
+		&i.Equipment,
+		&i.MovementPattern,
+		&i.Measurement,
+		&i.RestSeconds,
 	)
 	return i, err
 }
 
+const getExerciseTypeHistory = `-- name: GetExerciseTypeHistory :many
+SELECT w.id as workout_id, w.name as workout_name, w.completed_on, s.id, s.type, s.weight, s.repetitions, s.duration_seconds, s.distance_meters, s.rpe, s.rir, s.note FROM exercises e
+JOIN sets s ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+WHERE e.exercise_type_id = ?1
+AND s.user_id = ?2
+AND w.completed_on IS NOT NULL
+AND w.id IN (SELECT hw.id FROM workouts hw
+JOIN exercises he ON he.workout_id = hw.id
+WHERE he.exercise_type_id = ?1
+AND hw.user_id = ?2
+AND hw.completed_on IS NOT NULL
+GROUP BY hw.id
+ORDER BY MAX(hw.completed_on) DESC, hw.id DESC
+LIMIT ?3)
+ORDER BY w.completed_on DESC, w.id DESC, s.id ASC
+`
+
+type GetExerciseTypeHistoryParams struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	Limit  int64  `json:"limit"`
+}
+
+type GetExerciseTypeHistoryRow struct {
+	WorkoutID       string      `json:"workout_id"`
+	WorkoutName     string      `json:"workout_name"`
+	CompletedOn     interface{} `json:"completed_on"`
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`
+	Weight          float64     `json:"weight"`
+	Repetitions     int64       `json:"repetitions"`
+	DurationSeconds interface{} `json:"duration_seconds"`
+	DistanceMeters  interface{} `json:"distance_meters"`
+	Rpe             interface{} `json:"rpe"`
+	Rir             interface{} `json:"rir"`
+	Note            interface{} `json:"note"`
+}
+
+func (q *Queries) GetExerciseTypeHistory(ctx context.Context, arg GetExerciseTypeHistoryParams) ([]GetExerciseTypeHistoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, getExerciseTypeHistory, arg.ID, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetExerciseTypeHistoryRow{}
+	for rows.Next() {
+		var i GetExerciseTypeHistoryRow
+		if err := rows.Scan(
+			&i.WorkoutID,
+			&i.WorkoutName,
+			&i.CompletedOn,
+			&i.ID,
+			&i.Type,
+			&i.Weight,
+			&i.Repetitions,
+			&i.DurationSeconds,
+			&i.DistanceMeters,
+			&i.Rpe,
+			&i.Rir,
+			&i.Note,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLastPerformedOnByExerciseTypeId = `-- name: GetLastPerformedOnByExerciseTypeId :one
+SELECT CAST(s.performed_on AS TEXT) as performed_on FROM sets s
+JOIN exercises e ON s.exercise_id = e.id
+WHERE e.exercise_type_id = ?1
+AND s.user_id = ?2
+AND s.performed_on IS NOT NULL
+ORDER BY s.performed_on DESC
+LIMIT 1
+`
+
+type GetLastPerformedOnByExerciseTypeIdParams struct {
+	ExerciseTypeID string `json:"exercise_type_id"`
+	UserID         string `json:"user_id"`
+}
+
+func (q *Queries) GetLastPerformedOnByExerciseTypeId(ctx context.Context, arg GetLastPerformedOnByExerciseTypeIdParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getLastPerformedOnByExerciseTypeId, arg.ExerciseTypeID, arg.UserID)
+	var performed_on string
+	err := row.Scan(&performed_on)
+	return performed_on, err
+}
+
 const getLastWeightRepsByExerciseTypeId = `-- name: GetLastWeightRepsByExerciseTypeId :one
-SELECT s.repetitions, s.weight FROM exercises e
+SELECT s.repetitions, s.weight, s.duration_seconds, s.distance_meters FROM exercises e
 JOIN sets s ON s.exercise_id = e.id
 JOIN workouts w ON e.workout_id = w.id
 WHERE exercise_type_id = ?1 
 AND s.user_id = ?2
+AND s.type != 'warmup'
 AND w.completed_on IS NOT NULL
-ORDER BY s.id desc LIMIT 1
+ORDER BY w.completed_on DESC, s.id DESC LIMIT 1
 `
 
 type GetLastWeightRepsByExerciseTypeIdParams struct {
@@ -133,14 +351,21 @@ type GetLastWeightRepsByExerciseTypeIdParams struct {
 }
 
 type GetLastWeightRepsByExerciseTypeIdRow struct {
-	Repetitions int64   `json:"repetitions"`
-	Weight      float64 `json:"weight"`
+	Repetitions     int64       `json:"repetitions"`
+	Weight          float64     `json:"weight"`
+	DurationSeconds interface{} `json:"duration_seconds"`
+	DistanceMeters  interface{} `json:"distance_meters"`
 }
 
 func (q *Queries) GetLastWeightRepsByExerciseTypeId(ctx context.Context, arg GetLastWeightRepsByExerciseTypeIdParams) (GetLastWeightRepsByExerciseTypeIdRow, error) {
 	row := q.db.QueryRowContext(ctx, getLastWeightRepsByExerciseTypeId, arg.ID, arg.UserID)
 	var i GetLastWeightRepsByExerciseTypeIdRow
-	err := row.Scan(&i.Repetitions, &i.Weight)
+	err := row.Scan(
+		&i.Repetitions,
+		&i.Weight,
+		&i.DurationSeconds,
+		&i.DistanceMeters,
+	)
 	return i, err
 }
 
@@ -148,9 +373,11 @@ const getMaxWeightRepsByExerciseTypeId = `-- name: GetMaxWeightRepsByExerciseTyp
 SELECT COALESCE(s.weight, 0) as weight, COALESCE(Max(s.repetitions), 0) as repetitions FROM exercises e
 LEFT JOIN sets s ON s.exercise_id = e.id
 WHERE e.exercise_type_id = ?1 AND (s.user_id = ?2 OR s.id IS NULL)
+AND (s.type != 'warmup' OR s.id IS NULL)
 AND (s.weight = (SELECT Max(s.weight) as weight FROM exercises e
 JOIN sets s ON s.exercise_id = e.id
-WHERE e.exercise_type_id = ?1 AND s.user_id = ?2) OR s.weight IS NULL)
+WHERE e.exercise_type_id = ?1 AND s.user_id = ?2
+AND s.type != 'warmup') OR s.weight IS NULL)
 GROUP BY e.exercise_type_id
 `
 
@@ -171,6 +398,188 @@ func (q *Queries) GetMaxWeightRepsByExerciseTypeId(ctx context.Context, arg GetM
 	return i, err
 }
 
+const getMeasuredSetsByExerciseTypeId = `-- name: GetMeasuredSetsByExerciseTypeId :many
+SELECT s.weight, s.repetitions, s.duration_seconds, s.distance_meters FROM exercises e
+JOIN sets s ON s.exercise_id = e.id
+WHERE e.exercise_type_id = ?1
+AND s.user_id = ?2
+AND s.type != 'warmup'
+ORDER BY s.id ASC
+`
+
+type GetMeasuredSetsByExerciseTypeIdParams struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+}
+
+type GetMeasuredSetsByExerciseTypeIdRow struct {
+	Weight          float64     `json:"weight"`
+	Repetitions     int64       `json:"repetitions"`
+	DurationSeconds interface{} `json:"duration_seconds"`
+	DistanceMeters  interface{} `json:"distance_meters"`
+}
+
+func (q *Queries) GetMeasuredSetsByExerciseTypeId(ctx context.Context, arg GetMeasuredSetsByExerciseTypeIdParams) ([]GetMeasuredSetsByExerciseTypeIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMeasuredSetsByExerciseTypeId, arg.ID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMeasuredSetsByExerciseTypeIdRow{}
+	for rows.Next() {
+		var i GetMeasuredSetsByExerciseTypeIdRow
+		if err := rows.Scan(
+			&i.Weight,
+			&i.Repetitions,
+			&i.DurationSeconds,
+			&i.DistanceMeters,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMuscleGroupsByExerciseTypeId = `-- name: GetMuscleGroupsByExerciseTypeId :many
+SELECT id, muscle_group, role, created_on, updated_on, user_id, exercise_type_id FROM exercise_type_muscle_groups
+WHERE exercise_type_id = ?1
+AND user_id = ?2
+ORDER BY role ASC, muscle_group ASC
+`
+
+type GetMuscleGroupsByExerciseTypeIdParams struct {
+	ExerciseTypeID string `json:"exercise_type_id"`
+	UserID         string `json:"user_id"`
+}
+
+func (q *Queries) GetMuscleGroupsByExerciseTypeId(ctx context.Context, arg GetMuscleGroupsByExerciseTypeIdParams) ([]ExerciseTypeMuscleGroup, error) {
+	rows, err := q.db.QueryContext(ctx, getMuscleGroupsByExerciseTypeId, arg.ExerciseTypeID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExerciseTypeMuscleGroup{}
+	for rows.Next() {
+		var i ExerciseTypeMuscleGroup
+		if err := rows.Scan(
+			&i.ID,
+			&i.MuscleGroup,
+			&i.Role,
+			&i.CreatedOn,
+			&i.UpdatedOn,
+			&i.UserID,
+			&i.ExerciseTypeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMuscleGroupsByUserId = `-- name: GetMuscleGroupsByUserId :many
+SELECT id, muscle_group, role, created_on, updated_on, user_id, exercise_type_id FROM exercise_type_muscle_groups
+WHERE user_id = ?1
+ORDER BY exercise_type_id ASC, role ASC, muscle_group ASC
+`
+
+func (q *Queries) GetMuscleGroupsByUserId(ctx context.Context, userID string) ([]ExerciseTypeMuscleGroup, error) {
+	rows, err := q.db.QueryContext(ctx, getMuscleGroupsByUserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExerciseTypeMuscleGroup{}
+	for rows.Next() {
+		var i ExerciseTypeMuscleGroup
+		if err := rows.Scan(
+			&i.ID,
+			&i.MuscleGroup,
+			&i.Role,
+			&i.CreatedOn,
+			&i.UpdatedOn,
+			&i.UserID,
+			&i.ExerciseTypeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSetHistoryByExerciseTypeId = `-- name: GetSetHistoryByExerciseTypeId :many
+SELECT w.id as workout_id, w.completed_on, s.weight, s.repetitions FROM exercises e
+JOIN sets s ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+WHERE e.exercise_type_id = ?1
+AND s.user_id = ?2
+AND s.type != 'warmup'
+AND w.completed_on IS NOT NULL
+ORDER BY w.completed_on DESC, s.id ASC
+LIMIT ?3
+`
+
+type GetSetHistoryByExerciseTypeIdParams struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	Limit  int64  `json:"limit"`
+}
+
+type GetSetHistoryByExerciseTypeIdRow struct {
+	WorkoutID   string      `json:"workout_id"`
+	CompletedOn interface{} `json:"completed_on"`
+	Weight      float64     `json:"weight"`
+	Repetitions int64       `json:"repetitions"`
+}
+
+func (q *Queries) GetSetHistoryByExerciseTypeId(ctx context.Context, arg GetSetHistoryByExerciseTypeIdParams) ([]GetSetHistoryByExerciseTypeIdRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSetHistoryByExerciseTypeId, arg.ID, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetSetHistoryByExerciseTypeIdRow{}
+	for rows.Next() {
+		var i GetSetHistoryByExerciseTypeIdRow
+		if err := rows.Scan(
+			&i.WorkoutID,
+			&i.CompletedOn,
+			&i.Weight,
+			&i.Repetitions,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateExerciseType = `-- name: UpdateExerciseType :execrows
 UPDATE exercise_types
 SET name = ?1, updated_on = ?2
@@ -197,3 +606,59 @@ func (q *Queries) UpdateExerciseType(ctx context.Context, arg UpdateExerciseType
 	}
 	return result.RowsAffected()
 }
+
+const updateExerciseTypeMetadata = `-- name: UpdateExerciseTypeMetadata :execrows
+UPDATE exercise_types
+SET equipment = ?1, movement_pattern = ?2, updated_on = ?3
+WHERE id = ?4
+AND user_id = ?5
+`
+
+type UpdateExerciseTypeMetadataParams struct {
+	Equipment       interface{} `json:"equipment"`
+	MovementPattern interface{} `json:"movement_pattern"`
+	UpdatedOn       string      `json:"updated_on"`
+	ID              string      `json:"id"`
+	UserID          string      `json:"user_id"`
+}
+
+func (q *Queries) UpdateExerciseTypeMetadata(ctx context.Context, arg UpdateExerciseTypeMetadataParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateExerciseTypeMetadata,
+		arg.Equipment,
+		arg.MovementPattern,
+		arg.UpdatedOn,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateExerciseTypeRestSeconds = `-- name: UpdateExerciseTypeRestSeconds :execrows
+UPDATE exercise_types
+SET rest_seconds = ?1, updated_on = ?2
+WHERE id = ?3
+AND user_id = ?4
+`
+
+type UpdateExerciseTypeRestSecondsParams struct {
+	RestSeconds interface{} `json:"rest_seconds"`
+	UpdatedOn   string      `json:"updated_on"`
+	ID          string      `json:"id"`
+	UserID      string      `json:"user_id"`
+}
+
+func (q *Queries) UpdateExerciseTypeRestSeconds(ctx context.Context, arg UpdateExerciseTypeRestSecondsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateExerciseTypeRestSeconds,
+		arg.RestSeconds,
+		arg.UpdatedOn,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -11,9 +11,11 @@ import (
 
 const createExerciseAndReturnId = `-- name: CreateExerciseAndReturnId :one
 INSERT INTO exercises (
-  id, name, workout_id, exercise_type_id, exercise_item_id, created_on, updated_on, user_id
+  id, name, workout_id, exercise_type_id, exercise_item_id, position, created_on, updated_on, user_id
 ) VALUES (
-  ?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8
+  ?1, ?2, ?3, ?4, ?5,
+  (SELECT COALESCE(MAX(position) + 1, 0) FROM exercises WHERE exercise_item_id = ?5 AND user_id = ?6),
+  ?7, ?8, ?6
 )
 RETURNING id
 `
@@ -24,9 +26,9 @@ type CreateExerciseAndReturnIdParams struct {
 	WorkoutID      string `json:"workout_id"`
 	ExerciseTypeID string `json:"exercise_type_id"`
 	ExerciseItemID string `json:"exercise_item_id"`
+	UserID         string `json:"user_id"`
 	CreatedOn      string `json:"created_on"`
 	UpdatedOn      string `json:"updated_on"`
-	UserID         string `json:"user_id"`
 }
 
 func (q *Queries) CreateExerciseAndReturnId(ctx context.Context, arg CreateExerciseAndReturnIdParams) (string, error) {
@@ -36,9 +38,9 @@ func (q *Queries) CreateExerciseAndReturnId(ctx context.Context, arg CreateExerc
 		arg.WorkoutID,
 		arg.ExerciseTypeID,
 		arg.ExerciseItemID,
+		arg.UserID,
 		arg.CreatedOn,
 		arg.UpdatedOn,
-		arg.UserID,
 	)
 	var id string
 	err := row.Scan(&id)
@@ -65,7 +67,7 @@ func (q *Queries) DeleteExerciseById(ctx context.Context, arg DeleteExerciseById
 }
 
 const getAllExercises = `-- name: GetAllExercises :many
-SELECT id, name, created_on, updated_on, user_id, workout_id, exercise_type_id, exercise_item_id FROM exercises 
+SELECT id, name, created_on, updated_on, user_id, workout_id, exercise_type_id, exercise_item_id, position FROM exercises 
 WHERE user_id = ?1
 ORDER by id
 `
@@ -88,6 +90,7 @@ func (q *Queries) GetAllExercises(ctx context.Context, userID string) ([]Exercis
 			&i.WorkoutID,
 			&i.ExerciseTypeID,
 			&i.ExerciseItemID,
+			&i.Position,
 		); err != nil {
 			return nil, err
 		}
@@ -103,7 +106,7 @@ func (q *Queries) GetAllExercises(ctx context.Context, userID string) ([]Exercis
 }
 
 const getExerciseById = `-- name: GetExerciseById :one
-SELECT id, name, created_on, updated_on, user_id, workout_id, exercise_type_id, exercise_item_id FROM exercises 
+SELECT id, name, created_on, updated_on, user_id, workout_id, exercise_type_id, exercise_item_id, position FROM exercises 
 WHERE id = ?1
 AND user_id = ?2
 `
@@ -125,14 +128,16 @@ func (q *Queries) GetExerciseById(ctx context.Context, arg GetExerciseByIdParams
 		&i.WorkoutID,
 		&i.ExerciseTypeID,
 		&i.ExerciseItemID,
+		&i.Position,
 	)
 	return i, err
 }
 
 const getExercisesByExerciseItemId = `-- name: GetExercisesByExerciseItemId :many
-SELECT id, name, created_on, updated_on, user_id, workout_id, exercise_type_id, exercise_item_id FROM exercises
+SELECT id, name, created_on, updated_on, user_id, workout_id, exercise_type_id, exercise_item_id, position FROM exercises
 WHERE exercise_item_id = ?1
 AND user_id = ?2
+ORDER BY position, id
 `
 
 type GetExercisesByExerciseItemIdParams struct {
@@ -158,6 +163,7 @@ func (q *Queries) GetExercisesByExerciseItemId(ctx context.Context, arg GetExerc
 			&i.WorkoutID,
 			&i.ExerciseTypeID,
 			&i.ExerciseItemID,
+			&i.Position,
 		); err != nil {
 			return nil, err
 		}
@@ -173,9 +179,10 @@ func (q *Queries) GetExercisesByExerciseItemId(ctx context.Context, arg GetExerc
 }
 
 const getExercisesByWorkoutId = `-- name: GetExercisesByWorkoutId :many
-SELECT id, name, created_on, updated_on, user_id, workout_id, exercise_type_id, exercise_item_id FROM exercises
+SELECT id, name, created_on, updated_on, user_id, workout_id, exercise_type_id, exercise_item_id, position FROM exercises
 WHERE workout_id = ?1
 AND user_id = ?2
+ORDER BY (SELECT i.position FROM exercise_items i WHERE i.id = exercises.exercise_item_id), position, id
 `
 
 type GetExercisesByWorkoutIdParams struct {
@@ -201,6 +208,7 @@ func (q *Queries) GetExercisesByWorkoutId(ctx context.Context, arg GetExercisesB
 			&i.WorkoutID,
 			&i.ExerciseTypeID,
 			&i.ExerciseItemID,
+			&i.Position,
 		); err != nil {
 			return nil, err
 		}
@@ -214,3 +222,66 @@ func (q *Queries) GetExercisesByWorkoutId(ctx context.Context, arg GetExercisesB
 	}
 	return items, nil
 }
+
+const updateExercise = `-- name: UpdateExercise :execrows
+UPDATE exercises
+SET name = ?1,
+exercise_type_id = ?2,
+position = CASE WHEN exercise_item_id = ?3 THEN position
+  ELSE (SELECT COALESCE(MAX(e.position) + 1, 0) FROM exercises e WHERE e.exercise_item_id = ?3 AND e.user_id = ?4) END,
+exercise_item_id = ?3,
+updated_on = ?5
+WHERE id = ?6
+AND user_id = ?4
+`
+
+type UpdateExerciseParams struct {
+	Name           string `json:"name"`
+	ExerciseTypeID string `json:"exercise_type_id"`
+	ExerciseItemID string `json:"exercise_item_id"`
+	UserID         string `json:"user_id"`
+	UpdatedOn      string `json:"updated_on"`
+	ID             string `json:"id"`
+}
+
+func (q *Queries) UpdateExercise(ctx context.Context, arg UpdateExerciseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateExercise,
+		arg.Name,
+		arg.ExerciseTypeID,
+		arg.ExerciseItemID,
+		arg.UserID,
+		arg.UpdatedOn,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateExercisePosition = `-- name: UpdateExercisePosition :execrows
+UPDATE exercises
+SET position = ?1, updated_on = ?2
+WHERE id = ?3
+AND user_id = ?4
+`
+
+type UpdateExercisePositionParams struct {
+	Position  int64  `json:"position"`
+	UpdatedOn string `json:"updated_on"`
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+}
+
+func (q *Queries) UpdateExercisePosition(ctx context.Context, arg UpdateExercisePositionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateExercisePosition,
+		arg.Position,
+		arg.UpdatedOn,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -4,6 +4,26 @@
 
 package repository
 
+type BodyMeasurement struct {
+	ID           string  `json:"id"`
+	Site         string  `json:"site"`
+	Centimeters  float64 `json:"centimeters"`
+	CreatedOn    string  `json:"created_on"`
+	UpdatedOn    string  `json:"updated_on"`
+	UserID       string  `json:"user_id"`
+	BodyMetricID string  `json:"body_metric_id"`
+}
+
+type BodyMetric struct {
+	ID                string      `json:"id"`
+	MeasuredOn        string      `json:"measured_on"`
+	Bodyweight        interface{} `json:"bodyweight"`
+	BodyFatPercentage interface{} `json:"body_fat_percentage"`
+	CreatedOn         string      `json:"created_on"`
+	UpdatedOn         string      `json:"updated_on"`
+	UserID            string      `json:"user_id"`
+}
+
 type Exercise struct {
 	ID             string `json:"id"`
 	Name           string `json:"name"`
@@ -13,24 +33,43 @@ type Exercise struct {
 	WorkoutID      string `json:"workout_id"`
 	ExerciseTypeID string `json:"exercise_type_id"`
 	ExerciseItemID string `json:"exercise_item_id"`
+	Position       int64  `json:"position"`
 }
 
 type ExerciseItem struct {
-	ID        string      `json:"id"`
-	Type      string      `json:"type"`
-	UserID    string      `json:"user_id"`
-	WorkoutID string      `json:"workout_id"`
-	CreatedOn string      `json:"created_on"`
-	UpdatedOn string      `json:"updated_on"`
-	Foreign   interface{} `json:"foreign"`
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`
+	UserID          string      `json:"user_id"`
+	WorkoutID       string      `json:"workout_id"`
+	CreatedOn       string      `json:"created_on"`
+	UpdatedOn       string      `json:"updated_on"`
+	Foreign         interface{} `json:"foreign"`
+	Position        int64       `json:"position"`
+	Rounds          interface{} `json:"rounds"`
+	IntervalSeconds interface{} `json:"interval_seconds"`
+	TimeCapSeconds  interface{} `json:"time_cap_seconds"`
 }
 
 type ExerciseType struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	CreatedOn string `json:"created_on"`
-	UpdatedOn string `json:"updated_on"`
-	UserID    string `json:"user_id"`
+	ID              string      `json:"id"`
+	Name            string      `json:"name"`
+	CreatedOn       string      `json:"created_on"`
+	UpdatedOn       string      `json:"updated_on"`
+	UserID          string      `json:"user_id"`
+	Equipment       interface{} `json:"equipment"`
+	MovementPattern interface{} `json:"movement_pattern"`
+	Measurement     string      `json:"measurement"`
+	RestSeconds     interface{} `json:"rest_seconds"`
+}
+
+type ExerciseTypeMuscleGroup struct {
+	ID             string `json:"id"`
+	MuscleGroup    string `json:"muscle_group"`
+	Role           string `json:"role"`
+	CreatedOn      string `json:"created_on"`
+	UpdatedOn      string `json:"updated_on"`
+	UserID         string `json:"user_id"`
+	ExerciseTypeID string `json:"exercise_type_id"`
 }
 
 type ExpiredToken struct {
@@ -41,15 +80,166 @@ type ExpiredToken struct {
 	RemoveOn  string `json:"remove_on"`
 }
 
+type Goal struct {
+	ID                string      `json:"id"`
+	Kind              string      `json:"kind"`
+	TargetWeight      interface{} `json:"target_weight"`
+	TargetRepetitions interface{} `json:"target_repetitions"`
+	TargetValue       float64     `json:"target_value"`
+	StartValue        float64     `json:"start_value"`
+	Deadline          string      `json:"deadline"`
+	AchievedOn        interface{} `json:"achieved_on"`
+	CreatedOn         string      `json:"created_on"`
+	UpdatedOn         string      `json:"updated_on"`
+	UserID            string      `json:"user_id"`
+	ExerciseTypeID    interface{} `json:"exercise_type_id"`
+}
+
+type GoalProgress struct {
+	ID         string  `json:"id"`
+	Value      float64 `json:"value"`
+	RecordedOn string  `json:"recorded_on"`
+	CreatedOn  string  `json:"created_on"`
+	UpdatedOn  string  `json:"updated_on"`
+	UserID     string  `json:"user_id"`
+	GoalID     string  `json:"goal_id"`
+}
+
+type Program struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedOn string `json:"created_on"`
+	UpdatedOn string `json:"updated_on"`
+	UserID    string `json:"user_id"`
+}
+
+type ProgramDay struct {
+	ID         string `json:"id"`
+	Week       int64  `json:"week"`
+	Day        int64  `json:"day"`
+	CreatedOn  string `json:"created_on"`
+	UpdatedOn  string `json:"updated_on"`
+	UserID     string `json:"user_id"`
+	ProgramID  string `json:"program_id"`
+	TemplateID string `json:"template_id"`
+}
+
+type ProgramEnrollment struct {
+	ID        string      `json:"id"`
+	StartedOn string      `json:"started_on"`
+	EndedOn   interface{} `json:"ended_on"`
+	CreatedOn string      `json:"created_on"`
+	UpdatedOn string      `json:"updated_on"`
+	UserID    string      `json:"user_id"`
+	ProgramID string      `json:"program_id"`
+}
+
+type ProgramProgress struct {
+	ID                  string      `json:"id"`
+	CompletedOn         interface{} `json:"completed_on"`
+	CreatedOn           string      `json:"created_on"`
+	UpdatedOn           string      `json:"updated_on"`
+	UserID              string      `json:"user_id"`
+	ProgramID           string      `json:"program_id"`
+	ProgramEnrollmentID string      `json:"program_enrollment_id"`
+	ProgramDayID        string      `json:"program_day_id"`
+	WorkoutID           string      `json:"workout_id"`
+}
+
+type ProgressionRule struct {
+	ID             string  `json:"id"`
+	Rule           string  `json:"rule"`
+	Increment      float64 `json:"increment"`
+	MinReps        int64   `json:"min_reps"`
+	MaxReps        int64   `json:"max_reps"`
+	TrainingMax    float64 `json:"training_max"`
+	Percentage     float64 `json:"percentage"`
+	BarWeight      float64 `json:"bar_weight"`
+	Plates         string  `json:"plates"`
+	CreatedOn      string  `json:"created_on"`
+	UpdatedOn      string  `json:"updated_on"`
+	UserID         string  `json:"user_id"`
+	ExerciseTypeID string  `json:"exercise_type_id"`
+}
+
+type Record struct {
+	ID             string  `json:"id"`
+	Kind           string  `json:"kind"`
+	Value          float64 `json:"value"`
+	Weight         float64 `json:"weight"`
+	Repetitions    int64   `json:"repetitions"`
+	AchievedOn     string  `json:"achieved_on"`
+	CreatedOn      string  `json:"created_on"`
+	UpdatedOn      string  `json:"updated_on"`
+	UserID         string  `json:"user_id"`
+	ExerciseTypeID string  `json:"exercise_type_id"`
+	WorkoutID      string  `json:"workout_id"`
+}
+
 type Set struct {
-	ID          string      `json:"id"`
-	Repetitions int64       `json:"repetitions"`
-	Weight      float64     `json:"weight"`
-	CreatedOn   string      `json:"created_on"`
-	UpdatedOn   string      `json:"updated_on"`
-	UserID      string      `json:"user_id"`
-	ExerciseID  string      `json:"exercise_id"`
-	Foreign     interface{} `json:"foreign"`
+	ID              string      `json:"id"`
+	Repetitions     int64       `json:"repetitions"`
+	Weight          float64     `json:"weight"`
+	CreatedOn       string      `json:"created_on"`
+	UpdatedOn       string      `json:"updated_on"`
+	UserID          string      `json:"user_id"`
+	ExerciseID      string      `json:"exercise_id"`
+	Foreign         interface{} `json:"foreign"`
+	Type            string      `json:"type"`
+	Rpe             interface{} `json:"rpe"`
+	Rir             interface{} `json:"rir"`
+	Note            interface{} `json:"note"`
+	Position        int64       `json:"position"`
+	DurationSeconds interface{} `json:"duration_seconds"`
+	DistanceMeters  interface{} `json:"distance_meters"`
+	RoundNumber     interface{} `json:"round_number"`
+	PerformedOn     interface{} `json:"performed_on"`
+	RestSeconds     interface{} `json:"rest_seconds"`
+}
+
+type Template struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedOn string `json:"created_on"`
+	UpdatedOn string `json:"updated_on"`
+	UserID    string `json:"user_id"`
+}
+
+type TemplateExercise struct {
+	ID                     string `json:"id"`
+	Name                   string `json:"name"`
+	CreatedOn              string `json:"created_on"`
+	UpdatedOn              string `json:"updated_on"`
+	UserID                 string `json:"user_id"`
+	TemplateID             string `json:"template_id"`
+	TemplateExerciseItemID string `json:"template_exercise_item_id"`
+	ExerciseTypeID         string `json:"exercise_type_id"`
+}
+
+type TemplateExerciseItem struct {
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`
+	CreatedOn       string      `json:"created_on"`
+	UpdatedOn       string      `json:"updated_on"`
+	UserID          string      `json:"user_id"`
+	TemplateID      string      `json:"template_id"`
+	Rounds          interface{} `json:"rounds"`
+	IntervalSeconds interface{} `json:"interval_seconds"`
+	TimeCapSeconds  interface{} `json:"time_cap_seconds"`
+}
+
+type TemplateSet struct {
+	ID                 string      `json:"id"`
+	Repetitions        int64       `json:"repetitions"`
+	Weight             float64     `json:"weight"`
+	CreatedOn          string      `json:"created_on"`
+	UpdatedOn          string      `json:"updated_on"`
+	UserID             string      `json:"user_id"`
+	TemplateID         string      `json:"template_id"`
+	TemplateExerciseID string      `json:"template_exercise_id"`
+	Type               string      `json:"type"`
+	DurationSeconds    interface{} `json:"duration_seconds"`
+	DistanceMeters     interface{} `json:"distance_meters"`
 }
 
 type User struct {
@@ -62,12 +252,28 @@ type User struct {
 	IsVerified bool        `json:"is_verified"`
 }
 
+type UserPreference struct {
+	UserID             string `json:"user_id"`
+	WeightUnit         string `json:"weight_unit"`
+	WeekStart          string `json:"week_start"`
+	Timezone           string `json:"timezone"`
+	DefaultRestSeconds int64  `json:"default_rest_seconds"`
+	CreatedOn          string `json:"created_on"`
+	UpdatedOn          string `json:"updated_on"`
+	WeeklyTarget       int64  `json:"weekly_target"`
+}
+
 type Workout struct {
-	ID          string      `json:"id"`
-	Name        string      `json:"name"`
-	CompletedOn interface{} `json:"completed_on"`
-	CreatedOn   string      `json:"created_on"`
-	UpdatedOn   string      `json:"updated_on"`
-	UserID      string      `json:"user_id"`
-	Note        interface{} `json:"note"`
+	ID               string      `json:"id"`
+	Name             string      `json:"name"`
+	CompletedOn      interface{} `json:"completed_on"`
+	CreatedOn        string      `json:"created_on"`
+	UpdatedOn        string      `json:"updated_on"`
+	UserID           string      `json:"user_id"`
+	Note             interface{} `json:"note"`
+	Bodyweight       interface{} `json:"bodyweight"`
+	StartedOn        interface{} `json:"started_on"`
+	ResumedOn        interface{} `json:"resumed_on"`
+	ActiveSeconds    int64       `json:"active_seconds"`
+	AverageHeartRate interface{} `json:"average_heart_rate"`
 }
@@ -10,49 +10,185 @@ import (
 
 type Querier interface {
 	CheckIfTokenExists(ctx context.Context, arg CheckIfTokenExistsParams) (int64, error)
+	CompleteProgramProgressByWorkoutId(ctx context.Context, arg CompleteProgramProgressByWorkoutIdParams) (int64, error)
 	CompleteWorkoutById(ctx context.Context, arg CompleteWorkoutByIdParams) (int64, error)
+	CountProgramDaysByTemplateId(ctx context.Context, arg CountProgramDaysByTemplateIdParams) (int64, error)
+	CreateBodyMeasurementAndReturnId(ctx context.Context, arg CreateBodyMeasurementAndReturnIdParams) (string, error)
+	CreateBodyMetricAndReturnId(ctx context.Context, arg CreateBodyMetricAndReturnIdParams) (string, error)
+	CreateCompletedWorkoutAndReturnId(ctx context.Context, arg CreateCompletedWorkoutAndReturnIdParams) (string, error)
 	CreateExerciseAndReturnId(ctx context.Context, arg CreateExerciseAndReturnIdParams) (string, error)
 	CreateExerciseItemAndReturnId(ctx context.Context, arg CreateExerciseItemAndReturnIdParams) (string, error)
 	CreateExerciseTypeAndReturnId(ctx context.Context, arg CreateExerciseTypeAndReturnIdParams) (string, error)
+	CreateExerciseTypeMuscleGroupAndReturnId(ctx context.Context, arg CreateExerciseTypeMuscleGroupAndReturnIdParams) (string, error)
 	CreateExpiredToken(ctx context.Context, arg CreateExpiredTokenParams) (int64, error)
+	CreateGoalAndReturnId(ctx context.Context, arg CreateGoalAndReturnIdParams) (string, error)
+	CreateGoalProgressAndReturnId(ctx context.Context, arg CreateGoalProgressAndReturnIdParams) (string, error)
+	CreateProgramAndReturnId(ctx context.Context, arg CreateProgramAndReturnIdParams) (string, error)
+	CreateProgramDayAndReturnId(ctx context.Context, arg CreateProgramDayAndReturnIdParams) (string, error)
+	CreateProgramEnrollmentAndReturnId(ctx context.Context, arg CreateProgramEnrollmentAndReturnIdParams) (string, error)
+	CreateProgramProgressAndReturnId(ctx context.Context, arg CreateProgramProgressAndReturnIdParams) (string, error)
+	CreateRecordAndReturnId(ctx context.Context, arg CreateRecordAndReturnIdParams) (string, error)
 	CreateSetAndReturnId(ctx context.Context, arg CreateSetAndReturnIdParams) (string, error)
+	CreateTemplateAndReturnId(ctx context.Context, arg CreateTemplateAndReturnIdParams) (string, error)
+	CreateTemplateExerciseAndReturnId(ctx context.Context, arg CreateTemplateExerciseAndReturnIdParams) (string, error)
+	CreateTemplateExerciseItemAndReturnId(ctx context.Context, arg CreateTemplateExerciseItemAndReturnIdParams) (string, error)
+	CreateTemplateSetAndReturnId(ctx context.Context, arg CreateTemplateSetAndReturnIdParams) (string, error)
 	CreateUserAndReturnId(ctx context.Context, arg CreateUserAndReturnIdParams) (string, error)
 	CreateWorkoutAndReturnId(ctx context.Context, arg CreateWorkoutAndReturnIdParams) (string, error)
+	DeleteBodyMeasurementsByBodyMetricId(ctx context.Context, arg DeleteBodyMeasurementsByBodyMetricIdParams) (int64, error)
+	DeleteBodyMeasurementsByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteBodyMetricById(ctx context.Context, arg DeleteBodyMetricByIdParams) (int64, error)
+	DeleteBodyMetricsByUserId(ctx context.Context, userID string) (int64, error)
 	DeleteExerciseById(ctx context.Context, arg DeleteExerciseByIdParams) (int64, error)
 	DeleteExerciseItemById(ctx context.Context, arg DeleteExerciseItemByIdParams) (int64, error)
+	DeleteExerciseItemsByUserId(ctx context.Context, userID string) (int64, error)
 	DeleteExerciseTypeById(ctx context.Context, arg DeleteExerciseTypeByIdParams) (int64, error)
+	DeleteExerciseTypeMuscleGroupsByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteExerciseTypesByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteExercisesByUserId(ctx context.Context, userID string) (int64, error)
 	DeleteExpiredTokens(ctx context.Context, currTime string) (int64, error)
+	DeleteGoalById(ctx context.Context, arg DeleteGoalByIdParams) (int64, error)
+	DeleteGoalProgressByGoalId(ctx context.Context, arg DeleteGoalProgressByGoalIdParams) (int64, error)
+	DeleteGoalProgressByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteGoalsByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteMuscleGroupsByExerciseTypeId(ctx context.Context, arg DeleteMuscleGroupsByExerciseTypeIdParams) (int64, error)
+	DeletePreferencesByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteProgramById(ctx context.Context, arg DeleteProgramByIdParams) (int64, error)
+	DeleteProgramDaysByProgramId(ctx context.Context, arg DeleteProgramDaysByProgramIdParams) (int64, error)
+	DeleteProgramDaysByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteProgramEnrollmentsByProgramId(ctx context.Context, arg DeleteProgramEnrollmentsByProgramIdParams) (int64, error)
+	DeleteProgramEnrollmentsByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteProgramProgressByProgramId(ctx context.Context, arg DeleteProgramProgressByProgramIdParams) (int64, error)
+	DeleteProgramProgressByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteProgramsByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteProgressionRulesByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteRecordsByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteRecordsByWorkoutId(ctx context.Context, arg DeleteRecordsByWorkoutIdParams) (int64, error)
 	DeleteSetById(ctx context.Context, arg DeleteSetByIdParams) (int64, error)
+	DeleteSetsByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteTemplateById(ctx context.Context, arg DeleteTemplateByIdParams) (int64, error)
+	DeleteTemplateExerciseItemsByTemplateId(ctx context.Context, arg DeleteTemplateExerciseItemsByTemplateIdParams) (int64, error)
+	DeleteTemplateExerciseItemsByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteTemplateExercisesByTemplateId(ctx context.Context, arg DeleteTemplateExercisesByTemplateIdParams) (int64, error)
+	DeleteTemplateExercisesByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteTemplateSetsByTemplateId(ctx context.Context, arg DeleteTemplateSetsByTemplateIdParams) (int64, error)
+	DeleteTemplateSetsByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteTemplatesByUserId(ctx context.Context, userID string) (int64, error)
+	DeleteUnfinishedProgramProgressByDayId(ctx context.Context, arg DeleteUnfinishedProgramProgressByDayIdParams) (int64, error)
 	DeleteUser(ctx context.Context, id string) (int64, error)
 	DeleteWorkoutById(ctx context.Context, arg DeleteWorkoutByIdParams) (int64, error)
+	DeleteWorkoutsByUserId(ctx context.Context, userID string) (int64, error)
 	EmailExists(ctx context.Context, email interface{}) (int64, error)
+	EndActiveProgramEnrollments(ctx context.Context, arg EndActiveProgramEnrollmentsParams) (int64, error)
+	EndProgramEnrollmentById(ctx context.Context, arg EndProgramEnrollmentByIdParams) (int64, error)
+	GetActiveGoals(ctx context.Context, userID string) ([]Goal, error)
+	GetActiveProgramEnrollment(ctx context.Context, userID string) (ProgramEnrollment, error)
 	GetAllExerciseTypes(ctx context.Context, userID string) ([]ExerciseType, error)
 	GetAllExercises(ctx context.Context, userID string) ([]Exercise, error)
+	GetAllGoals(ctx context.Context, userID string) ([]Goal, error)
+	GetAllPrograms(ctx context.Context, userID string) ([]Program, error)
+	GetAllRecords(ctx context.Context, userID string) ([]GetAllRecordsRow, error)
 	GetAllSets(ctx context.Context, userID string) ([]Set, error)
+	GetAllTemplates(ctx context.Context, userID string) ([]Template, error)
 	GetAllWorkouts(ctx context.Context, arg GetAllWorkoutsParams) ([]Workout, error)
 	GetAllWorkoutsCount(ctx context.Context, userID string) (int64, error)
+	GetBodyMeasurementsBetweenDates(ctx context.Context, arg GetBodyMeasurementsBetweenDatesParams) ([]BodyMeasurement, error)
+	GetBodyMeasurementsByBodyMetricId(ctx context.Context, arg GetBodyMeasurementsByBodyMetricIdParams) ([]BodyMeasurement, error)
+	GetBodyMeasurementsByUserId(ctx context.Context, userID string) ([]BodyMeasurement, error)
+	GetBodyMetricById(ctx context.Context, arg GetBodyMetricByIdParams) (BodyMetric, error)
+	GetBodyMetricsBetweenDates(ctx context.Context, arg GetBodyMetricsBetweenDatesParams) ([]BodyMetric, error)
+	GetBodyMetricsByUserId(ctx context.Context, userID string) ([]BodyMetric, error)
 	GetByEmail(ctx context.Context, email interface{}) (User, error)
 	GetByUserId(ctx context.Context, id string) (User, error)
 	GetByUsername(ctx context.Context, username string) (User, error)
+	GetCompletedOnByUserId(ctx context.Context, userID string) ([]string, error)
+	GetCompletedSetsByExerciseTypeId(ctx context.Context, arg GetCompletedSetsByExerciseTypeIdParams) ([]GetCompletedSetsByExerciseTypeIdRow, error)
 	GetExerciseById(ctx context.Context, arg GetExerciseByIdParams) (Exercise, error)
 	GetExerciseItemById(ctx context.Context, arg GetExerciseItemByIdParams) (ExerciseItem, error)
+	GetExerciseItemsByUserId(ctx context.Context, userID string) ([]ExerciseItem, error)
 	GetExerciseItemsByWorkoutId(ctx context.Context, arg GetExerciseItemsByWorkoutIdParams) ([]ExerciseItem, error)
 	GetExerciseTypeById(ctx context.Context, arg GetExerciseTypeByIdParams) (ExerciseType, error)
+	GetExerciseTypeHistory(ctx context.Context, arg GetExerciseTypeHistoryParams) ([]GetExerciseTypeHistoryRow, error)
+	GetExerciseTypesByUserId(ctx context.Context, userID string) ([]ExerciseType, error)
 	GetExercisesByExerciseItemId(ctx context.Context, arg GetExercisesByExerciseItemIdParams) ([]Exercise, error)
+	GetExercisesByUserId(ctx context.Context, userID string) ([]Exercise, error)
 	GetExercisesByWorkoutId(ctx context.Context, arg GetExercisesByWorkoutIdParams) ([]Exercise, error)
+	GetGoalById(ctx context.Context, arg GetGoalByIdParams) (Goal, error)
+	GetGoalProgressByGoalId(ctx context.Context, arg GetGoalProgressByGoalIdParams) ([]GoalProgress, error)
+	GetGoalProgressByUserId(ctx context.Context, userID string) ([]GoalProgress, error)
+	GetGoalsByUserId(ctx context.Context, userID string) ([]Goal, error)
+	GetLastPerformedOnByExerciseTypeId(ctx context.Context, arg GetLastPerformedOnByExerciseTypeIdParams) (string, error)
 	GetLastWeightRepsByExerciseTypeId(ctx context.Context, arg GetLastWeightRepsByExerciseTypeIdParams) (GetLastWeightRepsByExerciseTypeIdRow, error)
+	GetLatestBodyweight(ctx context.Context, userID string) (float64, error)
 	GetMaxWeightRepsByExerciseTypeId(ctx context.Context, arg GetMaxWeightRepsByExerciseTypeIdParams) (GetMaxWeightRepsByExerciseTypeIdRow, error)
+	GetMeasuredSetsByExerciseTypeId(ctx context.Context, arg GetMeasuredSetsByExerciseTypeIdParams) ([]GetMeasuredSetsByExerciseTypeIdRow, error)
+	GetMeasurementByExerciseId(ctx context.Context, arg GetMeasurementByExerciseIdParams) (string, error)
+	GetMuscleGroupSetsBetweenDates(ctx context.Context, arg GetMuscleGroupSetsBetweenDatesParams) ([]GetMuscleGroupSetsBetweenDatesRow, error)
+	GetMuscleGroupsByExerciseTypeId(ctx context.Context, arg GetMuscleGroupsByExerciseTypeIdParams) ([]ExerciseTypeMuscleGroup, error)
+	GetMuscleGroupsByUserId(ctx context.Context, userID string) ([]ExerciseTypeMuscleGroup, error)
+	GetNextRoundByExerciseItemId(ctx context.Context, arg GetNextRoundByExerciseItemIdParams) (int64, error)
+	GetPreferencesByUserId(ctx context.Context, userID string) (UserPreference, error)
+	GetPreviousPerformedOnByExerciseId(ctx context.Context, arg GetPreviousPerformedOnByExerciseIdParams) (string, error)
+	GetPreviousSetsForRecordsByExerciseTypeId(ctx context.Context, arg GetPreviousSetsForRecordsByExerciseTypeIdParams) ([]GetPreviousSetsForRecordsByExerciseTypeIdRow, error)
+	GetProgramById(ctx context.Context, arg GetProgramByIdParams) (Program, error)
+	GetProgramDaysByProgramId(ctx context.Context, arg GetProgramDaysByProgramIdParams) ([]ProgramDay, error)
+	GetProgramDaysByUserId(ctx context.Context, userID string) ([]ProgramDay, error)
+	GetProgramEnrollmentsByUserId(ctx context.Context, userID string) ([]ProgramEnrollment, error)
+	GetProgramProgressByEnrollmentId(ctx context.Context, arg GetProgramProgressByEnrollmentIdParams) ([]ProgramProgress, error)
+	GetProgramProgressByUserId(ctx context.Context, userID string) ([]ProgramProgress, error)
+	GetProgramsByUserId(ctx context.Context, userID string) ([]Program, error)
+	GetProgressionRuleByExerciseTypeId(ctx context.Context, arg GetProgressionRuleByExerciseTypeIdParams) (ProgressionRule, error)
+	GetProgressionRulesByUserId(ctx context.Context, userID string) ([]ProgressionRule, error)
+	GetRecordsByUserId(ctx context.Context, userID string) ([]Record, error)
+	GetRecordsByWorkoutId(ctx context.Context, arg GetRecordsByWorkoutIdParams) ([]GetRecordsByWorkoutIdRow, error)
+	GetSessionDurationsBetweenDates(ctx context.Context, arg GetSessionDurationsBetweenDatesParams) (GetSessionDurationsBetweenDatesRow, error)
 	GetSetById(ctx context.Context, arg GetSetByIdParams) (Set, error)
+	GetSetHistoryByExerciseTypeId(ctx context.Context, arg GetSetHistoryByExerciseTypeIdParams) ([]GetSetHistoryByExerciseTypeIdRow, error)
 	GetSetsByExerciseId(ctx context.Context, arg GetSetsByExerciseIdParams) ([]Set, error)
+	GetSetsByUserId(ctx context.Context, userID string) ([]Set, error)
+	GetSetsByWorkoutId(ctx context.Context, arg GetSetsByWorkoutIdParams) ([]Set, error)
+	GetSetsForRecordsByWorkoutId(ctx context.Context, arg GetSetsForRecordsByWorkoutIdParams) ([]GetSetsForRecordsByWorkoutIdRow, error)
 	GetStatisticsBetweenDates(ctx context.Context, arg GetStatisticsBetweenDatesParams) (int64, error)
 	GetStatisticsSinceDate(ctx context.Context, arg GetStatisticsSinceDateParams) (int64, error)
+	GetTemplateById(ctx context.Context, arg GetTemplateByIdParams) (Template, error)
+	GetTemplateExerciseItemsByTemplateId(ctx context.Context, arg GetTemplateExerciseItemsByTemplateIdParams) ([]TemplateExerciseItem, error)
+	GetTemplateExerciseItemsByUserId(ctx context.Context, userID string) ([]TemplateExerciseItem, error)
+	GetTemplateExercisesByTemplateId(ctx context.Context, arg GetTemplateExercisesByTemplateIdParams) ([]TemplateExercise, error)
+	GetTemplateExercisesByUserId(ctx context.Context, userID string) ([]TemplateExercise, error)
+	GetTemplateSetsByTemplateId(ctx context.Context, arg GetTemplateSetsByTemplateIdParams) ([]TemplateSet, error)
+	GetTemplateSetsByUserId(ctx context.Context, userID string) ([]TemplateSet, error)
+	GetTemplatesByUserId(ctx context.Context, userID string) ([]Template, error)
 	GetUnverifiedUsers(ctx context.Context) ([]User, error)
+	GetVolumeBetweenDates(ctx context.Context, arg GetVolumeBetweenDatesParams) (GetVolumeBetweenDatesRow, error)
+	GetVolumePerExerciseItemTypeBetweenDates(ctx context.Context, arg GetVolumePerExerciseItemTypeBetweenDatesParams) ([]GetVolumePerExerciseItemTypeBetweenDatesRow, error)
+	GetVolumePerExerciseTypeBetweenDates(ctx context.Context, arg GetVolumePerExerciseTypeBetweenDatesParams) ([]GetVolumePerExerciseTypeBetweenDatesRow, error)
+	GetVolumeSinceDate(ctx context.Context, arg GetVolumeSinceDateParams) (GetVolumeSinceDateRow, error)
 	GetWorkoutById(ctx context.Context, arg GetWorkoutByIdParams) (Workout, error)
+	GetWorkoutCompletedOn(ctx context.Context, arg GetWorkoutCompletedOnParams) (string, error)
+	GetWorkoutTonnageBetweenDates(ctx context.Context, arg GetWorkoutTonnageBetweenDatesParams) ([]GetWorkoutTonnageBetweenDatesRow, error)
+	GetWorkoutsByUserId(ctx context.Context, userID string) ([]Workout, error)
+	PauseWorkoutById(ctx context.Context, arg PauseWorkoutByIdParams) (int64, error)
 	ReopenWorkoutById(ctx context.Context, arg ReopenWorkoutByIdParams) (int64, error)
-	UpdateExerciseItemType(ctx context.Context, arg UpdateExerciseItemTypeParams) (int64, error)
+	ResumeWorkoutById(ctx context.Context, arg ResumeWorkoutByIdParams) (int64, error)
+	SetWorkoutBodyweightFromBodyMetrics(ctx context.Context, arg SetWorkoutBodyweightFromBodyMetricsParams) (int64, error)
+	StartWorkoutById(ctx context.Context, arg StartWorkoutByIdParams) (int64, error)
+	UpdateBodyMetric(ctx context.Context, arg UpdateBodyMetricParams) (int64, error)
+	UpdateExercise(ctx context.Context, arg UpdateExerciseParams) (int64, error)
+	UpdateExerciseItem(ctx context.Context, arg UpdateExerciseItemParams) (int64, error)
+	UpdateExerciseItemPosition(ctx context.Context, arg UpdateExerciseItemPositionParams) (int64, error)
+	UpdateExercisePosition(ctx context.Context, arg UpdateExercisePositionParams) (int64, error)
 	UpdateExerciseType(ctx context.Context, arg UpdateExerciseTypeParams) (int64, error)
+	UpdateExerciseTypeMetadata(ctx context.Context, arg UpdateExerciseTypeMetadataParams) (int64, error)
+	UpdateExerciseTypeRestSeconds(ctx context.Context, arg UpdateExerciseTypeRestSecondsParams) (int64, error)
+	UpdateGoalAchievedOn(ctx context.Context, arg UpdateGoalAchievedOnParams) (int64, error)
+	UpdateSet(ctx context.Context, arg UpdateSetParams) (int64, error)
+	UpdateSetPosition(ctx context.Context, arg UpdateSetPositionParams) (int64, error)
+	UpdateTemplateById(ctx context.Context, arg UpdateTemplateByIdParams) (int64, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (int64, error)
+	UpdateWorkoutBodyweight(ctx context.Context, arg UpdateWorkoutBodyweightParams) (int64, error)
 	UpdateWorkoutById(ctx context.Context, arg UpdateWorkoutByIdParams) (int64, error)
+	UpsertPreferences(ctx context.Context, arg UpsertPreferencesParams) (int64, error)
+	UpsertProgressionRule(ctx context.Context, arg UpsertProgressionRuleParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
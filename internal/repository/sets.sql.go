@@ -11,21 +11,32 @@ import (
 
 const createSetAndReturnId = `-- name: CreateSetAndReturnId :one
 INSERT INTO sets (
-  id, repetitions, weight, exercise_id, created_on, updated_on, user_id
+  id, repetitions, weight, duration_seconds, distance_meters, type, rpe, rir, note, round_number, performed_on, rest_seconds, exercise_id, position, created_on, updated_on, user_id
 ) VALUES (
-  ?1, ?2, ?3, ?4, ?5, ?6, ?7
+  ?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12, ?13,
+  (SELECT COALESCE(MAX(position) + 1, 0) FROM sets WHERE exercise_id = ?13 AND user_id = ?14),
+  ?15, ?16, ?14
 )
 RETURNING id
 `
 
 type CreateSetAndReturnIdParams struct {
-	ID          string  `json:"id"`
-	Repetitions int64   `json:"repetitions"`
-	Weight      float64 `json:"weight"`
-	ExerciseID  string  `json:"exercise_id"`
-	CreatedOn   string  `json:"created_on"`
-	UpdatedOn   string  `json:"updated_on"`
-	UserID      string  `json:"user_id"`
+	ID              string      `json:"id"`
+	Repetitions     int64       `json:"repetitions"`
+	Weight          float64     `json:"weight"`
+	DurationSeconds interface{} `json:"duration_seconds"`
+	DistanceMeters  interface{} `json:"distance_meters"`
+	Type            string      `json:"type"`
+	Rpe             interface{} `json:"rpe"`
+	Rir             interface{} `json:"rir"`
+	Note            interface{} `json:"note"`
+	RoundNumber     interface{} `json:"round_number"`
+	PerformedOn     interface{} `json:"performed_on"`
+	RestSeconds     interface{} `json:"rest_seconds"`
+	ExerciseID      string      `json:"exercise_id"`
+	UserID          string      `json:"user_id"`
+	CreatedOn       string      `json:"created_on"`
+	UpdatedOn       string      `json:"updated_on"`
 }
 
 func (q *Queries) CreateSetAndReturnId(ctx context.Context, arg CreateSetAndReturnIdParams) (string, error) {
@@ -33,10 +44,19 @@ func (q *Queries) CreateSetAndReturnId(ctx context.Context, arg CreateSetAndRetu
 		arg.ID,
 		arg.Repetitions,
 		arg.Weight,
+		arg.DurationSeconds,
+		arg.DistanceMeters,
+		arg.Type,
+		arg.Rpe,
+		arg.Rir,
+		arg.Note,
+		arg.RoundNumber,
+		arg.PerformedOn,
+		arg.RestSeconds,
 		arg.ExerciseID,
+		arg.UserID,
 		arg.CreatedOn,
 		arg.UpdatedOn,
-		arg.UserID,
 	)
 	var id string
 	err := row.Scan(&id)
@@ -63,7 +83,7 @@ func (q *Queries) DeleteSetById(ctx context.Context, arg DeleteSetByIdParams) (i
 }
 
 const getAllSets = `-- name: GetAllSets :many
-SELECT id, repetitions, weight, created_on, updated_on, user_id, exercise_id, "foreign" FROM sets 
+SELECT id, repetitions, weight, created_on, updated_on, user_id, exercise_id, "foreign", type, rpe, rir, note, position, duration_seconds, distance_meters, round_number, performed_on, rest_seconds FROM sets 
 WHERE user_id = ?1
 ORDER by id
 `
@@ -86,6 +106,16 @@ func (q *Queries) GetAllSets(ctx context.Context, userID string) ([]Set, error)
 			&i.UserID,
 			&i.ExerciseID,
 			&i.Foreign,
+			&i.Type,
+			&i.Rpe,
+			&i.Rir,
+			&i.Note,
+			&i.Position,
+			&i.DurationSeconds,
+			&i.DistanceMeters,
+			&i.RoundNumber,
+			&i.PerformedOn,
+			&i.RestSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -100,8 +130,77 @@ func (q *Queries) GetAllSets(ctx context.Context, userID string) ([]Set, error)
 	return items, nil
 }
 
+const getMeasurementByExerciseId = `-- name: GetMeasurementByExerciseId :one
+SELECT t.measurement FROM exercises e
+JOIN exercise_types t ON t.id = e.exercise_type_id
+WHERE e.id = ?1
+AND e.user_id = ?2
+`
+
+type GetMeasurementByExerciseIdParams struct {
+	ExerciseID string `json:"exercise_id"`
+	UserID     string `json:"user_id"`
+}
+
+func (q *Queries) GetMeasurementByExerciseId(ctx context.Context, arg GetMeasurementByExerciseIdParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getMeasurementByExerciseId, arg.ExerciseID, arg.UserID)
+	var measurement string
+	err := row.Scan(&measurement)
+	return measurement, err
+}
+
+const getNextRoundByExerciseItemId = `-- name: GetNextRoundByExerciseItemId :one
+SELECT CAST(COALESCE(MAX(s.round_number), 0) + 1 AS INTEGER) as next_round FROM sets s
+JOIN exercises e ON s.exercise_id = e.id
+WHERE e.exercise_item_id = ?1
+AND s.user_id = ?2
+`
+
+type GetNextRoundByExerciseItemIdParams struct {
+	ExerciseItemID string `json:"exercise_item_id"`
+	UserID         string `json:"user_id"`
+}
+
+func (q *Queries) GetNextRoundByExerciseItemId(ctx context.Context, arg GetNextRoundByExerciseItemIdParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getNextRoundByExerciseItemId, arg.ExerciseItemID, arg.UserID)
+	var next_round int64
+	err := row.Scan(&next_round)
+	return next_round, err
+}
+
+const getPreviousPerformedOnByExerciseId = `-- name: GetPreviousPerformedOnByExerciseId :one
+SELECT CAST(s.performed_on AS TEXT) as performed_on FROM sets s
+JOIN exercises e ON s.exercise_id = e.id
+WHERE e.workout_id = (SELECT workout_id FROM exercises WHERE exercises.id = ?1 AND exercises.user_id = ?2)
+AND s.user_id = ?2
+AND s.id != ?3
+AND s.performed_on IS NOT NULL
+AND s.performed_on <= ?4
+ORDER BY s.performed_on DESC, s.id DESC
+LIMIT 1
+`
+
+type GetPreviousPerformedOnByExerciseIdParams struct {
+	ExerciseID  string      `json:"exercise_id"`
+	UserID      string      `json:"user_id"`
+	ID          string      `json:"id"`
+	PerformedOn interface{} `json:"performed_on"`
+}
+
+func (q *Queries) GetPreviousPerformedOnByExerciseId(ctx context.Context, arg GetPreviousPerformedOnByExerciseIdParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getPreviousPerformedOnByExerciseId,
+		arg.ExerciseID,
+		arg.UserID,
+		arg.ID,
+		arg.PerformedOn,
+	)
+	var performed_on string
+	err := row.Scan(&performed_on)
+	return performed_on, err
+}
+
 const getSetById = `-- name: GetSetById :one
-SELECT id, repetitions, weight, created_on, updated_on, user_id, exercise_id, "foreign" FROM sets 
+SELECT id, repetitions, weight, created_on, updated_on, user_id, exercise_id, "foreign", type, rpe, rir, note, position, duration_seconds, distance_meters, round_number, performed_on, rest_seconds FROM sets 
 WHERE id = ?1 AND user_id = ?2
 `
 
@@ -122,14 +221,25 @@ func (q *Queries) GetSetById(ctx context.Context, arg GetSetByIdParams) (Set, er
 		&i.UserID,
 		&i.ExerciseID,
 		&i.Foreign,
+		&i.Type,
+		&i.Rpe,
+		&i.Rir,
+		&i.Note,
+		&i.Position,
+		&i.DurationSeconds,
+		&i.DistanceMeters,
+		&i.RoundNumber,
+		&i.PerformedOn,
+		&i.RestSeconds,
 	)
 	return i, err
 }
 
 const getSetsByExerciseId = `-- name: GetSetsByExerciseId :many
-SELECT id, repetitions, weight, created_on, updated_on, user_id, exercise_id, "foreign" FROM sets 
+SELECT id, repetitions, weight, created_on, updated_on, user_id, exercise_id, "foreign", type, rpe, rir, note, position, duration_seconds, distance_meters, round_number, performed_on, rest_seconds FROM sets 
 WHERE exercise_id = ?1
 AND user_id = ?2
+ORDER BY position, id
 `
 
 type GetSetsByExerciseIdParams struct {
@@ -155,6 +265,16 @@ func (q *Queries) GetSetsByExerciseId(ctx context.Context, arg GetSetsByExercise
 			&i.UserID,
 			&i.ExerciseID,
 			&i.Foreign,
+			&i.Type,
+			&i.Rpe,
+			&i.Rir,
+			&i.Note,
+			&i.Position,
+			&i.DurationSeconds,
+			&i.DistanceMeters,
+			&i.RoundNumber,
+			&i.PerformedOn,
+			&i.RestSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -168,3 +288,140 @@ func (q *Queries) GetSetsByExerciseId(ctx context.Context, arg GetSetsByExercise
 	}
 	return items, nil
 }
+
+const getSetsByWorkoutId = `-- name: GetSetsByWorkoutId :many
+SELECT id, repetitions, weight, created_on, updated_on, user_id, exercise_id, "foreign", type, rpe, rir, note, position, duration_seconds, distance_meters, round_number, performed_on, rest_seconds FROM sets
+WHERE exercise_id IN (SELECT e.id FROM exercises e WHERE e.workout_id = ?1 AND e.user_id = ?2)
+AND user_id = ?2
+ORDER BY (SELECT i.position FROM exercises e JOIN exercise_items i ON i.id = e.exercise_item_id WHERE e.id = sets.exercise_id),
+(SELECT e.position FROM exercises e WHERE e.id = sets.exercise_id), position, id
+`
+
+type GetSetsByWorkoutIdParams struct {
+	WorkoutID string `json:"workout_id"`
+	UserID    string `json:"user_id"`
+}
+
+func (q *Queries) GetSetsByWorkoutId(ctx context.Context, arg GetSetsByWorkoutIdParams) ([]Set, error) {
+	rows, err := q.db.QueryContext(ctx, getSetsByWorkoutId, arg.WorkoutID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Set{}
+	for rows.Next() {
+		var i Set
+		if err := rows.Scan(
+			&i.ID,
+			&i.Repetitions,
+			&i.Weight,
+			&i.CreatedOn,
+			&i.UpdatedOn,
+			&i.UserID,
+			&i.ExerciseID,
+			&i.Foreign,
+			&i.Type,
+			&i.Rpe,
+			&i.Rir,
+			&i.Note,
+			&i.Position,
+			&i.DurationSeconds,
+			&i.DistanceMeters,
+			&i.RoundNumber,
+			&i.PerformedOn,
+			&i.RestSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateSet = `-- name: UpdateSet :execrows
+UPDATE sets
+SET repetitions = ?1,
+weight = ?2,
+duration_seconds = ?3,
+distance_meters = ?4,
+type = ?5,
+rpe = ?6,
+rir = ?7,
+note = ?8,
+performed_on = ?9,
+rest_seconds = ?10,
+updated_on = ?11
+WHERE id = ?12
+AND user_id = ?13
+`
+
+type UpdateSetParams struct {
+	Repetitions     int64       `json:"repetitions"`
+	Weight          float64     `json:"weight"`
+	DurationSeconds interface{} `json:"duration_seconds"`
+	DistanceMeters  interface{} `json:"distance_meters"`
+	Type            string      `json:"type"`
+	Rpe             interface{} `json:"rpe"`
+	Rir             interface{} `json:"rir"`
+	Note            interface{} `json:"note"`
+	PerformedOn     interface{} `json:"performed_on"`
+	RestSeconds     interface{} `json:"rest_seconds"`
+	UpdatedOn       string      `json:"updated_on"`
+	ID              string      `json:"id"`
+	UserID          string      `json:"user_id"`
+}
+
+func (q *Queries) UpdateSet(ctx context.Context, arg UpdateSetParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateSet,
+		arg.Repetitions,
+		arg.Weight,
+		arg.DurationSeconds,
+		arg.DistanceMeters,
+		arg.Type,
+		arg.Rpe,
+		arg.Rir,
+		arg.Note,
+		arg.PerformedOn,
+		arg.RestSeconds,
+		arg.UpdatedOn,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateSetPosition = `-- name: UpdateSetPosition :execrows
+UPDATE sets
+SET position = ?1, updated_on = ?2
+WHERE id = ?3
+AND user_id = ?4
+`
+
+type UpdateSetPositionParams struct {
+	Position  int64  `json:"position"`
+	UpdatedOn string `json:"updated_on"`
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+}
+
+func (q *Queries) UpdateSetPosition(ctx context.Context, arg UpdateSetPositionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateSetPosition,
+		arg.Position,
+		arg.UpdatedOn,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
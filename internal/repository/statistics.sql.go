@@ -9,12 +9,118 @@ import (
 	"context"
 )
 
+const getCompletedOnByUserId = `-- name: GetCompletedOnByUserId :many
+SELECT CAST(completed_on AS TEXT) as completed_on FROM
+workouts
+WHERE user_id = ?1 AND
+completed_on IS NOT NULL
+ORDER BY completed_on ASC
+`
+
+func (q *Queries) GetCompletedOnByUserId(ctx context.Context, userID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getCompletedOnByUserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var completed_on string
+		if err := rows.Scan(&completed_on); err != nil {
+			return nil, err
+		}
+		items = append(items, completed_on)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMuscleGroupSetsBetweenDates = `-- name: GetMuscleGroupSetsBetweenDates :many
+SELECT CAST(w.completed_on AS TEXT) as completed_on, mg.muscle_group, mg.role FROM
+sets s
+JOIN exercises e ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN exercise_type_muscle_groups mg ON mg.exercise_type_id = e.exercise_type_id
+WHERE w.user_id = ?1 AND
+s.type != 'warmup' AND
+w.completed_on >= ?2 AND
+w.completed_on < ?3
+ORDER BY w.completed_on ASC
+`
+
+type GetMuscleGroupSetsBetweenDatesParams struct {
+	UserID    string      `json:"user_id"`
+	StartDate interface{} `json:"start_date"`
+	EndDate   interface{} `json:"end_date"`
+}
+
+type GetMuscleGroupSetsBetweenDatesRow struct {
+	CompletedOn string `json:"completed_on"`
+	MuscleGroup string `json:"muscle_group"`
+	Role        string `json:"role"`
+}
+
+func (q *Queries) GetMuscleGroupSetsBetweenDates(ctx context.Context, arg GetMuscleGroupSetsBetweenDatesParams) ([]GetMuscleGroupSetsBetweenDatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMuscleGroupSetsBetweenDates, arg.UserID, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMuscleGroupSetsBetweenDatesRow{}
+	for rows.Next() {
+		var i GetMuscleGroupSetsBetweenDatesRow
+		if err := rows.Scan(&i.CompletedOn, &i.MuscleGroup, &i.Role); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSessionDurationsBetweenDates = `-- name: GetSessionDurationsBetweenDates :one
+SELECT count(*) as session_count, CAST(COALESCE(SUM(active_seconds), 0) AS INTEGER) as active_seconds FROM
+workouts
+WHERE user_id = ?1 AND
+started_on IS NOT NULL AND
+completed_on >= ?2 AND
+completed_on < ?3
+`
+
+type GetSessionDurationsBetweenDatesParams struct {
+	UserID    string      `json:"user_id"`
+	StartDate interface{} `json:"start_date"`
+	EndDate   interface{} `json:"end_date"`
+}
+
+type GetSessionDurationsBetweenDatesRow struct {
+	SessionCount  int64 `json:"session_count"`
+	ActiveSeconds int64 `json:"active_seconds"`
+}
+
+func (q *Queries) GetSessionDurationsBetweenDates(ctx context.Context, arg GetSessionDurationsBetweenDatesParams) (GetSessionDurationsBetweenDatesRow, error) {
+	row := q.db.QueryRowContext(ctx, getSessionDurationsBetweenDates, arg.UserID, arg.StartDate, arg.EndDate)
+	var i GetSessionDurationsBetweenDatesRow
+	err := row.Scan(&i.SessionCount, &i.ActiveSeconds)
+	return i, err
+}
+
 const getStatisticsBetweenDates = `-- name: GetStatisticsBetweenDates :one
 SELECT count(*) FROM
 workouts
 WHERE user_id = ?1 AND
 completed_on >= ?2 AND
-completed_on <= ?3
+completed_on < ?3
 `
 
 type GetStatisticsBetweenDatesParams struct {
@@ -48,3 +154,249 @@ func (q *Queries) GetStatisticsSinceDate(ctx context.Context, arg GetStatisticsS
 	err := row.Scan(&count)
 	return count, err
 }
+
+const getVolumeBetweenDates = `-- name: GetVolumeBetweenDates :one
+SELECT CAST(COALESCE(SUM((s.weight + CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END) * s.repetitions), 0) AS REAL) as tonnage, count(s.id) as set_count, CAST(COALESCE(SUM(s.repetitions), 0) AS INTEGER) as repetitions,
+CAST(COALESCE(SUM(s.duration_seconds), 0) AS INTEGER) as duration_seconds, CAST(COALESCE(SUM(s.distance_meters), 0) AS REAL) as distance_meters FROM
+sets s
+JOIN exercises e ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN exercise_types et ON e.exercise_type_id = et.id
+WHERE w.user_id = ?1 AND
+s.type != 'warmup' AND
+w.completed_on >= ?2 AND
+w.completed_on < ?3
+`
+
+type GetVolumeBetweenDatesParams struct {
+	UserID    string      `json:"user_id"`
+	StartDate interface{} `json:"start_date"`
+	EndDate   interface{} `json:"end_date"`
+}
+
+type GetVolumeBetweenDatesRow struct {
+	Tonnage         float64 `json:"tonnage"`
+	SetCount        int64   `json:"set_count"`
+	Repetitions     int64   `json:"repetitions"`
+	DurationSeconds int64   `json:"duration_seconds"`
+	DistanceMeters  float64 `json:"distance_meters"`
+}
+
+func (q *Queries) GetVolumeBetweenDates(ctx context.Context, arg GetVolumeBetweenDatesParams) (GetVolumeBetweenDatesRow, error) {
+	row := q.db.QueryRowContext(ctx, getVolumeBetweenDates, arg.UserID, arg.StartDate, arg.EndDate)
+	var i GetVolumeBetweenDatesRow
+	err := row.Scan(
+		&i.Tonnage,
+		&i.SetCount,
+		&i.Repetitions,
+		&i.DurationSeconds,
+		&i.DistanceMeters,
+	)
+	return i, err
+}
+
+const getVolumePerExerciseItemTypeBetweenDates = `-- name: GetVolumePerExerciseItemTypeBetweenDates :many
+SELECT ei.type, count(DISTINCT ei.id) as item_count, count(DISTINCT ei.id || ':' || s.round_number) as round_count,
+CAST(COALESCE(SUM((s.weight + CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END) * s.repetitions), 0) AS REAL) as tonnage, count(s.id) as set_count FROM
+sets s
+JOIN exercises e ON s.exercise_id = e.id
+JOIN exercise_items ei ON e.exercise_item_id = ei.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN exercise_types et ON e.exercise_type_id = et.id
+WHERE w.user_id = ?1 AND
+s.type != 'warmup' AND
+w.completed_on >= ?2 AND
+w.completed_on < ?3
+GROUP BY ei.type
+ORDER BY ei.type ASC
+`
+
+type GetVolumePerExerciseItemTypeBetweenDatesParams struct {
+	UserID    string      `json:"user_id"`
+	StartDate interface{} `json:"start_date"`
+	EndDate   interface{} `json:"end_date"`
+}
+
+type GetVolumePerExerciseItemTypeBetweenDatesRow struct {
+	Type       string  `json:"type"`
+	ItemCount  int64   `json:"item_count"`
+	RoundCount int64   `json:"round_count"`
+	Tonnage    float64 `json:"tonnage"`
+	SetCount   int64   `json:"set_count"`
+}
+
+func (q *Queries) GetVolumePerExerciseItemTypeBetweenDates(ctx context.Context, arg GetVolumePerExerciseItemTypeBetweenDatesParams) ([]GetVolumePerExerciseItemTypeBetweenDatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getVolumePerExerciseItemTypeBetweenDates, arg.UserID, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetVolumePerExerciseItemTypeBetweenDatesRow{}
+	for rows.Next() {
+		var i GetVolumePerExerciseItemTypeBetweenDatesRow
+		if err := rows.Scan(
+			&i.Type,
+			&i.ItemCount,
+			&i.RoundCount,
+			&i.Tonnage,
+			&i.SetCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVolumePerExerciseTypeBetweenDates = `-- name: GetVolumePerExerciseTypeBetweenDates :many
+SELECT e.exercise_type_id, et.name, CAST(COALESCE(SUM((s.weight + CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END) * s.repetitions), 0) AS REAL) as tonnage, count(s.id) as set_count, CAST(COALESCE(SUM(s.repetitions), 0) AS INTEGER) as repetitions,
+CAST(COALESCE(SUM(s.duration_seconds), 0) AS INTEGER) as duration_seconds, CAST(COALESCE(SUM(s.distance_meters), 0) AS REAL) as distance_meters FROM
+sets s
+JOIN exercises e ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN exercise_types et ON e.exercise_type_id = et.id
+WHERE w.user_id = ?1 AND
+s.type != 'warmup' AND
+w.completed_on >= ?2 AND
+w.completed_on < ?3
+GROUP BY e.exercise_type_id, et.name
+ORDER BY et.name ASC
+`
+
+type GetVolumePerExerciseTypeBetweenDatesParams struct {
+	UserID    string      `json:"user_id"`
+	StartDate interface{} `json:"start_date"`
+	EndDate   interface{} `json:"end_date"`
+}
+
+type GetVolumePerExerciseTypeBetweenDatesRow struct {
+	ExerciseTypeID  string  `json:"exercise_type_id"`
+	Name            string  `json:"name"`
+	Tonnage         float64 `json:"tonnage"`
+	SetCount        int64   `json:"set_count"`
+	Repetitions     int64   `json:"repetitions"`
+	DurationSeconds int64   `json:"duration_seconds"`
+	DistanceMeters  float64 `json:"distance_meters"`
+}
+
+func (q *Queries) GetVolumePerExerciseTypeBetweenDates(ctx context.Context, arg GetVolumePerExerciseTypeBetweenDatesParams) ([]GetVolumePerExerciseTypeBetweenDatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getVolumePerExerciseTypeBetweenDates, arg.UserID, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetVolumePerExerciseTypeBetweenDatesRow{}
+	for rows.Next() {
+		var i GetVolumePerExerciseTypeBetweenDatesRow
+		if err := rows.Scan(
+			&i.ExerciseTypeID,
+			&i.Name,
+			&i.Tonnage,
+			&i.SetCount,
+			&i.Repetitions,
+			&i.DurationSeconds,
+			&i.DistanceMeters,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVolumeSinceDate = `-- name: GetVolumeSinceDate :one
+SELECT CAST(COALESCE(SUM((s.weight + CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END) * s.repetitions), 0) AS REAL) as tonnage, count(s.id) as set_count, CAST(COALESCE(SUM(s.repetitions), 0) AS INTEGER) as repetitions,
+CAST(COALESCE(SUM(s.duration_seconds), 0) AS INTEGER) as duration_seconds, CAST(COALESCE(SUM(s.distance_meters), 0) AS REAL) as distance_meters FROM
+sets s
+JOIN exercises e ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN exercise_types et ON e.exercise_type_id = et.id
+WHERE w.user_id = ?1 AND
+s.type != 'warmup' AND
+w.completed_on >= ?2
+`
+
+type GetVolumeSinceDateParams struct {
+	UserID    string      `json:"user_id"`
+	StartDate interface{} `json:"start_date"`
+}
+
+type GetVolumeSinceDateRow struct {
+	Tonnage         float64 `json:"tonnage"`
+	SetCount        int64   `json:"set_count"`
+	Repetitions     int64   `json:"repetitions"`
+	DurationSeconds int64   `json:"duration_seconds"`
+	DistanceMeters  float64 `json:"distance_meters"`
+}
+
+func (q *Queries) GetVolumeSinceDate(ctx context.Context, arg GetVolumeSinceDateParams) (GetVolumeSinceDateRow, error) {
+	row := q.db.QueryRowContext(ctx, getVolumeSinceDate, arg.UserID, arg.StartDate)
+	var i GetVolumeSinceDateRow
+	err := row.Scan(
+		&i.Tonnage,
+		&i.SetCount,
+		&i.Repetitions,
+		&i.DurationSeconds,
+		&i.DistanceMeters,
+	)
+	return i, err
+}
+
+const getWorkoutTonnageBetweenDates = `-- name: GetWorkoutTonnageBetweenDates :many
+SELECT CAST(w.completed_on AS TEXT) as completed_on, CAST(COALESCE(SUM((s.weight + CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END) * s.repetitions), 0) AS REAL) as tonnage FROM
+workouts w
+LEFT JOIN exercises e ON e.workout_id = w.id
+LEFT JOIN exercise_types et ON e.exercise_type_id = et.id
+LEFT JOIN sets s ON s.exercise_id = e.id AND s.type != 'warmup'
+WHERE w.user_id = ?1 AND
+w.completed_on >= ?2 AND
+w.completed_on < ?3
+GROUP BY w.id, w.completed_on
+ORDER BY w.completed_on ASC
+`
+
+type GetWorkoutTonnageBetweenDatesParams struct {
+	UserID    string      `json:"user_id"`
+	StartDate interface{} `json:"start_date"`
+	EndDate   interface{} `json:"end_date"`
+}
+
+type GetWorkoutTonnageBetweenDatesRow struct {
+	CompletedOn string  `json:"completed_on"`
+	Tonnage     float64 `json:"tonnage"`
+}
+
+func (q *Queries) GetWorkoutTonnageBetweenDates(ctx context.Context, arg GetWorkoutTonnageBetweenDatesParams) ([]GetWorkoutTonnageBetweenDatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkoutTonnageBetweenDates, arg.UserID, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetWorkoutTonnageBetweenDatesRow{}
+	for rows.Next() {
+		var i GetWorkoutTonnageBetweenDatesRow
+		if err := rows.Scan(&i.CompletedOn, &i.Tonnage); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -11,9 +11,12 @@ import (
 
 const completeWorkoutById = `-- name: CompleteWorkoutById :execrows
 UPDATE workouts 
-SET completed_on = ?1, updated_on = ?2
+SET completed_on = ?1, updated_on = ?2,
+active_seconds = active_seconds + CASE WHEN resumed_on IS NULL THEN 0 ELSE MAX(strftime('%s', ?1) - strftime('%s', resumed_on), 0) END,
+resumed_on = NULL
 WHERE id = ?3
 AND user_id = ?4
+AND completed_on IS NULL
 `
 
 type CompleteWorkoutByIdParams struct {
@@ -36,6 +39,46 @@ func (q *Queries) CompleteWorkoutById(ctx context.Context, arg CompleteWorkoutBy
 	return result.RowsAffected()
 }
 
+const createCompletedWorkoutAndReturnId = `-- name: CreateCompletedWorkoutAndReturnId :one
+INSERT INTO workouts (
+  id, name, note, started_on, completed_on, active_seconds, average_heart_rate, created_on, updated_on, user_id
+) VALUES (
+  ?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10
+)
+RETURNING id
+`
+
+type CreateCompletedWorkoutAndReturnIdParams struct {
+	ID               string      `json:"id"`
+	Name             string      `json:"name"`
+	Note             interface{} `json:"note"`
+	StartedOn        string      `json:"started_on"`
+	CompletedOn      interface{} `json:"completed_on"`
+	ActiveSeconds    int64       `json:"active_seconds"`
+	AverageHeartRate interface{} `json:"average_heart_rate"`
+	CreatedOn        string      `json:"created_on"`
+	UpdatedOn        string      `json:"updated_on"`
+	UserID           string      `json:"user_id"`
+}
+
+func (q *Queries) CreateCompletedWorkoutAndReturnId(ctx context.Context, arg CreateCompletedWorkoutAndReturnIdParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, createCompletedWorkoutAndReturnId,
+		arg.ID,
+		arg.Name,
+		arg.Note,
+		arg.StartedOn,
+		arg.CompletedOn,
+		arg.ActiveSeconds,
+		arg.AverageHeartRate,
+		arg.CreatedOn,
+		arg.UpdatedOn,
+		arg.UserID,
+	)
+	var id string
+	err := row.Scan(&id)
+	return id, err
+}
+
 const createWorkoutAndReturnId = `-- name: CreateWorkoutAndReturnId :one
 INSERT INTO workouts (
   id, name, created_on, updated_on, user_id
@@ -86,7 +129,7 @@ func (q *Queries) DeleteWorkoutById(ctx context.Context, arg DeleteWorkoutByIdPa
 }
 
 const getAllWorkouts = `-- name: GetAllWorkouts :many
-SELECT id, name, completed_on, created_on, updated_on, user_id, note FROM workouts 
+SELECT id, name, completed_on, created_on, updated_on, user_id, note, bodyweight, started_on, resumed_on, active_seconds, average_heart_rate FROM workouts 
 WHERE user_id = ?1
 ORDER BY id DESC
 LIMIT ?3 OFFSET ?2
@@ -115,6 +158,11 @@ func (q *Queries) GetAllWorkouts(ctx context.Context, arg GetAllWorkoutsParams)
 			&i.UpdatedOn,
 			&i.UserID,
 			&i.Note,
+			&i.Bodyweight,
+			&i.StartedOn,
+			&i.ResumedOn,
+			&i.ActiveSeconds,
+			&i.AverageHeartRate,
 		); err != nil {
 			return nil, err
 		}
@@ -142,7 +190,7 @@ func (q *Queries) GetAllWorkoutsCount(ctx context.Context, userID string) (int64
 }
 
 const getWorkoutById = `-- name: GetWorkoutById :one
-SELECT id, name, completed_on, created_on, updated_on, user_id, note FROM workouts 
+SELECT id, name, completed_on, created_on, updated_on, user_id, note, bodyweight, started_on, resumed_on, active_seconds, average_heart_rate FROM workouts 
 WHERE id = ?1
 AND user_id = ?2
 `
@@ -163,10 +211,57 @@ func (q *Queries) GetWorkoutById(ctx context.Context, arg GetWorkoutByIdParams)
 		&i.UpdatedOn,
 		&i.UserID,
 		&i.Note,
+		&i.Bodyweight,
+		&i.StartedOn,
+		&i.ResumedOn,
+		&i.ActiveSeconds,
+		&i.AverageHeartRate,
 	)
 	return i, err
 }
 
+const getWorkoutCompletedOn = `-- name: GetWorkoutCompletedOn :one
+SELECT CAST(completed_on AS TEXT) as completed_on FROM workouts
+WHERE id = ?1
+AND user_id = ?2
+AND completed_on IS NOT NULL
+`
+
+type GetWorkoutCompletedOnParams struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+}
+
+func (q *Queries) GetWorkoutCompletedOn(ctx context.Context, arg GetWorkoutCompletedOnParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getWorkoutCompletedOn, arg.ID, arg.UserID)
+	var completed_on string
+	err := row.Scan(&completed_on)
+	return completed_on, err
+}
+
+const pauseWorkoutById = `-- name: PauseWorkoutById :execrows
+UPDATE workouts
+SET active_seconds = active_seconds + MAX(strftime('%s', ?1) - strftime('%s', resumed_on), 0), resumed_on = NULL, updated_on = ?1
+WHERE id = ?2
+AND user_id = ?3
+AND resumed_on IS NOT NULL
+AND completed_on IS NULL
+`
+
+type PauseWorkoutByIdParams struct {
+	PausedOn string `json:"paused_on"`
+	ID       string `json:"id"`
+	UserID   string `json:"user_id"`
+}
+
+func (q *Queries) PauseWorkoutById(ctx context.Context, arg PauseWorkoutByIdParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, pauseWorkoutById, arg.PausedOn, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const reopenWorkoutById = `-- name: ReopenWorkoutById :execrows
 UPDATE workouts
 SET completed_on = NULL, updated_on = ?1
@@ -188,6 +283,80 @@ func (q *Queries) ReopenWorkoutById(ctx context.Context, arg ReopenWorkoutByIdPa
 	return result.RowsAffected()
 }
 
+const resumeWorkoutById = `-- name: ResumeWorkoutById :execrows
+UPDATE workouts
+SET resumed_on = ?1, updated_on = ?1
+WHERE id = ?2
+AND user_id = ?3
+AND started_on IS NOT NULL
+AND resumed_on IS NULL
+AND completed_on IS NULL
+`
+
+type ResumeWorkoutByIdParams struct {
+	ResumedOn string `json:"resumed_on"`
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+}
+
+func (q *Queries) ResumeWorkoutById(ctx context.Context, arg ResumeWorkoutByIdParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resumeWorkoutById, arg.ResumedOn, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const startWorkoutById = `-- name: StartWorkoutById :execrows
+UPDATE workouts
+SET started_on = ?1, resumed_on = ?1, updated_on = ?1
+WHERE id = ?2
+AND user_id = ?3
+AND started_on IS NULL
+AND completed_on IS NULL
+`
+
+type StartWorkoutByIdParams struct {
+	StartedOn string `json:"started_on"`
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+}
+
+func (q *Queries) StartWorkoutById(ctx context.Context, arg StartWorkoutByIdParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, startWorkoutById, arg.StartedOn, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateWorkoutBodyweight = `-- name: UpdateWorkoutBodyweight :execrows
+UPDATE workouts
+SET bodyweight = ?1, updated_on = ?2
+WHERE id = ?3
+AND user_id = ?4
+`
+
+type UpdateWorkoutBodyweightParams struct {
+	Bodyweight interface{} `json:"bodyweight"`
+	UpdatedOn  string      `json:"updated_on"`
+	ID         string      `json:"id"`
+	UserID     string      `json:"user_id"`
+}
+
+func (q *Queries) UpdateWorkoutBodyweight(ctx context.Context, arg UpdateWorkoutBodyweightParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateWorkoutBodyweight,
+		arg.Bodyweight,
+		arg.UpdatedOn,
+		arg.ID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateWorkoutById = `-- name: UpdateWorkoutById :execrows
 UPDATE workouts
 SET note = ?1, updated_on = ?2
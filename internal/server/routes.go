@@ -9,13 +9,23 @@ import (
 	"net/http"
 	"os"
 	"time"
+	"weight-tracker/internal/account"
+	"weight-tracker/internal/bodymetrics"
 	"weight-tracker/internal/exerciseitems"
 	"weight-tracker/internal/exercises"
 	"weight-tracker/internal/exercisetypes"
+	"weight-tracker/internal/export"
+	"weight-tracker/internal/goals"
+	"weight-tracker/internal/imports"
+	"weight-tracker/internal/insights"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/programs"
 	"weight-tracker/internal/ratelimiter"
+	"weight-tracker/internal/records"
 	"weight-tracker/internal/repository"
 	"weight-tracker/internal/sets"
 	"weight-tracker/internal/statistics"
+	"weight-tracker/internal/templates"
 	"weight-tracker/internal/users"
 	"weight-tracker/internal/utils"
 	"weight-tracker/internal/workouts"
@@ -45,6 +55,26 @@ func (s *Server) RegisterRoutes() http.Handler {
 
 	statistics.AddEndpoints(mux, s.db, s.AuthenticatedMiddleware)
 
+	templates.AddEndpoints(mux, s.db, s.AuthenticatedMiddleware)
+
+	programs.AddEndpoints(mux, s.db, s.AuthenticatedMiddleware)
+
+	records.AddEndpoints(mux, s.db, s.AuthenticatedMiddleware)
+
+	bodymetrics.AddEndpoints(mux, s.db, s.AuthenticatedMiddleware)
+
+	preferences.AddEndpoints(mux, s.db, s.AuthenticatedMiddleware)
+
+	goals.AddEndpoints(mux, s.db, s.AuthenticatedMiddleware)
+
+	insights.AddEndpoints(mux, s.db, s.AuthenticatedMiddleware)
+
+	export.AddEndpoints(mux, s.db, s.AuthenticatedMiddleware)
+
+	imports.AddEndpoints(mux, s.db, s.AuthenticatedMiddleware)
+
+	account.AddEndpoints(mux, s.db, s.AuthenticatedMiddleware)
+
 	return s.corsMiddleware(s.loggingMiddleware(mux))
 }
 
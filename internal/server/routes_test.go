@@ -203,6 +203,10 @@ func (m *dbStub) GetRepository() repository.Querier {
 	return m.repo
 }
 
+func (m *dbStub) WithTx(ctx context.Context, fn func(repository.Querier) error) error {
+	return fn(m.repo)
+}
+
 type querierMock struct {
 	mock.Mock
 }
@@ -334,12 +338,420 @@ func (m *querierMock) GetWorkoutById(ctx context.Context, arg repository.GetWork
 func (m *querierMock) UpdateExerciseType(ctx context.Context, arg repository.UpdateExerciseTypeParams) (int64, error) {
 	panic("not implemented")
 }
-func (m *querierMock) UpdateExerciseItemType(ctx context.Context, arg repository.UpdateExerciseItemTypeParams) (int64, error) {
-	panic("not implemented")
-}
 func (m *querierMock) UpdateUser(ctx context.Context, arg repository.UpdateUserParams) (int64, error) {
 	panic("not implemented")
 }
 func (m *querierMock) UpdateWorkoutById(ctx context.Context, arg repository.UpdateWorkoutByIdParams) (int64, error) {
 	panic("not implemented")
 }
+func (m *querierMock) CreateTemplateAndReturnId(ctx context.Context, arg repository.CreateTemplateAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateTemplateExerciseAndReturnId(ctx context.Context, arg repository.CreateTemplateExerciseAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateTemplateExerciseItemAndReturnId(ctx context.Context, arg repository.CreateTemplateExerciseItemAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateTemplateSetAndReturnId(ctx context.Context, arg repository.CreateTemplateSetAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteTemplateById(ctx context.Context, arg repository.DeleteTemplateByIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteTemplateExerciseItemsByTemplateId(ctx context.Context, arg repository.DeleteTemplateExerciseItemsByTemplateIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteTemplateExercisesByTemplateId(ctx context.Context, arg repository.DeleteTemplateExercisesByTemplateIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteTemplateSetsByTemplateId(ctx context.Context, arg repository.DeleteTemplateSetsByTemplateIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetAllTemplates(ctx context.Context, userID string) ([]repository.Template, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetTemplateById(ctx context.Context, arg repository.GetTemplateByIdParams) (repository.Template, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetTemplateExerciseItemsByTemplateId(ctx context.Context, arg repository.GetTemplateExerciseItemsByTemplateIdParams) ([]repository.TemplateExerciseItem, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetTemplateExercisesByTemplateId(ctx context.Context, arg repository.GetTemplateExercisesByTemplateIdParams) ([]repository.TemplateExercise, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetTemplateSetsByTemplateId(ctx context.Context, arg repository.GetTemplateSetsByTemplateIdParams) ([]repository.TemplateSet, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpdateTemplateById(ctx context.Context, arg repository.UpdateTemplateByIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CompleteProgramProgressByWorkoutId(ctx context.Context, arg repository.CompleteProgramProgressByWorkoutIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateProgramAndReturnId(ctx context.Context, arg repository.CreateProgramAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateProgramDayAndReturnId(ctx context.Context, arg repository.CreateProgramDayAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateProgramEnrollmentAndReturnId(ctx context.Context, arg repository.CreateProgramEnrollmentAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateProgramProgressAndReturnId(ctx context.Context, arg repository.CreateProgramProgressAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteProgramById(ctx context.Context, arg repository.DeleteProgramByIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteProgramDaysByProgramId(ctx context.Context, arg repository.DeleteProgramDaysByProgramIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteProgramEnrollmentsByProgramId(ctx context.Context, arg repository.DeleteProgramEnrollmentsByProgramIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteProgramProgressByProgramId(ctx context.Context, arg repository.DeleteProgramProgressByProgramIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) EndActiveProgramEnrollments(ctx context.Context, arg repository.EndActiveProgramEnrollmentsParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) EndProgramEnrollmentById(ctx context.Context, arg repository.EndProgramEnrollmentByIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetActiveProgramEnrollment(ctx context.Context, userID string) (repository.ProgramEnrollment, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetAllPrograms(ctx context.Context, userID string) ([]repository.Program, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetProgramById(ctx context.Context, arg repository.GetProgramByIdParams) (repository.Program, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetProgramDaysByProgramId(ctx context.Context, arg repository.GetProgramDaysByProgramIdParams) ([]repository.ProgramDay, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetProgramProgressByEnrollmentId(ctx context.Context, arg repository.GetProgramProgressByEnrollmentIdParams) ([]repository.ProgramProgress, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetProgressionRuleByExerciseTypeId(ctx context.Context, arg repository.GetProgressionRuleByExerciseTypeIdParams) (repository.ProgressionRule, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetSetHistoryByExerciseTypeId(ctx context.Context, arg repository.GetSetHistoryByExerciseTypeIdParams) ([]repository.GetSetHistoryByExerciseTypeIdRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpsertProgressionRule(ctx context.Context, arg repository.UpsertProgressionRuleParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetCompletedSetsByExerciseTypeId(ctx context.Context, arg repository.GetCompletedSetsByExerciseTypeIdParams) ([]repository.GetCompletedSetsByExerciseTypeIdRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateRecordAndReturnId(ctx context.Context, arg repository.CreateRecordAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteRecordsByWorkoutId(ctx context.Context, arg repository.DeleteRecordsByWorkoutIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetAllRecords(ctx context.Context, userID string) ([]repository.GetAllRecordsRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetRecordsByWorkoutId(ctx context.Context, arg repository.GetRecordsByWorkoutIdParams) ([]repository.GetRecordsByWorkoutIdRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetSetsForRecordsByWorkoutId(ctx context.Context, arg repository.GetSetsForRecordsByWorkoutIdParams) ([]repository.GetSetsForRecordsByWorkoutIdRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetVolumeBetweenDates(ctx context.Context, arg repository.GetVolumeBetweenDatesParams) (repository.GetVolumeBetweenDatesRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetVolumePerExerciseTypeBetweenDates(ctx context.Context, arg repository.GetVolumePerExerciseTypeBetweenDatesParams) ([]repository.GetVolumePerExerciseTypeBetweenDatesRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetVolumeSinceDate(ctx context.Context, arg repository.GetVolumeSinceDateParams) (repository.GetVolumeSinceDateRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateExerciseTypeMuscleGroupAndReturnId(ctx context.Context, arg repository.CreateExerciseTypeMuscleGroupAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteMuscleGroupsByExerciseTypeId(ctx context.Context, arg repository.DeleteMuscleGroupsByExerciseTypeIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetMuscleGroupSetsBetweenDates(ctx context.Context, arg repository.GetMuscleGroupSetsBetweenDatesParams) ([]repository.GetMuscleGroupSetsBetweenDatesRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetMuscleGroupsByExerciseTypeId(ctx context.Context, arg repository.GetMuscleGroupsByExerciseTypeIdParams) ([]repository.ExerciseTypeMuscleGroup, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetMuscleGroupsByUserId(ctx context.Context, userID string) ([]repository.ExerciseTypeMuscleGroup, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpdateExerciseTypeMetadata(ctx context.Context, arg repository.UpdateExerciseTypeMetadataParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetExerciseTypeHistory(ctx context.Context, arg repository.GetExerciseTypeHistoryParams) ([]repository.GetExerciseTypeHistoryRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpdateExercise(ctx context.Context, arg repository.UpdateExerciseParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpdateExerciseItemPosition(ctx context.Context, arg repository.UpdateExerciseItemPositionParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpdateExercisePosition(ctx context.Context, arg repository.UpdateExercisePositionParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpdateSet(ctx context.Context, arg repository.UpdateSetParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpdateSetPosition(ctx context.Context, arg repository.UpdateSetPositionParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetMeasuredSetsByExerciseTypeId(ctx context.Context, arg repository.GetMeasuredSetsByExerciseTypeIdParams) ([]repository.GetMeasuredSetsByExerciseTypeIdRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetMeasurementByExerciseId(ctx context.Context, arg repository.GetMeasurementByExerciseIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetNextRoundByExerciseItemId(ctx context.Context, arg repository.GetNextRoundByExerciseItemIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetVolumePerExerciseItemTypeBetweenDates(ctx context.Context, arg repository.GetVolumePerExerciseItemTypeBetweenDatesParams) ([]repository.GetVolumePerExerciseItemTypeBetweenDatesRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpdateExerciseItem(ctx context.Context, arg repository.UpdateExerciseItemParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateBodyMeasurementAndReturnId(ctx context.Context, arg repository.CreateBodyMeasurementAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateBodyMetricAndReturnId(ctx context.Context, arg repository.CreateBodyMetricAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteBodyMeasurementsByBodyMetricId(ctx context.Context, arg repository.DeleteBodyMeasurementsByBodyMetricIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteBodyMetricById(ctx context.Context, arg repository.DeleteBodyMetricByIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetBodyMeasurementsBetweenDates(ctx context.Context, arg repository.GetBodyMeasurementsBetweenDatesParams) ([]repository.BodyMeasurement, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetBodyMeasurementsByBodyMetricId(ctx context.Context, arg repository.GetBodyMeasurementsByBodyMetricIdParams) ([]repository.BodyMeasurement, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetBodyMetricById(ctx context.Context, arg repository.GetBodyMetricByIdParams) (repository.BodyMetric, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetBodyMetricsBetweenDates(ctx context.Context, arg repository.GetBodyMetricsBetweenDatesParams) ([]repository.BodyMetric, error) {
+	panic("not implemented")
+}
+func (m *querierMock) SetWorkoutBodyweightFromBodyMetrics(ctx context.Context, arg repository.SetWorkoutBodyweightFromBodyMetricsParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpdateBodyMetric(ctx context.Context, arg repository.UpdateBodyMetricParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpdateWorkoutBodyweight(ctx context.Context, arg repository.UpdateWorkoutBodyweightParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetPreferencesByUserId(ctx context.Context, userID string) (repository.UserPreference, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpsertPreferences(ctx context.Context, arg repository.UpsertPreferencesParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetSessionDurationsBetweenDates(ctx context.Context, arg repository.GetSessionDurationsBetweenDatesParams) (repository.GetSessionDurationsBetweenDatesRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) PauseWorkoutById(ctx context.Context, arg repository.PauseWorkoutByIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) ResumeWorkoutById(ctx context.Context, arg repository.ResumeWorkoutByIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) StartWorkoutById(ctx context.Context, arg repository.StartWorkoutByIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetLastPerformedOnByExerciseTypeId(ctx context.Context, arg repository.GetLastPerformedOnByExerciseTypeIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetPreviousPerformedOnByExerciseId(ctx context.Context, arg repository.GetPreviousPerformedOnByExerciseIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpdateExerciseTypeRestSeconds(ctx context.Context, arg repository.UpdateExerciseTypeRestSecondsParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetCompletedOnByUserId(ctx context.Context, userID string) ([]string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetWorkoutTonnageBetweenDates(ctx context.Context, arg repository.GetWorkoutTonnageBetweenDatesParams) ([]repository.GetWorkoutTonnageBetweenDatesRow, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateGoalAndReturnId(ctx context.Context, arg repository.CreateGoalAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateGoalProgressAndReturnId(ctx context.Context, arg repository.CreateGoalProgressAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteGoalById(ctx context.Context, arg repository.DeleteGoalByIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteGoalProgressByGoalId(ctx context.Context, arg repository.DeleteGoalProgressByGoalIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetActiveGoals(ctx context.Context, userID string) ([]repository.Goal, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetAllGoals(ctx context.Context, userID string) ([]repository.Goal, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetGoalById(ctx context.Context, arg repository.GetGoalByIdParams) (repository.Goal, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetGoalProgressByGoalId(ctx context.Context, arg repository.GetGoalProgressByGoalIdParams) ([]repository.GoalProgress, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetLatestBodyweight(ctx context.Context, userID string) (float64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) UpdateGoalAchievedOn(ctx context.Context, arg repository.UpdateGoalAchievedOnParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetSetsByWorkoutId(ctx context.Context, arg repository.GetSetsByWorkoutIdParams) ([]repository.Set, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CreateCompletedWorkoutAndReturnId(ctx context.Context, arg repository.CreateCompletedWorkoutAndReturnIdParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteBodyMeasurementsByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteBodyMetricsByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteExerciseItemsByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteExerciseTypeMuscleGroupsByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteExerciseTypesByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteExercisesByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteGoalProgressByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteGoalsByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeletePreferencesByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteProgramDaysByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteProgramEnrollmentsByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteProgramProgressByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteProgramsByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteProgressionRulesByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteRecordsByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteSetsByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteTemplateExerciseItemsByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteTemplateExercisesByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteTemplateSetsByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteTemplatesByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteWorkoutsByUserId(ctx context.Context, userID string) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetBodyMeasurementsByUserId(ctx context.Context, userID string) ([]repository.BodyMeasurement, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetBodyMetricsByUserId(ctx context.Context, userID string) ([]repository.BodyMetric, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetExerciseItemsByUserId(ctx context.Context, userID string) ([]repository.ExerciseItem, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetExerciseTypesByUserId(ctx context.Context, userID string) ([]repository.ExerciseType, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetExercisesByUserId(ctx context.Context, userID string) ([]repository.Exercise, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetGoalProgressByUserId(ctx context.Context, userID string) ([]repository.GoalProgress, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetGoalsByUserId(ctx context.Context, userID string) ([]repository.Goal, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetProgramDaysByUserId(ctx context.Context, userID string) ([]repository.ProgramDay, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetProgramEnrollmentsByUserId(ctx context.Context, userID string) ([]repository.ProgramEnrollment, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetProgramProgressByUserId(ctx context.Context, userID string) ([]repository.ProgramProgress, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetProgramsByUserId(ctx context.Context, userID string) ([]repository.Program, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetProgressionRulesByUserId(ctx context.Context, userID string) ([]repository.ProgressionRule, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetRecordsByUserId(ctx context.Context, userID string) ([]repository.Record, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetSetsByUserId(ctx context.Context, userID string) ([]repository.Set, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetTemplateExerciseItemsByUserId(ctx context.Context, userID string) ([]repository.TemplateExerciseItem, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetTemplateExercisesByUserId(ctx context.Context, userID string) ([]repository.TemplateExercise, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetTemplateSetsByUserId(ctx context.Context, userID string) ([]repository.TemplateSet, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetTemplatesByUserId(ctx context.Context, userID string) ([]repository.Template, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetWorkoutsByUserId(ctx context.Context, userID string) ([]repository.Workout, error) {
+	panic("not implemented")
+}
+func (m *querierMock) CountProgramDaysByTemplateId(ctx context.Context, arg repository.CountProgramDaysByTemplateIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) DeleteUnfinishedProgramProgressByDayId(ctx context.Context, arg repository.DeleteUnfinishedProgramProgressByDayIdParams) (int64, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetWorkoutCompletedOn(ctx context.Context, arg repository.GetWorkoutCompletedOnParams) (string, error) {
+	panic("not implemented")
+}
+func (m *querierMock) GetPreviousSetsForRecordsByExerciseTypeId(ctx context.Context, arg repository.GetPreviousSetsForRecordsByExerciseTypeIdParams) ([]repository.GetPreviousSetsForRecordsByExerciseTypeIdRow, error) {
+	panic("not implemented")
+}
@@ -1,24 +1,47 @@
 package sets
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"weight-tracker/internal/database"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/units"
 	"weight-tracker/internal/utils"
 )
 
 type handler struct {
 	service Service
+	units   unitPreferences
+}
+
+// Weights are stored in kilograms, requests and responses use the unit of the user
+type unitPreferences interface {
+	GetWeightUnit(ctx context.Context, userId string) (string, error)
 }
 
 func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
 	handler := handler{
-		service: NewService(&setsRepository{s.GetRepository()}),
+		service: NewService(&setsRepository{s.GetRepository()},
+			func(ctx context.Context, fn func(SetsRepository) error) error {
+				return s.WithTx(ctx, func(q repository.Querier) error {
+					return fn(&setsRepository{q})
+				})
+			},
+		),
+		units: preferences.NewServiceFromDatabase(s),
 	}
 	mux.Handle("GET /workouts/{id}/exercises/{exerciseId}/sets", authenticationWrapper(http.HandlerFunc(handler.getSetsByExerciseIdHandler)))
 	mux.Handle("POST /workouts/{id}/exercises/{exerciseId}/sets", authenticationWrapper(http.HandlerFunc(handler.createSetHandler)))
 	mux.Handle("DELETE /workouts/{id}/exercises/{exerciseId}/sets/{setId}", authenticationWrapper(http.HandlerFunc(handler.deleteSetByIdHandler)))
+	mux.Handle("PUT /workouts/{id}/exercises/{exerciseId}/sets/order", authenticationWrapper(http.HandlerFunc(handler.reorderSetsHandler)))
+	mux.Handle("PUT /workouts/{id}/exercises/{exerciseId}/sets/{setId}", authenticationWrapper(http.HandlerFunc(handler.updateSetByIdHandler)))
+	mux.Handle("PATCH /workouts/{id}/exercises/{exerciseId}/sets/{setId}", authenticationWrapper(http.HandlerFunc(handler.patchSetByIdHandler)))
+	mux.Handle("POST /workouts/{id}/exercise-items/{exerciseItemId}/rounds", authenticationWrapper(http.HandlerFunc(handler.createRoundHandler)))
 }
 
 func (s *handler) deleteSetByIdHandler(w http.ResponseWriter, r *http.Request) {
@@ -37,6 +60,104 @@ func (s *handler) deleteSetByIdHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (s *handler) updateSetByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	setId := r.PathValue("setId")
+	decoder := json.NewDecoder(r.Body)
+	var t createSetRequest
+	err := decoder.Decode(&t)
+	if err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to update set", http.StatusBadRequest)
+		return
+	}
+	t.Weight = units.ToKilograms(t.Weight, unit)
+
+	err = s.service.UpdateById(r.Context(), setId, t, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		slog.Warn("Failed to update set", "error", err, "setId", setId)
+		http.Error(w, "Failed to update set", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *handler) patchSetByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	setId := r.PathValue("setId")
+	decoder := json.NewDecoder(r.Body)
+	var t patchSetRequest
+	err := decoder.Decode(&t)
+	if err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to update set", http.StatusBadRequest)
+		return
+	}
+	if t.Weight != nil {
+		weight := units.ToKilograms(*t.Weight, unit)
+		t.Weight = &weight
+	}
+
+	err = s.service.PatchById(r.Context(), setId, t, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		slog.Warn("Failed to update set", "error", err, "setId", setId)
+		http.Error(w, "Failed to update set", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *handler) reorderSetsHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseId := r.PathValue("exerciseId")
+	decoder := json.NewDecoder(r.Body)
+	var t reorderRequest
+	err := decoder.Decode(&t)
+	if err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = s.service.Reorder(r.Context(), exerciseId, t.IDs, userId)
+	if err != nil {
+		slog.Warn("Failed to reorder sets", "error", err, "exerciseId", exerciseId)
+		http.Error(w, "Failed to reorder sets", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (s *handler) createSetHandler(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value("sub").(string)
 	exerciseId := r.PathValue("exerciseId")
@@ -50,6 +171,14 @@ func (s *handler) createSetHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to create set", http.StatusBadRequest)
+		return
+	}
+	t.Weight = units.ToKilograms(t.Weight, unit)
+
 	id, err := s.service.CreateAndReturnId(r.Context(), t, exerciseId, userId)
 
 	if err != nil {
@@ -70,6 +199,52 @@ func (s *handler) createSetHandler(w http.ResponseWriter, r *http.Request) {
 	utils.ReturnJson(w, jsonResp)
 }
 
+func (s *handler) createRoundHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	exerciseItemId := r.PathValue("exerciseItemId")
+	decoder := json.NewDecoder(r.Body)
+	var t createRoundRequest
+	err := decoder.Decode(&t)
+	if err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to create round", http.StatusBadRequest)
+		return
+	}
+	for i, v := range t.Sets {
+		t.Sets[i].Weight = units.ToKilograms(v.Weight, unit)
+	}
+
+	round, err := s.service.CreateRound(r.Context(), exerciseItemId, t.Sets, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		slog.Warn("Failed to create round", "error", err, "exerciseItemId", exerciseItemId)
+		http.Error(w, "Failed to create round", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	jsonResp, err := utils.CreateResponse(round)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	utils.ReturnJson(w, jsonResp)
+}
+
 func (s *handler) getSetsByExerciseIdHandler(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value("sub").(string)
 	exerciseId := r.PathValue("exerciseId")
@@ -88,6 +263,16 @@ func (s *handler) getSetsByExerciseIdHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get sets", http.StatusBadRequest)
+		return
+	}
+	for i, v := range sets {
+		sets[i].Weight = units.FromKilograms(v.Weight, unit)
+	}
+
 	jsonResp, err := utils.CreateResponse(sets)
 	if err != nil {
 		slog.Warn("Failed to marshal response", "error", err)
@@ -98,6 +283,44 @@ func (s *handler) getSetsByExerciseIdHandler(w http.ResponseWriter, r *http.Requ
 }
 
 type createSetRequest struct {
-	Repetitions int     `json:"repetitions"`
-	Weight      float64 `json:"weight"`
+	Repetitions int      `json:"repetitions"`
+	Weight      float64  `json:"weight"`
+	Type        string   `json:"type"`
+	RPE         *float64 `json:"rpe"`
+	RIR         *int     `json:"rir"`
+	Note        string   `json:"note"`
+	// Only logged for exercise types measured in time or distance
+	DurationSeconds *int     `json:"duration_seconds"`
+	DistanceMeters  *float64 `json:"distance_meters"`
+	// RFC 3339 time the set was finished, now when left out. A left out rest is
+	// derived from the set performed before it. Updates keep left out timing.
+	PerformedOn string `json:"performed_on"`
+	RestSeconds *int   `json:"rest_seconds"`
+}
+
+// Fields left out of the request keep their current value
+type patchSetRequest struct {
+	Repetitions     *int     `json:"repetitions"`
+	Weight          *float64 `json:"weight"`
+	Type            *string  `json:"type"`
+	RPE             *float64 `json:"rpe"`
+	RIR             *int     `json:"rir"`
+	Note            *string  `json:"note"`
+	DurationSeconds *int     `json:"duration_seconds"`
+	DistanceMeters  *float64 `json:"distance_meters"`
+	PerformedOn     *string  `json:"performed_on"`
+	RestSeconds     *int     `json:"rest_seconds"`
+}
+
+type createRoundRequest struct {
+	Sets []roundSetRequest `json:"sets"`
+}
+
+type roundSetRequest struct {
+	ExerciseID string `json:"exercise_id"`
+	createSetRequest
+}
+
+type reorderRequest struct {
+	IDs []string `json:"ids"`
 }
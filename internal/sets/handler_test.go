@@ -3,11 +3,14 @@ package sets
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"weight-tracker/internal/units"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -31,6 +34,34 @@ func (s *serviceMock) CreateAndReturnId(context context.Context, t createSetRequ
 	return args.String(0), args.Error(1)
 }
 
+func (s *serviceMock) UpdateById(context context.Context, setId string, t createSetRequest, userId string) error {
+	args := s.Called(context, setId, t, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) PatchById(context context.Context, setId string, t patchSetRequest, userId string) error {
+	args := s.Called(context, setId, t, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) CreateRound(context context.Context, exerciseItemId string, sets []roundSetRequest, userId string) (Round, error) {
+	args := s.Called(context, exerciseItemId, sets, userId)
+	return args.Get(0).(Round), args.Error(1)
+}
+
+func (s *serviceMock) Reorder(context context.Context, exerciseId string, ids []string, userId string) error {
+	args := s.Called(context, exerciseId, ids, userId)
+	return args.Error(0)
+}
+
+type unitsStub struct {
+	unit string
+}
+
+func (u unitsStub) GetWeightUnit(ctx context.Context, userId string) (string, error) {
+	return u.unit, nil
+}
+
 func populateContextWithSub(req *http.Request, userId string) *http.Request {
 	ctx := req.Context()
 	ctx = context.WithValue(ctx, "sub", userId)
@@ -60,7 +91,7 @@ func TestDeleteSetByIdHandler(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.deleteSetByIdHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusNoContent {
@@ -93,7 +124,7 @@ func TestDeleteSetByIdHandlerErr(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.deleteSetByIdHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusBadRequest {
@@ -125,18 +156,18 @@ func TestGetSetsByExerciseIdHandler(t *testing.T) {
 
 	serviceMock := serviceMock{}
 	serviceMock.On("GetByExerciseId", req.Context(), exerciseId, userId).
-		Return([]Set{{ID: "a", Repetitions: 10, Weight: 100, ExerciseID: exerciseId}}, nil).
+		Return([]Set{{ID: "a", Repetitions: 10, Weight: 100, Type: TypeWorking, ExerciseID: exerciseId}}, nil).
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getSetsByExerciseIdHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
 	}
 
-	expected := `{"data":[{"id":"a","repetitions":10,"weight":100,"exercise_id":"exerciseId"}]}`
+	expected := `{"data":[{"id":"a","repetitions":10,"weight":100,"type":"working","exercise_id":"exerciseId"}]}`
 	if rr.Body.String() != expected {
 		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
 	}
@@ -160,7 +191,7 @@ func TestGetSetsByExerciseIdHandlerNoExerciseId(t *testing.T) {
 	serviceMock := serviceMock{}
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getSetsByExerciseIdHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusBadRequest {
@@ -193,7 +224,7 @@ func TestGetSetsByExerciseIdHandlerServiceErr(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getSetsByExerciseIdHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusBadRequest {
@@ -242,7 +273,7 @@ func TestCreateSetHandler(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.createSetHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusCreated {
@@ -291,7 +322,7 @@ func TestCreateSetHandlerErr(t *testing.T) {
 		Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.createSetHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusBadRequest {
@@ -305,3 +336,199 @@ func TestCreateSetHandlerErr(t *testing.T) {
 
 	serviceMock.AssertExpectations(t)
 }
+
+func TestUpdateSetByIdHandler(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	exerciseId := "exerciseId"
+	setId := "setId"
+
+	body := createSetRequest{Repetitions: 8, Weight: 80, Type: TypeWorking}
+	bodyBytes, _ := json.Marshal(body)
+	req, err := http.NewRequest("PUT", "/workouts/"+workoutId+"/exercises/"+exerciseId+"/sets/"+setId, bytes.NewBuffer(bodyBytes))
+	req.SetPathValue("id", workoutId)
+	req.SetPathValue("exerciseId", exerciseId)
+	req.SetPathValue("setId", setId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("UpdateById", req.Context(), setId, body, userId).
+		Return(nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.updateSetByIdHandler)
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestPatchSetByIdHandlerNotFound(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	exerciseId := "exerciseId"
+	setId := "setId"
+
+	req, err := http.NewRequest("PATCH", "/workouts/"+workoutId+"/exercises/"+exerciseId+"/sets/"+setId, bytes.NewBufferString(`{"weight":82.5}`))
+	req.SetPathValue("id", workoutId)
+	req.SetPathValue("exerciseId", exerciseId)
+	req.SetPathValue("setId", setId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("PatchById", req.Context(), setId, mock.MatchedBy(func(input patchSetRequest) bool {
+		return input.Weight != nil && *input.Weight == 82.5 && input.Repetitions == nil
+	}), userId).
+		Return(fmt.Errorf("failed to get set by id: %w", sql.ErrNoRows)).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.patchSetByIdHandler)
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestReorderSetsHandler(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	exerciseId := "exerciseId"
+
+	req, err := http.NewRequest("PUT", "/workouts/"+workoutId+"/exercises/"+exerciseId+"/sets/order", bytes.NewBufferString(`{"ids":["b","a"]}`))
+	req.SetPathValue("id", workoutId)
+	req.SetPathValue("exerciseId", exerciseId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("Reorder", req.Context(), exerciseId, []string{"b", "a"}, userId).
+		Return(nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.reorderSetsHandler)
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestCreateRoundHandler(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	exerciseItemId := "exerciseItemId"
+
+	req, err := http.NewRequest("POST", "/workouts/"+workoutId+"/exercise-items/"+exerciseItemId+"/rounds", bytes.NewBufferString(`{"sets":[{"exercise_id":"a","repetitions":10,"weight":20},{"exercise_id":"b","repetitions":8,"weight":30}]}`))
+	req.SetPathValue("id", workoutId)
+	req.SetPathValue("exerciseItemId", exerciseItemId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	serviceMock.On("CreateRound", req.Context(), exerciseItemId, []roundSetRequest{
+		{ExerciseID: "a", createSetRequest: createSetRequest{Repetitions: 10, Weight: 20}},
+		{ExerciseID: "b", createSetRequest: createSetRequest{Repetitions: 8, Weight: 30}},
+	}, userId).
+		Return(Round{Round: 2, IDs: []string{"setA", "setB"}}, nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.createRoundHandler)
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	expected := `{"data":{"round":2,"ids":["setA","setB"]}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestCreateSetHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+	exerciseId := "exerciseId"
+
+	req, err := http.NewRequest("POST", "/workouts/workoutId/exercises/"+exerciseId+"/sets", bytes.NewBufferString(`{"repetitions":5,"weight":225}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("exerciseId", exerciseId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("CreateAndReturnId", req.Context(), mock.MatchedBy(func(input createSetRequest) bool {
+		return input.Repetitions == 5 && input.Weight == units.ToKilograms(225, units.Pounds)
+	}), exerciseId, userId).
+		Return("setId", nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(s.createSetHandler).ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetSetsByExerciseIdHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+	exerciseId := "exerciseId"
+
+	req, err := http.NewRequest("GET", "/workouts/workoutId/exercises/"+exerciseId+"/sets", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("exerciseId", exerciseId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetByExerciseId", req.Context(), exerciseId, userId).
+		Return([]Set{{ID: "a", Repetitions: 5, Weight: 100, Type: TypeWorking, ExerciseID: exerciseId}}, nil).
+		Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(s.getSetsByExerciseIdHandler).ServeHTTP(rr, req)
+
+	expected := `{"data":[{"id":"a","repetitions":5,"weight":220.46,"type":"working","exercise_id":"exerciseId"}]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
@@ -2,23 +2,56 @@ package sets
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"log/slog"
+	"time"
 	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
 )
 
 type Set struct {
-	ID          string  `json:"id"`
-	Repetitions int64   `json:"repetitions"`
-	Weight      float64 `json:"weight"`
+	ID              string   `json:"id"`
+	Repetitions     int64    `json:"repetitions"`
+	Weight          float64  `json:"weight"`
+	Type            string   `json:"type"`
+	RPE             *float64 `json:"rpe,omitempty"`
+	RIR             *int64   `json:"rir,omitempty"`
+	Note            string   `json:"note,omitempty"`
+	DurationSeconds *int64   `json:"duration_seconds,omitempty"`
+	DistanceMeters  *float64 `json:"distance_meters,omitempty"`
+	// Sets logged in the same round of a grouped exercise item share a round
+	Round *int64 `json:"round,omitempty"`
+	// When the set was finished and the rest taken since the set before it in the workout
+	PerformedOn *string `json:"performed_on,omitempty"`
+	RestSeconds *int64  `json:"rest_seconds,omitempty"`
 	ExerciseID  string  `json:"exercise_id"`
 }
 
+// Round is one round through a grouped exercise item, one set per exercise
+type Round struct {
+	Round int64    `json:"round"`
+	IDs   []string `json:"ids"`
+}
+
 type SetsRepository interface {
 	GetAll(ctx context.Context, userId string) ([]Set, error)
 	GetById(ctx context.Context, arg repository.GetSetByIdParams) (Set, error)
 	GetByExerciseId(ctx context.Context, arg repository.GetSetsByExerciseIdParams) ([]Set, error)
+	GetByWorkoutId(ctx context.Context, arg repository.GetSetsByWorkoutIdParams) ([]Set, error)
 	CreateAndReturnId(ctx context.Context, arg repository.CreateSetAndReturnIdParams) (string, error)
 	DeleteById(ctx context.Context, arg repository.DeleteSetByIdParams) (int64, error)
+	UpdateById(ctx context.Context, arg repository.UpdateSetParams) error
+	UpdatePosition(ctx context.Context, arg repository.UpdateSetPositionParams) error
+	GetMeasurement(ctx context.Context, arg repository.GetMeasurementByExerciseIdParams) (string, error)
+	GetExerciseItemType(ctx context.Context, arg repository.GetExerciseItemByIdParams) (string, error)
+	GetExerciseIdsByExerciseItemId(ctx context.Context, arg repository.GetExercisesByExerciseItemIdParams) ([]string, error)
+	GetNextRound(ctx context.Context, arg repository.GetNextRoundByExerciseItemIdParams) (int64, error)
+	GetPreviousPerformedOn(ctx context.Context, arg repository.GetPreviousPerformedOnByExerciseIdParams) (time.Time, error)
+}
+
+func NewSetsRepository(repo repository.Querier) SetsRepository {
+	return &setsRepository{repo: repo}
 }
 
 type setsRepository struct {
@@ -33,6 +66,79 @@ func (s *setsRepository) DeleteById(ctx context.Context, arg repository.DeleteSe
 	return s.repo.DeleteSetById(ctx, arg)
 }
 
+func (s *setsRepository) UpdateById(ctx context.Context, arg repository.UpdateSetParams) error {
+	rows, err := s.repo.UpdateSet(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update set: %w", err)
+	}
+
+	if rows == 0 {
+		slog.Warn("Tried to update set that did not exist", "setId", arg.ID)
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (s *setsRepository) UpdatePosition(ctx context.Context, arg repository.UpdateSetPositionParams) error {
+	_, err := s.repo.UpdateSetPosition(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update set position: %w", err)
+	}
+	return nil
+}
+
+func (s *setsRepository) GetMeasurement(ctx context.Context, arg repository.GetMeasurementByExerciseIdParams) (string, error) {
+	measurement, err := s.repo.GetMeasurementByExerciseId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to get measurement: %w", err)
+	}
+	return measurement, nil
+}
+
+func (s *setsRepository) GetExerciseItemType(ctx context.Context, arg repository.GetExerciseItemByIdParams) (string, error) {
+	item, err := s.repo.GetExerciseItemById(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to get exercise item: %w", err)
+	}
+	return item.Type, nil
+}
+
+func (s *setsRepository) GetExerciseIdsByExerciseItemId(ctx context.Context, arg repository.GetExercisesByExerciseItemIdParams) ([]string, error) {
+	exercises, err := s.repo.GetExercisesByExerciseItemId(ctx, arg)
+	if err != nil {
+		return []string{}, fmt.Errorf("failed to get exercises by exercise item id: %w", err)
+	}
+
+	result := []string{}
+	for _, v := range exercises {
+		result = append(result, v.ID)
+	}
+	return result, nil
+}
+
+func (s *setsRepository) GetNextRound(ctx context.Context, arg repository.GetNextRoundByExerciseItemIdParams) (int64, error) {
+	round, err := s.repo.GetNextRoundByExerciseItemId(ctx, arg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get next round: %w", err)
+	}
+	return round, nil
+}
+
+// GetPreviousPerformedOn returns when the set performed last before the given
+// time in the workout of the exercise was finished
+func (s *setsRepository) GetPreviousPerformedOn(ctx context.Context, arg repository.GetPreviousPerformedOnByExerciseIdParams) (time.Time, error) {
+	performedOn, err := s.repo.GetPreviousPerformedOnByExerciseId(ctx, arg)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get previous performed set: %w", err)
+	}
+
+	result, err := time.Parse(time.RFC3339, performedOn)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse performed on: %w", err)
+	}
+	return result, nil
+}
+
 func (s *setsRepository) GetAll(ctx context.Context, userId string) ([]Set, error) {
 	sets, err := s.repo.GetAllSets(ctx, userId)
 	if err != nil {
@@ -49,10 +155,24 @@ func (s *setsRepository) GetAll(ctx context.Context, userId string) ([]Set, erro
 
 func newSet(v repository.Set) Set {
 	set := Set{
-		ID:          v.ID,
-		Repetitions: v.Repetitions,
-		Weight:      v.Weight,
-		ExerciseID:  v.ExerciseID,
+		ID:              v.ID,
+		Repetitions:     v.Repetitions,
+		Weight:          v.Weight,
+		Type:            v.Type,
+		RPE:             utils.NullableFloat(v.Rpe),
+		RIR:             utils.NullableInt(v.Rir),
+		DurationSeconds: utils.NullableInt(v.DurationSeconds),
+		DistanceMeters:  utils.NullableFloat(v.DistanceMeters),
+		Round:           utils.NullableInt(v.RoundNumber),
+		RestSeconds:     utils.NullableInt(v.RestSeconds),
+		ExerciseID:      v.ExerciseID,
+	}
+	if v.Note != nil {
+		set.Note = v.Note.(string)
+	}
+	if v.PerformedOn != nil {
+		performedOn := v.PerformedOn.(string)
+		set.PerformedOn = &performedOn
 	}
 
 	return set
@@ -82,3 +202,19 @@ func (s *setsRepository) GetByExerciseId(ctx context.Context, arg repository.Get
 
 	return result, nil
 }
+
+// GetByWorkoutId returns the sets of the workout in the order of their exercise
+// items, exercises and positions
+func (s *setsRepository) GetByWorkoutId(ctx context.Context, arg repository.GetSetsByWorkoutIdParams) ([]Set, error) {
+	sets, err := s.repo.GetSetsByWorkoutId(ctx, arg)
+	if err != nil {
+		return []Set{}, fmt.Errorf("failed to get sets by workout id: %w", err)
+	}
+
+	result := []Set{}
+	for _, v := range sets {
+		result = append(result, newSet(v))
+	}
+
+	return result, nil
+}
@@ -2,9 +2,17 @@ package sets
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"math"
 	"time"
+	"slices"
+	"unicode/utf8"
+	"weight-tracker/internal/exerciseitems"
+	"weight-tracker/internal/measurement"
 	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
 
 	"github.com/google/uuid"
 )
@@ -13,6 +21,10 @@ type Service interface {
 	GetByExerciseId(context context.Context, exerciseId string, userId string) ([]Set, error)
 	DeleteById(context context.Context, setId string, userId string) error
 	CreateAndReturnId(context context.Context, t createSetRequest, exerciseId string, userId string) (string, error)
+	UpdateById(context context.Context, setId string, t createSetRequest, userId string) error
+	PatchById(context context.Context, setId string, t patchSetRequest, userId string) error
+	Reorder(context context.Context, exerciseId string, ids []string, userId string) error
+	CreateRound(context context.Context, exerciseItemId string, sets []roundSetRequest, userId string) (Round, error)
 }
 
 func (s *setsService) GetByExerciseId(context context.Context, exerciseId string, userId string) ([]Set, error) {
@@ -37,15 +49,54 @@ func (s *setsService) DeleteById(context context.Context, setId string, userId s
 }
 
 func (s *setsService) CreateAndReturnId(context context.Context, t createSetRequest, exerciseId string, userId string) (string, error) {
+	err := validateSetRequest(t)
+	if err != nil {
+		return "", err
+	}
+
+	setType, err := NormalizeType(t.Type)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.validateMeasurement(context, t, exerciseId, userId)
+	if err != nil {
+		return "", err
+	}
+
+	return s.create(context, t, setType, exerciseId, nil, userId)
+}
+
+func (s *setsService) create(context context.Context, t createSetRequest, setType string, exerciseId string, round interface{}, userId string) (string, error) {
 	uuid, err := uuid.NewV7()
 	if err != nil {
 		return "", fmt.Errorf("failed to generate UUID: %w", err)
 	}
 
+	performedOn, err := parsePerformedOn(t.PerformedOn)
+	if err != nil {
+		return "", err
+	}
+	rest, err := s.restSeconds(context, t, uuid.String(), performedOn, exerciseId, userId)
+	if err != nil {
+		return "", err
+	}
+
+	rpe, rir, note := effortParams(t)
+	duration, distance := measurementParams(t)
 	set := repository.CreateSetAndReturnIdParams{
 		ID:          uuid.String(),
 		Repetitions: int64(t.Repetitions),
 		Weight:      t.Weight,
+		DurationSeconds: duration,
+		DistanceMeters:  distance,
+		Type:        setType,
+		Rpe:         rpe,
+		Rir:         rir,
+		Note:        note,
+		RoundNumber: round,
+		PerformedOn: performedOn.Format(time.RFC3339),
+		RestSeconds: rest,
 		ExerciseID:  exerciseId,
 		CreatedOn: time.Now().UTC().Format(time.RFC3339),
 		UpdatedOn: time.Now().UTC().Format(time.RFC3339),
@@ -55,10 +106,378 @@ func (s *setsService) CreateAndReturnId(context context.Context, t createSetRequ
 	return id, err
 }
 
+func (s *setsService) UpdateById(context context.Context, setId string, t createSetRequest, userId string) error {
+	current, err := s.repo.GetById(context, repository.GetSetByIdParams{
+		ID:     setId,
+		UserID: userId,
+	})
+	if err != nil {
+		return err
+	}
+	return s.update(context, current, t, userId)
+}
+
+func (s *setsService) update(context context.Context, current Set, t createSetRequest, userId string) error {
+	err := validateSetRequest(t)
+	if err != nil {
+		return err
+	}
+
+	setType, err := NormalizeType(t.Type)
+	if err != nil {
+		return err
+	}
+
+	err = s.validateMeasurement(context, t, current.ExerciseID, userId)
+	if err != nil {
+		return err
+	}
+
+	// Timing left out of the request is kept, a new time without a rest derives the rest again
+	var performedOn, rest interface{}
+	if current.PerformedOn != nil {
+		performedOn = *current.PerformedOn
+	}
+	if current.RestSeconds != nil {
+		rest = *current.RestSeconds
+	}
+	if t.PerformedOn != "" {
+		parsed, err := parsePerformedOn(t.PerformedOn)
+		if err != nil {
+			return err
+		}
+		performedOn = parsed.Format(time.RFC3339)
+
+		rest, err = s.restSeconds(context, t, current.ID, parsed, current.ExerciseID, userId)
+		if err != nil {
+			return err
+		}
+	} else if t.RestSeconds != nil {
+		rest = int64(*t.RestSeconds)
+	}
+
+	rpe, rir, note := effortParams(t)
+	duration, distance := measurementParams(t)
+	return s.repo.UpdateById(context, repository.UpdateSetParams{
+		ID:          current.ID,
+		Repetitions: int64(t.Repetitions),
+		Weight:      t.Weight,
+		DurationSeconds: duration,
+		DistanceMeters:  distance,
+		Type:        setType,
+		Rpe:         rpe,
+		Rir:         rir,
+		Note:        note,
+		PerformedOn: performedOn,
+		RestSeconds: rest,
+		UpdatedOn:   time.Now().UTC().Format(time.RFC3339),
+		UserID:      userId,
+	})
+}
+
+func (s *setsService) PatchById(context context.Context, setId string, t patchSetRequest, userId string) error {
+	current, err := s.repo.GetById(context, repository.GetSetByIdParams{
+		ID:     setId,
+		UserID: userId,
+	})
+	if err != nil {
+		return err
+	}
+
+	updated := createSetRequest{
+		Repetitions: int(current.Repetitions),
+		Weight:      current.Weight,
+		Type:        current.Type,
+		RPE:         current.RPE,
+		Note:        current.Note,
+	}
+	if current.RIR != nil {
+		rir := int(*current.RIR)
+		updated.RIR = &rir
+	}
+	if current.DurationSeconds != nil {
+		duration := int(*current.DurationSeconds)
+		updated.DurationSeconds = &duration
+	}
+	if current.DistanceMeters != nil {
+		updated.DistanceMeters = current.DistanceMeters
+	}
+
+	if t.Repetitions != nil {
+		updated.Repetitions = *t.Repetitions
+	}
+	if t.Weight != nil {
+		updated.Weight = *t.Weight
+	}
+	if t.Type != nil {
+		updated.Type = *t.Type
+	}
+	if t.RPE != nil {
+		updated.RPE = t.RPE
+	}
+	if t.RIR != nil {
+		updated.RIR = t.RIR
+	}
+	if t.Note != nil {
+		updated.Note = *t.Note
+	}
+	if t.DurationSeconds != nil {
+		updated.DurationSeconds = t.DurationSeconds
+	}
+	if t.DistanceMeters != nil {
+		updated.DistanceMeters = t.DistanceMeters
+	}
+	if t.PerformedOn != nil {
+		updated.PerformedOn = *t.PerformedOn
+	}
+	if t.RestSeconds != nil {
+		updated.RestSeconds = t.RestSeconds
+	}
+	return s.update(context, current, updated, userId)
+}
+
+// CreateRound logs one set per exercise of a grouped exercise item, linked by the next round number
+func (s *setsService) CreateRound(context context.Context, exerciseItemId string, sets []roundSetRequest, userId string) (Round, error) {
+	itemType, err := s.repo.GetExerciseItemType(context, repository.GetExerciseItemByIdParams{
+		ID:     exerciseItemId,
+		UserID: userId,
+	})
+	if err != nil {
+		return Round{}, err
+	}
+	if !exerciseitems.Grouped(itemType) {
+		return Round{}, fmt.Errorf("rounds can not be logged for %s exercise items", itemType)
+	}
+
+	exerciseIds, err := s.repo.GetExerciseIdsByExerciseItemId(context, repository.GetExercisesByExerciseItemIdParams{
+		ExerciseItemID: exerciseItemId,
+		UserID:         userId,
+	})
+	if err != nil {
+		return Round{}, err
+	}
+
+	if len(sets) == 0 {
+		return Round{}, fmt.Errorf("a round needs at least one set")
+	}
+
+	// Validate the whole round up front so a bad set does not leave half a round behind
+	setTypes := []string{}
+	seen := map[string]bool{}
+	for _, set := range sets {
+		if !slices.Contains(exerciseIds, set.ExerciseID) {
+			return Round{}, fmt.Errorf("exercise %s is not part of the exercise item", set.ExerciseID)
+		}
+		if seen[set.ExerciseID] {
+			return Round{}, fmt.Errorf("exercise %s added more than once", set.ExerciseID)
+		}
+		seen[set.ExerciseID] = true
+
+		err := validateSetRequest(set.createSetRequest)
+		if err != nil {
+			return Round{}, err
+		}
+		setType, err := NormalizeType(set.Type)
+		if err != nil {
+			return Round{}, err
+		}
+		err = s.validateMeasurement(context, set.createSetRequest, set.ExerciseID, userId)
+		if err != nil {
+			return Round{}, err
+		}
+		setTypes = append(setTypes, setType)
+	}
+
+	// The round number is taken and its sets created in one transaction, two
+	// rounds logged at the same time can not get the same number
+	result := Round{IDs: []string{}}
+	err = s.withTx(context, func(repo SetsRepository) error {
+		round, err := repo.GetNextRound(context, repository.GetNextRoundByExerciseItemIdParams{
+			ExerciseItemID: exerciseItemId,
+			UserID:         userId,
+		})
+		if err != nil {
+			return err
+		}
+		result.Round = round
+
+		tx := &setsService{repo: repo}
+		for i, set := range sets {
+			id, err := tx.create(context, set.createSetRequest, setTypes[i], set.ExerciseID, round, userId)
+			if err != nil {
+				return fmt.Errorf("failed to create set: %w", err)
+			}
+			result.IDs = append(result.IDs, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return Round{}, err
+	}
+	return result, nil
+}
+
+func (s *setsService) Reorder(context context.Context, exerciseId string, ids []string, userId string) error {
+	sets, err := s.repo.GetByExerciseId(context, repository.GetSetsByExerciseIdParams{
+		ExerciseID: exerciseId,
+		UserID:     userId,
+	})
+	if err != nil {
+		return err
+	}
+
+	existing := []string{}
+	for _, set := range sets {
+		existing = append(existing, set.ID)
+	}
+	if err := utils.ValidateOrder(existing, ids); err != nil {
+		return fmt.Errorf("invalid set order: %w", err)
+	}
+
+	// All positions are written together, a failure keeps the previous order
+	now := time.Now().UTC().Format(time.RFC3339)
+	return s.withTx(context, func(repo SetsRepository) error {
+		for i, id := range ids {
+			err := repo.UpdatePosition(context, repository.UpdateSetPositionParams{
+				ID:        id,
+				Position:  int64(i),
+				UpdatedOn: now,
+				UserID:    userId,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// effortParams returns the optional effort fields, left out values are stored as NULL
+func effortParams(t createSetRequest) (interface{}, interface{}, interface{}) {
+	var rpe, rir, note interface{}
+	if t.RPE != nil {
+		rpe = *t.RPE
+	}
+	if t.RIR != nil {
+		rir = int64(*t.RIR)
+	}
+	if t.Note != "" {
+		note = t.Note
+	}
+	return rpe, rir, note
+}
+
+// measurementParams returns the duration and distance, left out values are stored as NULL
+func measurementParams(t createSetRequest) (interface{}, interface{}) {
+	var duration, distance interface{}
+	if t.DurationSeconds != nil {
+		duration = int64(*t.DurationSeconds)
+	}
+	if t.DistanceMeters != nil {
+		distance = *t.DistanceMeters
+	}
+	return duration, distance
+}
+
+// parsePerformedOn returns when the set was finished, sets without a time are finished now
+func parsePerformedOn(v string) (time.Time, error) {
+	if v == "" {
+		return time.Now().UTC(), nil
+	}
+	performedOn, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid performed on time: %w", err)
+	}
+	return performedOn.UTC(), nil
+}
+
+// restSeconds returns the rest taken before the set. Without a logged rest it is
+// the time from the set performed before it in the workout until this set was
+// started, timed sets starting their duration before they were finished. The
+// first set of a workout has no rest.
+func (s *setsService) restSeconds(context context.Context, t createSetRequest, setId string, performedOn time.Time, exerciseId string, userId string) (interface{}, error) {
+	if t.RestSeconds != nil {
+		return int64(*t.RestSeconds), nil
+	}
+
+	previous, err := s.repo.GetPreviousPerformedOn(context, repository.GetPreviousPerformedOnByExerciseIdParams{
+		ExerciseID:  exerciseId,
+		UserID:      userId,
+		ID:          setId,
+		PerformedOn: performedOn.Format(time.RFC3339),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	started := performedOn
+	if t.DurationSeconds != nil {
+		started = started.Add(-time.Duration(*t.DurationSeconds) * time.Second)
+	}
+	return int64(max(started.Sub(previous), 0).Seconds()), nil
+}
+
+// validateMeasurement checks the set against how its exercise type is measured
+func (s *setsService) validateMeasurement(context context.Context, t createSetRequest, exerciseId string, userId string) error {
+	kind, err := s.repo.GetMeasurement(context, repository.GetMeasurementByExerciseIdParams{
+		ExerciseID: exerciseId,
+		UserID:     userId,
+	})
+	if err != nil {
+		return err
+	}
+
+	set := measurement.Set{
+		Weight: t.Weight,
+		Reps:   t.Repetitions,
+	}
+	if t.DurationSeconds != nil {
+		set.DurationSeconds = *t.DurationSeconds
+	}
+	if t.DistanceMeters != nil {
+		set.DistanceMeters = *t.DistanceMeters
+	}
+	return measurement.Validate(kind, set)
+}
+
+const (
+	minRPE        = 6
+	maxRPE        = 10
+	maxRIR        = 10
+	maxNoteLength = 500
+)
+
+func validateSetRequest(t createSetRequest) error {
+	// RPE is logged in half steps, e.g. 7.5
+	if t.RPE != nil && (*t.RPE < minRPE || *t.RPE > maxRPE || math.Mod(*t.RPE*2, 1) != 0) {
+		return fmt.Errorf("rpe must be between %d and %d in half steps", minRPE, maxRPE)
+	}
+	if t.RIR != nil && (*t.RIR < 0 || *t.RIR > maxRIR) {
+		return fmt.Errorf("rir must be between 0 and %d", maxRIR)
+	}
+	if utf8.RuneCountInString(t.Note) > maxNoteLength {
+		return fmt.Errorf("note must be at most %d characters", maxNoteLength)
+	}
+	if t.RestSeconds != nil && *t.RestSeconds < 0 {
+		return fmt.Errorf("rest seconds must not be negative")
+	}
+	if _, err := parsePerformedOn(t.PerformedOn); err != nil {
+		return err
+	}
+	return nil
+}
+
 type setsService struct {
-	repo SetsRepository
+	repo   SetsRepository
+	withTx Transactor
 }
 
-func NewService(repo SetsRepository) Service {
-	return &setsService{repo}
+// Transactor runs fn with a repository whose writes are committed together
+type Transactor func(ctx context.Context, fn func(SetsRepository) error) error
+
+func NewService(repo SetsRepository, withTx Transactor) Service {
+	return &setsService{repo, withTx}
 }
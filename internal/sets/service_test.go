@@ -2,7 +2,12 @@ package sets
 
  import (
  	"context"
+	"database/sql"
+	"fmt"
+	"strings"
  	"testing"
+	"time"
+	"weight-tracker/internal/measurement"
  	"weight-tracker/internal/repository"
 
  	"github.com/stretchr/testify/assert"
@@ -38,6 +43,57 @@ package sets
  	return args.Get(0).([]Set), args.Error(1)
  }
 
+func (r *repoMock) GetByWorkoutId(ctx context.Context, arg repository.GetSetsByWorkoutIdParams) ([]Set, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]Set), args.Error(1)
+}
+
+func (r *repoMock) UpdateById(ctx context.Context, arg repository.UpdateSetParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *repoMock) GetExerciseItemType(ctx context.Context, arg repository.GetExerciseItemByIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) GetExerciseIdsByExerciseItemId(ctx context.Context, arg repository.GetExercisesByExerciseItemIdParams) ([]string, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (r *repoMock) GetNextRound(ctx context.Context, arg repository.GetNextRoundByExerciseItemIdParams) (int64, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (r *repoMock) GetMeasurement(ctx context.Context, arg repository.GetMeasurementByExerciseIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) GetPreviousPerformedOn(ctx context.Context, arg repository.GetPreviousPerformedOnByExerciseIdParams) (time.Time, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (r *repoMock) UpdatePosition(ctx context.Context, arg repository.UpdateSetPositionParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+// transactorStub runs fn on the repository it holds and counts the transactions
+type transactorStub struct {
+	repo SetsRepository
+	runs int
+}
+
+func (s *transactorStub) withTx(ctx context.Context, fn func(SetsRepository) error) error {
+	s.runs++
+	return fn(s.repo)
+}
+
  func TestGetByExerciseId(t *testing.T) {
 	userId := "userid"
 	exerciseId := "exerciseIdA"
@@ -56,7 +112,7 @@ package sets
  		{ID: "b", Repetitions: 1, Weight: 10.0, ExerciseID: exerciseId},
  	}, nil).Once()
 
- 	service := NewService(&repoMock)
+ 	service := NewService(&repoMock, nil)
 
  	result, err := service.GetByExerciseId(ctx, exerciseId, userId)
 
@@ -74,11 +130,13 @@ package sets
  	exerciseId := "exerciseId"
 
  	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, repository.GetMeasurementByExerciseIdParams{ExerciseID: exerciseId, UserID: userId}).Return(measurement.WeightReps, nil).Once()
+ 	repoMock.On("GetPreviousPerformedOn", ctx, mock.Anything).Return(time.Time{}, sql.ErrNoRows)
  	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
- 		return input.Weight == 10.5 && input.Repetitions == 1 && input.ExerciseID == exerciseId && input.CreatedOn != "" && input.UpdatedOn != "" && input.UserID == userId
+ 		return input.Weight == 10.5 && input.Repetitions == 1 && input.Type == TypeWorking && input.ExerciseID == exerciseId && input.CreatedOn != "" && input.UpdatedOn != "" && input.UserID == userId
  	})).Return(setId, nil).Once()
 
- 	service := NewService(&repoMock)
+ 	service := NewService(&repoMock, nil)
  	id, err := service.CreateAndReturnId(context.Background(), createSetRequest{
  		Repetitions: 1,
  		Weight: 10.5,
@@ -90,6 +148,158 @@ package sets
  	repoMock.AssertExpectations(t)
  }
 
+func TestCreateAndReturnIdWithType(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+	exerciseId := "exerciseId"
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.WeightReps, nil).Once()
+	repoMock.On("GetPreviousPerformedOn", ctx, mock.Anything).Return(time.Time{}, sql.ErrNoRows)
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		return input.Type == TypeWarmup
+	})).Return("setId", nil).Once()
+
+	service := NewService(&repoMock, nil)
+	id, err := service.CreateAndReturnId(ctx, createSetRequest{Repetitions: 10, Weight: 40, Type: TypeWarmup}, exerciseId, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "setId", id)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateAndReturnIdInvalidType(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	service := NewService(&repoMock, nil)
+	_, err := service.CreateAndReturnId(ctx, createSetRequest{Repetitions: 10, Weight: 40, Type: "cooldown"}, "exerciseId", "userId")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "CreateAndReturnId")
+}
+
+func TestCreateAndReturnIdWithEffort(t *testing.T) {
+	ctx := context.Background()
+	rpe := 8.5
+	rir := 2
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.WeightReps, nil).Once()
+	repoMock.On("GetPreviousPerformedOn", ctx, mock.Anything).Return(time.Time{}, sql.ErrNoRows)
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		return input.Rpe == 8.5 && input.Rir == int64(2) && input.Note == "Felt heavy"
+	})).Return("setId", nil).Once()
+
+	service := NewService(&repoMock, nil)
+	_, err := service.CreateAndReturnId(ctx, createSetRequest{Repetitions: 5, Weight: 100, RPE: &rpe, RIR: &rir, Note: "Felt heavy"}, "exerciseId", "userId")
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateAndReturnIdWithoutEffort(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.WeightReps, nil).Once()
+	repoMock.On("GetPreviousPerformedOn", ctx, mock.Anything).Return(time.Time{}, sql.ErrNoRows)
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		return input.Rpe == nil && input.Rir == nil && input.Note == nil
+	})).Return("setId", nil).Once()
+
+	service := NewService(&repoMock, nil)
+	_, err := service.CreateAndReturnId(ctx, createSetRequest{Repetitions: 5, Weight: 100}, "exerciseId", "userId")
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateAndReturnIdWithDistanceDuration(t *testing.T) {
+	ctx := context.Background()
+	duration := 1500
+	distance := 5000.0
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.DistanceDuration, nil).Once()
+	repoMock.On("GetPreviousPerformedOn", ctx, mock.Anything).Return(time.Time{}, sql.ErrNoRows)
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		return input.DurationSeconds == int64(1500) && input.DistanceMeters == 5000.0 && input.Weight == 0 && input.Repetitions == 0
+	})).Return("setId", nil).Once()
+
+	service := NewService(&repoMock, nil)
+	_, err := service.CreateAndReturnId(ctx, createSetRequest{DurationSeconds: &duration, DistanceMeters: &distance}, "exerciseId", "userId")
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateAndReturnIdMissingMeasurementFields(t *testing.T) {
+	ctx := context.Background()
+	duration := 60
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.DistanceDuration, nil).Once()
+
+	service := NewService(&repoMock, nil)
+	_, err := service.CreateAndReturnId(ctx, createSetRequest{DurationSeconds: &duration}, "exerciseId", "userId")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "CreateAndReturnId")
+}
+
+func TestCreateAndReturnIdDurationOnWeightReps(t *testing.T) {
+	ctx := context.Background()
+	duration := 60
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.WeightReps, nil).Once()
+
+	service := NewService(&repoMock, nil)
+	_, err := service.CreateAndReturnId(ctx, createSetRequest{Repetitions: 5, Weight: 100, DurationSeconds: &duration}, "exerciseId", "userId")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "CreateAndReturnId")
+}
+
+func TestPatchByIdKeepsDuration(t *testing.T) {
+	ctx := context.Background()
+	duration := int64(60)
+	weight := 10.0
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(Set{ID: "setId", Type: TypeWorking, DurationSeconds: &duration, ExerciseID: "exerciseId"}, nil).Once()
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.Duration, nil).Once()
+	repoMock.On("UpdateById", ctx, mock.MatchedBy(func(input repository.UpdateSetParams) bool {
+		return input.DurationSeconds == int64(60) && input.DistanceMeters == nil && input.Weight == 10.0
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock, nil)
+	err := service.PatchById(ctx, "setId", patchSetRequest{Weight: &weight}, "userId")
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestValidateSetRequest(t *testing.T) {
+	valid := []float64{6, 7.5, 10}
+	for _, v := range valid {
+		assert.Nil(t, validateSetRequest(createSetRequest{RPE: &v}))
+	}
+
+	invalid := []float64{5.5, 7.25, 10.5}
+	for _, v := range invalid {
+		assert.NotNil(t, validateSetRequest(createSetRequest{RPE: &v}))
+	}
+
+	rir := -1
+	assert.NotNil(t, validateSetRequest(createSetRequest{RIR: &rir}))
+	rir = 3
+	assert.Nil(t, validateSetRequest(createSetRequest{RIR: &rir}))
+
+	assert.NotNil(t, validateSetRequest(createSetRequest{Note: strings.Repeat("a", maxNoteLength+1)}))
+}
+
 func TestDeleteById(t *testing.T) {
 	ctx := context.Background()
 	userId := "userId"
@@ -100,9 +310,322 @@ func TestDeleteById(t *testing.T) {
 		return input.ID == setId && input.UserID == userId
 	})).Return(int64(1), nil).Once()
 
-	service := NewService(&repoMock)
+	service := NewService(&repoMock, nil)
 	err := service.DeleteById(ctx, setId, userId)
 
 	assert.Nil(t, err)
 	repoMock.AssertExpectations(t)
 }
+
+func TestUpdateById(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+	setId := "setId"
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, repository.GetSetByIdParams{ID: setId, UserID: userId}).Return(Set{ID: setId, ExerciseID: "exerciseId"}, nil).Once()
+	repoMock.On("GetMeasurement", ctx, repository.GetMeasurementByExerciseIdParams{ExerciseID: "exerciseId", UserID: userId}).Return(measurement.WeightReps, nil).Once()
+	repoMock.On("UpdateById", ctx, mock.MatchedBy(func(input repository.UpdateSetParams) bool {
+		return input.ID == setId && input.UserID == userId && input.Repetitions == 8 && input.Weight == 80 && input.Type == TypeWorking && input.Rpe == nil && input.UpdatedOn != ""
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock, nil)
+	err := service.UpdateById(ctx, setId, createSetRequest{Repetitions: 8, Weight: 80}, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateByIdInvalid(t *testing.T) {
+	ctx := context.Background()
+	rpe := 11.0
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(Set{ID: "setId", ExerciseID: "exerciseId"}, nil)
+	service := NewService(&repoMock, nil)
+
+	err := service.UpdateById(ctx, "setId", createSetRequest{Repetitions: 8, Weight: 80, RPE: &rpe}, "userId")
+	assert.NotNil(t, err)
+
+	err = service.UpdateById(ctx, "setId", createSetRequest{Repetitions: 8, Weight: 80, Type: "cooldown"}, "userId")
+	assert.NotNil(t, err)
+
+	repoMock.AssertNotCalled(t, "UpdateById")
+}
+
+func TestPatchById(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+	setId := "setId"
+	rpe := 8.0
+	rir := int64(2)
+	weight := 82.5
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, repository.GetSetByIdParams{ID: setId, UserID: userId}).
+		Return(Set{ID: setId, Repetitions: 5, Weight: 80, Type: TypeDrop, RPE: &rpe, RIR: &rir, Note: "Paused", ExerciseID: "exerciseId"}, nil).Once()
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.WeightReps, nil).Once()
+	repoMock.On("UpdateById", ctx, mock.MatchedBy(func(input repository.UpdateSetParams) bool {
+		return input.Weight == 82.5 && input.Repetitions == 5 && input.Type == TypeDrop && input.Rpe == 8.0 && input.Rir == int64(2) && input.Note == "Paused"
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock, nil)
+	err := service.PatchById(ctx, setId, patchSetRequest{Weight: &weight}, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestPatchByIdNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(Set{}, fmt.Errorf("failed to get set by id: %w", sql.ErrNoRows)).Once()
+
+	service := NewService(&repoMock, nil)
+	err := service.PatchById(ctx, "setId", patchSetRequest{}, "userId")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	repoMock.AssertNotCalled(t, "UpdateById")
+}
+
+func TestReorder(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+	exerciseId := "exerciseId"
+
+	repoMock := repoMock{}
+	repoMock.On("GetByExerciseId", ctx, repository.GetSetsByExerciseIdParams{ExerciseID: exerciseId, UserID: userId}).
+		Return([]Set{{ID: "a"}, {ID: "b"}}, nil).Once()
+	repoMock.On("UpdatePosition", ctx, mock.MatchedBy(func(input repository.UpdateSetPositionParams) bool {
+		return input.ID == "b" && input.Position == 0
+	})).Return(nil).Once()
+	repoMock.On("UpdatePosition", ctx, mock.MatchedBy(func(input repository.UpdateSetPositionParams) bool {
+		return input.ID == "a" && input.Position == 1
+	})).Return(nil).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, transactor.withTx)
+	err := service.Reorder(ctx, exerciseId, []string{"b", "a"}, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestReorderInOneTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetByExerciseId", ctx, mock.Anything).Return([]Set{{ID: "a"}, {ID: "b"}}, nil).Once()
+	repoMock.On("UpdatePosition", ctx, mock.MatchedBy(func(input repository.UpdateSetPositionParams) bool {
+		return input.ID == "b"
+	})).Return(nil).Once()
+	repoMock.On("UpdatePosition", ctx, mock.MatchedBy(func(input repository.UpdateSetPositionParams) bool {
+		return input.ID == "a"
+	})).Return(testError).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, transactor.withTx)
+	err := service.Reorder(ctx, "exerciseId", []string{"b", "a"}, "userId")
+
+	// The error is returned from the transaction, so the first position is rolled back
+	assert.ErrorIs(t, err, testError)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestReorderInvalidIds(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetByExerciseId", ctx, mock.Anything).Return([]Set{{ID: "a"}, {ID: "b"}}, nil).Times(3)
+
+	service := NewService(&repoMock, nil)
+	assert.NotNil(t, service.Reorder(ctx, "exerciseId", []string{"a"}, "userId"))
+	assert.NotNil(t, service.Reorder(ctx, "exerciseId", []string{"a", "a"}, "userId"))
+	assert.NotNil(t, service.Reorder(ctx, "exerciseId", []string{"a", "c"}, "userId"))
+	repoMock.AssertNotCalled(t, "UpdatePosition")
+}
+
+func TestCreateRound(t *testing.T) {
+	ctx := context.Background()
+	itemParams := repository.GetExerciseItemByIdParams{ID: "itemId", UserID: "userId"}
+
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseItemType", ctx, itemParams).Return("superset", nil).Once()
+	repoMock.On("GetExerciseIdsByExerciseItemId", ctx, repository.GetExercisesByExerciseItemIdParams{ExerciseItemID: "itemId", UserID: "userId"}).Return([]string{"a", "b"}, nil).Once()
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.WeightReps, nil).Twice()
+	repoMock.On("GetNextRound", ctx, repository.GetNextRoundByExerciseItemIdParams{ExerciseItemID: "itemId", UserID: "userId"}).Return(int64(3), nil).Once()
+	repoMock.On("GetPreviousPerformedOn", ctx, mock.Anything).Return(time.Time{}, sql.ErrNoRows)
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		return input.ExerciseID == "a" && input.RoundNumber == int64(3)
+	})).Return("setA", nil).Once()
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		return input.ExerciseID == "b" && input.RoundNumber == int64(3)
+	})).Return("setB", nil).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, transactor.withTx)
+	round, err := service.CreateRound(ctx, "itemId", []roundSetRequest{
+		{ExerciseID: "a", createSetRequest: createSetRequest{Repetitions: 10, Weight: 20}},
+		{ExerciseID: "b", createSetRequest: createSetRequest{Repetitions: 8, Weight: 30}},
+	}, "userId")
+
+	assert.Nil(t, err)
+	assert.Equal(t, Round{Round: 3, IDs: []string{"setA", "setB"}}, round)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateRoundInOneTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseItemType", ctx, mock.Anything).Return("superset", nil).Once()
+	repoMock.On("GetExerciseIdsByExerciseItemId", ctx, mock.Anything).Return([]string{"a", "b"}, nil).Once()
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.WeightReps, nil).Twice()
+	repoMock.On("GetNextRound", ctx, mock.Anything).Return(int64(3), nil).Once()
+	repoMock.On("GetPreviousPerformedOn", ctx, mock.Anything).Return(time.Time{}, sql.ErrNoRows)
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		return input.ExerciseID == "a"
+	})).Return("setA", nil).Once()
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		return input.ExerciseID == "b"
+	})).Return("", testError).Once()
+
+	transactor := transactorStub{repo: &repoMock}
+	service := NewService(&repoMock, transactor.withTx)
+	_, err := service.CreateRound(ctx, "itemId", []roundSetRequest{
+		{ExerciseID: "a", createSetRequest: createSetRequest{Repetitions: 10, Weight: 20}},
+		{ExerciseID: "b", createSetRequest: createSetRequest{Repetitions: 8, Weight: 30}},
+	}, "userId")
+
+	// The error is returned from the transaction, so the first set is rolled back
+	assert.ErrorIs(t, err, testError)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateRoundStraightItem(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseItemType", ctx, mock.Anything).Return("straight", nil).Once()
+
+	service := NewService(&repoMock, nil)
+	_, err := service.CreateRound(ctx, "itemId", []roundSetRequest{{ExerciseID: "a", createSetRequest: createSetRequest{Repetitions: 10, Weight: 20}}}, "userId")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "CreateAndReturnId")
+}
+
+func TestCreateRoundInvalidExercises(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetExerciseItemType", ctx, mock.Anything).Return("circuit", nil)
+	repoMock.On("GetExerciseIdsByExerciseItemId", ctx, mock.Anything).Return([]string{"a", "b"}, nil)
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.WeightReps, nil)
+
+	service := NewService(&repoMock, nil)
+
+	_, err := service.CreateRound(ctx, "itemId", []roundSetRequest{}, "userId")
+	assert.NotNil(t, err)
+
+	_, err = service.CreateRound(ctx, "itemId", []roundSetRequest{{ExerciseID: "c", createSetRequest: createSetRequest{Repetitions: 10}}}, "userId")
+	assert.NotNil(t, err)
+
+	_, err = service.CreateRound(ctx, "itemId", []roundSetRequest{
+		{ExerciseID: "a", createSetRequest: createSetRequest{Repetitions: 10}},
+		{ExerciseID: "a", createSetRequest: createSetRequest{Repetitions: 10}},
+	}, "userId")
+	assert.NotNil(t, err)
+
+	repoMock.AssertNotCalled(t, "CreateAndReturnId")
+}
+
+func TestCreateAndReturnIdDerivesRest(t *testing.T) {
+	ctx := context.Background()
+	duration := 60
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.Duration, nil).Once()
+	repoMock.On("GetPreviousPerformedOn", ctx, mock.MatchedBy(func(input repository.GetPreviousPerformedOnByExerciseIdParams) bool {
+		return input.ExerciseID == "exerciseId" && input.UserID == "userId" && input.PerformedOn == "2025-01-01T10:05:00Z" && input.ID != ""
+	})).Return(time.Date(2025, 1, 1, 10, 2, 0, 0, time.UTC), nil).Once()
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		// Three minutes between the sets, one of them spent on the timed set
+		return input.PerformedOn == "2025-01-01T10:05:00Z" && input.RestSeconds == int64(120)
+	})).Return("setId", nil).Once()
+
+	service := NewService(&repoMock, nil)
+	_, err := service.CreateAndReturnId(ctx, createSetRequest{DurationSeconds: &duration, PerformedOn: "2025-01-01T11:05:00+01:00"}, "exerciseId", "userId")
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateAndReturnIdFirstSetHasNoRest(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.WeightReps, nil).Once()
+	repoMock.On("GetPreviousPerformedOn", ctx, mock.Anything).Return(time.Time{}, fmt.Errorf("failed to get previous performed set: %w", sql.ErrNoRows)).Once()
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		_, err := time.Parse(time.RFC3339, input.PerformedOn.(string))
+		return err == nil && input.RestSeconds == nil
+	})).Return("setId", nil).Once()
+
+	service := NewService(&repoMock, nil)
+	_, err := service.CreateAndReturnId(ctx, createSetRequest{Repetitions: 5, Weight: 100}, "exerciseId", "userId")
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateAndReturnIdWithLoggedRest(t *testing.T) {
+	ctx := context.Background()
+	rest := 90
+
+	repoMock := repoMock{}
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.WeightReps, nil).Once()
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		return input.RestSeconds == int64(90)
+	})).Return("setId", nil).Once()
+
+	service := NewService(&repoMock, nil)
+	_, err := service.CreateAndReturnId(ctx, createSetRequest{Repetitions: 5, Weight: 100, RestSeconds: &rest}, "exerciseId", "userId")
+
+	assert.Nil(t, err)
+	repoMock.AssertNotCalled(t, "GetPreviousPerformedOn", mock.Anything, mock.Anything)
+	repoMock.AssertExpectations(t)
+}
+
+func TestPatchByIdKeepsTiming(t *testing.T) {
+	ctx := context.Background()
+	performedOn := "2025-01-01T10:00:00Z"
+	rest := int64(150)
+	reps := 6
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(Set{ID: "setId", Type: TypeWorking, ExerciseID: "exerciseId", PerformedOn: &performedOn, RestSeconds: &rest}, nil).Once()
+	repoMock.On("GetMeasurement", ctx, mock.Anything).Return(measurement.WeightReps, nil).Once()
+	repoMock.On("UpdateById", ctx, mock.MatchedBy(func(input repository.UpdateSetParams) bool {
+		return input.Repetitions == 6 && input.PerformedOn == performedOn && input.RestSeconds == rest
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock, nil)
+	err := service.PatchById(ctx, "setId", patchSetRequest{Repetitions: &reps}, "userId")
+
+	assert.Nil(t, err)
+	repoMock.AssertNotCalled(t, "GetPreviousPerformedOn", mock.Anything, mock.Anything)
+	repoMock.AssertExpectations(t)
+}
+
+func TestValidateSetRequestTiming(t *testing.T) {
+	negative := -1
+	assert.NotNil(t, validateSetRequest(createSetRequest{RestSeconds: &negative}))
+	assert.NotNil(t, validateSetRequest(createSetRequest{PerformedOn: "2025-01-01 10:00"}))
+	assert.Nil(t, validateSetRequest(createSetRequest{PerformedOn: "2025-01-01T10:00:00Z"}))
+}
@@ -0,0 +1,27 @@
+package sets
+
+import (
+	"fmt"
+	"slices"
+)
+
+const (
+	TypeWarmup  = "warmup"
+	TypeWorking = "working"
+	TypeDrop    = "drop"
+	TypeFailure = "failure"
+	TypeAmrap   = "amrap"
+)
+
+var TypeNames = []string{TypeWarmup, TypeWorking, TypeDrop, TypeFailure, TypeAmrap}
+
+// NormalizeType returns the set type to store, sets without a type are working sets
+func NormalizeType(t string) (string, error) {
+	if t == "" {
+		return TypeWorking, nil
+	}
+	if !slices.Contains(TypeNames, t) {
+		return "", fmt.Errorf("unknown set type: %s", t)
+	}
+	return t, nil
+}
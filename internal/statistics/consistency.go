@@ -0,0 +1,126 @@
+package statistics
+
+import (
+	"math"
+	"time"
+)
+
+// CalendarDay is the number of workouts completed on a day and their tonnage
+type CalendarDay struct {
+	Date     string  `json:"date"`
+	Workouts int     `json:"workouts"`
+	Tonnage  float64 `json:"tonnage"`
+}
+
+// CompletedWorkout is the tonnage of a single completed workout
+type CompletedWorkout struct {
+	CompletedOn time.Time
+	Tonnage     float64
+}
+
+type WeekConsistency struct {
+	WeekStart string `json:"week_start"`
+	Workouts  int    `json:"workouts"`
+	TargetMet bool   `json:"target_met"`
+}
+
+// Consistency is how often the user met the weekly target. Streaks count
+// consecutive weeks meeting the target, the current week only counting once
+// its target is met so a week in progress does not break the streak.
+type Consistency struct {
+	WeeklyTarget        int64             `json:"weekly_target"`
+	CurrentStreak       int               `json:"current_streak"`
+	LongestStreak       int               `json:"longest_streak"`
+	AdherencePercentage float64           `json:"adherence_percentage"`
+	Weeks               []WeekConsistency `json:"weeks"`
+}
+
+// calendarDays returns every day from and to, both inclusive, with the
+// workouts completed on it. Days without workouts are included with zeroes.
+func calendarDays(workouts []CompletedWorkout, calendar Calendar, from time.Time, to time.Time) []CalendarDay {
+	days := map[string]*CalendarDay{}
+	for _, v := range workouts {
+		date := calendar.Day(v.CompletedOn).Format(time.DateOnly)
+		if days[date] == nil {
+			days[date] = &CalendarDay{Date: date}
+		}
+		days[date].Workouts++
+		days[date].Tonnage += v.Tonnage
+	}
+
+	result := []CalendarDay{}
+	for day := calendar.Date(from); !day.After(calendar.Date(to)); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		if v, ok := days[date]; ok {
+			result = append(result, *v)
+			continue
+		}
+		result = append(result, CalendarDay{Date: date})
+	}
+	return result
+}
+
+// weeklyWorkouts counts the workouts per week, keyed by the date the week starts on
+func weeklyWorkouts(completedOn []time.Time, calendar Calendar) map[string]int {
+	counts := map[string]int{}
+	for _, v := range completedOn {
+		counts[calendar.Week(v).Format(time.DateOnly)]++
+	}
+	return counts
+}
+
+// consistency lists the given number of weeks up to and including the current
+// one and computes the streaks over all weeks with workouts. Adherence caps
+// every week at the target and leaves the current week out until it is met.
+// The completed dates are expected to be ordered.
+func consistency(completedOn []time.Time, calendar Calendar, now time.Time, weeks int, target int64) Consistency {
+	counts := weeklyWorkouts(completedOn, calendar)
+	currentWeek := calendar.Week(now)
+	met := func(week time.Time) bool {
+		return int64(counts[week.Format(time.DateOnly)]) >= target
+	}
+
+	result := Consistency{WeeklyTarget: target, Weeks: []WeekConsistency{}}
+
+	week := currentWeek
+	if !met(week) {
+		week = week.AddDate(0, 0, -7)
+	}
+	for ; met(week); week = week.AddDate(0, 0, -7) {
+		result.CurrentStreak++
+	}
+
+	if len(completedOn) > 0 {
+		streak := 0
+		for week := calendar.Week(completedOn[0]); !week.After(currentWeek); week = week.AddDate(0, 0, 7) {
+			if !met(week) {
+				streak = 0
+				continue
+			}
+			streak++
+			result.LongestStreak = max(result.LongestStreak, streak)
+		}
+	}
+
+	var done, expected int64
+	from := currentWeek.AddDate(0, 0, -7*(weeks-1))
+	for i := range weeks {
+		week := from.AddDate(0, 0, 7*i)
+		workouts := counts[week.Format(time.DateOnly)]
+		result.Weeks = append(result.Weeks, WeekConsistency{
+			WeekStart: week.Format(time.DateOnly),
+			Workouts:  workouts,
+			TargetMet: met(week),
+		})
+
+		if week.Equal(currentWeek) && !met(week) {
+			continue
+		}
+		done += min(int64(workouts), target)
+		expected += target
+	}
+	if expected > 0 {
+		result.AdherencePercentage = math.Round(float64(done)/float64(expected)*10000) / 100
+	}
+	return result
+}
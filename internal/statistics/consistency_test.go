@@ -0,0 +1,86 @@
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func workoutsOn(dates ...string) []time.Time {
+	result := []time.Time{}
+	for _, v := range dates {
+		date, _ := time.Parse(time.DateOnly, v)
+		result = append(result, date.Add(18*time.Hour))
+	}
+	return result
+}
+
+func TestConsistency(t *testing.T) {
+	completedOn := workoutsOn(
+		"2024-12-16", "2024-12-18",
+		"2024-12-23", "2024-12-27",
+		"2024-12-30", "2025-01-01", "2025-01-03",
+		"2025-01-08",
+		"2025-01-13", "2025-01-15",
+		"2025-01-20",
+	)
+	now := time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC)
+
+	result := consistency(completedOn, DefaultCalendar, now, 4, 2)
+
+	assert.Equal(t, Consistency{
+		WeeklyTarget:  2,
+		CurrentStreak: 1,
+		LongestStreak: 3,
+		// The current week is left out as its target is not met yet
+		AdherencePercentage: 83.33,
+		Weeks: []WeekConsistency{
+			{WeekStart: "2024-12-30", Workouts: 3, TargetMet: true},
+			{WeekStart: "2025-01-06", Workouts: 1},
+			{WeekStart: "2025-01-13", Workouts: 2, TargetMet: true},
+			{WeekStart: "2025-01-20", Workouts: 1},
+		},
+	}, result)
+}
+
+func TestConsistencyCurrentWeekMet(t *testing.T) {
+	completedOn := workoutsOn("2025-01-08", "2025-01-13", "2025-01-15", "2025-01-20", "2025-01-21")
+	now := time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC)
+
+	result := consistency(completedOn, DefaultCalendar, now, 3, 2)
+
+	assert.Equal(t, 2, result.CurrentStreak)
+	assert.Equal(t, 2, result.LongestStreak)
+	assert.Equal(t, 83.33, result.AdherencePercentage)
+}
+
+func TestConsistencyStreakBroken(t *testing.T) {
+	completedOn := workoutsOn("2025-01-06", "2025-01-07")
+	now := time.Date(2025, 1, 22, 12, 0, 0, 0, time.UTC)
+
+	result := consistency(completedOn, DefaultCalendar, now, 1, 2)
+
+	assert.Equal(t, 0, result.CurrentStreak)
+	assert.Equal(t, 1, result.LongestStreak)
+	assert.Equal(t, 0.0, result.AdherencePercentage)
+}
+
+func TestCalendarDaysInTimezone(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	calendar := Calendar{Location: newYork, WeekStart: time.Monday}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	workouts := []CompletedWorkout{
+		// The evening of the first of January in New York
+		{CompletedOn: time.Date(2025, 1, 2, 2, 0, 0, 0, time.UTC), Tonnage: 500},
+		{CompletedOn: time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC), Tonnage: 250.5},
+	}
+
+	result := calendarDays(workouts, calendar, from, to)
+
+	assert.Equal(t, []CalendarDay{
+		{Date: "2025-01-01", Workouts: 2, Tonnage: 750.5},
+		{Date: "2025-01-02"},
+	}, result)
+}
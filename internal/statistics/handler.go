@@ -1,23 +1,45 @@
 package statistics
 
 import (
+	"context"
 	"database/sql"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 	"weight-tracker/internal/database"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/units"
 	"weight-tracker/internal/utils"
 )
 
 func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
+	preferences := preferences.NewServiceFromDatabase(s)
 	handler := handler{
-		service: NewService(&statisticsRepository{s.GetRepository()}),
+		service: NewService(NewStatisticsRepository(s.GetRepository(), time.Now), preferences),
+		units:   preferences,
 	}
 
 	mux.Handle("GET /statistics", authenticationWrapper(http.HandlerFunc(handler.getStatistics)))
+	mux.Handle("GET /statistics/volume", authenticationWrapper(http.HandlerFunc(handler.getVolume)))
+	mux.Handle("GET /statistics/muscle-groups", authenticationWrapper(http.HandlerFunc(handler.getWeeklyMuscleGroupSets)))
+	mux.Handle("GET /statistics/calendar", authenticationWrapper(http.HandlerFunc(handler.getCalendar)))
+	mux.Handle("GET /statistics/consistency", authenticationWrapper(http.HandlerFunc(handler.getConsistency)))
 }
 
 type handler struct {
 	service Service
+	units   unitPreferences
+}
+
+// Weights are stored in kilograms, responses use the unit of the user
+type unitPreferences interface {
+	GetWeightUnit(ctx context.Context, userId string) (string, error)
+}
+
+func convertVolume(volume Volume, unit string) Volume {
+	volume.Tonnage = units.FromKilograms(volume.Tonnage, unit)
+	return volume
 }
 
 type getStatisticsResponse struct {
@@ -27,6 +49,13 @@ type getStatisticsResponse struct {
 	PreviousMonth int `json:"previous_month"`
 	Year          int `json:"year"`
 	PreviousYear  int `json:"previous_year"`
+
+	WeekVolume          Volume `json:"week_volume"`
+	PreviousWeekVolume  Volume `json:"previous_week_volume"`
+	MonthVolume         Volume `json:"month_volume"`
+	PreviousMonthVolume Volume `json:"previous_month_volume"`
+	YearVolume          Volume `json:"year_volume"`
+	PreviousYearVolume  Volume `json:"previous_year_volume"`
 }
 
 func (s *handler) getStatistics(w http.ResponseWriter, r *http.Request) {
@@ -44,6 +73,13 @@ func (s *handler) getStatistics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
 	response := getStatisticsResponse{
 		Week:          statistics.Week,
 		PreviousWeek:  statistics.PreviousWeek,
@@ -51,6 +87,13 @@ func (s *handler) getStatistics(w http.ResponseWriter, r *http.Request) {
 		PreviousMonth: statistics.PreviousMonth,
 		Year:          statistics.Year,
 		PreviousYear:  statistics.PreviousYear,
+
+		WeekVolume:          convertVolume(statistics.WeekVolume, unit),
+		PreviousWeekVolume:  convertVolume(statistics.PreviousWeekVolume, unit),
+		MonthVolume:         convertVolume(statistics.MonthVolume, unit),
+		PreviousMonthVolume: convertVolume(statistics.PreviousMonthVolume, unit),
+		YearVolume:          convertVolume(statistics.YearVolume, unit),
+		PreviousYearVolume:  convertVolume(statistics.PreviousYearVolume, unit),
 	}
 	jsonResp, err := utils.CreateResponse(response)
 	if err != nil {
@@ -61,3 +104,186 @@ func (s *handler) getStatistics(w http.ResponseWriter, r *http.Request) {
 
 	utils.ReturnJson(w, jsonResp)
 }
+
+// Number of days included in the volume statistics when no range is given
+const defaultVolumeDays = 30
+
+func (s *handler) getVolume(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, -(defaultVolumeDays - 1))
+
+	var err error
+	if v := r.URL.Query().Get("to"); v != "" {
+		to, err = time.Parse(time.DateOnly, v)
+		if err != nil {
+			http.Error(w, "Invalid to date", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("from") == "" {
+			from = to.AddDate(0, 0, -(defaultVolumeDays - 1))
+		}
+	}
+	if v := r.URL.Query().Get("from"); v != "" {
+		from, err = time.Parse(time.DateOnly, v)
+		if err != nil {
+			http.Error(w, "Invalid from date", http.StatusBadRequest)
+			return
+		}
+	}
+
+	volume, err := s.service.GetVolume(r.Context(), from, to, userId)
+	if err != nil {
+		slog.Warn("Failed to get volume", "error", err)
+		http.Error(w, "Failed to get volume", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get volume", http.StatusBadRequest)
+		return
+	}
+	volume.Total = convertVolume(volume.Total, unit)
+	for i, v := range volume.ExerciseTypes {
+		volume.ExerciseTypes[i].Tonnage = units.FromKilograms(v.Tonnage, unit)
+	}
+	for i, v := range volume.ExerciseItemTypes {
+		volume.ExerciseItemTypes[i].Tonnage = units.FromKilograms(v.Tonnage, unit)
+	}
+
+	jsonResp, err := utils.CreateResponse(volume)
+	if err != nil {
+		slog.Warn("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	utils.ReturnJson(w, jsonResp)
+}
+
+// Upper limit for the number of weeks of muscle group statistics in one request
+const maxMuscleGroupWeeks = 52
+
+func (s *handler) getWeeklyMuscleGroupSets(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	weeks := 1
+	if v := r.URL.Query().Get("weeks"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 || parsed > maxMuscleGroupWeeks {
+			http.Error(w, "Invalid number of weeks", http.StatusBadRequest)
+			return
+		}
+		weeks = parsed
+	}
+
+	muscleGroupSets, err := s.service.GetWeeklyMuscleGroupSets(r.Context(), weeks, userId)
+	if err != nil {
+		slog.Warn("Failed to get muscle group sets", "error", err)
+		http.Error(w, "Failed to get muscle group sets", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(muscleGroupSets)
+	if err != nil {
+		slog.Warn("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	utils.ReturnJson(w, jsonResp)
+}
+
+// Number of days in the calendar when no range is given
+const defaultCalendarDays = 365
+
+// Upper limit for the number of days in the calendar, a leap year
+const maxCalendarDays = 366
+
+func (s *handler) getCalendar(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	var err error
+	if v := r.URL.Query().Get("to"); v != "" {
+		to, err = time.Parse(time.DateOnly, v)
+		if err != nil {
+			http.Error(w, "Invalid to date", http.StatusBadRequest)
+			return
+		}
+	}
+	from := to.AddDate(0, 0, -(defaultCalendarDays - 1))
+	if v := r.URL.Query().Get("from"); v != "" {
+		from, err = time.Parse(time.DateOnly, v)
+		if err != nil {
+			http.Error(w, "Invalid from date", http.StatusBadRequest)
+			return
+		}
+	}
+	if to.Before(from) || to.Sub(from) >= maxCalendarDays*24*time.Hour {
+		http.Error(w, "Invalid date range", http.StatusBadRequest)
+		return
+	}
+
+	days, err := s.service.GetCalendar(r.Context(), from, to, userId)
+	if err != nil {
+		slog.Warn("Failed to get calendar", "error", err)
+		http.Error(w, "Failed to get calendar", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get calendar", http.StatusBadRequest)
+		return
+	}
+	for i, v := range days {
+		days[i].Tonnage = units.FromKilograms(v.Tonnage, unit)
+	}
+
+	jsonResp, err := utils.CreateResponse(days)
+	if err != nil {
+		slog.Warn("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	utils.ReturnJson(w, jsonResp)
+}
+
+// Number of weeks of consistency returned when none is given
+const defaultConsistencyWeeks = 12
+
+func (s *handler) getConsistency(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	weeks := defaultConsistencyWeeks
+	if v := r.URL.Query().Get("weeks"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 || parsed > maxMuscleGroupWeeks {
+			http.Error(w, "Invalid number of weeks", http.StatusBadRequest)
+			return
+		}
+		weeks = parsed
+	}
+
+	consistency, err := s.service.GetConsistency(r.Context(), weeks, userId)
+	if err != nil {
+		slog.Warn("Failed to get consistency", "error", err)
+		http.Error(w, "Failed to get consistency", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(consistency)
+	if err != nil {
+		slog.Warn("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	utils.ReturnJson(w, jsonResp)
+}
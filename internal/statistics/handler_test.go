@@ -0,0 +1,279 @@
+package statistics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"weight-tracker/internal/units"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type serviceMock struct {
+	mock.Mock
+}
+
+func (s *serviceMock) GetStatistics(ctx context.Context, userId string) (Statistics, error) {
+	args := s.Called(ctx, userId)
+	return args.Get(0).(Statistics), args.Error(1)
+}
+
+func (s *serviceMock) GetVolume(ctx context.Context, from time.Time, to time.Time, userId string) (VolumeReport, error) {
+	args := s.Called(ctx, from, to, userId)
+	return args.Get(0).(VolumeReport), args.Error(1)
+}
+
+func (s *serviceMock) GetWeeklyMuscleGroupSets(ctx context.Context, weeks int, userId string) ([]WeeklyMuscleGroupSets, error) {
+	args := s.Called(ctx, weeks, userId)
+	return args.Get(0).([]WeeklyMuscleGroupSets), args.Error(1)
+}
+
+func (s *serviceMock) GetCalendar(ctx context.Context, from time.Time, to time.Time, userId string) ([]CalendarDay, error) {
+	args := s.Called(ctx, from, to, userId)
+	return args.Get(0).([]CalendarDay), args.Error(1)
+}
+
+func (s *serviceMock) GetConsistency(ctx context.Context, weeks int, userId string) (Consistency, error) {
+	args := s.Called(ctx, weeks, userId)
+	return args.Get(0).(Consistency), args.Error(1)
+}
+
+type unitsStub struct {
+	unit string
+}
+
+func (u unitsStub) GetWeightUnit(ctx context.Context, userId string) (string, error) {
+	return u.unit, nil
+}
+
+func populateContextWithSub(req *http.Request, userId string) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, "sub", userId)
+	return req.WithContext(ctx)
+}
+
+func TestGetVolumeHandler(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/statistics/volume?from=2025-01-01&to=2025-01-31", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetVolume", req.Context(), from, to, userId).Return(VolumeReport{
+		From:              "2025-01-01",
+		To:                "2025-01-31",
+		Total:             Volume{Tonnage: 500, Sets: 1, Reps: 5, TimeUnderLoadSeconds: 15},
+		Sessions:          Sessions{Count: 1, ActiveSeconds: 3600, AverageSeconds: 3600},
+		ExerciseTypes:     []ExerciseTypeVolume{{ExerciseTypeID: "bench", Name: "Bench", Tonnage: 500, Sets: 1, Reps: 5, TimeUnderLoadSeconds: 15}},
+		ExerciseItemTypes: []ExerciseItemTypeVolume{{Type: "superset", Items: 1, Rounds: 1, Sets: 1, Tonnage: 500}},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(s.getVolume).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":{"from":"2025-01-01","to":"2025-01-31","total":{"tonnage":500,"sets":1,"reps":5,"duration_seconds":0,"distance_meters":0,"time_under_load_seconds":15},"sessions":{"count":1,"active_seconds":3600,"average_seconds":3600},"exercise_types":[{"exercise_type_id":"bench","name":"Bench","tonnage":500,"sets":1,"reps":5,"duration_seconds":0,"distance_meters":0,"time_under_load_seconds":15}],"exercise_item_types":[{"type":"superset","items":1,"rounds":1,"sets":1,"tonnage":500}]}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetVolumeHandlerInvalidDate(t *testing.T) {
+	req, err := http.NewRequest("GET", "/statistics/volume?from=01-01-2025", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	serviceMock := serviceMock{}
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(s.getVolume).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+	serviceMock.AssertNotCalled(t, "GetVolume", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetWeeklyMuscleGroupSetsHandler(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/statistics/muscle-groups?weeks=2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetWeeklyMuscleGroupSets", req.Context(), 2, userId).Return([]WeeklyMuscleGroupSets{
+		{WeekStart: "2025-01-06", MuscleGroups: []MuscleGroupSets{}},
+		{WeekStart: "2025-01-13", MuscleGroups: []MuscleGroupSets{{MuscleGroup: "chest", Sets: 1.5, PrimarySets: 1, SecondarySets: 1}}},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(s.getWeeklyMuscleGroupSets).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":[{"week_start":"2025-01-06","muscle_groups":[]},{"week_start":"2025-01-13","muscle_groups":[{"muscle_group":"chest","sets":1.5,"primary_sets":1,"secondary_sets":1}]}]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetWeeklyMuscleGroupSetsHandlerInvalidWeeks(t *testing.T) {
+	req, err := http.NewRequest("GET", "/statistics/muscle-groups?weeks=0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	serviceMock := serviceMock{}
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(s.getWeeklyMuscleGroupSets).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+	serviceMock.AssertNotCalled(t, "GetWeeklyMuscleGroupSets", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetVolumeHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/statistics/volume?from=2025-01-01&to=2025-01-31", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetVolume", req.Context(), from, to, userId).Return(VolumeReport{
+		From:              "2025-01-01",
+		To:                "2025-01-31",
+		Total:             Volume{Tonnage: 500, Sets: 1, Reps: 5, TimeUnderLoadSeconds: 15},
+		Sessions:          Sessions{Count: 1, ActiveSeconds: 3600, AverageSeconds: 3600},
+		ExerciseTypes:     []ExerciseTypeVolume{{ExerciseTypeID: "bench", Name: "Bench", Tonnage: 500, Sets: 1, Reps: 5, TimeUnderLoadSeconds: 15}},
+		ExerciseItemTypes: []ExerciseItemTypeVolume{{Type: "superset", Items: 1, Rounds: 1, Sets: 1, Tonnage: 500}},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(s.getVolume).ServeHTTP(rr, req)
+
+	expected := `{"data":{"from":"2025-01-01","to":"2025-01-31","total":{"tonnage":1102.31,"sets":1,"reps":5,"duration_seconds":0,"distance_meters":0,"time_under_load_seconds":15},"sessions":{"count":1,"active_seconds":3600,"average_seconds":3600},"exercise_types":[{"exercise_type_id":"bench","name":"Bench","tonnage":1102.31,"sets":1,"reps":5,"duration_seconds":0,"distance_meters":0,"time_under_load_seconds":15}],"exercise_item_types":[{"type":"superset","items":1,"rounds":1,"sets":1,"tonnage":1102.31}]}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetCalendarHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/statistics/calendar?from=2025-01-01&to=2025-01-02", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetCalendar", req.Context(), from, to, userId).Return([]CalendarDay{
+		{Date: "2025-01-01"},
+		{Date: "2025-01-02", Workouts: 1, Tonnage: 100},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(s.getCalendar).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":[{"date":"2025-01-01","workouts":0,"tonnage":0},{"date":"2025-01-02","workouts":1,"tonnage":220.46}]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetCalendarHandlerRangeTooLong(t *testing.T) {
+	req, err := http.NewRequest("GET", "/statistics/calendar?from=2024-01-01&to=2025-01-01", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	serviceMock := serviceMock{}
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(s.getCalendar).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+	serviceMock.AssertNotCalled(t, "GetCalendar", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetConsistencyHandler(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/statistics/consistency", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetConsistency", req.Context(), defaultConsistencyWeeks, userId).Return(Consistency{
+		WeeklyTarget:        3,
+		CurrentStreak:       2,
+		LongestStreak:       5,
+		AdherencePercentage: 75,
+		Weeks:               []WeekConsistency{{WeekStart: "2025-01-06", Workouts: 3, TargetMet: true}},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(s.getConsistency).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":{"weekly_target":3,"current_streak":2,"longest_streak":5,"adherence_percentage":75,"weeks":[{"week_start":"2025-01-06","workouts":3,"target_met":true}]}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
@@ -0,0 +1,50 @@
+package statistics
+
+import "time"
+
+// Calendar is the timezone and first day of the week that the statistics
+// periods of a user are computed in
+type Calendar struct {
+	Location  *time.Location
+	WeekStart time.Weekday
+}
+
+// DefaultCalendar is used for users that did not save any preferences
+var DefaultCalendar = Calendar{Location: time.UTC, WeekStart: time.Monday}
+
+// Day returns the local midnight starting the day the date falls on
+func (c Calendar) Day(date time.Time) time.Time {
+	local := date.In(c.Location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.Location)
+}
+
+// Week returns the local midnight starting the week the date falls on
+func (c Calendar) Week(date time.Time) time.Time {
+	day := c.Day(date)
+	offset := (int(day.Weekday()) - int(c.WeekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// Month returns the local midnight starting the month the date falls on
+func (c Calendar) Month(date time.Time) time.Time {
+	local := date.In(c.Location)
+	return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, c.Location)
+}
+
+// Year returns the local midnight starting the year the date falls on
+func (c Calendar) Year(date time.Time) time.Time {
+	local := date.In(c.Location)
+	return time.Date(local.Year(), 1, 1, 0, 0, 0, 0, c.Location)
+}
+
+// Date returns the local midnight starting the calendar date, ignoring the
+// timezone the date was parsed in
+func (c Calendar) Date(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, c.Location)
+}
+
+// boundary formats the instant the way completed_on is stored, so the
+// boundaries compare correctly against it whatever the timezone of the user
+func boundary(date time.Time) string {
+	return date.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,51 @@
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return location
+}
+
+func TestCalendarWeek(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	// Sunday evening in New York, already monday in UTC
+	now := time.Date(2025, 1, 13, 3, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC), DefaultCalendar.Week(now))
+	assert.Equal(t, time.Date(2025, 1, 6, 0, 0, 0, 0, newYork), Calendar{Location: newYork, WeekStart: time.Monday}.Week(now))
+	assert.Equal(t, time.Date(2025, 1, 12, 0, 0, 0, 0, newYork), Calendar{Location: newYork, WeekStart: time.Sunday}.Week(now))
+	assert.Equal(t, time.Date(2025, 1, 11, 0, 0, 0, 0, newYork), Calendar{Location: newYork, WeekStart: time.Saturday}.Week(now))
+}
+
+func TestCalendarMonthAndYear(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	// Already the new year in Tokyo
+	now := time.Date(2024, 12, 31, 20, 0, 0, 0, time.UTC)
+	calendar := Calendar{Location: tokyo, WeekStart: time.Monday}
+
+	assert.Equal(t, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), DefaultCalendar.Month(now))
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, tokyo), calendar.Month(now))
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, tokyo), calendar.Year(now))
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, tokyo), calendar.Day(now))
+}
+
+func TestCalendarWeekAcrossDaylightSaving(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	calendar := Calendar{Location: berlin, WeekStart: time.Monday}
+	// Clocks moved forward on sunday the 30th of march
+	now := time.Date(2025, 3, 30, 12, 0, 0, 0, berlin)
+
+	week := calendar.Week(now)
+	assert.Equal(t, time.Date(2025, 3, 24, 0, 0, 0, 0, berlin), week)
+	assert.Equal(t, time.Date(2025, 3, 31, 0, 0, 0, 0, berlin), week.AddDate(0, 0, 7))
+	assert.Equal(t, "2025-03-30T22:00:00Z", boundary(week.AddDate(0, 0, 7)))
+}
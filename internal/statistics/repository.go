@@ -14,20 +14,127 @@ type Statistics struct {
 	PreviousMonth int
 	Year          int
 	PreviousYear  int
+
+	WeekVolume          Volume
+	PreviousWeekVolume  Volume
+	MonthVolume         Volume
+	PreviousMonthVolume Volume
+	YearVolume          Volume
+	PreviousYearVolume  Volume
+}
+
+// Volume is the work done in completed workouts, tonnage being the sum of reps × weight.
+// Duration and distance are summed from sets measured in time or distance.
+type Volume struct {
+	Tonnage              float64 `json:"tonnage"`
+	Sets                 int     `json:"sets"`
+	Reps                 int     `json:"reps"`
+	DurationSeconds      int     `json:"duration_seconds"`
+	DistanceMeters       float64 `json:"distance_meters"`
+	TimeUnderLoadSeconds int     `json:"time_under_load_seconds"`
+}
+
+type ExerciseTypeVolume struct {
+	ExerciseTypeID       string  `json:"exercise_type_id"`
+	Name                 string  `json:"name"`
+	Tonnage              float64 `json:"tonnage"`
+	Sets                 int     `json:"sets"`
+	Reps                 int     `json:"reps"`
+	DurationSeconds      int     `json:"duration_seconds"`
+	DistanceMeters       float64 `json:"distance_meters"`
+	TimeUnderLoadSeconds int     `json:"time_under_load_seconds"`
+}
+
+// Estimated seconds a single repetition keeps the muscles under load
+const secondsPerRepetition = 3
+
+// timeUnderLoad estimates the time under load of sets, timed sets count their
+// duration and repetitions count a fixed tempo
+func timeUnderLoad(reps int, durationSeconds int) int {
+	return durationSeconds + reps*secondsPerRepetition
+}
+
+// Sessions is the time spent in workouts that were started before being
+// completed, workouts completed without being started are not counted
+type Sessions struct {
+	Count          int `json:"count"`
+	ActiveSeconds  int `json:"active_seconds"`
+	AverageSeconds int `json:"average_seconds"`
+}
+
+// ExerciseItemTypeVolume is the volume done in exercise items of a kind, rounds
+// only being counted for sets logged as part of a round
+type ExerciseItemTypeVolume struct {
+	Type    string  `json:"type"`
+	Items   int     `json:"items"`
+	Rounds  int     `json:"rounds"`
+	Sets    int     `json:"sets"`
+	Tonnage float64 `json:"tonnage"`
+}
+
+// VolumeReport is the volume between two dates, both inclusive, in total, per exercise type and per exercise item kind
+type VolumeReport struct {
+	From              string                   `json:"from"`
+	To                string                   `json:"to"`
+	Total             Volume                   `json:"total"`
+	Sessions          Sessions                 `json:"sessions"`
+	ExerciseTypes     []ExerciseTypeVolume     `json:"exercise_types"`
+	ExerciseItemTypes []ExerciseItemTypeVolume `json:"exercise_item_types"`
+}
+
+// MuscleGroupSet is one set counted towards a muscle group of its exercise type
+type MuscleGroupSet struct {
+	CompletedOn time.Time
+	MuscleGroup string
+	Role        string
+}
+
+type MuscleGroupSets struct {
+	MuscleGroup   string  `json:"muscle_group"`
+	Sets          float64 `json:"sets"`
+	PrimarySets   int     `json:"primary_sets"`
+	SecondarySets int     `json:"secondary_sets"`
+}
+
+type WeeklyMuscleGroupSets struct {
+	WeekStart    string            `json:"week_start"`
+	MuscleGroups []MuscleGroupSets `json:"muscle_groups"`
 }
 
 type StatisticsRepository interface {
-	GetStatistics(context context.Context, userId string) (Statistics, error)
+	GetStatistics(context context.Context, calendar Calendar, userId string) (Statistics, error)
+	GetVolumePerExerciseType(context context.Context, arg repository.GetVolumePerExerciseTypeBetweenDatesParams) ([]ExerciseTypeVolume, error)
+	GetVolumePerExerciseItemType(context context.Context, arg repository.GetVolumePerExerciseItemTypeBetweenDatesParams) ([]ExerciseItemTypeVolume, error)
+	GetMuscleGroupSets(context context.Context, arg repository.GetMuscleGroupSetsBetweenDatesParams) ([]MuscleGroupSet, error)
+	GetSessions(context context.Context, arg repository.GetSessionDurationsBetweenDatesParams) (Sessions, error)
+	GetWorkoutTonnage(context context.Context, arg repository.GetWorkoutTonnageBetweenDatesParams) ([]CompletedWorkout, error)
+	GetCompletedOn(context context.Context, userId string) ([]time.Time, error)
+}
+
+func NewStatisticsRepository(repo repository.Querier, now func() time.Time) StatisticsRepository {
+	return &statisticsRepository{repo: repo, now: now}
 }
 
 type statisticsRepository struct {
 	repo repository.Querier
+	// Clock the current periods are computed from
+	now func() time.Time
 }
 
-func (s *statisticsRepository) GetStatistics(context context.Context, userId string) (Statistics, error) {
+// GetStatistics counts the workouts and volume of the current and previous
+// week, month and year, the periods starting at local midnight of the calendar
+func (s *statisticsRepository) GetStatistics(context context.Context, calendar Calendar, userId string) (Statistics, error) {
+	now := s.now()
+	weekStart := calendar.Week(now)
+	previousWeekStart := weekStart.AddDate(0, 0, -7)
+	monthStart := calendar.Month(now)
+	previousMonthStart := monthStart.AddDate(0, -1, 0)
+	yearStart := calendar.Year(now)
+	previousYearStart := yearStart.AddDate(-1, 0, 0)
+
 	week, err := s.repo.GetStatisticsSinceDate(context, repository.GetStatisticsSinceDateParams{
 		UserID:    userId,
-		StartDate: weekStartDate(time.Now()),
+		StartDate: boundary(weekStart),
 	})
 
 	if err != nil {
@@ -36,8 +143,8 @@ func (s *statisticsRepository) GetStatistics(context context.Context, userId str
 
 	previousWeek, err := s.repo.GetStatisticsBetweenDates(context, repository.GetStatisticsBetweenDatesParams{
 		UserID:    userId,
-		StartDate: weekStartDate(time.Now().AddDate(0, 0, -7)),
-		EndDate:   weekStartDate(time.Now()),
+		StartDate: boundary(previousWeekStart),
+		EndDate:   boundary(weekStart),
 	})
 	if err != nil {
 		return Statistics{}, fmt.Errorf("failed to get statistics for previous week: %w", err)
@@ -45,7 +152,7 @@ func (s *statisticsRepository) GetStatistics(context context.Context, userId str
 
 	month, err := s.repo.GetStatisticsSinceDate(context, repository.GetStatisticsSinceDateParams{
 		UserID:    userId,
-		StartDate: time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.UTC),
+		StartDate: boundary(monthStart),
 	})
 
 	if err != nil {
@@ -54,8 +161,8 @@ func (s *statisticsRepository) GetStatistics(context context.Context, userId str
 
 	previousMonth, err := s.repo.GetStatisticsBetweenDates(context, repository.GetStatisticsBetweenDatesParams{
 		UserID:    userId,
-		StartDate: time.Date(time.Now().Year(), time.Now().Month()-1, 1, 0, 0, 0, 0, time.UTC),
-		EndDate:   time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.UTC),
+		StartDate: boundary(previousMonthStart),
+		EndDate:   boundary(monthStart),
 	})
 
 	if err != nil {
@@ -64,7 +171,7 @@ func (s *statisticsRepository) GetStatistics(context context.Context, userId str
 
 	year, err := s.repo.GetStatisticsSinceDate(context, repository.GetStatisticsSinceDateParams{
 		UserID:    userId,
-		StartDate: time.Date(time.Now().Year(), 1, 1, 0, 0, 0, 0, time.UTC),
+		StartDate: boundary(yearStart),
 	})
 
 	if err != nil {
@@ -73,14 +180,44 @@ func (s *statisticsRepository) GetStatistics(context context.Context, userId str
 
 	previousYear, err := s.repo.GetStatisticsBetweenDates(context, repository.GetStatisticsBetweenDatesParams{
 		UserID:    userId,
-		StartDate: time.Date(time.Now().Year()-1, 1, 1, 0, 0, 0, 0, time.UTC),
-		EndDate:   time.Date(time.Now().Year(), 1, 1, 0, 0, 0, 0, time.UTC),
+		StartDate: boundary(previousYearStart),
+		EndDate:   boundary(yearStart),
 	})
 
 	if err != nil {
 		return Statistics{}, fmt.Errorf("failed to get statistics for previous year: %w", err)
 	}
 
+	weekVolume, err := s.getVolumeSinceDate(context, userId, weekStart)
+	if err != nil {
+		return Statistics{}, fmt.Errorf("failed to get volume for week: %w", err)
+	}
+
+	previousWeekVolume, err := s.getVolumeBetweenDates(context, userId, previousWeekStart, weekStart)
+	if err != nil {
+		return Statistics{}, fmt.Errorf("failed to get volume for previous week: %w", err)
+	}
+
+	monthVolume, err := s.getVolumeSinceDate(context, userId, monthStart)
+	if err != nil {
+		return Statistics{}, fmt.Errorf("failed to get volume for month: %w", err)
+	}
+
+	previousMonthVolume, err := s.getVolumeBetweenDates(context, userId, previousMonthStart, monthStart)
+	if err != nil {
+		return Statistics{}, fmt.Errorf("failed to get volume for previous month: %w", err)
+	}
+
+	yearVolume, err := s.getVolumeSinceDate(context, userId, yearStart)
+	if err != nil {
+		return Statistics{}, fmt.Errorf("failed to get volume for year: %w", err)
+	}
+
+	previousYearVolume, err := s.getVolumeBetweenDates(context, userId, previousYearStart, yearStart)
+	if err != nil {
+		return Statistics{}, fmt.Errorf("failed to get volume for previous year: %w", err)
+	}
+
 	return Statistics{
 		Week:          int(week),
 		PreviousWeek:  int(previousWeek),
@@ -88,11 +225,159 @@ func (s *statisticsRepository) GetStatistics(context context.Context, userId str
 		PreviousMonth: int(previousMonth),
 		Year:          int(year),
 		PreviousYear:  int(previousYear),
+
+		WeekVolume:          weekVolume,
+		PreviousWeekVolume:  previousWeekVolume,
+		MonthVolume:         monthVolume,
+		PreviousMonthVolume: previousMonthVolume,
+		YearVolume:          yearVolume,
+		PreviousYearVolume:  previousYearVolume,
 	}, nil
 }
 
-func weekStartDate(date time.Time) time.Time {
-	offset := (int(time.Monday) - int(date.Weekday()) - 7) % 7
-	result := date.Add(time.Duration(offset*24) * time.Hour)
-	return result
+func (s *statisticsRepository) getVolumeSinceDate(context context.Context, userId string, startDate time.Time) (Volume, error) {
+	volume, err := s.repo.GetVolumeSinceDate(context, repository.GetVolumeSinceDateParams{
+		UserID:    userId,
+		StartDate: boundary(startDate),
+	})
+	if err != nil {
+		return Volume{}, err
+	}
+	return Volume{
+		Tonnage:              volume.Tonnage,
+		Sets:                 int(volume.SetCount),
+		Reps:                 int(volume.Repetitions),
+		DurationSeconds:      int(volume.DurationSeconds),
+		DistanceMeters:       volume.DistanceMeters,
+		TimeUnderLoadSeconds: timeUnderLoad(int(volume.Repetitions), int(volume.DurationSeconds)),
+	}, nil
+}
+
+func (s *statisticsRepository) getVolumeBetweenDates(context context.Context, userId string, startDate time.Time, endDate time.Time) (Volume, error) {
+	volume, err := s.repo.GetVolumeBetweenDates(context, repository.GetVolumeBetweenDatesParams{
+		UserID:    userId,
+		StartDate: boundary(startDate),
+		EndDate:   boundary(endDate),
+	})
+	if err != nil {
+		return Volume{}, err
+	}
+	return Volume{
+		Tonnage:              volume.Tonnage,
+		Sets:                 int(volume.SetCount),
+		Reps:                 int(volume.Repetitions),
+		DurationSeconds:      int(volume.DurationSeconds),
+		DistanceMeters:       volume.DistanceMeters,
+		TimeUnderLoadSeconds: timeUnderLoad(int(volume.Repetitions), int(volume.DurationSeconds)),
+	}, nil
+}
+
+func (s *statisticsRepository) GetVolumePerExerciseType(context context.Context, arg repository.GetVolumePerExerciseTypeBetweenDatesParams) ([]ExerciseTypeVolume, error) {
+	rows, err := s.repo.GetVolumePerExerciseTypeBetweenDates(context, arg)
+	if err != nil {
+		return []ExerciseTypeVolume{}, fmt.Errorf("failed to get volume per exercise type: %w", err)
+	}
+
+	result := []ExerciseTypeVolume{}
+	for _, v := range rows {
+		result = append(result, ExerciseTypeVolume{
+			ExerciseTypeID:       v.ExerciseTypeID,
+			Name:                 v.Name,
+			Tonnage:              v.Tonnage,
+			Sets:                 int(v.SetCount),
+			Reps:                 int(v.Repetitions),
+			DurationSeconds:      int(v.DurationSeconds),
+			DistanceMeters:       v.DistanceMeters,
+			TimeUnderLoadSeconds: timeUnderLoad(int(v.Repetitions), int(v.DurationSeconds)),
+		})
+	}
+	return result, nil
+}
+
+func (s *statisticsRepository) GetVolumePerExerciseItemType(context context.Context, arg repository.GetVolumePerExerciseItemTypeBetweenDatesParams) ([]ExerciseItemTypeVolume, error) {
+	rows, err := s.repo.GetVolumePerExerciseItemTypeBetweenDates(context, arg)
+	if err != nil {
+		return []ExerciseItemTypeVolume{}, fmt.Errorf("failed to get volume per exercise item type: %w", err)
+	}
+
+	result := []ExerciseItemTypeVolume{}
+	for _, v := range rows {
+		result = append(result, ExerciseItemTypeVolume{
+			Type:    v.Type,
+			Items:   int(v.ItemCount),
+			Rounds:  int(v.RoundCount),
+			Sets:    int(v.SetCount),
+			Tonnage: v.Tonnage,
+		})
+	}
+	return result, nil
+}
+
+func (s *statisticsRepository) GetMuscleGroupSets(context context.Context, arg repository.GetMuscleGroupSetsBetweenDatesParams) ([]MuscleGroupSet, error) {
+	rows, err := s.repo.GetMuscleGroupSetsBetweenDates(context, arg)
+	if err != nil {
+		return []MuscleGroupSet{}, fmt.Errorf("failed to get muscle group sets: %w", err)
+	}
+
+	result := []MuscleGroupSet{}
+	for _, v := range rows {
+		completedOn, err := time.Parse(time.RFC3339, v.CompletedOn)
+		if err != nil {
+			return []MuscleGroupSet{}, fmt.Errorf("failed to parse completed on: %w", err)
+		}
+
+		result = append(result, MuscleGroupSet{
+			CompletedOn: completedOn,
+			MuscleGroup: v.MuscleGroup,
+			Role:        v.Role,
+		})
+	}
+	return result, nil
+}
+
+func (s *statisticsRepository) GetSessions(context context.Context, arg repository.GetSessionDurationsBetweenDatesParams) (Sessions, error) {
+	row, err := s.repo.GetSessionDurationsBetweenDates(context, arg)
+	if err != nil {
+		return Sessions{}, fmt.Errorf("failed to get session durations: %w", err)
+	}
+
+	sessions := Sessions{Count: int(row.SessionCount), ActiveSeconds: int(row.ActiveSeconds)}
+	if sessions.Count > 0 {
+		sessions.AverageSeconds = sessions.ActiveSeconds / sessions.Count
+	}
+	return sessions, nil
+}
+
+func (s *statisticsRepository) GetWorkoutTonnage(context context.Context, arg repository.GetWorkoutTonnageBetweenDatesParams) ([]CompletedWorkout, error) {
+	rows, err := s.repo.GetWorkoutTonnageBetweenDates(context, arg)
+	if err != nil {
+		return []CompletedWorkout{}, fmt.Errorf("failed to get workout tonnage: %w", err)
+	}
+
+	result := []CompletedWorkout{}
+	for _, v := range rows {
+		completedOn, err := time.Parse(time.RFC3339, v.CompletedOn)
+		if err != nil {
+			return []CompletedWorkout{}, fmt.Errorf("failed to parse completed on: %w", err)
+		}
+		result = append(result, CompletedWorkout{CompletedOn: completedOn, Tonnage: v.Tonnage})
+	}
+	return result, nil
+}
+
+func (s *statisticsRepository) GetCompletedOn(context context.Context, userId string) ([]time.Time, error) {
+	rows, err := s.repo.GetCompletedOnByUserId(context, userId)
+	if err != nil {
+		return []time.Time{}, fmt.Errorf("failed to get completed workouts: %w", err)
+	}
+
+	result := []time.Time{}
+	for _, v := range rows {
+		completedOn, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return []time.Time{}, fmt.Errorf("failed to parse completed on: %w", err)
+		}
+		result = append(result, completedOn)
+	}
+	return result, nil
 }
@@ -0,0 +1,163 @@
+package statistics
+
+import (
+	"context"
+	"testing"
+	"time"
+	"weight-tracker/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// querierStub implements the queries used by the statistics repository, the
+// embedded querier panics on anything else
+type querierStub struct {
+	repository.Querier
+	mock.Mock
+}
+
+func (q *querierStub) GetStatisticsSinceDate(ctx context.Context, arg repository.GetStatisticsSinceDateParams) (int64, error) {
+	args := q.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (q *querierStub) GetStatisticsBetweenDates(ctx context.Context, arg repository.GetStatisticsBetweenDatesParams) (int64, error) {
+	args := q.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (q *querierStub) GetVolumeSinceDate(ctx context.Context, arg repository.GetVolumeSinceDateParams) (repository.GetVolumeSinceDateRow, error) {
+	args := q.Called(ctx, arg)
+	return args.Get(0).(repository.GetVolumeSinceDateRow), args.Error(1)
+}
+
+func (q *querierStub) GetVolumeBetweenDates(ctx context.Context, arg repository.GetVolumeBetweenDatesParams) (repository.GetVolumeBetweenDatesRow, error) {
+	args := q.Called(ctx, arg)
+	return args.Get(0).(repository.GetVolumeBetweenDatesRow), args.Error(1)
+}
+
+func (q *querierStub) GetVolumePerExerciseTypeBetweenDates(ctx context.Context, arg repository.GetVolumePerExerciseTypeBetweenDatesParams) ([]repository.GetVolumePerExerciseTypeBetweenDatesRow, error) {
+	args := q.Called(ctx, arg)
+	return args.Get(0).([]repository.GetVolumePerExerciseTypeBetweenDatesRow), args.Error(1)
+}
+
+func (q *querierStub) GetSessionDurationsBetweenDates(ctx context.Context, arg repository.GetSessionDurationsBetweenDatesParams) (repository.GetSessionDurationsBetweenDatesRow, error) {
+	args := q.Called(ctx, arg)
+	return args.Get(0).(repository.GetSessionDurationsBetweenDatesRow), args.Error(1)
+}
+
+func TestGetStatisticsPeriodsInTimezone(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+	newYork := mustLoadLocation(t, "America/New_York")
+	// Sunday evening in New York, already monday in UTC
+	now := time.Date(2025, 1, 13, 3, 30, 0, 0, time.UTC)
+
+	weekStart := "2025-01-12T05:00:00Z"
+	previousWeekStart := "2025-01-05T05:00:00Z"
+	monthStart := "2025-01-01T05:00:00Z"
+	previousMonthStart := "2024-12-01T05:00:00Z"
+	previousYearStart := "2024-01-01T05:00:00Z"
+
+	q := querierStub{}
+	q.On("GetStatisticsSinceDate", ctx, repository.GetStatisticsSinceDateParams{UserID: userId, StartDate: weekStart}).Return(int64(1), nil).Once()
+	// Month and year both started on new year
+	q.On("GetStatisticsSinceDate", ctx, repository.GetStatisticsSinceDateParams{UserID: userId, StartDate: monthStart}).Return(int64(5), nil).Twice()
+	q.On("GetStatisticsBetweenDates", ctx, repository.GetStatisticsBetweenDatesParams{UserID: userId, StartDate: previousWeekStart, EndDate: weekStart}).Return(int64(2), nil).Once()
+	q.On("GetStatisticsBetweenDates", ctx, repository.GetStatisticsBetweenDatesParams{UserID: userId, StartDate: previousMonthStart, EndDate: monthStart}).Return(int64(8), nil).Once()
+	q.On("GetStatisticsBetweenDates", ctx, repository.GetStatisticsBetweenDatesParams{UserID: userId, StartDate: previousYearStart, EndDate: monthStart}).Return(int64(90), nil).Once()
+	q.On("GetVolumeSinceDate", ctx, repository.GetVolumeSinceDateParams{UserID: userId, StartDate: weekStart}).Return(repository.GetVolumeSinceDateRow{Tonnage: 100, SetCount: 1}, nil).Once()
+	q.On("GetVolumeSinceDate", ctx, repository.GetVolumeSinceDateParams{UserID: userId, StartDate: monthStart}).Return(repository.GetVolumeSinceDateRow{Tonnage: 500, SetCount: 5}, nil).Twice()
+	q.On("GetVolumeBetweenDates", ctx, repository.GetVolumeBetweenDatesParams{UserID: userId, StartDate: previousWeekStart, EndDate: weekStart}).Return(repository.GetVolumeBetweenDatesRow{Tonnage: 200, SetCount: 2}, nil).Once()
+	q.On("GetVolumeBetweenDates", ctx, repository.GetVolumeBetweenDatesParams{UserID: userId, StartDate: previousMonthStart, EndDate: monthStart}).Return(repository.GetVolumeBetweenDatesRow{Tonnage: 800, SetCount: 8}, nil).Once()
+	q.On("GetVolumeBetweenDates", ctx, repository.GetVolumeBetweenDatesParams{UserID: userId, StartDate: previousYearStart, EndDate: monthStart}).Return(repository.GetVolumeBetweenDatesRow{Tonnage: 9000, SetCount: 90}, nil).Once()
+
+	repo := NewStatisticsRepository(&q, func() time.Time { return now })
+	statistics, err := repo.GetStatistics(ctx, Calendar{Location: newYork, WeekStart: time.Sunday}, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, Statistics{
+		Week:          1,
+		PreviousWeek:  2,
+		Month:         5,
+		PreviousMonth: 8,
+		Year:          5,
+		PreviousYear:  90,
+
+		WeekVolume:          Volume{Tonnage: 100, Sets: 1},
+		PreviousWeekVolume:  Volume{Tonnage: 200, Sets: 2},
+		MonthVolume:         Volume{Tonnage: 500, Sets: 5},
+		PreviousMonthVolume: Volume{Tonnage: 800, Sets: 8},
+		YearVolume:          Volume{Tonnage: 500, Sets: 5},
+		PreviousYearVolume:  Volume{Tonnage: 9000, Sets: 90},
+	}, statistics)
+	q.AssertExpectations(t)
+}
+
+func TestGetStatisticsDefaultCalendar(t *testing.T) {
+	ctx := context.Background()
+	userId := "userId"
+	// Same instant as above, monday in UTC starts a new week
+	now := time.Date(2025, 1, 13, 3, 30, 0, 0, time.UTC)
+
+	q := querierStub{}
+	q.On("GetStatisticsSinceDate", ctx, repository.GetStatisticsSinceDateParams{UserID: userId, StartDate: "2025-01-13T00:00:00Z"}).Return(int64(0), nil).Once()
+	q.On("GetStatisticsSinceDate", ctx, mock.Anything).Return(int64(0), nil)
+	q.On("GetStatisticsBetweenDates", ctx, repository.GetStatisticsBetweenDatesParams{UserID: userId, StartDate: "2025-01-06T00:00:00Z", EndDate: "2025-01-13T00:00:00Z"}).Return(int64(0), nil).Once()
+	q.On("GetStatisticsBetweenDates", ctx, mock.Anything).Return(int64(0), nil)
+	q.On("GetVolumeSinceDate", ctx, mock.Anything).Return(repository.GetVolumeSinceDateRow{}, nil)
+	q.On("GetVolumeBetweenDates", ctx, mock.Anything).Return(repository.GetVolumeBetweenDatesRow{}, nil)
+
+	repo := NewStatisticsRepository(&q, func() time.Time { return now })
+	_, err := repo.GetStatistics(ctx, DefaultCalendar, userId)
+
+	assert.Nil(t, err)
+	q.AssertExpectations(t)
+}
+
+func TestGetSessions(t *testing.T) {
+	ctx := context.Background()
+	arg := repository.GetSessionDurationsBetweenDatesParams{UserID: "userId", StartDate: "2025-01-01T00:00:00Z", EndDate: "2025-02-01T00:00:00Z"}
+
+	q := querierStub{}
+	q.On("GetSessionDurationsBetweenDates", ctx, arg).Return(repository.GetSessionDurationsBetweenDatesRow{SessionCount: 3, ActiveSeconds: 10000}, nil).Once()
+
+	repo := NewStatisticsRepository(&q, time.Now)
+	sessions, err := repo.GetSessions(ctx, arg)
+
+	assert.Nil(t, err)
+	assert.Equal(t, Sessions{Count: 3, ActiveSeconds: 10000, AverageSeconds: 3333}, sessions)
+	q.AssertExpectations(t)
+}
+
+func TestGetSessionsWithoutSessions(t *testing.T) {
+	ctx := context.Background()
+
+	q := querierStub{}
+	q.On("GetSessionDurationsBetweenDates", ctx, mock.Anything).Return(repository.GetSessionDurationsBetweenDatesRow{}, nil).Once()
+
+	repo := NewStatisticsRepository(&q, time.Now)
+	sessions, err := repo.GetSessions(ctx, repository.GetSessionDurationsBetweenDatesParams{UserID: "userId"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, Sessions{}, sessions)
+}
+
+func TestGetVolumeTimeUnderLoad(t *testing.T) {
+	ctx := context.Background()
+	arg := repository.GetVolumePerExerciseTypeBetweenDatesParams{UserID: "userId"}
+
+	q := querierStub{}
+	q.On("GetVolumePerExerciseTypeBetweenDates", ctx, arg).Return([]repository.GetVolumePerExerciseTypeBetweenDatesRow{
+		{ExerciseTypeID: "bench", Name: "Bench", Tonnage: 1000, SetCount: 2, Repetitions: 10},
+		{ExerciseTypeID: "plank", Name: "Plank", SetCount: 2, DurationSeconds: 120},
+	}, nil).Once()
+
+	repo := NewStatisticsRepository(&q, time.Now)
+	volume, err := repo.GetVolumePerExerciseType(ctx, arg)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 30, volume[0].TimeUnderLoadSeconds)
+	assert.Equal(t, 120, volume[1].TimeUnderLoadSeconds)
+}
@@ -1,20 +1,221 @@
 package statistics
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+	"weight-tracker/internal/exercisetypes"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/repository"
+)
 
-func NewService(repo StatisticsRepository) Service {
-	return &statisticsService{repo}
+func NewService(repo StatisticsRepository, preferences calendarPreferences) Service {
+	return &statisticsService{repo, preferences}
+}
+
+// Periods are computed in the timezone and week start of the user
+type calendarPreferences interface {
+	Get(ctx context.Context, userId string) (preferences.Preferences, error)
 }
 
 type Service interface {
 	GetStatistics(context context.Context, userId string) (Statistics, error)
+	GetVolume(context context.Context, from time.Time, to time.Time, userId string) (VolumeReport, error)
+	GetWeeklyMuscleGroupSets(context context.Context, weeks int, userId string) ([]WeeklyMuscleGroupSets, error)
+	GetCalendar(context context.Context, from time.Time, to time.Time, userId string) ([]CalendarDay, error)
+	GetConsistency(context context.Context, weeks int, userId string) (Consistency, error)
 } 
 
 
 type statisticsService struct {
-	repo StatisticsRepository
+	repo        StatisticsRepository
+	preferences calendarPreferences
+}
+
+func (s *statisticsService) calendar(context context.Context, userId string) (Calendar, error) {
+	userPreferences, err := s.preferences.Get(context, userId)
+	if err != nil {
+		return Calendar{}, fmt.Errorf("failed to get preferences: %w", err)
+	}
+	return newCalendar(userPreferences), nil
+}
+
+func newCalendar(userPreferences preferences.Preferences) Calendar {
+	return Calendar{Location: userPreferences.Location(), WeekStart: userPreferences.FirstDayOfWeek()}
 }
 
 func (s *statisticsService) GetStatistics(context context.Context, userId string) (Statistics, error) {
-	return s.repo.GetStatistics(context, userId)
+	calendar, err := s.calendar(context, userId)
+	if err != nil {
+		return Statistics{}, err
+	}
+	return s.repo.GetStatistics(context, calendar, userId)
+}
+
+func (s *statisticsService) GetVolume(context context.Context, from time.Time, to time.Time, userId string) (VolumeReport, error) {
+	if to.Before(from) {
+		return VolumeReport{}, fmt.Errorf("from must not be after to")
+	}
+
+	calendar, err := s.calendar(context, userId)
+	if err != nil {
+		return VolumeReport{}, err
+	}
+	start := boundary(calendar.Date(from))
+	end := boundary(calendar.Date(to).AddDate(0, 0, 1))
+
+	exerciseTypes, err := s.repo.GetVolumePerExerciseType(context, repository.GetVolumePerExerciseTypeBetweenDatesParams{
+		UserID:    userId,
+		StartDate: start,
+		EndDate:   end,
+	})
+	if err != nil {
+		return VolumeReport{}, err
+	}
+
+	total := Volume{}
+	for _, v := range exerciseTypes {
+		total.Tonnage += v.Tonnage
+		total.Sets += v.Sets
+		total.Reps += v.Reps
+		total.DurationSeconds += v.DurationSeconds
+		total.DistanceMeters += v.DistanceMeters
+		total.TimeUnderLoadSeconds += v.TimeUnderLoadSeconds
+	}
+
+	exerciseItemTypes, err := s.repo.GetVolumePerExerciseItemType(context, repository.GetVolumePerExerciseItemTypeBetweenDatesParams{
+		UserID:    userId,
+		StartDate: start,
+		EndDate:   end,
+	})
+	if err != nil {
+		return VolumeReport{}, err
+	}
+
+	sessions, err := s.repo.GetSessions(context, repository.GetSessionDurationsBetweenDatesParams{
+		UserID:    userId,
+		StartDate: start,
+		EndDate:   end,
+	})
+	if err != nil {
+		return VolumeReport{}, err
+	}
+
+	return VolumeReport{
+		From:              from.Format(time.DateOnly),
+		To:                to.Format(time.DateOnly),
+		Total:             total,
+		Sessions:          sessions,
+		ExerciseTypes:     exerciseTypes,
+		ExerciseItemTypes: exerciseItemTypes,
+	}, nil
+}
+
+// GetWeeklyMuscleGroupSets counts the sets per muscle group for the given number
+// of weeks up to and including the current one, oldest week first
+func (s *statisticsService) GetWeeklyMuscleGroupSets(context context.Context, weeks int, userId string) ([]WeeklyMuscleGroupSets, error) {
+	if weeks <= 0 {
+		return []WeeklyMuscleGroupSets{}, fmt.Errorf("weeks must be positive")
+	}
+
+	calendar, err := s.calendar(context, userId)
+	if err != nil {
+		return []WeeklyMuscleGroupSets{}, err
+	}
+
+	currentWeek := calendar.Week(time.Now())
+	from := currentWeek.AddDate(0, 0, -7*(weeks-1))
+
+	sets, err := s.repo.GetMuscleGroupSets(context, repository.GetMuscleGroupSetsBetweenDatesParams{
+		UserID:    userId,
+		StartDate: boundary(from),
+		EndDate:   boundary(currentWeek.AddDate(0, 0, 7)),
+	})
+	if err != nil {
+		return []WeeklyMuscleGroupSets{}, err
+	}
+
+	return weeklyMuscleGroupSets(sets, calendar, from, weeks), nil
+}
+
+// GetCalendar returns the completed workouts and tonnage of every day between
+// the dates, both inclusive
+func (s *statisticsService) GetCalendar(context context.Context, from time.Time, to time.Time, userId string) ([]CalendarDay, error) {
+	if to.Before(from) {
+		return []CalendarDay{}, fmt.Errorf("from must not be after to")
+	}
+
+	calendar, err := s.calendar(context, userId)
+	if err != nil {
+		return []CalendarDay{}, err
+	}
+
+	workouts, err := s.repo.GetWorkoutTonnage(context, repository.GetWorkoutTonnageBetweenDatesParams{
+		UserID:    userId,
+		StartDate: boundary(calendar.Date(from)),
+		EndDate:   boundary(calendar.Date(to).AddDate(0, 0, 1)),
+	})
+	if err != nil {
+		return []CalendarDay{}, err
+	}
+
+	return calendarDays(workouts, calendar, from, to), nil
+}
+
+// GetConsistency compares the workouts of the given number of weeks up to and
+// including the current one against the weekly target of the user
+func (s *statisticsService) GetConsistency(context context.Context, weeks int, userId string) (Consistency, error) {
+	if weeks <= 0 {
+		return Consistency{}, fmt.Errorf("weeks must be positive")
+	}
+
+	userPreferences, err := s.preferences.Get(context, userId)
+	if err != nil {
+		return Consistency{}, fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	completedOn, err := s.repo.GetCompletedOn(context, userId)
+	if err != nil {
+		return Consistency{}, err
+	}
+
+	return consistency(completedOn, newCalendar(userPreferences), time.Now(), weeks, userPreferences.WeeklyTarget), nil
+}
+
+func weeklyMuscleGroupSets(sets []MuscleGroupSet, calendar Calendar, from time.Time, weeks int) []WeeklyMuscleGroupSets {
+	counts := map[string]map[string]*MuscleGroupSets{}
+	for _, set := range sets {
+		week := calendar.Week(set.CompletedOn).Format(time.DateOnly)
+		if counts[week] == nil {
+			counts[week] = map[string]*MuscleGroupSets{}
+		}
+		if counts[week][set.MuscleGroup] == nil {
+			counts[week][set.MuscleGroup] = &MuscleGroupSets{MuscleGroup: set.MuscleGroup}
+		}
+
+		count := counts[week][set.MuscleGroup]
+		count.Sets += exercisetypes.MuscleGroupWeight(set.Role)
+		if set.Role == exercisetypes.MuscleGroupRoleSecondary {
+			count.SecondarySets++
+		} else {
+			count.PrimarySets++
+		}
+	}
+
+	result := []WeeklyMuscleGroupSets{}
+	for i := range weeks {
+		week := from.AddDate(0, 0, 7*i).Format(time.DateOnly)
+
+		muscleGroups := []MuscleGroupSets{}
+		for _, v := range counts[week] {
+			muscleGroups = append(muscleGroups, *v)
+		}
+		sort.Slice(muscleGroups, func(i, j int) bool {
+			return muscleGroups[i].MuscleGroup < muscleGroups[j].MuscleGroup
+		})
+
+		result = append(result, WeeklyMuscleGroupSets{WeekStart: week, MuscleGroups: muscleGroups})
+	}
+	return result
 }
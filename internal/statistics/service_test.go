@@ -0,0 +1,282 @@
+package statistics
+
+import (
+	"context"
+	"testing"
+	"time"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type repoMock struct {
+	mock.Mock
+}
+
+func (m *repoMock) GetStatistics(ctx context.Context, calendar Calendar, userId string) (Statistics, error) {
+	args := m.Called(ctx, calendar, userId)
+	return args.Get(0).(Statistics), args.Error(1)
+}
+
+func (m *repoMock) GetVolumePerExerciseType(ctx context.Context, arg repository.GetVolumePerExerciseTypeBetweenDatesParams) ([]ExerciseTypeVolume, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]ExerciseTypeVolume), args.Error(1)
+}
+
+func (m *repoMock) GetVolumePerExerciseItemType(ctx context.Context, arg repository.GetVolumePerExerciseItemTypeBetweenDatesParams) ([]ExerciseItemTypeVolume, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]ExerciseItemTypeVolume), args.Error(1)
+}
+
+func (m *repoMock) GetMuscleGroupSets(ctx context.Context, arg repository.GetMuscleGroupSetsBetweenDatesParams) ([]MuscleGroupSet, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]MuscleGroupSet), args.Error(1)
+}
+
+func (m *repoMock) GetSessions(ctx context.Context, arg repository.GetSessionDurationsBetweenDatesParams) (Sessions, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(Sessions), args.Error(1)
+}
+
+func (m *repoMock) GetWorkoutTonnage(ctx context.Context, arg repository.GetWorkoutTonnageBetweenDatesParams) ([]CompletedWorkout, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]CompletedWorkout), args.Error(1)
+}
+
+func (m *repoMock) GetCompletedOn(ctx context.Context, userId string) ([]time.Time, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+type preferencesStub struct {
+	preferences preferences.Preferences
+}
+
+func (p preferencesStub) Get(ctx context.Context, userId string) (preferences.Preferences, error) {
+	return p.preferences, nil
+}
+
+func TestGetVolume(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	repoMock := repoMock{}
+	repoMock.On("GetVolumePerExerciseType", ctx, repository.GetVolumePerExerciseTypeBetweenDatesParams{
+		UserID:    userId,
+		StartDate: "2025-01-01T00:00:00Z",
+		EndDate:   "2025-02-01T00:00:00Z",
+	}).Return([]ExerciseTypeVolume{
+		{ExerciseTypeID: "bench", Name: "Bench", Tonnage: 1500, Sets: 3, Reps: 15, TimeUnderLoadSeconds: 45},
+		{ExerciseTypeID: "squat", Name: "Squat", Tonnage: 2500.5, Sets: 5, Reps: 25, TimeUnderLoadSeconds: 75},
+		{ExerciseTypeID: "run", Name: "Run", Sets: 1, DurationSeconds: 1500, DistanceMeters: 5000, TimeUnderLoadSeconds: 1500},
+	}, nil).Once()
+	repoMock.On("GetVolumePerExerciseItemType", ctx, repository.GetVolumePerExerciseItemTypeBetweenDatesParams{
+		UserID:    userId,
+		StartDate: "2025-01-01T00:00:00Z",
+		EndDate:   "2025-02-01T00:00:00Z",
+	}).Return([]ExerciseItemTypeVolume{
+		{Type: "straight", Items: 2, Sets: 8, Tonnage: 4000.5},
+		{Type: "circuit", Items: 1, Rounds: 3, Sets: 1},
+	}, nil).Once()
+	repoMock.On("GetSessions", ctx, repository.GetSessionDurationsBetweenDatesParams{
+		UserID:    userId,
+		StartDate: "2025-01-01T00:00:00Z",
+		EndDate:   "2025-02-01T00:00:00Z",
+	}).Return(Sessions{Count: 2, ActiveSeconds: 7200, AverageSeconds: 3600}, nil).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()})
+	result, err := service.GetVolume(ctx, from, to, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "2025-01-01", result.From)
+	assert.Equal(t, "2025-01-31", result.To)
+	assert.Equal(t, Volume{Tonnage: 4000.5, Sets: 9, Reps: 40, DurationSeconds: 1500, DistanceMeters: 5000, TimeUnderLoadSeconds: 1620}, result.Total)
+	assert.Equal(t, Sessions{Count: 2, ActiveSeconds: 7200, AverageSeconds: 3600}, result.Sessions)
+	assert.Len(t, result.ExerciseTypes, 3)
+	assert.Equal(t, ExerciseItemTypeVolume{Type: "circuit", Items: 1, Rounds: 3, Sets: 1}, result.ExerciseItemTypes[1])
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetVolumeFromAfterTo(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	repoMock := repoMock{}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()})
+	_, err := service.GetVolume(ctx, from, to, "userId")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "GetVolumePerExerciseType", mock.Anything, mock.Anything)
+}
+
+func TestWeeklyMuscleGroupSets(t *testing.T) {
+	from := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	sets := []MuscleGroupSet{
+		{CompletedOn: time.Date(2025, 1, 7, 10, 0, 0, 0, time.UTC), MuscleGroup: "chest", Role: "primary"},
+		{CompletedOn: time.Date(2025, 1, 7, 10, 0, 0, 0, time.UTC), MuscleGroup: "triceps", Role: "secondary"},
+		{CompletedOn: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC), MuscleGroup: "chest", Role: "primary"},
+		{CompletedOn: time.Date(2025, 1, 19, 10, 0, 0, 0, time.UTC), MuscleGroup: "triceps", Role: "primary"},
+	}
+
+	result := weeklyMuscleGroupSets(sets, DefaultCalendar, from, 3)
+
+	assert.Equal(t, []WeeklyMuscleGroupSets{
+		{WeekStart: "2025-01-06", MuscleGroups: []MuscleGroupSets{
+			{MuscleGroup: "chest", Sets: 2, PrimarySets: 2},
+			{MuscleGroup: "triceps", Sets: 0.5, SecondarySets: 1},
+		}},
+		{WeekStart: "2025-01-13", MuscleGroups: []MuscleGroupSets{
+			{MuscleGroup: "triceps", Sets: 1, PrimarySets: 1},
+		}},
+		{WeekStart: "2025-01-20", MuscleGroups: []MuscleGroupSets{}},
+	}, result)
+}
+
+func TestGetWeeklyMuscleGroupSets(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetMuscleGroupSets", ctx, mock.MatchedBy(func(input repository.GetMuscleGroupSetsBetweenDatesParams) bool {
+		start, err := time.Parse(time.RFC3339, input.StartDate.(string))
+		if err != nil {
+			return false
+		}
+		end, err := time.Parse(time.RFC3339, input.EndDate.(string))
+		if err != nil {
+			return false
+		}
+		return input.UserID == userId && start.Weekday() == time.Monday && end.Sub(start) == 4*7*24*time.Hour
+	})).Return([]MuscleGroupSet{}, nil).Once()
+
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()})
+	result, err := service.GetWeeklyMuscleGroupSets(ctx, 4, userId)
+
+	assert.Nil(t, err)
+	assert.Len(t, result, 4)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetVolumeInTimezone(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	// The dates are local dates of the user, Tokyo being nine hours ahead
+	repoMock := repoMock{}
+	repoMock.On("GetVolumePerExerciseType", ctx, repository.GetVolumePerExerciseTypeBetweenDatesParams{
+		UserID:    userId,
+		StartDate: "2024-12-31T15:00:00Z",
+		EndDate:   "2025-01-31T15:00:00Z",
+	}).Return([]ExerciseTypeVolume{}, nil).Once()
+	repoMock.On("GetVolumePerExerciseItemType", ctx, repository.GetVolumePerExerciseItemTypeBetweenDatesParams{
+		UserID:    userId,
+		StartDate: "2024-12-31T15:00:00Z",
+		EndDate:   "2025-01-31T15:00:00Z",
+	}).Return([]ExerciseItemTypeVolume{}, nil).Once()
+	repoMock.On("GetSessions", ctx, repository.GetSessionDurationsBetweenDatesParams{
+		UserID:    userId,
+		StartDate: "2024-12-31T15:00:00Z",
+		EndDate:   "2025-01-31T15:00:00Z",
+	}).Return(Sessions{}, nil).Once()
+
+	userPreferences := preferences.Defaults()
+	userPreferences.Timezone = "Asia/Tokyo"
+	service := NewService(&repoMock, preferencesStub{userPreferences})
+	result, err := service.GetVolume(ctx, from, to, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "2025-01-01", result.From)
+	assert.Equal(t, "2025-01-31", result.To)
+	repoMock.AssertExpectations(t)
+}
+
+func TestWeeklyMuscleGroupSetsStartingSunday(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	calendar := Calendar{Location: newYork, WeekStart: time.Sunday}
+	from := time.Date(2025, 1, 5, 0, 0, 0, 0, newYork)
+	sets := []MuscleGroupSet{
+		// Saturday evening in New York, sunday in UTC
+		{CompletedOn: time.Date(2025, 1, 12, 2, 0, 0, 0, time.UTC), MuscleGroup: "chest", Role: "primary"},
+		{CompletedOn: time.Date(2025, 1, 12, 15, 0, 0, 0, time.UTC), MuscleGroup: "back", Role: "primary"},
+	}
+
+	result := weeklyMuscleGroupSets(sets, calendar, from, 2)
+
+	assert.Equal(t, []WeeklyMuscleGroupSets{
+		{WeekStart: "2025-01-05", MuscleGroups: []MuscleGroupSets{
+			{MuscleGroup: "chest", Sets: 1, PrimarySets: 1},
+		}},
+		{WeekStart: "2025-01-12", MuscleGroups: []MuscleGroupSets{
+			{MuscleGroup: "back", Sets: 1, PrimarySets: 1},
+		}},
+	}, result)
+}
+
+func TestGetCalendar(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	repoMock := repoMock{}
+	repoMock.On("GetWorkoutTonnage", ctx, repository.GetWorkoutTonnageBetweenDatesParams{
+		UserID:    userId,
+		StartDate: "2024-12-31T15:00:00Z",
+		EndDate:   "2025-01-03T15:00:00Z",
+	}).Return([]CompletedWorkout{
+		// The second of January in Tokyo
+		{CompletedOn: time.Date(2025, 1, 1, 16, 0, 0, 0, time.UTC), Tonnage: 1000},
+	}, nil).Once()
+
+	userPreferences := preferences.Defaults()
+	userPreferences.Timezone = "Asia/Tokyo"
+	service := NewService(&repoMock, preferencesStub{userPreferences})
+	result, err := service.GetCalendar(ctx, from, to, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []CalendarDay{
+		{Date: "2025-01-01"},
+		{Date: "2025-01-02", Workouts: 1, Tonnage: 1000},
+		{Date: "2025-01-03"},
+	}, result)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetCalendarFromAfterTo(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	repoMock := repoMock{}
+	service := NewService(&repoMock, preferencesStub{preferences.Defaults()})
+	_, err := service.GetCalendar(ctx, from, to, "userId")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "GetWorkoutTonnage", mock.Anything, mock.Anything)
+}
+
+func TestGetConsistency(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetCompletedOn", ctx, userId).Return([]time.Time{}, nil).Once()
+
+	userPreferences := preferences.Defaults()
+	userPreferences.WeeklyTarget = 4
+	service := NewService(&repoMock, preferencesStub{userPreferences})
+	result, err := service.GetConsistency(ctx, 6, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), result.WeeklyTarget)
+	assert.Len(t, result.Weeks, 6)
+	assert.Equal(t, 0.0, result.AdherencePercentage)
+	repoMock.AssertExpectations(t)
+}
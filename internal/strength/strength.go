@@ -0,0 +1,121 @@
+package strength
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	FormulaEpley    = "epley"
+	FormulaBrzycki  = "brzycki"
+	FormulaLombardi = "lombardi"
+)
+
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+)
+
+// Set is a completed set with the time the workout was completed
+type Set struct {
+	CompletedOn time.Time
+	Weight      float64
+	Reps        int
+	// Bodyweight is added to the weight for bodyweight exercises
+	Bodyweight float64
+}
+
+// Point is the best estimated one rep max within a bucket together with the set it came from
+type Point struct {
+	Date   string  `json:"date"`
+	E1RM   float64 `json:"e1rm"`
+	Weight float64 `json:"weight"`
+	Reps   int     `json:"reps"`
+}
+
+func ValidFormula(formula string) bool {
+	return formula == FormulaEpley || formula == FormulaBrzycki || formula == FormulaLombardi
+}
+
+func ValidBucket(bucket string) bool {
+	return bucket == BucketDay || bucket == BucketWeek || bucket == BucketMonth
+}
+
+// EstimateOneRepMax returns the estimated one rep max for the set. A single
+// repetition is its own max regardless of formula.
+func EstimateOneRepMax(formula string, weight float64, reps int) (float64, error) {
+	if reps <= 0 || weight <= 0 {
+		return 0, nil
+	}
+	if reps == 1 {
+		return weight, nil
+	}
+
+	switch formula {
+	case FormulaEpley:
+		return weight * (1 + float64(reps)/30), nil
+	case FormulaBrzycki:
+		// The formula breaks down at 37 reps, cap it where it still makes sense
+		return weight * 36 / (37 - float64(min(reps, 36))), nil
+	case FormulaLombardi:
+		return weight * math.Pow(float64(reps), 0.10), nil
+	}
+	return 0, fmt.Errorf("unknown formula: %s", formula)
+}
+
+// BucketStart returns the local midnight starting the day, week or month the
+// date falls on in the timezone of the user. Weeks start on weekStart.
+func BucketStart(date time.Time, bucket string, location *time.Location, weekStart time.Weekday) time.Time {
+	local := date.In(location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	switch bucket {
+	case BucketWeek:
+		offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+		return day.AddDate(0, 0, -offset)
+	case BucketMonth:
+		return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, location)
+	}
+	return day
+}
+
+// History returns the best estimated one rep max per bucket, oldest first.
+// Sets are expected to be ordered by completion time. Buckets are computed in
+// the timezone and with the first day of the week of the user.
+func History(sets []Set, formula string, bucket string, location *time.Location, weekStart time.Weekday) ([]Point, error) {
+	if !ValidFormula(formula) {
+		return []Point{}, fmt.Errorf("unknown formula: %s", formula)
+	}
+	if !ValidBucket(bucket) {
+		return []Point{}, fmt.Errorf("unknown bucket: %s", bucket)
+	}
+
+	result := []Point{}
+	for _, set := range sets {
+		e1rm, err := EstimateOneRepMax(formula, set.Weight+set.Bodyweight, set.Reps)
+		if err != nil {
+			return []Point{}, err
+		}
+		if e1rm == 0 {
+			continue
+		}
+
+		date := BucketStart(set.CompletedOn, bucket, location, weekStart).Format(time.DateOnly)
+		point := Point{
+			Date:   date,
+			E1RM:   math.Round(e1rm*100) / 100,
+			Weight: set.Weight,
+			Reps:   set.Reps,
+		}
+
+		if len(result) > 0 && result[len(result)-1].Date == date {
+			if point.E1RM > result[len(result)-1].E1RM {
+				result[len(result)-1] = point
+			}
+			continue
+		}
+		result = append(result, point)
+	}
+	return result, nil
+}
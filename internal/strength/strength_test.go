@@ -0,0 +1,100 @@
+package strength
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateOneRepMax(t *testing.T) {
+	epley, err := EstimateOneRepMax(FormulaEpley, 100, 5)
+	assert.Nil(t, err)
+	assert.InDelta(t, 116.67, epley, 0.01)
+
+	brzycki, err := EstimateOneRepMax(FormulaBrzycki, 100, 5)
+	assert.Nil(t, err)
+	assert.InDelta(t, 112.5, brzycki, 0.01)
+
+	lombardi, err := EstimateOneRepMax(FormulaLombardi, 100, 5)
+	assert.Nil(t, err)
+	assert.InDelta(t, 117.46, lombardi, 0.01)
+}
+
+func TestEstimateOneRepMaxSingleRep(t *testing.T) {
+	for _, formula := range []string{FormulaEpley, FormulaBrzycki, FormulaLombardi} {
+		result, err := EstimateOneRepMax(formula, 140, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 140.0, result)
+	}
+}
+
+func TestEstimateOneRepMaxInvalid(t *testing.T) {
+	result, err := EstimateOneRepMax(FormulaEpley, 100, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, result)
+
+	_, err = EstimateOneRepMax("unknown", 100, 5)
+	assert.NotNil(t, err)
+}
+
+func TestBucketStart(t *testing.T) {
+	date := time.Date(2025, 1, 16, 18, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC), BucketStart(date, BucketDay, time.UTC, time.Monday))
+	assert.Equal(t, time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC), BucketStart(date, BucketWeek, time.UTC, time.Monday))
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), BucketStart(date, BucketMonth, time.UTC, time.Monday))
+
+	sunday := time.Date(2025, 1, 19, 8, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC), BucketStart(sunday, BucketWeek, time.UTC, time.Monday))
+	assert.Equal(t, time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC), BucketStart(sunday, BucketWeek, time.UTC, time.Sunday))
+}
+
+func TestBucketStartInLocation(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+
+	// Late on the last day of january in New York is already february in UTC
+	date := time.Date(2025, 2, 1, 2, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 1, 31, 0, 0, 0, 0, location), BucketStart(date, BucketDay, location, time.Monday))
+	assert.Equal(t, time.Date(2025, 1, 27, 0, 0, 0, 0, location), BucketStart(date, BucketWeek, location, time.Monday))
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, location), BucketStart(date, BucketMonth, location, time.Monday))
+}
+
+func TestHistoryKeepsBestSetPerBucket(t *testing.T) {
+	sets := []Set{
+		{CompletedOn: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), Weight: 100, Reps: 5},
+		{CompletedOn: time.Date(2025, 1, 20, 10, 0, 0, 0, time.UTC), Weight: 110, Reps: 2},
+		{CompletedOn: time.Date(2025, 1, 20, 10, 0, 0, 0, time.UTC), Weight: 0, Reps: 10},
+		{CompletedOn: time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC), Weight: 105, Reps: 5},
+	}
+
+	result, err := History(sets, FormulaBrzycki, BucketMonth, time.UTC, time.Monday)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Point{
+		{Date: "2025-01-01", E1RM: 113.14, Weight: 110, Reps: 2},
+		{Date: "2025-02-01", E1RM: 118.13, Weight: 105, Reps: 5},
+	}, result)
+}
+
+func TestHistoryCountsBodyweight(t *testing.T) {
+	sets := []Set{
+		{CompletedOn: time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC), Weight: 10, Reps: 5, Bodyweight: 80},
+		{CompletedOn: time.Date(2025, 1, 3, 10, 0, 0, 0, time.UTC), Weight: 0, Reps: 10, Bodyweight: 80},
+	}
+
+	result, err := History(sets, FormulaEpley, BucketDay, time.UTC, time.Monday)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []Point{
+		{Date: "2025-01-02", E1RM: 105, Weight: 10, Reps: 5},
+		{Date: "2025-01-03", E1RM: 106.67, Weight: 0, Reps: 10},
+	}, result)
+}
+
+func TestHistoryInvalidBucket(t *testing.T) {
+	_, err := History([]Set{}, FormulaEpley, "year", time.UTC, time.Monday)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,310 @@
+package templates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"weight-tracker/internal/database"
+	"weight-tracker/internal/exerciseitems"
+	"weight-tracker/internal/exercises"
+	"weight-tracker/internal/measurement"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/sets"
+	"weight-tracker/internal/units"
+	"weight-tracker/internal/utils"
+)
+
+type handler struct {
+	service Service
+	units   unitPreferences
+}
+
+// Weights are stored in kilograms, requests and responses use the unit of the user
+type unitPreferences interface {
+	GetWeightUnit(ctx context.Context, userId string) (string, error)
+}
+
+type templateRequest struct {
+	Name          string                        `json:"name"`
+	ExerciseItems []templateExerciseItemRequest `json:"exercise_items"`
+}
+
+type templateExerciseItemRequest struct {
+	Type            string                    `json:"type"`
+	Rounds          *int64                    `json:"rounds"`
+	IntervalSeconds *int64                    `json:"interval_seconds"`
+	TimeCapSeconds  *int64                    `json:"time_cap_seconds"`
+	Exercises       []templateExerciseRequest `json:"exercises"`
+}
+
+func (t templateExerciseItemRequest) settings() exerciseitems.Settings {
+	return exerciseitems.Settings{
+		Type:            t.Type,
+		Rounds:          t.Rounds,
+		IntervalSeconds: t.IntervalSeconds,
+		TimeCapSeconds:  t.TimeCapSeconds,
+	}
+}
+
+type templateExerciseRequest struct {
+	ExerciseTypeID string               `json:"exercise_type_id"`
+	Sets           []templateSetRequest `json:"sets"`
+}
+
+type templateSetRequest struct {
+	Repetitions     int      `json:"repetitions"`
+	Weight          float64  `json:"weight"`
+	DurationSeconds *int     `json:"duration_seconds"`
+	DistanceMeters  *float64 `json:"distance_meters"`
+	Type            string   `json:"type"`
+}
+
+func (t templateSetRequest) measurement() measurement.Set {
+	set := measurement.Set{
+		Weight: t.Weight,
+		Reps:   t.Repetitions,
+	}
+	if t.DurationSeconds != nil {
+		set.DurationSeconds = *t.DurationSeconds
+	}
+	if t.DistanceMeters != nil {
+		set.DistanceMeters = *t.DistanceMeters
+	}
+	return set
+}
+
+// measurementParams returns the duration and distance, left out values are stored as NULL
+func (t templateSetRequest) measurementParams() (interface{}, interface{}) {
+	var duration, distance interface{}
+	if t.DurationSeconds != nil {
+		duration = int64(*t.DurationSeconds)
+	}
+	if t.DistanceMeters != nil {
+		distance = *t.DistanceMeters
+	}
+	return duration, distance
+}
+
+func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
+	handler := handler{
+		service: NewServiceFromDatabase(s),
+		units:   preferences.NewServiceFromDatabase(s),
+	}
+
+	mux.Handle("GET /templates", authenticationWrapper(http.HandlerFunc(handler.getAllTemplatesHandler)))
+	mux.Handle("GET /templates/{id}", authenticationWrapper(http.HandlerFunc(handler.getTemplateByIdHandler)))
+	mux.Handle("POST /templates", authenticationWrapper(http.HandlerFunc(handler.createTemplateHandler)))
+	mux.Handle("POST /templates/{id}/start", authenticationWrapper(http.HandlerFunc(handler.startWorkoutHandler)))
+	mux.Handle("PUT /templates/{id}", authenticationWrapper(http.HandlerFunc(handler.updateTemplateByIdHandler)))
+	mux.Handle("DELETE /templates/{id}", authenticationWrapper(http.HandlerFunc(handler.deleteTemplateByIdHandler)))
+}
+
+// NewServiceFromDatabase wires the template service and its dependencies from the database service
+func NewServiceFromDatabase(s database.Service) Service {
+	repos := newRepositories(s.GetRepository())
+	return NewService(repos.Templates, repos.Exercises, repos.ExerciseItems, repos.Sets,
+		func(ctx context.Context, fn func(Repositories) error) error {
+			return s.WithTx(ctx, func(q repository.Querier) error {
+				return fn(newRepositories(q))
+			})
+		},
+	)
+}
+
+// NewServiceInTransaction wires the template service on the querier of a
+// running transaction, its writes are committed together with those of the caller
+func NewServiceInTransaction(q repository.Querier) Service {
+	repos := newRepositories(q)
+	return NewService(repos.Templates, repos.Exercises, repos.ExerciseItems, repos.Sets,
+		func(ctx context.Context, fn func(Repositories) error) error {
+			return fn(repos)
+		},
+	)
+}
+
+func newRepositories(q repository.Querier) Repositories {
+	return Repositories{
+		Templates:     NewTemplateRepository(q),
+		Exercises:     exercises.NewExerciseRepository(q),
+		ExerciseItems: exerciseitems.NewServiceInTransaction(q),
+		Sets:          sets.NewSetsRepository(q),
+	}
+}
+
+// requestToKilograms converts the set weights of the request from the unit
+func requestToKilograms(req templateRequest, unit string) templateRequest {
+	for i, item := range req.ExerciseItems {
+		for j, exercise := range item.Exercises {
+			for k, set := range exercise.Sets {
+				req.ExerciseItems[i].Exercises[j].Sets[k].Weight = units.ToKilograms(set.Weight, unit)
+			}
+		}
+	}
+	return req
+}
+
+// convertTemplate converts the set weights of the template to the unit
+func convertTemplate(template TemplateWithExerciseItems, unit string) TemplateWithExerciseItems {
+	for i, item := range template.ExerciseItems {
+		for j, exercise := range item.Exercises {
+			for k, set := range exercise.Sets {
+				template.ExerciseItems[i].Exercises[j].Sets[k].Weight = units.FromKilograms(set.Weight, unit)
+			}
+		}
+	}
+	return template
+}
+
+func (h *handler) getAllTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	templates, err := h.service.GetAll(r.Context(), userId)
+	if err != nil {
+		slog.Error("Failed to get templates", "error", err)
+		http.Error(w, "Failed to get templates", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(templates)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) getTemplateByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	id := r.PathValue("id")
+
+	template, err := h.service.GetById(r.Context(), id, userId)
+	if err != nil {
+		slog.Error("Failed to get template", "error", err, "templateId", id)
+		http.Error(w, "Failed to get template", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to get template", http.StatusBadRequest)
+		return
+	}
+	template = convertTemplate(template, unit)
+
+	jsonResp, err := utils.CreateResponse(template)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) createTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+
+	var req templateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to create template", http.StatusBadRequest)
+		return
+	}
+	req = requestToKilograms(req, unit)
+
+	id, err := h.service.CreateAndReturnId(r.Context(), req, userId)
+	if err != nil {
+		slog.Error("Failed to create template", "error", err)
+		http.Error(w, "Failed to create template", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	jsonResp, err := utils.CreateIdResponse(id)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
+
+func (h *handler) updateTemplateByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	id := r.PathValue("id")
+
+	var req templateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request body", "error", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	unit, err := h.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "Failed to update template", http.StatusBadRequest)
+		return
+	}
+	req = requestToKilograms(req, unit)
+
+	err = h.service.UpdateById(r.Context(), id, req, userId)
+	if err != nil {
+		slog.Error("Failed to update template", "error", err, "templateId", id)
+		http.Error(w, "Failed to update template", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) deleteTemplateByIdHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	id := r.PathValue("id")
+
+	err := h.service.DeleteById(r.Context(), id, userId)
+	if errors.Is(err, ErrTemplateInUse) {
+		slog.Warn("Failed to delete template", "error", err, "templateId", id)
+		http.Error(w, "Template is used by a program", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to delete template", "error", err, "templateId", id)
+		http.Error(w, "Failed to delete template", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) startWorkoutHandler(w http.ResponseWriter, r *http.Request) {
+	userId := r.Context().Value("sub").(string)
+	id := r.PathValue("id")
+
+	workoutId, err := h.service.StartWorkoutAndReturnId(r.Context(), id, userId)
+	if err != nil {
+		slog.Error("Failed to start workout from template", "error", err, "templateId", id)
+		http.Error(w, "Failed to start workout from template", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	jsonResp, err := utils.CreateIdResponse(workoutId)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	utils.ReturnJson(w, jsonResp)
+}
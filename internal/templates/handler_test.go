@@ -0,0 +1,382 @@
+package templates
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"weight-tracker/internal/units"
+
+	"github.com/stretchr/testify/mock"
+)
+
+func populateContextWithSub(req *http.Request, userId string) *http.Request {
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, "sub", userId)
+	return req.WithContext(ctx)
+}
+
+type serviceMock struct {
+	mock.Mock
+}
+
+func (s *serviceMock) GetAll(ctx context.Context, userId string) ([]Template, error) {
+	args := s.Called(ctx, userId)
+	return args.Get(0).([]Template), args.Error(1)
+}
+
+func (s *serviceMock) GetById(ctx context.Context, templateId string, userId string) (TemplateWithExerciseItems, error) {
+	args := s.Called(ctx, templateId, userId)
+	return args.Get(0).(TemplateWithExerciseItems), args.Error(1)
+}
+
+func (s *serviceMock) CreateAndReturnId(ctx context.Context, t templateRequest, userId string) (string, error) {
+	args := s.Called(ctx, t, userId)
+	return args.String(0), args.Error(1)
+}
+
+func (s *serviceMock) UpdateById(ctx context.Context, templateId string, t templateRequest, userId string) error {
+	args := s.Called(ctx, templateId, t, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) DeleteById(ctx context.Context, templateId string, userId string) error {
+	args := s.Called(ctx, templateId, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) CreateFromWorkoutAndReturnId(ctx context.Context, workoutId string, userId string) (string, error) {
+	args := s.Called(ctx, workoutId, userId)
+	return args.String(0), args.Error(1)
+}
+
+func (s *serviceMock) StartWorkoutAndReturnId(ctx context.Context, templateId string, userId string) (string, error) {
+	args := s.Called(ctx, templateId, userId)
+	return args.String(0), args.Error(1)
+}
+
+type unitsStub struct {
+	unit string
+}
+
+func (u unitsStub) GetWeightUnit(ctx context.Context, userId string) (string, error) {
+	return u.unit, nil
+}
+
+func TestGetAllTemplatesHandler(t *testing.T) {
+	userId := "userId"
+
+	req, err := http.NewRequest("GET", "/templates", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetAll", req.Context(), userId).Return([]Template{
+		{ID: "templateId", Name: "Push", CreatedOn: "2025-01-01T00:00:00Z", UpdatedOn: "2025-01-01T00:00:00Z"},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.getAllTemplatesHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":[{"id":"templateId","name":"Push","created_on":"2025-01-01T00:00:00Z","updated_on":"2025-01-01T00:00:00Z"}]}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetTemplateByIdHandlerServiceErr(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+
+	req, err := http.NewRequest("GET", "/templates/"+templateId, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", templateId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetById", req.Context(), templateId, userId).Return(TemplateWithExerciseItems{}, testError).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.getTemplateByIdHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestCreateTemplateHandler(t *testing.T) {
+	userId := "userId"
+	request := templateRequest{
+		Name: "Push",
+		ExerciseItems: []templateExerciseItemRequest{
+			{
+				Type: "exercise",
+				Exercises: []templateExerciseRequest{
+					{ExerciseTypeID: "type1", Sets: []templateSetRequest{{Repetitions: 5, Weight: 100}}},
+				},
+			},
+		},
+	}
+	body, _ := json.Marshal(request)
+
+	req, err := http.NewRequest("POST", "/templates", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("CreateAndReturnId", req.Context(), request, userId).Return("templateId", nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.createTemplateHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	expected := `{"id":"templateId"}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestCreateTemplateHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+	body := `{"name":"Push","exercise_items":[{"type":"exercise","exercises":[{"exercise_type_id":"type1","sets":[{"repetitions":5,"weight":225}]}]}]}`
+
+	req, err := http.NewRequest("POST", "/templates", bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("CreateAndReturnId", req.Context(), mock.MatchedBy(func(input templateRequest) bool {
+		return units.FromKilograms(input.ExerciseItems[0].Exercises[0].Sets[0].Weight, units.Kilograms) == 102.06
+	}), userId).Return("templateId", nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(s.createTemplateHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestGetTemplateByIdHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+
+	req, err := http.NewRequest("GET", "/templates/"+templateId, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", templateId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("GetById", req.Context(), templateId, userId).Return(TemplateWithExerciseItems{
+		ID:   templateId,
+		Name: "Push",
+		ExerciseItems: []TemplateExerciseItem{
+			{ID: "item", Type: "exercise", Exercises: []TemplateExercise{
+				{ID: "exercise", Name: "Bench", ExerciseTypeID: "type1", TemplateExerciseItemID: "item", Sets: []TemplateSet{
+					{ID: "set", Repetitions: 5, Weight: 100, Type: "working", TemplateExerciseID: "exercise"},
+				}},
+			}},
+		},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(s.getTemplateByIdHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	expected := `{"data":{"id":"templateId","name":"Push","created_on":"","updated_on":"","exercise_items":[{"id":"item","type":"exercise","exercises":[{"id":"exercise","name":"Bench","exercise_type_id":"type1","template_exercise_item_id":"item","sets":[{"id":"set","repetitions":5,"weight":220.46,"type":"working","template_exercise_id":"exercise"}]}]}]}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestCreateTemplateHandlerInvalidBody(t *testing.T) {
+	req, err := http.NewRequest("POST", "/templates", bytes.NewBufferString("{"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req = populateContextWithSub(req, "userId")
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock{}}
+	handler := http.HandlerFunc(s.createTemplateHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+}
+
+func TestUpdateTemplateByIdHandler(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+	request := templateRequest{Name: "Pull"}
+	body, _ := json.Marshal(request)
+
+	req, err := http.NewRequest("PUT", "/templates/"+templateId, bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", templateId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("UpdateById", req.Context(), templateId, request, userId).Return(nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.updateTemplateByIdHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestDeleteTemplateByIdHandler(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+
+	req, err := http.NewRequest("DELETE", "/templates/"+templateId, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", templateId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("DeleteById", req.Context(), templateId, userId).Return(nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.deleteTemplateByIdHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestDeleteTemplateByIdHandlerUsedByProgram(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+
+	req, err := http.NewRequest("DELETE", "/templates/"+templateId, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", templateId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("DeleteById", req.Context(), templateId, userId).Return(fmt.Errorf("failed: %w", ErrTemplateInUse)).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.deleteTemplateByIdHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusConflict {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusConflict)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestStartWorkoutHandler(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+
+	req, err := http.NewRequest("POST", "/templates/"+templateId+"/start", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", templateId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("StartWorkoutAndReturnId", req.Context(), templateId, userId).Return("workoutId", nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.startWorkoutHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	expected := `{"id":"workoutId"}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+func TestStartWorkoutHandlerServiceErr(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+
+	req, err := http.NewRequest("POST", "/templates/"+templateId+"/start", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", templateId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("StartWorkoutAndReturnId", req.Context(), templateId, userId).Return("", testError).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock}
+	handler := http.HandlerFunc(s.startWorkoutHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
@@ -0,0 +1,43 @@
+package templates
+
+type Template struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedOn string `json:"created_on"`
+	UpdatedOn string `json:"updated_on"`
+}
+
+type TemplateWithExerciseItems struct {
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	CreatedOn     string                 `json:"created_on"`
+	UpdatedOn     string                 `json:"updated_on"`
+	ExerciseItems []TemplateExerciseItem `json:"exercise_items"`
+}
+
+type TemplateExerciseItem struct {
+	ID              string             `json:"id"`
+	Type            string             `json:"type"`
+	Rounds          *int64             `json:"rounds,omitempty"`
+	IntervalSeconds *int64             `json:"interval_seconds,omitempty"`
+	TimeCapSeconds  *int64             `json:"time_cap_seconds,omitempty"`
+	Exercises       []TemplateExercise `json:"exercises"`
+}
+
+type TemplateExercise struct {
+	ID                     string        `json:"id"`
+	Name                   string        `json:"name"`
+	ExerciseTypeID         string        `json:"exercise_type_id"`
+	TemplateExerciseItemID string        `json:"template_exercise_item_id"`
+	Sets                   []TemplateSet `json:"sets"`
+}
+
+type TemplateSet struct {
+	ID                 string   `json:"id"`
+	Repetitions        int64    `json:"repetitions"`
+	Weight             float64  `json:"weight"`
+	DurationSeconds    *int64   `json:"duration_seconds,omitempty"`
+	DistanceMeters     *float64 `json:"distance_meters,omitempty"`
+	Type               string   `json:"type"`
+	TemplateExerciseID string   `json:"template_exercise_id"`
+}
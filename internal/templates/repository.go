@@ -0,0 +1,247 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
+)
+
+type TemplateRepository interface {
+	GetAll(ctx context.Context, userId string) ([]Template, error)
+	GetById(ctx context.Context, arg repository.GetTemplateByIdParams) (Template, error)
+	CreateAndReturnId(ctx context.Context, arg repository.CreateTemplateAndReturnIdParams) (string, error)
+	UpdateById(ctx context.Context, arg repository.UpdateTemplateByIdParams) error
+	DeleteById(ctx context.Context, arg repository.DeleteTemplateByIdParams) error
+	DeleteContentsByTemplateId(ctx context.Context, templateId string, userId string) error
+	GetExerciseItemsByTemplateId(ctx context.Context, templateId string, userId string) ([]TemplateExerciseItem, error)
+	GetExercisesByTemplateId(ctx context.Context, templateId string, userId string) ([]TemplateExercise, error)
+	GetSetsByTemplateId(ctx context.Context, templateId string, userId string) ([]TemplateSet, error)
+	CreateExerciseItemAndReturnId(ctx context.Context, arg repository.CreateTemplateExerciseItemAndReturnIdParams) (string, error)
+	CreateExerciseAndReturnId(ctx context.Context, arg repository.CreateTemplateExerciseAndReturnIdParams) (string, error)
+	CreateSetAndReturnId(ctx context.Context, arg repository.CreateTemplateSetAndReturnIdParams) (string, error)
+	GetWorkoutNameById(ctx context.Context, workoutId string, userId string) (string, error)
+	CreateWorkoutAndReturnId(ctx context.Context, arg repository.CreateWorkoutAndReturnIdParams) (string, error)
+	IsUsedByProgram(ctx context.Context, templateId string, userId string) (bool, error)
+}
+
+func NewTemplateRepository(repo repository.Querier) TemplateRepository {
+	return templateRepository{repo: repo}
+}
+
+type templateRepository struct {
+	repo repository.Querier
+}
+
+func (t templateRepository) GetAll(ctx context.Context, userId string) ([]Template, error) {
+	templates, err := t.repo.GetAllTemplates(ctx, userId)
+	if err != nil {
+		return []Template{}, fmt.Errorf("failed to get all templates: %w", err)
+	}
+
+	result := []Template{}
+	for _, v := range templates {
+		result = append(result, newTemplate(v))
+	}
+	return result, nil
+}
+
+func (t templateRepository) GetById(ctx context.Context, arg repository.GetTemplateByIdParams) (Template, error) {
+	template, err := t.repo.GetTemplateById(ctx, arg)
+	if err != nil {
+		return Template{}, fmt.Errorf("failed to get template by id: %w", err)
+	}
+	return newTemplate(template), nil
+}
+
+func (t templateRepository) CreateAndReturnId(ctx context.Context, arg repository.CreateTemplateAndReturnIdParams) (string, error) {
+	id, err := t.repo.CreateTemplateAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create template: %w", err)
+	}
+	return id, nil
+}
+
+func (t templateRepository) UpdateById(ctx context.Context, arg repository.UpdateTemplateByIdParams) error {
+	rows, err := t.repo.UpdateTemplateById(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("template not found")
+	}
+	return nil
+}
+
+func (t templateRepository) DeleteById(ctx context.Context, arg repository.DeleteTemplateByIdParams) error {
+	rows, err := t.repo.DeleteTemplateById(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	if rows == 0 {
+		slog.Warn("Tried to delete template that did not exist", "templateId", arg.ID)
+	}
+	return nil
+}
+
+func (t templateRepository) IsUsedByProgram(ctx context.Context, templateId string, userId string) (bool, error) {
+	count, err := t.repo.CountProgramDaysByTemplateId(ctx, repository.CountProgramDaysByTemplateIdParams{
+		TemplateID: templateId,
+		UserID:     userId,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to count program days of template: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (t templateRepository) DeleteContentsByTemplateId(ctx context.Context, templateId string, userId string) error {
+	_, err := t.repo.DeleteTemplateSetsByTemplateId(ctx, repository.DeleteTemplateSetsByTemplateIdParams{
+		TemplateID: templateId,
+		UserID:     userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete template sets: %w", err)
+	}
+
+	_, err = t.repo.DeleteTemplateExercisesByTemplateId(ctx, repository.DeleteTemplateExercisesByTemplateIdParams{
+		TemplateID: templateId,
+		UserID:     userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete template exercises: %w", err)
+	}
+
+	_, err = t.repo.DeleteTemplateExerciseItemsByTemplateId(ctx, repository.DeleteTemplateExerciseItemsByTemplateIdParams{
+		TemplateID: templateId,
+		UserID:     userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete template exercise items: %w", err)
+	}
+	return nil
+}
+
+func (t templateRepository) GetExerciseItemsByTemplateId(ctx context.Context, templateId string, userId string) ([]TemplateExerciseItem, error) {
+	items, err := t.repo.GetTemplateExerciseItemsByTemplateId(ctx, repository.GetTemplateExerciseItemsByTemplateIdParams{
+		TemplateID: templateId,
+		UserID:     userId,
+	})
+	if err != nil {
+		return []TemplateExerciseItem{}, fmt.Errorf("failed to get template exercise items: %w", err)
+	}
+
+	result := []TemplateExerciseItem{}
+	for _, v := range items {
+		result = append(result, TemplateExerciseItem{
+			ID:              v.ID,
+			Type:            v.Type,
+			Rounds:          utils.NullableInt(v.Rounds),
+			IntervalSeconds: utils.NullableInt(v.IntervalSeconds),
+			TimeCapSeconds:  utils.NullableInt(v.TimeCapSeconds),
+			Exercises:       []TemplateExercise{},
+		})
+	}
+	return result, nil
+}
+
+func (t templateRepository) GetExercisesByTemplateId(ctx context.Context, templateId string, userId string) ([]TemplateExercise, error) {
+	exercises, err := t.repo.GetTemplateExercisesByTemplateId(ctx, repository.GetTemplateExercisesByTemplateIdParams{
+		TemplateID: templateId,
+		UserID:     userId,
+	})
+	if err != nil {
+		return []TemplateExercise{}, fmt.Errorf("failed to get template exercises: %w", err)
+	}
+
+	result := []TemplateExercise{}
+	for _, v := range exercises {
+		result = append(result, TemplateExercise{
+			ID:                     v.ID,
+			Name:                   v.Name,
+			ExerciseTypeID:         v.ExerciseTypeID,
+			TemplateExerciseItemID: v.TemplateExerciseItemID,
+			Sets:                   []TemplateSet{},
+		})
+	}
+	return result, nil
+}
+
+func (t templateRepository) GetSetsByTemplateId(ctx context.Context, templateId string, userId string) ([]TemplateSet, error) {
+	sets, err := t.repo.GetTemplateSetsByTemplateId(ctx, repository.GetTemplateSetsByTemplateIdParams{
+		TemplateID: templateId,
+		UserID:     userId,
+	})
+	if err != nil {
+		return []TemplateSet{}, fmt.Errorf("failed to get template sets: %w", err)
+	}
+
+	result := []TemplateSet{}
+	for _, v := range sets {
+		result = append(result, TemplateSet{
+			ID:                 v.ID,
+			Repetitions:        v.Repetitions,
+			Weight:             v.Weight,
+			DurationSeconds:    utils.NullableInt(v.DurationSeconds),
+			DistanceMeters:     utils.NullableFloat(v.DistanceMeters),
+			Type:               v.Type,
+			TemplateExerciseID: v.TemplateExerciseID,
+		})
+	}
+	return result, nil
+}
+
+func (t templateRepository) CreateExerciseItemAndReturnId(ctx context.Context, arg repository.CreateTemplateExerciseItemAndReturnIdParams) (string, error) {
+	id, err := t.repo.CreateTemplateExerciseItemAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create template exercise item: %w", err)
+	}
+	return id, nil
+}
+
+func (t templateRepository) CreateExerciseAndReturnId(ctx context.Context, arg repository.CreateTemplateExerciseAndReturnIdParams) (string, error) {
+	id, err := t.repo.CreateTemplateExerciseAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create template exercise: %w", err)
+	}
+	return id, nil
+}
+
+func (t templateRepository) CreateSetAndReturnId(ctx context.Context, arg repository.CreateTemplateSetAndReturnIdParams) (string, error) {
+	id, err := t.repo.CreateTemplateSetAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create template set: %w", err)
+	}
+	return id, nil
+}
+
+func (t templateRepository) GetWorkoutNameById(ctx context.Context, workoutId string, userId string) (string, error) {
+	workout, err := t.repo.GetWorkoutById(ctx, repository.GetWorkoutByIdParams{
+		ID:     workoutId,
+		UserID: userId,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get workout by id: %w", err)
+	}
+	return workout.Name, nil
+}
+
+func (t templateRepository) CreateWorkoutAndReturnId(ctx context.Context, arg repository.CreateWorkoutAndReturnIdParams) (string, error) {
+	id, err := t.repo.CreateWorkoutAndReturnId(ctx, arg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create workout: %w", err)
+	}
+	return id, nil
+}
+
+func newTemplate(v repository.Template) Template {
+	return Template{
+		ID:        v.ID,
+		Name:      v.Name,
+		CreatedOn: v.CreatedOn,
+		UpdatedOn: v.UpdatedOn,
+	}
+}
@@ -0,0 +1,532 @@
+package templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"weight-tracker/internal/exerciseitems"
+	"weight-tracker/internal/exercises"
+	"weight-tracker/internal/exercisetypes"
+	"weight-tracker/internal/measurement"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/sets"
+
+	"github.com/google/uuid"
+)
+
+// ErrTemplateInUse is returned when a template that is a day of a program is deleted
+var ErrTemplateInUse = errors.New("template is used by a program")
+
+type Service interface {
+	GetAll(ctx context.Context, userId string) ([]Template, error)
+	GetById(ctx context.Context, templateId string, userId string) (TemplateWithExerciseItems, error)
+	CreateAndReturnId(ctx context.Context, t templateRequest, userId string) (string, error)
+	UpdateById(ctx context.Context, templateId string, t templateRequest, userId string) error
+	DeleteById(ctx context.Context, templateId string, userId string) error
+	CreateFromWorkoutAndReturnId(ctx context.Context, workoutId string, userId string) (string, error)
+	StartWorkoutAndReturnId(ctx context.Context, templateId string, userId string) (string, error)
+}
+
+// Repositories are the repositories a template, or a workout started from it, is written with
+type Repositories struct {
+	Templates     TemplateRepository
+	Exercises     exercises.ExerciseRepository
+	ExerciseItems exerciseitems.Service
+	Sets          sets.SetsRepository
+}
+
+// Transactor runs fn with repositories whose queries share one transaction
+type Transactor func(ctx context.Context, fn func(Repositories) error) error
+
+type templateService struct {
+	repo            TemplateRepository
+	exerciseRepo    exercises.ExerciseRepository
+	exerciseItemSvc exerciseitems.Service
+	setsRepo        sets.SetsRepository
+	withTx          Transactor
+}
+
+func NewService(repo TemplateRepository, exerciseRepo exercises.ExerciseRepository, exerciseItemSvc exerciseitems.Service, setsRepo sets.SetsRepository, withTx Transactor) Service {
+	return &templateService{repo, exerciseRepo, exerciseItemSvc, setsRepo, withTx}
+}
+
+func (s *templateService) GetAll(ctx context.Context, userId string) ([]Template, error) {
+	return s.repo.GetAll(ctx, userId)
+}
+
+func (s *templateService) GetById(ctx context.Context, templateId string, userId string) (TemplateWithExerciseItems, error) {
+	template, err := s.repo.GetById(ctx, repository.GetTemplateByIdParams{
+		ID:     templateId,
+		UserID: userId,
+	})
+	if err != nil {
+		return TemplateWithExerciseItems{}, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	items, err := s.repo.GetExerciseItemsByTemplateId(ctx, templateId, userId)
+	if err != nil {
+		return TemplateWithExerciseItems{}, fmt.Errorf("failed to get template exercise items: %w", err)
+	}
+
+	exs, err := s.repo.GetExercisesByTemplateId(ctx, templateId, userId)
+	if err != nil {
+		return TemplateWithExerciseItems{}, fmt.Errorf("failed to get template exercises: %w", err)
+	}
+
+	templateSets, err := s.repo.GetSetsByTemplateId(ctx, templateId, userId)
+	if err != nil {
+		return TemplateWithExerciseItems{}, fmt.Errorf("failed to get template sets: %w", err)
+	}
+
+	setsByExercise := map[string][]TemplateSet{}
+	for _, set := range templateSets {
+		setsByExercise[set.TemplateExerciseID] = append(setsByExercise[set.TemplateExerciseID], set)
+	}
+
+	exercisesByItem := map[string][]TemplateExercise{}
+	for _, exercise := range exs {
+		if exerciseSets, ok := setsByExercise[exercise.ID]; ok {
+			exercise.Sets = exerciseSets
+		}
+		exercisesByItem[exercise.TemplateExerciseItemID] = append(exercisesByItem[exercise.TemplateExerciseItemID], exercise)
+	}
+
+	for i, item := range items {
+		if itemExercises, ok := exercisesByItem[item.ID]; ok {
+			items[i].Exercises = itemExercises
+		}
+	}
+
+	return TemplateWithExerciseItems{
+		ID:            template.ID,
+		Name:          template.Name,
+		CreatedOn:     template.CreatedOn,
+		UpdatedOn:     template.UpdatedOn,
+		ExerciseItems: items,
+	}, nil
+}
+
+func (s *templateService) CreateAndReturnId(ctx context.Context, t templateRequest, userId string) (string, error) {
+	err := validateTemplateRequest(t)
+	if err != nil {
+		return "", err
+	}
+
+	exerciseTypes, err := s.getExerciseTypes(ctx, t.ExerciseItems, userId)
+	if err != nil {
+		return "", err
+	}
+
+	err = validateMeasurements(t.ExerciseItems, exerciseTypes)
+	if err != nil {
+		return "", err
+	}
+
+	uuid, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	var id string
+	err = s.withTx(ctx, func(repos Repositories) error {
+		now := time.Now().UTC().Format(time.RFC3339)
+		var err error
+		id, err = repos.Templates.CreateAndReturnId(ctx, repository.CreateTemplateAndReturnIdParams{
+			ID:        uuid.String(),
+			Name:      t.Name,
+			CreatedOn: now,
+			UpdatedOn: now,
+			UserID:    userId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create template: %w", err)
+		}
+
+		return createContents(ctx, repos.Templates, id, t.ExerciseItems, exerciseTypes, userId)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (s *templateService) UpdateById(ctx context.Context, templateId string, t templateRequest, userId string) error {
+	err := validateTemplateRequest(t)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repo.GetById(ctx, repository.GetTemplateByIdParams{
+		ID:     templateId,
+		UserID: userId,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get template: %w", err)
+	}
+
+	// Exercise types are checked before anything is changed, an unknown one leaves the template as it was
+	exerciseTypes, err := s.getExerciseTypes(ctx, t.ExerciseItems, userId)
+	if err != nil {
+		return err
+	}
+
+	err = validateMeasurements(t.ExerciseItems, exerciseTypes)
+	if err != nil {
+		return err
+	}
+
+	return s.withTx(ctx, func(repos Repositories) error {
+		err := repos.Templates.UpdateById(ctx, repository.UpdateTemplateByIdParams{
+			Name:      t.Name,
+			UpdatedOn: time.Now().UTC().Format(time.RFC3339),
+			ID:        templateId,
+			UserID:    userId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update template: %w", err)
+		}
+
+		// The contents of a template are replaced as a whole
+		err = repos.Templates.DeleteContentsByTemplateId(ctx, templateId, userId)
+		if err != nil {
+			return fmt.Errorf("failed to delete template contents: %w", err)
+		}
+
+		return createContents(ctx, repos.Templates, templateId, t.ExerciseItems, exerciseTypes, userId)
+	})
+}
+
+func (s *templateService) DeleteById(ctx context.Context, templateId string, userId string) error {
+	return s.withTx(ctx, func(repos Repositories) error {
+		used, err := repos.Templates.IsUsedByProgram(ctx, templateId, userId)
+		if err != nil {
+			return err
+		}
+		if used {
+			return ErrTemplateInUse
+		}
+
+		err = repos.Templates.DeleteContentsByTemplateId(ctx, templateId, userId)
+		if err != nil {
+			return fmt.Errorf("failed to delete template contents: %w", err)
+		}
+
+		return repos.Templates.DeleteById(ctx, repository.DeleteTemplateByIdParams{
+			ID:     templateId,
+			UserID: userId,
+		})
+	})
+}
+
+func (s *templateService) CreateFromWorkoutAndReturnId(ctx context.Context, workoutId string, userId string) (string, error) {
+	name, err := s.repo.GetWorkoutNameById(ctx, workoutId, userId)
+	if err != nil {
+		return "", fmt.Errorf("failed to get workout by id: %w", err)
+	}
+
+	exerciseItems, err := s.exerciseItemSvc.GetByWorkoutIdWithExercises(ctx, repository.GetExerciseItemsByWorkoutIdParams{
+		WorkoutID: workoutId,
+		UserID:    userId,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get exercise items: %w", err)
+	}
+
+	t := templateRequest{
+		Name:          name,
+		ExerciseItems: []templateExerciseItemRequest{},
+	}
+	for _, item := range exerciseItems {
+		itemRequest := templateExerciseItemRequest{
+			Type:            item.Type,
+			Rounds:          item.Rounds,
+			IntervalSeconds: item.IntervalSeconds,
+			TimeCapSeconds:  item.TimeCapSeconds,
+			Exercises:       []templateExerciseRequest{},
+		}
+
+		for _, exercise := range item.Exercises {
+			exerciseSets, err := s.setsRepo.GetByExerciseId(ctx, repository.GetSetsByExerciseIdParams{
+				ExerciseID: exercise.ID,
+				UserID:     userId,
+			})
+			if err != nil {
+				return "", fmt.Errorf("failed to get sets: %w", err)
+			}
+
+			exerciseRequest := templateExerciseRequest{
+				ExerciseTypeID: exercise.ExerciseTypeID,
+				Sets:           []templateSetRequest{},
+			}
+			for _, set := range exerciseSets {
+				setRequest := templateSetRequest{
+					Repetitions:    int(set.Repetitions),
+					Weight:         set.Weight,
+					DistanceMeters: set.DistanceMeters,
+					Type:           set.Type,
+				}
+				if set.DurationSeconds != nil {
+					duration := int(*set.DurationSeconds)
+					setRequest.DurationSeconds = &duration
+				}
+				exerciseRequest.Sets = append(exerciseRequest.Sets, setRequest)
+			}
+			itemRequest.Exercises = append(itemRequest.Exercises, exerciseRequest)
+		}
+		t.ExerciseItems = append(t.ExerciseItems, itemRequest)
+	}
+
+	return s.CreateAndReturnId(ctx, t, userId)
+}
+
+func (s *templateService) StartWorkoutAndReturnId(ctx context.Context, templateId string, userId string) (string, error) {
+	template, err := s.GetById(ctx, templateId, userId)
+	if err != nil {
+		return "", fmt.Errorf("failed to get template by id: %w", err)
+	}
+
+	workoutUuid, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	// The workout is created in one transaction, a failure halfway leaves no partial workout
+	var workoutId string
+	err = s.withTx(ctx, func(repos Repositories) error {
+		now := time.Now().UTC().Format(time.RFC3339)
+		var err error
+		workoutId, err = repos.Templates.CreateWorkoutAndReturnId(ctx, repository.CreateWorkoutAndReturnIdParams{
+			ID:        workoutUuid.String(),
+			Name:      template.Name,
+			CreatedOn: now,
+			UpdatedOn: now,
+			UserID:    userId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create workout: %w", err)
+		}
+
+		for _, item := range template.ExerciseItems {
+			itemId, err := repos.ExerciseItems.CreateAndReturnId(ctx, exerciseitems.Settings{
+				Type:            item.Type,
+				Rounds:          item.Rounds,
+				IntervalSeconds: item.IntervalSeconds,
+				TimeCapSeconds:  item.TimeCapSeconds,
+			}, workoutId, userId)
+			if err != nil {
+				return fmt.Errorf("failed to create exercise item: %w", err)
+			}
+
+			for _, exercise := range item.Exercises {
+				exerciseUuid, err := uuid.NewV7()
+				if err != nil {
+					return fmt.Errorf("failed to generate UUID for exercise: %w", err)
+				}
+
+				exerciseId, err := repos.Exercises.CreateAndReturnId(ctx, repository.CreateExerciseAndReturnIdParams{
+					ID:             exerciseUuid.String(),
+					WorkoutID:      workoutId,
+					ExerciseItemID: itemId,
+					Name:           exercise.Name,
+					ExerciseTypeID: exercise.ExerciseTypeID,
+					CreatedOn:      now,
+					UserID:         userId,
+					UpdatedOn:      now,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to create exercise: %w", err)
+				}
+
+				// Planned sets are created up front so they can be adjusted while training
+				for _, set := range exercise.Sets {
+					setUuid, err := uuid.NewV7()
+					if err != nil {
+						return fmt.Errorf("failed to generate UUID for set: %w", err)
+					}
+
+					var duration, distance interface{}
+					if set.DurationSeconds != nil {
+						duration = *set.DurationSeconds
+					}
+					if set.DistanceMeters != nil {
+						distance = *set.DistanceMeters
+					}
+
+					_, err = repos.Sets.CreateAndReturnId(ctx, repository.CreateSetAndReturnIdParams{
+						ID:              setUuid.String(),
+						Repetitions:     set.Repetitions,
+						Weight:          set.Weight,
+						DurationSeconds: duration,
+						DistanceMeters:  distance,
+						Type:            set.Type,
+						ExerciseID:      exerciseId,
+						CreatedOn:       now,
+						UpdatedOn:       now,
+						UserID:          userId,
+					})
+					if err != nil {
+						return fmt.Errorf("failed to create set: %w", err)
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return workoutId, nil
+}
+
+// getExerciseTypes looks up the exercise types of the items by their id, an
+// unknown type or one of another user fails the request before anything is written
+func (s *templateService) getExerciseTypes(ctx context.Context, items []templateExerciseItemRequest, userId string) (map[string]*exercisetypes.ExerciseType, error) {
+	exerciseTypes := map[string]*exercisetypes.ExerciseType{}
+	for _, item := range items {
+		for _, exercise := range item.Exercises {
+			if _, ok := exerciseTypes[exercise.ExerciseTypeID]; ok {
+				continue
+			}
+			exerciseType, err := s.exerciseRepo.GetExerciseTypeById(ctx, repository.GetExerciseTypeByIdParams{
+				ID:     exercise.ExerciseTypeID,
+				UserID: userId,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get exercise type: %w", err)
+			}
+			exerciseTypes[exercise.ExerciseTypeID] = exerciseType
+		}
+	}
+	return exerciseTypes, nil
+}
+
+func createContents(ctx context.Context, repo TemplateRepository, templateId string, items []templateExerciseItemRequest, exerciseTypes map[string]*exercisetypes.ExerciseType, userId string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, item := range items {
+		settings, err := exerciseitems.NormalizeSettings(item.settings())
+		if err != nil {
+			return err
+		}
+		rounds, interval, timeCap := exerciseitems.NullableSettings(settings)
+
+		itemUuid, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("failed to generate UUID for exercise item: %w", err)
+		}
+
+		itemId, err := repo.CreateExerciseItemAndReturnId(ctx, repository.CreateTemplateExerciseItemAndReturnIdParams{
+			ID:              itemUuid.String(),
+			Type:            settings.Type,
+			Rounds:          rounds,
+			IntervalSeconds: interval,
+			TimeCapSeconds:  timeCap,
+			CreatedOn:       now,
+			UpdatedOn:       now,
+			UserID:          userId,
+			TemplateID:      templateId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create template exercise item: %w", err)
+		}
+
+		for _, exercise := range item.Exercises {
+			exerciseType := exerciseTypes[exercise.ExerciseTypeID]
+
+			exerciseUuid, err := uuid.NewV7()
+			if err != nil {
+				return fmt.Errorf("failed to generate UUID for exercise: %w", err)
+			}
+
+			exerciseId, err := repo.CreateExerciseAndReturnId(ctx, repository.CreateTemplateExerciseAndReturnIdParams{
+				ID:                     exerciseUuid.String(),
+				Name:                   exerciseType.Name,
+				CreatedOn:              now,
+				UpdatedOn:              now,
+				UserID:                 userId,
+				TemplateID:             templateId,
+				TemplateExerciseItemID: itemId,
+				ExerciseTypeID:         exerciseType.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create template exercise: %w", err)
+			}
+
+			for _, set := range exercise.Sets {
+				setType, err := sets.NormalizeType(set.Type)
+				if err != nil {
+					return err
+				}
+
+				setUuid, err := uuid.NewV7()
+				if err != nil {
+					return fmt.Errorf("failed to generate UUID for set: %w", err)
+				}
+
+				duration, distance := set.measurementParams()
+				_, err = repo.CreateSetAndReturnId(ctx, repository.CreateTemplateSetAndReturnIdParams{
+					ID:                 setUuid.String(),
+					Repetitions:        int64(set.Repetitions),
+					Weight:             set.Weight,
+					DurationSeconds:    duration,
+					DistanceMeters:     distance,
+					Type:               setType,
+					CreatedOn:          now,
+					UpdatedOn:          now,
+					UserID:             userId,
+					TemplateID:         templateId,
+					TemplateExerciseID: exerciseId,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to create template set: %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateMeasurements checks the sets against how their exercise type is measured
+func validateMeasurements(items []templateExerciseItemRequest, exerciseTypes map[string]*exercisetypes.ExerciseType) error {
+	for _, item := range items {
+		for _, exercise := range item.Exercises {
+			kind := exerciseTypes[exercise.ExerciseTypeID].Measurement
+			for _, set := range exercise.Sets {
+				if err := measurement.Validate(kind, set.measurement()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateTemplateRequest(t templateRequest) error {
+	if t.Name == "" {
+		return fmt.Errorf("template name is required")
+	}
+
+	for _, item := range t.ExerciseItems {
+		if _, err := exerciseitems.NormalizeSettings(item.settings()); err != nil {
+			return err
+		}
+
+		for _, exercise := range item.Exercises {
+			if exercise.ExerciseTypeID == "" {
+				return fmt.Errorf("exercise type id is required")
+			}
+
+			for _, set := range exercise.Sets {
+				if set.Repetitions < 0 || set.Weight < 0 {
+					return fmt.Errorf("repetitions and weight must not be negative")
+				}
+				if _, err := sets.NormalizeType(set.Type); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
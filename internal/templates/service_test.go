@@ -0,0 +1,701 @@
+package templates
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+	"weight-tracker/internal/exerciseitems"
+	"weight-tracker/internal/exercises"
+	"weight-tracker/internal/exercisetypes"
+	"weight-tracker/internal/measurement"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/sets"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testError = errors.New("Testerror")
+
+type repoMock struct {
+	mock.Mock
+}
+
+func (r *repoMock) GetAll(ctx context.Context, userId string) ([]Template, error) {
+	args := r.Called(ctx, userId)
+	return args.Get(0).([]Template), args.Error(1)
+}
+
+func (r *repoMock) GetById(ctx context.Context, arg repository.GetTemplateByIdParams) (Template, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(Template), args.Error(1)
+}
+
+func (r *repoMock) CreateAndReturnId(ctx context.Context, arg repository.CreateTemplateAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) UpdateById(ctx context.Context, arg repository.UpdateTemplateByIdParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *repoMock) DeleteById(ctx context.Context, arg repository.DeleteTemplateByIdParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *repoMock) DeleteContentsByTemplateId(ctx context.Context, templateId string, userId string) error {
+	args := r.Called(ctx, templateId, userId)
+	return args.Error(0)
+}
+
+func (r *repoMock) GetExerciseItemsByTemplateId(ctx context.Context, templateId string, userId string) ([]TemplateExerciseItem, error) {
+	args := r.Called(ctx, templateId, userId)
+	return args.Get(0).([]TemplateExerciseItem), args.Error(1)
+}
+
+func (r *repoMock) GetExercisesByTemplateId(ctx context.Context, templateId string, userId string) ([]TemplateExercise, error) {
+	args := r.Called(ctx, templateId, userId)
+	return args.Get(0).([]TemplateExercise), args.Error(1)
+}
+
+func (r *repoMock) GetSetsByTemplateId(ctx context.Context, templateId string, userId string) ([]TemplateSet, error) {
+	args := r.Called(ctx, templateId, userId)
+	return args.Get(0).([]TemplateSet), args.Error(1)
+}
+
+func (r *repoMock) CreateExerciseItemAndReturnId(ctx context.Context, arg repository.CreateTemplateExerciseItemAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) CreateExerciseAndReturnId(ctx context.Context, arg repository.CreateTemplateExerciseAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) CreateSetAndReturnId(ctx context.Context, arg repository.CreateTemplateSetAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) GetWorkoutNameById(ctx context.Context, workoutId string, userId string) (string, error) {
+	args := r.Called(ctx, workoutId, userId)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) CreateWorkoutAndReturnId(ctx context.Context, arg repository.CreateWorkoutAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *repoMock) IsUsedByProgram(ctx context.Context, templateId string, userId string) (bool, error) {
+	args := r.Called(ctx, templateId, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+type exerciseRepoMock struct {
+	mock.Mock
+}
+
+func (r *exerciseRepoMock) GetAll(ctx context.Context, userId string) ([]exercises.Exercise, error) {
+	args := r.Called(ctx, userId)
+	return args.Get(0).([]exercises.Exercise), args.Error(1)
+}
+
+func (r *exerciseRepoMock) GetByWorkoutId(ctx context.Context, arg repository.GetExercisesByWorkoutIdParams) ([]exercises.Exercise, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]exercises.Exercise), args.Error(1)
+}
+
+func (r *exerciseRepoMock) GetByExerciseItemId(ctx context.Context, exerciseItemId string, userId string) ([]exercises.Exercise, error) {
+	args := r.Called(ctx, exerciseItemId, userId)
+	return args.Get(0).([]exercises.Exercise), args.Error(1)
+}
+
+func (r *exerciseRepoMock) GetById(ctx context.Context, arg repository.GetExerciseByIdParams) (exercises.Exercise, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(exercises.Exercise), args.Error(1)
+}
+
+func (r *exerciseRepoMock) UpdateById(ctx context.Context, arg repository.UpdateExerciseParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *exerciseRepoMock) UpdatePosition(ctx context.Context, arg repository.UpdateExercisePositionParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *exerciseRepoMock) DeleteById(ctx context.Context, arg repository.DeleteExerciseByIdParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *exerciseRepoMock) CreateAndReturnId(ctx context.Context, exercise repository.CreateExerciseAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, exercise)
+	return args.String(0), args.Error(1)
+}
+
+func (r *exerciseRepoMock) GetExerciseTypeById(ctx context.Context, arg repository.GetExerciseTypeByIdParams) (*exercisetypes.ExerciseType, error) {
+	args := r.Called(ctx, arg)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*exercisetypes.ExerciseType), args.Error(1)
+}
+
+type exerciseItemsMock struct {
+	mock.Mock
+}
+
+func (r *exerciseItemsMock) GetById(ctx context.Context, arg repository.GetExerciseItemByIdParams) (exerciseitems.ExerciseItem, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(exerciseitems.ExerciseItem), args.Error(1)
+}
+
+func (r *exerciseItemsMock) GetByWorkoutId(ctx context.Context, arg repository.GetExerciseItemsByWorkoutIdParams) ([]exerciseitems.ExerciseItem, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]exerciseitems.ExerciseItem), args.Error(1)
+}
+
+func (r *exerciseItemsMock) GetByIdWithExercises(ctx context.Context, arg repository.GetExerciseItemByIdParams) (exerciseitems.ExerciseItemWithExercises, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(exerciseitems.ExerciseItemWithExercises), args.Error(1)
+}
+
+func (r *exerciseItemsMock) GetByWorkoutIdWithExercises(ctx context.Context, arg repository.GetExerciseItemsByWorkoutIdParams) ([]exerciseitems.ExerciseItemWithExercises, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]exerciseitems.ExerciseItemWithExercises), args.Error(1)
+}
+
+func (r *exerciseItemsMock) CreateAndReturnId(ctx context.Context, settings exerciseitems.Settings, workoutId string, userId string) (string, error) {
+	args := r.Called(ctx, settings, workoutId, userId)
+	return args.String(0), args.Error(1)
+}
+
+func (r *exerciseItemsMock) UpdateById(ctx context.Context, exerciseItemId string, settings exerciseitems.Settings, userId string) error {
+	args := r.Called(ctx, exerciseItemId, settings, userId)
+	return args.Error(0)
+}
+
+func (r *exerciseItemsMock) Reorder(ctx context.Context, workoutId string, ids []string, userId string) error {
+	args := r.Called(ctx, workoutId, ids, userId)
+	return args.Error(0)
+}
+
+func (r *exerciseItemsMock) DeleteById(ctx context.Context, arg repository.DeleteExerciseItemByIdParams) (int64, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type setsRepoMock struct {
+	mock.Mock
+}
+
+func (r *setsRepoMock) GetAll(ctx context.Context, userId string) ([]sets.Set, error) {
+	args := r.Called(ctx, userId)
+	return args.Get(0).([]sets.Set), args.Error(1)
+}
+
+func (r *setsRepoMock) GetById(ctx context.Context, arg repository.GetSetByIdParams) (sets.Set, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(sets.Set), args.Error(1)
+}
+
+func (r *setsRepoMock) GetByExerciseId(ctx context.Context, arg repository.GetSetsByExerciseIdParams) ([]sets.Set, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]sets.Set), args.Error(1)
+}
+
+func (r *setsRepoMock) GetByWorkoutId(ctx context.Context, arg repository.GetSetsByWorkoutIdParams) ([]sets.Set, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]sets.Set), args.Error(1)
+}
+
+func (r *setsRepoMock) CreateAndReturnId(ctx context.Context, arg repository.CreateSetAndReturnIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *setsRepoMock) UpdateById(ctx context.Context, arg repository.UpdateSetParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *setsRepoMock) UpdatePosition(ctx context.Context, arg repository.UpdateSetPositionParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
+func (r *setsRepoMock) GetExerciseItemType(ctx context.Context, arg repository.GetExerciseItemByIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *setsRepoMock) GetExerciseIdsByExerciseItemId(ctx context.Context, arg repository.GetExercisesByExerciseItemIdParams) ([]string, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (r *setsRepoMock) GetNextRound(ctx context.Context, arg repository.GetNextRoundByExerciseItemIdParams) (int64, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (r *setsRepoMock) GetPreviousPerformedOn(ctx context.Context, arg repository.GetPreviousPerformedOnByExerciseIdParams) (time.Time, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (r *setsRepoMock) GetMeasurement(ctx context.Context, arg repository.GetMeasurementByExerciseIdParams) (string, error) {
+	args := r.Called(ctx, arg)
+	return args.String(0), args.Error(1)
+}
+
+func (r *setsRepoMock) DeleteById(ctx context.Context, arg repository.DeleteSetByIdParams) (int64, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestGetById(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.MatchedBy(func(input repository.GetTemplateByIdParams) bool {
+		return input.ID == templateId && input.UserID == userId
+	})).Return(Template{ID: templateId, Name: "Push"}, nil).Once()
+	repoMock.On("GetExerciseItemsByTemplateId", ctx, templateId, userId).Return([]TemplateExerciseItem{
+		{ID: "item1", Type: "exercise", Exercises: []TemplateExercise{}},
+		{ID: "item2", Type: "superset", Exercises: []TemplateExercise{}},
+	}, nil).Once()
+	repoMock.On("GetExercisesByTemplateId", ctx, templateId, userId).Return([]TemplateExercise{
+		{ID: "exercise1", Name: "Bench press", ExerciseTypeID: "type1", TemplateExerciseItemID: "item1", Sets: []TemplateSet{}},
+		{ID: "exercise2", Name: "Dips", ExerciseTypeID: "type2", TemplateExerciseItemID: "item2", Sets: []TemplateSet{}},
+	}, nil).Once()
+	repoMock.On("GetSetsByTemplateId", ctx, templateId, userId).Return([]TemplateSet{
+		{ID: "set1", Repetitions: 5, Weight: 100, TemplateExerciseID: "exercise1"},
+		{ID: "set2", Repetitions: 5, Weight: 100, TemplateExerciseID: "exercise1"},
+	}, nil).Once()
+
+	service := NewService(&repoMock, nil, nil, nil, nil)
+
+	result, err := service.GetById(ctx, templateId, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Push", result.Name)
+	assert.Len(t, result.ExerciseItems, 2)
+	assert.Len(t, result.ExerciseItems[0].Exercises, 1)
+	assert.Len(t, result.ExerciseItems[0].Exercises[0].Sets, 2)
+	assert.Len(t, result.ExerciseItems[1].Exercises, 1)
+	assert.Len(t, result.ExerciseItems[1].Exercises[0].Sets, 0)
+	repoMock.AssertExpectations(t)
+}
+
+func TestGetByIdNotFound(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(Template{}, testError).Once()
+
+	service := NewService(&repoMock, nil, nil, nil, nil)
+
+	_, err := service.GetById(ctx, templateId, userId)
+
+	assert.ErrorIs(t, err, testError)
+	repoMock.AssertExpectations(t)
+}
+
+// transactorStub runs fn on the repositories it holds and counts the transactions
+type transactorStub struct {
+	repos Repositories
+	runs  int
+}
+
+func (s *transactorStub) withTx(ctx context.Context, fn func(Repositories) error) error {
+	s.runs++
+	return fn(s.repos)
+}
+
+func TestCreateAndReturnId(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+
+	request := templateRequest{
+		Name: "Push",
+		ExerciseItems: []templateExerciseItemRequest{
+			{
+				Exercises: []templateExerciseRequest{
+					{
+						ExerciseTypeID: "type1",
+						Sets:           []templateSetRequest{{Repetitions: 5, Weight: 100}},
+					},
+				},
+			},
+		},
+	}
+
+	repoMock := repoMock{}
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateTemplateAndReturnIdParams) bool {
+		return input.Name == "Push" && input.UserID == userId
+	})).Return("templateId", nil).Once()
+	repoMock.On("CreateExerciseItemAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateTemplateExerciseItemAndReturnIdParams) bool {
+		return input.Type == exerciseitems.KindStraight && input.TemplateID == "templateId" && input.UserID == userId
+	})).Return("itemId", nil).Once()
+	repoMock.On("CreateExerciseAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateTemplateExerciseAndReturnIdParams) bool {
+		return input.Name == "Bench press" && input.ExerciseTypeID == "type1" && input.TemplateExerciseItemID == "itemId" && input.TemplateID == "templateId"
+	})).Return("exerciseId", nil).Once()
+	repoMock.On("CreateSetAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateTemplateSetAndReturnIdParams) bool {
+		return input.Repetitions == 5 && input.Weight == 100 && input.Type == sets.TypeWorking && input.TemplateExerciseID == "exerciseId" && input.TemplateID == "templateId"
+	})).Return("setId", nil).Once()
+
+	exerciseRepoMock := exerciseRepoMock{}
+	exerciseRepoMock.On("GetExerciseTypeById", ctx, mock.MatchedBy(func(input repository.GetExerciseTypeByIdParams) bool {
+		return input.ID == "type1" && input.UserID == userId
+	})).Return(&exercisetypes.ExerciseType{ID: "type1", Name: "Bench press", Measurement: measurement.WeightReps}, nil).Once()
+
+	transactor := transactorStub{repos: Repositories{Templates: &repoMock}}
+	service := NewService(&repoMock, &exerciseRepoMock, nil, nil, transactor.withTx)
+
+	id, err := service.CreateAndReturnId(ctx, request, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "templateId", id)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+	exerciseRepoMock.AssertExpectations(t)
+}
+
+func TestCreateAndReturnIdInvalidRequest(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(&repoMock{}, nil, nil, nil, nil)
+
+	_, err := service.CreateAndReturnId(ctx, templateRequest{}, "userId")
+	assert.NotNil(t, err)
+
+	_, err = service.CreateAndReturnId(ctx, templateRequest{
+		Name: "Push",
+		ExerciseItems: []templateExerciseItemRequest{
+			{Exercises: []templateExerciseRequest{{ExerciseTypeID: "type1", Sets: []templateSetRequest{{Repetitions: -1}}}}},
+		},
+	}, "userId")
+	assert.NotNil(t, err)
+
+	_, err = service.CreateAndReturnId(ctx, templateRequest{
+		Name: "Push",
+		ExerciseItems: []templateExerciseItemRequest{
+			{Exercises: []templateExerciseRequest{{ExerciseTypeID: "type1", Sets: []templateSetRequest{{Repetitions: 5, Type: "cooldown"}}}}},
+		},
+	}, "userId")
+	assert.NotNil(t, err)
+}
+
+func TestCreateAndReturnIdWithMeasuredSets(t *testing.T) {
+	userId := "userId"
+	ctx := context.Background()
+	duration := 1500
+	distance := 5000.0
+
+	request := templateRequest{
+		Name: "Run",
+		ExerciseItems: []templateExerciseItemRequest{
+			{Exercises: []templateExerciseRequest{
+				{ExerciseTypeID: "run", Sets: []templateSetRequest{{DurationSeconds: &duration, DistanceMeters: &distance}}},
+			}},
+		},
+	}
+
+	repoMock := repoMock{}
+	repoMock.On("CreateAndReturnId", ctx, mock.Anything).Return("templateId", nil).Once()
+	repoMock.On("CreateExerciseItemAndReturnId", ctx, mock.Anything).Return("itemId", nil).Once()
+	repoMock.On("CreateExerciseAndReturnId", ctx, mock.Anything).Return("exerciseId", nil).Once()
+	repoMock.On("CreateSetAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateTemplateSetAndReturnIdParams) bool {
+		return input.DurationSeconds == int64(1500) && input.DistanceMeters == 5000.0 && input.TemplateExerciseID == "exerciseId"
+	})).Return("setId", nil).Once()
+
+	exerciseRepoMock := exerciseRepoMock{}
+	exerciseRepoMock.On("GetExerciseTypeById", ctx, mock.Anything).Return(&exercisetypes.ExerciseType{ID: "run", Name: "Run", Measurement: measurement.DistanceDuration}, nil).Once()
+
+	transactor := transactorStub{repos: Repositories{Templates: &repoMock}}
+	service := NewService(&repoMock, &exerciseRepoMock, nil, nil, transactor.withTx)
+
+	_, err := service.CreateAndReturnId(ctx, request, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestCreateAndReturnIdMeasurementMismatch(t *testing.T) {
+	ctx := context.Background()
+	duration := 60
+
+	request := templateRequest{
+		Name: "Push",
+		ExerciseItems: []templateExerciseItemRequest{
+			{Exercises: []templateExerciseRequest{
+				{ExerciseTypeID: "type1", Sets: []templateSetRequest{{Repetitions: 5, Weight: 100, DurationSeconds: &duration}}},
+			}},
+		},
+	}
+
+	exerciseRepoMock := exerciseRepoMock{}
+	exerciseRepoMock.On("GetExerciseTypeById", ctx, mock.Anything).Return(&exercisetypes.ExerciseType{ID: "type1", Name: "Bench press", Measurement: measurement.WeightReps}, nil).Once()
+
+	transactor := transactorStub{}
+	service := NewService(&repoMock{}, &exerciseRepoMock, nil, nil, transactor.withTx)
+
+	_, err := service.CreateAndReturnId(ctx, request, "userId")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, transactor.runs)
+}
+
+func TestUpdateByIdNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(Template{}, testError).Once()
+
+	service := NewService(&repoMock, nil, nil, nil, nil)
+
+	err := service.UpdateById(ctx, "templateId", templateRequest{Name: "Push"}, "userId")
+
+	assert.ErrorIs(t, err, testError)
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateByIdUnknownExerciseTypeKeepsTemplate(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+	ctx := context.Background()
+
+	request := templateRequest{
+		Name: "Push",
+		ExerciseItems: []templateExerciseItemRequest{
+			{Exercises: []templateExerciseRequest{{ExerciseTypeID: "type1"}, {ExerciseTypeID: "foreign"}}},
+		},
+	}
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(Template{ID: templateId, Name: "Push"}, nil).Once()
+
+	exerciseRepoMock := exerciseRepoMock{}
+	exerciseRepoMock.On("GetExerciseTypeById", ctx, repository.GetExerciseTypeByIdParams{ID: "type1", UserID: userId}).Return(&exercisetypes.ExerciseType{ID: "type1", Name: "Bench press", Measurement: measurement.WeightReps}, nil).Once()
+	exerciseRepoMock.On("GetExerciseTypeById", ctx, repository.GetExerciseTypeByIdParams{ID: "foreign", UserID: userId}).Return(nil, sql.ErrNoRows).Once()
+
+	transactor := transactorStub{repos: Repositories{Templates: &repoMock}}
+	service := NewService(&repoMock, &exerciseRepoMock, nil, nil, transactor.withTx)
+
+	err := service.UpdateById(ctx, templateId, request, userId)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Equal(t, 0, transactor.runs)
+	repoMock.AssertNotCalled(t, "UpdateById", mock.Anything, mock.Anything)
+	repoMock.AssertNotCalled(t, "DeleteContentsByTemplateId", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateByIdReplacesContentsInOneTransaction(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+	ctx := context.Background()
+
+	request := templateRequest{
+		Name:          "Push",
+		ExerciseItems: []templateExerciseItemRequest{{Exercises: []templateExerciseRequest{{ExerciseTypeID: "type1"}}}},
+	}
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(Template{ID: templateId, Name: "Push"}, nil).Once()
+	repoMock.On("UpdateById", ctx, mock.MatchedBy(func(input repository.UpdateTemplateByIdParams) bool {
+		return input.ID == templateId && input.Name == "Push" && input.UserID == userId
+	})).Return(nil).Once()
+	repoMock.On("DeleteContentsByTemplateId", ctx, templateId, userId).Return(nil).Once()
+	repoMock.On("CreateExerciseItemAndReturnId", ctx, mock.Anything).Return("itemId", nil).Once()
+	repoMock.On("CreateExerciseAndReturnId", ctx, mock.Anything).Return("", testError).Once()
+
+	exerciseRepoMock := exerciseRepoMock{}
+	exerciseRepoMock.On("GetExerciseTypeById", ctx, mock.Anything).Return(&exercisetypes.ExerciseType{ID: "type1", Name: "Bench press", Measurement: measurement.WeightReps}, nil).Once()
+
+	transactor := transactorStub{repos: Repositories{Templates: &repoMock}}
+	service := NewService(&repoMock, &exerciseRepoMock, nil, nil, transactor.withTx)
+
+	err := service.UpdateById(ctx, templateId, request, userId)
+
+	// The error is returned from the transaction, so the deleted contents are rolled back
+	assert.ErrorIs(t, err, testError)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+}
+
+func TestDeleteById(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("IsUsedByProgram", ctx, templateId, userId).Return(false, nil).Once()
+	repoMock.On("DeleteContentsByTemplateId", ctx, templateId, userId).Return(nil).Once()
+	repoMock.On("DeleteById", ctx, repository.DeleteTemplateByIdParams{ID: templateId, UserID: userId}).Return(nil).Once()
+
+	transactor := transactorStub{repos: Repositories{Templates: &repoMock}}
+	service := NewService(&repoMock, nil, nil, nil, transactor.withTx)
+
+	err := service.DeleteById(ctx, templateId, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestDeleteByIdUsedByProgram(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("IsUsedByProgram", ctx, templateId, userId).Return(true, nil).Once()
+
+	transactor := transactorStub{repos: Repositories{Templates: &repoMock}}
+	service := NewService(&repoMock, nil, nil, nil, transactor.withTx)
+
+	err := service.DeleteById(ctx, templateId, userId)
+
+	assert.ErrorIs(t, err, ErrTemplateInUse)
+	repoMock.AssertExpectations(t)
+	repoMock.AssertNotCalled(t, "DeleteContentsByTemplateId", mock.Anything, mock.Anything, mock.Anything)
+	repoMock.AssertNotCalled(t, "DeleteById", mock.Anything, mock.Anything)
+}
+
+func TestStartWorkoutAndReturnId(t *testing.T) {
+	userId := "userId"
+	templateId := "templateId"
+	rounds := int64(4)
+	duration := int64(600)
+	distance := 2000.0
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(Template{ID: templateId, Name: "Push"}, nil).Once()
+	repoMock.On("GetExerciseItemsByTemplateId", ctx, templateId, userId).Return([]TemplateExerciseItem{
+		{ID: "item1", Type: exerciseitems.KindSuperset, Rounds: &rounds, Exercises: []TemplateExercise{}},
+	}, nil).Once()
+	repoMock.On("GetExercisesByTemplateId", ctx, templateId, userId).Return([]TemplateExercise{
+		{ID: "exercise1", Name: "Bench press", ExerciseTypeID: "type1", TemplateExerciseItemID: "item1", Sets: []TemplateSet{}},
+	}, nil).Once()
+	repoMock.On("GetSetsByTemplateId", ctx, templateId, userId).Return([]TemplateSet{
+		{ID: "set1", Repetitions: 5, Weight: 60, Type: sets.TypeWarmup, TemplateExerciseID: "exercise1"},
+		{ID: "set2", Repetitions: 3, Weight: 110, Type: sets.TypeWorking, TemplateExerciseID: "exercise1"},
+		{ID: "set3", DurationSeconds: &duration, DistanceMeters: &distance, Type: sets.TypeWorking, TemplateExerciseID: "exercise1"},
+	}, nil).Once()
+	repoMock.On("CreateWorkoutAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateWorkoutAndReturnIdParams) bool {
+		return input.Name == "Push" && input.UserID == userId
+	})).Return("workoutId", nil).Once()
+
+	exerciseItemsMock := exerciseItemsMock{}
+	exerciseItemsMock.On("CreateAndReturnId", ctx, exerciseitems.Settings{Type: exerciseitems.KindSuperset, Rounds: &rounds}, "workoutId", userId).Return("exerciseItemId", nil).Once()
+
+	exerciseRepoMock := exerciseRepoMock{}
+	exerciseRepoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateExerciseAndReturnIdParams) bool {
+		return input.WorkoutID == "workoutId" && input.ExerciseItemID == "exerciseItemId" && input.Name == "Bench press" && input.ExerciseTypeID == "type1"
+	})).Return("exerciseId", nil).Once()
+
+	setsRepoMock := setsRepoMock{}
+	setsRepoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		return input.ExerciseID == "exerciseId" && input.UserID == userId && input.Type == sets.TypeWarmup
+	})).Return("setId", nil).Once()
+	setsRepoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		return input.ExerciseID == "exerciseId" && input.UserID == userId && input.Type == sets.TypeWorking && input.Weight == 110 && input.DurationSeconds == nil
+	})).Return("setId", nil).Once()
+	setsRepoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateSetAndReturnIdParams) bool {
+		return input.ExerciseID == "exerciseId" && input.DurationSeconds == int64(600) && input.DistanceMeters == 2000.0
+	})).Return("setId", nil).Once()
+
+	transactor := transactorStub{repos: Repositories{Templates: &repoMock, Exercises: &exerciseRepoMock, ExerciseItems: &exerciseItemsMock, Sets: &setsRepoMock}}
+	service := NewService(&repoMock, &exerciseRepoMock, &exerciseItemsMock, &setsRepoMock, transactor.withTx)
+
+	id, err := service.StartWorkoutAndReturnId(ctx, templateId, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "workoutId", id)
+	assert.Equal(t, 1, transactor.runs)
+	repoMock.AssertExpectations(t)
+	exerciseItemsMock.AssertExpectations(t)
+	exerciseRepoMock.AssertExpectations(t)
+	setsRepoMock.AssertExpectations(t)
+}
+
+func TestCreateFromWorkoutAndReturnId(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetWorkoutNameById", ctx, workoutId, userId).Return("Legs", nil).Once()
+	repoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateTemplateAndReturnIdParams) bool {
+		return input.Name == "Legs" && input.UserID == userId
+	})).Return("templateId", nil).Once()
+	repoMock.On("CreateExerciseItemAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateTemplateExerciseItemAndReturnIdParams) bool {
+		return input.Type == exerciseitems.KindStraight && input.TemplateID == "templateId"
+	})).Return("itemId", nil).Once()
+	repoMock.On("CreateExerciseAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateTemplateExerciseAndReturnIdParams) bool {
+		return input.ExerciseTypeID == "type1" && input.TemplateExerciseItemID == "itemId"
+	})).Return("templateExerciseId", nil).Once()
+	repoMock.On("CreateSetAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateTemplateSetAndReturnIdParams) bool {
+		return input.Repetitions == 8 && input.Weight == 80 && input.TemplateExerciseID == "templateExerciseId"
+	})).Return("setId", nil).Once()
+
+	exerciseItemsMock := exerciseItemsMock{}
+	exerciseItemsMock.On("GetByWorkoutIdWithExercises", ctx, mock.MatchedBy(func(input repository.GetExerciseItemsByWorkoutIdParams) bool {
+		return input.WorkoutID == workoutId && input.UserID == userId
+	})).Return([]exerciseitems.ExerciseItemWithExercises{
+		{
+			ID:   "exerciseItemId",
+			Type: "exercise",
+			Exercises: []exercises.Exercise{
+				{ID: "exerciseId", Name: "Squat", ExerciseTypeID: "type1", WorkoutID: workoutId},
+			},
+		},
+	}, nil).Once()
+
+	setsRepoMock := setsRepoMock{}
+	setsRepoMock.On("GetByExerciseId", ctx, mock.MatchedBy(func(input repository.GetSetsByExerciseIdParams) bool {
+		return input.ExerciseID == "exerciseId" && input.UserID == userId
+	})).Return([]sets.Set{{ID: "setId", Repetitions: 8, Weight: 80, ExerciseID: "exerciseId"}}, nil).Once()
+
+	exerciseRepoMock := exerciseRepoMock{}
+	exerciseRepoMock.On("GetExerciseTypeById", ctx, mock.Anything).Return(&exercisetypes.ExerciseType{ID: "type1", Name: "Squat", Measurement: measurement.WeightReps}, nil).Once()
+
+	transactor := transactorStub{repos: Repositories{Templates: &repoMock}}
+	service := NewService(&repoMock, &exerciseRepoMock, &exerciseItemsMock, &setsRepoMock, transactor.withTx)
+
+	id, err := service.CreateFromWorkoutAndReturnId(ctx, workoutId, userId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "templateId", id)
+	repoMock.AssertExpectations(t)
+	exerciseItemsMock.AssertExpectations(t)
+	setsRepoMock.AssertExpectations(t)
+	exerciseRepoMock.AssertExpectations(t)
+}
+
+func TestCreateFromWorkoutAndReturnIdWorkoutNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetWorkoutNameById", ctx, "workoutId", "userId").Return("", testError).Once()
+
+	service := NewService(&repoMock, nil, nil, nil, nil)
+
+	_, err := service.CreateFromWorkoutAndReturnId(ctx, "workoutId", "userId")
+
+	assert.ErrorIs(t, err, testError)
+	repoMock.AssertExpectations(t)
+}
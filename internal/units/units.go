@@ -0,0 +1,37 @@
+package units
+
+import (
+	"math"
+	"slices"
+)
+
+// Weights are stored in kilograms and converted to the unit of the user at the edges
+const (
+	Kilograms = "kg"
+	Pounds    = "lb"
+)
+
+var WeightNames = []string{Kilograms, Pounds}
+
+const kilogramsPerPound = 0.45359237
+
+func ValidWeight(unit string) bool {
+	return slices.Contains(WeightNames, unit)
+}
+
+// ToKilograms converts a weight given in the unit to kilograms
+func ToKilograms(weight float64, unit string) float64 {
+	if unit == Pounds {
+		return weight * kilogramsPerPound
+	}
+	return weight
+}
+
+// FromKilograms converts a stored weight to the unit, rounded to two decimals
+// so a weight entered in either unit reads back as it was entered
+func FromKilograms(weight float64, unit string) float64 {
+	if unit == Pounds {
+		weight = weight / kilogramsPerPound
+	}
+	return math.Round(weight*100) / 100
+}
@@ -0,0 +1,30 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToKilograms(t *testing.T) {
+	assert.Equal(t, 100.0, ToKilograms(100, Kilograms))
+	assert.InDelta(t, 102.058, ToKilograms(225, Pounds), 0.001)
+}
+
+func TestFromKilograms(t *testing.T) {
+	assert.Equal(t, 100.0, FromKilograms(100, Kilograms))
+	assert.Equal(t, 220.46, FromKilograms(100, Pounds))
+	assert.Equal(t, 20.41, FromKilograms(ToKilograms(45, Pounds), Kilograms))
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, v := range []float64{2.5, 45, 135, 225, 315.5} {
+		assert.Equal(t, v, FromKilograms(ToKilograms(v, Pounds), Pounds))
+	}
+}
+
+func TestValidWeight(t *testing.T) {
+	assert.True(t, ValidWeight(Kilograms))
+	assert.True(t, ValidWeight(Pounds))
+	assert.False(t, ValidWeight("stone"))
+}
@@ -0,0 +1,49 @@
+package utils
+
+// NullableFloat reads a nullable REAL column, NULL becomes nil
+func NullableFloat(v interface{}) *float64 {
+	switch value := v.(type) {
+	case float64:
+		return &value
+	case int64:
+		result := float64(value)
+		return &result
+	}
+	return nil
+}
+
+// NullableInt reads a nullable INTEGER column, NULL becomes nil
+func NullableInt(v interface{}) *int64 {
+	switch value := v.(type) {
+	case int64:
+		return &value
+	case float64:
+		result := int64(value)
+		return &result
+	}
+	return nil
+}
+
+// FloatOrZero reads a nullable REAL column, NULL becomes 0
+func FloatOrZero(v interface{}) float64 {
+	if value := NullableFloat(v); value != nil {
+		return *value
+	}
+	return 0
+}
+
+// IntOrZero reads a nullable INTEGER column, NULL becomes 0
+func IntOrZero(v interface{}) int64 {
+	if value := NullableInt(v); value != nil {
+		return *value
+	}
+	return 0
+}
+
+// NullableString writes a nullable TEXT column, an empty string becomes NULL
+func NullableString(v string) interface{} {
+	if v == "" {
+		return nil
+	}
+	return v
+}
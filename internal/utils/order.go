@@ -0,0 +1,23 @@
+package utils
+
+import "fmt"
+
+// ValidateOrder checks that ordered contains every existing id exactly once,
+// so a reorder can never drop or duplicate a row
+func ValidateOrder(existing []string, ordered []string) error {
+	if len(existing) != len(ordered) {
+		return fmt.Errorf("expected %d ids, got %d", len(existing), len(ordered))
+	}
+
+	remaining := map[string]bool{}
+	for _, id := range existing {
+		remaining[id] = true
+	}
+	for _, id := range ordered {
+		if !remaining[id] {
+			return fmt.Errorf("unknown or duplicate id: %s", id)
+		}
+		delete(remaining, id)
+	}
+	return nil
+}
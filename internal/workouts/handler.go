@@ -1,6 +1,7 @@
 package workouts
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -8,26 +9,62 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"weight-tracker/internal/bodymetrics"
 	"weight-tracker/internal/database"
 	"weight-tracker/internal/exerciseitems"
 	"weight-tracker/internal/exercises"
+	"weight-tracker/internal/goals"
+	"weight-tracker/internal/preferences"
+	"weight-tracker/internal/programs"
+	"weight-tracker/internal/records"
+	"weight-tracker/internal/repository"
+	"weight-tracker/internal/templates"
+	"weight-tracker/internal/units"
 	"weight-tracker/internal/utils"
 )
 
+type templateCreator interface {
+	CreateFromWorkoutAndReturnId(ctx context.Context, workoutId string, userId string) (string, error)
+}
+
 type handler struct {
-	service Service
+	service   Service
+	templates templateCreator
+	units     unitPreferences
+}
+
+// Bodyweight is stored in kilograms, requests and responses use the unit of the user
+type unitPreferences interface {
+	GetWeightUnit(ctx context.Context, userId string) (string, error)
+}
+
+func convertWorkout(workout Workout, unit string) Workout {
+	if workout.Bodyweight != nil {
+		bodyweight := units.FromKilograms(*workout.Bodyweight, unit)
+		workout.Bodyweight = &bodyweight
+	}
+	return workout
 }
 
 func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper func(next http.Handler) http.Handler) {
 	handler := handler{
 		service: NewService(
-			&workoutsRepository{s.GetRepository()},
+			NewWorkoutsRepository(s.GetRepository()),
 			exercises.NewExerciseRepository(s.GetRepository()),
-			exerciseitems.NewService(
-				exerciseitems.NewExerciseItemRepository(s.GetRepository()),
-				exercises.NewExerciseRepository(s.GetRepository()),
-			),
+			exerciseitems.NewServiceFromDatabase(s),
+			func(ctx context.Context, fn func(Repositories) error) error {
+				return s.WithTx(ctx, func(q repository.Querier) error {
+					return fn(Repositories{
+						Workouts:      NewWorkoutsRepository(q),
+						Exercises:     exercises.NewExerciseRepository(q),
+						ExerciseItems: exerciseitems.NewServiceInTransaction(q),
+					})
+				})
+			},
+			NewCompletionListenersFromDatabase(s)...,
 		),
+		templates: templates.NewServiceFromDatabase(s),
+		units:     preferences.NewServiceFromDatabase(s),
 	}
 
 	mux.Handle("GET /workouts", authenticationWrapper(http.HandlerFunc(handler.getAllWorkoutsHandler)))
@@ -35,11 +72,26 @@ func AddEndpoints(mux *http.ServeMux, s database.Service, authenticationWrapper
 	mux.Handle("GET /workouts/{id}", authenticationWrapper(http.HandlerFunc(handler.getWorkoutByIdHandler)))
 	mux.Handle("PUT /workouts/{id}", authenticationWrapper(http.HandlerFunc(handler.updateWorkoutByIdHandler)))
 	mux.Handle("PUT /workouts/{id}/complete", authenticationWrapper(http.HandlerFunc(handler.completeWorkoutById)))
+	mux.Handle("PUT /workouts/{id}/start", authenticationWrapper(http.HandlerFunc(handler.startWorkoutById)))
+	mux.Handle("PUT /workouts/{id}/pause", authenticationWrapper(http.HandlerFunc(handler.pauseWorkoutById)))
+	mux.Handle("PUT /workouts/{id}/resume", authenticationWrapper(http.HandlerFunc(handler.resumeWorkoutById)))
 	mux.Handle("PUT /workouts/{id}/reopen", authenticationWrapper(http.HandlerFunc(handler.ReopenById)))
 	mux.Handle("POST /workouts/{id}/clone", authenticationWrapper(http.HandlerFunc(handler.cloneWorkoutById)))
 	mux.Handle("DELETE /workouts/{id}", authenticationWrapper(http.HandlerFunc(handler.deleteWorkoutByIdHandler)))
 }
 
+// NewCompletionListenersFromDatabase wires the listeners notified of completed
+// workouts from the database service
+func NewCompletionListenersFromDatabase(s database.Service) []CompletionListener {
+	return []CompletionListener{
+		programs.NewServiceFromDatabase(s),
+		// Bodyweight is linked before records are looked for, bodyweight exercises count it
+		bodymetrics.NewServiceFromDatabase(s),
+		records.NewServiceFromDatabase(s),
+		goals.NewServiceFromDatabase(s),
+	}
+}
+
 func (s *handler) ReopenById(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value("sub").(string)
 	id := r.PathValue("id")
@@ -67,6 +119,17 @@ func (s *handler) updateWorkoutByIdHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if t.Bodyweight != nil {
+		unit, err := s.units.GetWeightUnit(r.Context(), userId)
+		if err != nil {
+			slog.Warn("Failed to get weight unit", "error", err)
+			http.Error(w, "Failed to update workout", http.StatusBadRequest)
+			return
+		}
+		bodyweight := units.ToKilograms(*t.Bodyweight, unit)
+		t.Bodyweight = &bodyweight
+	}
+
 	slog.Debug("Updating workout", "id", id, "note", t.Note)
 
 	err = s.service.UpdateById(r.Context(), id, t, userId)
@@ -83,6 +146,13 @@ func (s *handler) updateWorkoutByIdHandler(w http.ResponseWriter, r *http.Reques
 func (s *handler) cloneWorkoutById(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value("sub").(string)
 	id := r.PathValue("id")
+
+	// A workout can be cloned into a reusable template instead of a new workout
+	if r.URL.Query().Get("target") == "template" {
+		s.cloneWorkoutToTemplate(w, r, id, userId)
+		return
+	}
+
 	newId, err := s.service.CloneByIdAndReturnId(r.Context(), id, userId)
 	if err != nil {
 		slog.Error("Failed to create workout", "error", err)
@@ -102,6 +172,26 @@ func (s *handler) cloneWorkoutById(w http.ResponseWriter, r *http.Request) {
 	utils.ReturnJson(w, jsonResp)
 }
 
+func (s *handler) cloneWorkoutToTemplate(w http.ResponseWriter, r *http.Request, workoutId string, userId string) {
+	templateId, err := s.templates.CreateFromWorkoutAndReturnId(r.Context(), workoutId, userId)
+	if err != nil {
+		slog.Error("Failed to create template from workout", "error", err, "workoutId", workoutId)
+		http.Error(w, "Failed to create template", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	jsonResp, err := utils.CreateIdResponse(templateId)
+	if err != nil {
+		slog.Error("Failed to marshal response", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	utils.ReturnJson(w, jsonResp)
+}
+
 func (s *handler) deleteWorkoutByIdHandler(w http.ResponseWriter, r *http.Request) {
 	userId := r.Context().Value("sub").(string)
 	id := r.PathValue("id")
@@ -150,6 +240,16 @@ func (s *handler) getAllWorkoutsHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+	for i, v := range workouts {
+		workouts[i] = convertWorkout(v, unit)
+	}
+
 	slog.Debug(fmt.Sprintf("returning %d workouts", len(workouts)))
 
 	jsonResp, err := utils.CreatePaginatedResponse(workouts, page, pageSize, count)
@@ -180,7 +280,14 @@ func (s *handler) getWorkoutByIdHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	jsonResp, err := utils.CreateResponse(workout)
+	unit, err := s.units.GetWeightUnit(r.Context(), userId)
+	if err != nil {
+		slog.Warn("Failed to get weight unit", "error", err)
+		http.Error(w, "", http.StatusBadRequest)
+		return
+	}
+
+	jsonResp, err := utils.CreateResponse(convertWorkout(workout, unit))
 
 	if err != nil {
 		slog.Error("Failed to marshal response", "error", err)
@@ -237,10 +344,44 @@ func (s *handler) completeWorkoutById(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 }
 
+func (s *handler) startWorkoutById(w http.ResponseWriter, r *http.Request) {
+	s.changeWorkoutClock(w, r, "start", s.service.StartById)
+}
+
+func (s *handler) pauseWorkoutById(w http.ResponseWriter, r *http.Request) {
+	s.changeWorkoutClock(w, r, "pause", s.service.PauseById)
+}
+
+func (s *handler) resumeWorkoutById(w http.ResponseWriter, r *http.Request) {
+	s.changeWorkoutClock(w, r, "resume", s.service.ResumeById)
+}
+
+// changeWorkoutClock starts, pauses or resumes the workout of the path
+func (s *handler) changeWorkoutClock(w http.ResponseWriter, r *http.Request, action string, change func(ctx context.Context, workoutId string, userId string) error) {
+	userId := r.Context().Value("sub").(string)
+	workoutId := r.PathValue("id")
+
+	err := change(r.Context(), workoutId, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+
+		slog.Warn("Failed to "+action+" workout", "error", err, "workoutId", workoutId)
+		http.Error(w, "Failed to "+action+" workout", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNoContent)
+}
+
 type createWorkoutRequest struct {
 	Name string `json:"name"`
 }
 
 type updateWorkoutRequest struct {
-	Note string `json:"note"`
+	Note       string   `json:"note"`
+	Bodyweight *float64 `json:"bodyweight"`
 }
@@ -5,9 +5,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"weight-tracker/internal/units"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -18,6 +21,14 @@ func populateContextWithSub(req *http.Request, userId string) *http.Request {
 	return req.WithContext(ctx)
 }
 
+type unitsStub struct {
+	unit string
+}
+
+func (u unitsStub) GetWeightUnit(ctx context.Context, userId string) (string, error) {
+	return u.unit, nil
+}
+
 type serviceMock struct {
 	mock.Mock
 }
@@ -56,6 +67,21 @@ func (s *serviceMock) CloneByIdAndReturnId(context context.Context, workoutId st
 	args := s.Called(context, workoutId, userId)
 	return args.String(0), args.Error(1)
 }
+func (s *serviceMock) StartById(context context.Context, workoutId string, userId string) error {
+	args := s.Called(context, workoutId, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) PauseById(context context.Context, workoutId string, userId string) error {
+	args := s.Called(context, workoutId, userId)
+	return args.Error(0)
+}
+
+func (s *serviceMock) ResumeById(context context.Context, workoutId string, userId string) error {
+	args := s.Called(context, workoutId, userId)
+	return args.Error(0)
+}
+
 func (s *serviceMock) UpdateById(context context.Context, workoutId string, t updateWorkoutRequest, userId string) error {
 	args := s.Called(context, workoutId, t, userId)
 	return args.Error(0)
@@ -83,7 +109,7 @@ func TestGetAllWorkoutsHandler(t *testing.T) {
 	serviceMock.On("GetAllCount", req.Context(), userId).Return(1, nil).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getAllWorkoutsHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
@@ -115,7 +141,7 @@ func TestGetAllWorkoutsHandlerNoFound(t *testing.T) {
 		Return([]Workout{}, sql.ErrNoRows).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getAllWorkoutsHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusNotFound {
@@ -141,7 +167,7 @@ func TestGetAllWorkoutsHandlerGetAllErr(t *testing.T) {
 		Return([]Workout{}, testError).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getAllWorkoutsHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusBadRequest {
@@ -173,7 +199,7 @@ func TestGetAllWorkoutsHandlerGetAllCountErr(t *testing.T) {
 	serviceMock.On("GetAllCount", req.Context(), userId).Return(0, testError).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getAllWorkoutsHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusBadRequest {
@@ -204,7 +230,7 @@ func TestGetWorkoutByIdHandler(t *testing.T) {
 		}, nil).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getWorkoutByIdHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
@@ -214,6 +240,34 @@ func TestGetWorkoutByIdHandler(t *testing.T) {
 	serviceMock.AssertExpectations(t)
 }
 
+func TestGetWorkoutByIdHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+
+	req, err := http.NewRequest("GET", "/workouts/"+workoutId, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", workoutId)
+	req = populateContextWithSub(req, userId)
+
+	bodyweight := 90.718474
+	serviceMock := serviceMock{}
+	serviceMock.On("GetById", req.Context(), workoutId, userId).
+		Return(Workout{ID: workoutId, Name: "workoutName", Bodyweight: &bodyweight}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(s.getWorkoutByIdHandler).ServeHTTP(rr, req)
+
+	expected := `{"data":{"id":"workoutId","name":"workoutName","completed_on":null,"created_on":"","updated_on":"","note":"","bodyweight":200}}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got '%v' want '%v'", rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
 func TestGetWorkoutByIdHandlerNotFound(t *testing.T) {
 	userId := "userId"
 	workoutId := "workoutId"
@@ -232,7 +286,7 @@ func TestGetWorkoutByIdHandlerNotFound(t *testing.T) {
 		Return(Workout{}, sql.ErrNoRows).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getWorkoutByIdHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusNotFound {
@@ -260,7 +314,7 @@ func TestGetWorkoutByIdHandlerServiceErr(t *testing.T) {
 		Return(Workout{}, testError).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.getWorkoutByIdHandler)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusBadRequest {
@@ -297,7 +351,7 @@ func TestCreateWorkoutHandler(t *testing.T) {
 	}), userId).Return("id", nil).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.createWorkoutHandler)
 	handler.ServeHTTP(rr, req)
 
@@ -339,7 +393,7 @@ func TestCreateWorkoutHandlerServiceErr(t *testing.T) {
 	serviceMock.On("CreateAndReturnId", req.Context(), mock.Anything, userId).Return("", testError).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.createWorkoutHandler)
 	handler.ServeHTTP(rr, req)
 
@@ -367,7 +421,7 @@ func TestCompleteWorkoutByIdHandler(t *testing.T) {
 	serviceMock.On("CompleteById", req.Context(), workoutId, userId).Return(nil).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.completeWorkoutById)
 	handler.ServeHTTP(rr, req)
 
@@ -395,7 +449,7 @@ func TestCompleteWorkoutByIdHandlerServiceErr(t *testing.T) {
 	serviceMock.On("CompleteById", req.Context(), workoutId, userId).Return(testError).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.completeWorkoutById)
 	handler.ServeHTTP(rr, req)
 
@@ -435,7 +489,7 @@ func TestUpdateByIdHandler(t *testing.T) {
 	}), userId).Return(nil).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.updateWorkoutByIdHandler)
 	handler.ServeHTTP(rr, req)
 
@@ -446,6 +500,33 @@ func TestUpdateByIdHandler(t *testing.T) {
 	serviceMock.AssertExpectations(t)
 }
 
+func TestUpdateByIdHandlerConvertsPounds(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+
+	req, err := http.NewRequest("PUT", "/workouts/"+workoutId, bytes.NewBufferString(`{"note":"","bodyweight":200}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", workoutId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("UpdateById", req.Context(), workoutId, mock.MatchedBy(func(input updateWorkoutRequest) bool {
+		return units.FromKilograms(*input.Bodyweight, units.Kilograms) == 90.72
+	}), userId).Return(nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Pounds}}
+	http.HandlerFunc(s.updateWorkoutByIdHandler).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
 var invalidJsonBytes = []byte("{invalidJson}")
 
 func TestWorkoutByIdHandlerJsonErr(t *testing.T) {
@@ -464,7 +545,7 @@ func TestWorkoutByIdHandlerJsonErr(t *testing.T) {
 	serviceMock := serviceMock{}
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.updateWorkoutByIdHandler)
 	handler.ServeHTTP(rr, req)
 
@@ -500,7 +581,7 @@ func TestWorkoutByIdHandlerServiceErr(t *testing.T) {
 	serviceMock.On("UpdateById", req.Context(), workoutId, mock.Anything, userId).Return(testError).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.updateWorkoutByIdHandler)
 	handler.ServeHTTP(rr, req)
 
@@ -528,7 +609,7 @@ func TestDeleteWorkoutByIdHandler(t *testing.T) {
 	serviceMock.On("DeleteById", req.Context(), workoutId, userId).Return(nil).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.deleteWorkoutByIdHandler)
 	handler.ServeHTTP(rr, req)
 
@@ -556,7 +637,7 @@ func TestDeleteWorkoutByIdHandlerServiceErr(t *testing.T) {
 	serviceMock.On("DeleteById", req.Context(), workoutId, userId).Return(testError).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.deleteWorkoutByIdHandler)
 	handler.ServeHTTP(rr, req)
 
@@ -584,7 +665,7 @@ func TestCloneWorkoutByIdHandler(t *testing.T) {
 	serviceMock.On("CloneByIdAndReturnId", req.Context(), workoutId, userId).Return("newWorkoutId", nil).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
 	handler := http.HandlerFunc(s.cloneWorkoutById)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusCreated {
@@ -617,7 +698,79 @@ func TestCloneWorkoutByIdHandlerServiceErr(t *testing.T) {
 	serviceMock.On("CloneByIdAndReturnId", req.Context(), workoutId, userId).Return("", testError).Once()
 
 	rr := httptest.NewRecorder()
-	s := handler{service: &serviceMock}
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	handler := http.HandlerFunc(s.cloneWorkoutById)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+
+	serviceMock.AssertExpectations(t)
+}
+
+type templateCreatorMock struct {
+	mock.Mock
+}
+
+func (s *templateCreatorMock) CreateFromWorkoutAndReturnId(ctx context.Context, workoutId string, userId string) (string, error) {
+	args := s.Called(ctx, workoutId, userId)
+	return args.String(0), args.Error(1)
+}
+
+func TestCloneWorkoutByIdHandlerTargetTemplate(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+
+	req, err := http.NewRequest("POST", "/workouts/"+workoutId+"/clone?target=template", nil)
+	req.SetPathValue("id", workoutId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceMock := serviceMock{}
+	templateCreatorMock := templateCreatorMock{}
+	templateCreatorMock.On("CreateFromWorkoutAndReturnId", req.Context(), workoutId, userId).Return("templateId", nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, templates: &templateCreatorMock}
+	handler := http.HandlerFunc(s.cloneWorkoutById)
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	expected := `{"id":"templateId"}`
+	if rr.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+
+	serviceMock.AssertExpectations(t)
+	templateCreatorMock.AssertExpectations(t)
+}
+
+func TestCloneWorkoutByIdHandlerTargetTemplateErr(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+
+	req, err := http.NewRequest("POST", "/workouts/"+workoutId+"/clone?target=template", nil)
+	req.SetPathValue("id", workoutId)
+
+	req = populateContextWithSub(req, userId)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	templateCreatorMock := templateCreatorMock{}
+	templateCreatorMock.On("CreateFromWorkoutAndReturnId", req.Context(), workoutId, userId).Return("", testError).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock{}, templates: &templateCreatorMock}
 	handler := http.HandlerFunc(s.cloneWorkoutById)
 	handler.ServeHTTP(rr, req)
 
@@ -625,5 +778,77 @@ func TestCloneWorkoutByIdHandlerServiceErr(t *testing.T) {
 		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
 	}
 
+	templateCreatorMock.AssertExpectations(t)
+}
+
+func TestStartWorkoutByIdHandler(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+
+	req, err := http.NewRequest("PUT", "/workouts/"+workoutId+"/start", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", workoutId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("StartById", req.Context(), workoutId, userId).Return(nil).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(s.startWorkoutById).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNoContent)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestPauseWorkoutByIdHandlerNotFound(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+
+	req, err := http.NewRequest("PUT", "/workouts/"+workoutId+"/pause", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", workoutId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("PauseById", req.Context(), workoutId, userId).Return(fmt.Errorf("failed to get workout by id: %w", sql.ErrNoRows)).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(s.pauseWorkoutById).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+	serviceMock.AssertExpectations(t)
+}
+
+func TestResumeWorkoutByIdHandlerNotPaused(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+
+	req, err := http.NewRequest("PUT", "/workouts/"+workoutId+"/resume", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetPathValue("id", workoutId)
+	req = populateContextWithSub(req, userId)
+
+	serviceMock := serviceMock{}
+	serviceMock.On("ResumeById", req.Context(), workoutId, userId).Return(errors.New("workout is not paused")).Once()
+
+	rr := httptest.NewRecorder()
+	s := handler{service: &serviceMock, units: unitsStub{units.Kilograms}}
+	http.HandlerFunc(s.resumeWorkoutById).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
 	serviceMock.AssertExpectations(t)
 }
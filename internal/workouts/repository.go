@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"weight-tracker/internal/repository"
+	"weight-tracker/internal/utils"
 )
 
 type Workout struct {
@@ -14,6 +15,15 @@ type Workout struct {
 	CreatedOn   string `json:"created_on"`
 	UpdatedOn   string `json:"updated_on"`
 	Note        string `json:"note"`
+	// Bodyweight in kilograms at the time of the workout, counted for bodyweight exercises
+	Bodyweight *float64 `json:"bodyweight,omitempty"`
+	// Started workouts are running while resumed on is set and paused otherwise. The
+	// active duration is the active seconds plus the time since it was last resumed.
+	StartedOn     *string `json:"started_on,omitempty"`
+	ResumedOn     *string `json:"resumed_on,omitempty"`
+	ActiveSeconds int64   `json:"active_seconds,omitempty"`
+	// Average heart rate in beats per minute, known for workouts imported from an activity file
+	AverageHeartRate *int64 `json:"average_heart_rate,omitempty"`
 }
 
 type WorkoutsRepository interface {
@@ -25,6 +35,14 @@ type WorkoutsRepository interface {
 	DeleteById(ctx context.Context, arg repository.DeleteWorkoutByIdParams) error
 	UpdateById(context context.Context, arg repository.UpdateWorkoutByIdParams) error
 	ReopenWorkoutById(ctx context.Context, arg repository.ReopenWorkoutByIdParams) error
+	UpdateBodyweight(ctx context.Context, arg repository.UpdateWorkoutBodyweightParams) error
+	StartById(ctx context.Context, arg repository.StartWorkoutByIdParams) (int64, error)
+	PauseById(ctx context.Context, arg repository.PauseWorkoutByIdParams) (int64, error)
+	ResumeById(ctx context.Context, arg repository.ResumeWorkoutByIdParams) (int64, error)
+}
+
+func NewWorkoutsRepository(repo repository.Querier) WorkoutsRepository {
+	return &workoutsRepository{repo: repo}
 }
 
 type workoutsRepository struct {
@@ -52,6 +70,18 @@ func (w *workoutsRepository) UpdateById(ctx context.Context, arg repository.Upda
 	return nil
 }
 
+func (w *workoutsRepository) UpdateBodyweight(ctx context.Context, arg repository.UpdateWorkoutBodyweightParams) error {
+	rows, err := w.repo.UpdateWorkoutBodyweight(ctx, arg)
+	if err != nil {
+		return fmt.Errorf("failed to update workout bodyweight: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("workout not found")
+	}
+	return nil
+}
+
 func (w *workoutsRepository) DeleteById(ctx context.Context, arg repository.DeleteWorkoutByIdParams) error {
 	rows, err := w.repo.DeleteWorkoutById(ctx, arg)
 	if err != nil {
@@ -68,6 +98,30 @@ func (w *workoutsRepository) CompleteById(ctx context.Context, arg repository.Co
 	return w.repo.CompleteWorkoutById(ctx, arg)
 }
 
+func (w *workoutsRepository) StartById(ctx context.Context, arg repository.StartWorkoutByIdParams) (int64, error) {
+	rows, err := w.repo.StartWorkoutById(ctx, arg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start workout: %w", err)
+	}
+	return rows, nil
+}
+
+func (w *workoutsRepository) PauseById(ctx context.Context, arg repository.PauseWorkoutByIdParams) (int64, error) {
+	rows, err := w.repo.PauseWorkoutById(ctx, arg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to pause workout: %w", err)
+	}
+	return rows, nil
+}
+
+func (w *workoutsRepository) ResumeById(ctx context.Context, arg repository.ResumeWorkoutByIdParams) (int64, error) {
+	rows, err := w.repo.ResumeWorkoutById(ctx, arg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resume workout: %w", err)
+	}
+	return rows, nil
+}
+
 func (w *workoutsRepository) ReopenWorkoutById(ctx context.Context, arg repository.ReopenWorkoutByIdParams) error {
 	rows, err := w.repo.ReopenWorkoutById(ctx, arg)
 	if err != nil {
@@ -105,11 +159,21 @@ func newWorkout(v repository.Workout) Workout {
 		CompletedOn: v.CompletedOn,
 		CreatedOn:   v.CreatedOn,
 		UpdatedOn:   v.UpdatedOn,
+		Bodyweight:  utils.NullableFloat(v.Bodyweight),
+
+		ActiveSeconds:    v.ActiveSeconds,
+		AverageHeartRate: utils.NullableInt(v.AverageHeartRate),
 	}
 
 	if v.Note != nil {
 		workout.Note = v.Note.(string)
 	}
+	if startedOn, ok := v.StartedOn.(string); ok {
+		workout.StartedOn = &startedOn
+	}
+	if resumedOn, ok := v.ResumedOn.(string); ok {
+		workout.ResumedOn = &resumedOn
+	}
 
 	return workout
 }
@@ -3,6 +3,7 @@ package workouts
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sort"
 	"time"
 	"weight-tracker/internal/exerciseitems"
@@ -22,12 +23,32 @@ type Service interface {
 	CloneByIdAndReturnId(context context.Context, workoutId string, userId string) (string, error)
 	UpdateById(context context.Context, workoutId string, t updateWorkoutRequest, userId string) error
 	ReopenById(context context.Context, workoutId string, userId string) error
+	StartById(context context.Context, workoutId string, userId string) error
+	PauseById(context context.Context, workoutId string, userId string) error
+	ResumeById(context context.Context, workoutId string, userId string) error
 }
 
+// CompletionListener is notified after a workout has been marked as completed
+type CompletionListener interface {
+	OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error
+}
+
+// Repositories are the repositories a workout is written with
+type Repositories struct {
+	Workouts      WorkoutsRepository
+	Exercises     exercises.ExerciseRepository
+	ExerciseItems exerciseitems.Service
+}
+
+// Transactor runs fn with repositories whose queries share one transaction
+type Transactor func(ctx context.Context, fn func(Repositories) error) error
+
 type workoutsService struct {
 	repo            WorkoutsRepository
 	exerciseRepo    exercises.ExerciseRepository
 	exerciseItemSvc exerciseitems.Service
+	withTx          Transactor
+	listeners       []CompletionListener
 }
 
 func (w *workoutsService) ReopenById(context context.Context, workoutId string, userId string) error {
@@ -48,7 +69,73 @@ func (w *workoutsService) ReopenById(context context.Context, workoutId string,
 	return nil
 }
 
+// StartById starts the clock of a workout that was not started or completed yet
+func (w *workoutsService) StartById(context context.Context, workoutId string, userId string) error {
+	_, err := w.GetById(context, workoutId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to get workout by id: %w", err)
+	}
+
+	rows, err := w.repo.StartById(context, repository.StartWorkoutByIdParams{
+		StartedOn: time.Now().UTC().Format(time.RFC3339),
+		ID:        workoutId,
+		UserID:    userId,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("workout is already started or completed")
+	}
+	return nil
+}
+
+// PauseById adds the time since the workout was last resumed to its active duration
+func (w *workoutsService) PauseById(context context.Context, workoutId string, userId string) error {
+	_, err := w.GetById(context, workoutId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to get workout by id: %w", err)
+	}
+
+	rows, err := w.repo.PauseById(context, repository.PauseWorkoutByIdParams{
+		PausedOn: time.Now().UTC().Format(time.RFC3339),
+		ID:       workoutId,
+		UserID:   userId,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("workout is not running")
+	}
+	return nil
+}
+
+func (w *workoutsService) ResumeById(context context.Context, workoutId string, userId string) error {
+	_, err := w.GetById(context, workoutId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to get workout by id: %w", err)
+	}
+
+	rows, err := w.repo.ResumeById(context, repository.ResumeWorkoutByIdParams{
+		ResumedOn: time.Now().UTC().Format(time.RFC3339),
+		ID:        workoutId,
+		UserID:    userId,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("workout is not paused")
+	}
+	return nil
+}
+
 func (w *workoutsService) UpdateById(context context.Context, workoutId string, t updateWorkoutRequest, userId string) error {
+	if t.Bodyweight != nil && *t.Bodyweight <= 0 {
+		return fmt.Errorf("bodyweight must be positive")
+	}
+
 	_, err := w.GetById(context, workoutId, userId)
 	if err != nil {
 		return fmt.Errorf("failed to get workout by id: %w", err)
@@ -66,6 +153,19 @@ func (w *workoutsService) UpdateById(context context.Context, workoutId string,
 		return fmt.Errorf("failed to update workout: %w", err)
 	}
 
+	// Bodyweight is filled in when the workout is completed, it is only replaced when given
+	if t.Bodyweight != nil {
+		err = w.repo.UpdateBodyweight(context, repository.UpdateWorkoutBodyweightParams{
+			Bodyweight: *t.Bodyweight,
+			UpdatedOn:  time.Now().UTC().Format(time.RFC3339),
+			ID:         workoutId,
+			UserID:     userId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update workout bodyweight: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -75,53 +175,61 @@ func (w *workoutsService) CloneByIdAndReturnId(context context.Context, workoutI
 		return "", fmt.Errorf("failed to get workout by id: %w", err)
 	}
 
-	cloneId, err := w.CreateAndReturnId(context, createWorkoutRequest{
-		Name: workout.Name,
-	}, userId)
-
-	if err != nil {
-		return "", fmt.Errorf("failed to create cloned workout: %w", err)
-	}
-
-	// Get exercise items from the original workout (with their type and associated exercises)
-	exerciseItems, err := w.exerciseItemSvc.GetByWorkoutIdWithExercises(context, repository.GetExerciseItemsByWorkoutIdParams{
-		WorkoutID: workoutId,
-		UserID:    userId,
-	})
-	if err != nil {
-		return "", fmt.Errorf("failed to get exercise items: %w", err)
-	}
+	// The clone is created in one transaction, a failure halfway leaves no partial workout
+	var cloneId string
+	err = w.withTx(context, func(repos Repositories) error {
+		var err error
+		cloneId, err = createAndReturnId(context, repos.Workouts, createWorkoutRequest{
+			Name: workout.Name,
+		}, userId)
+		if err != nil {
+			return fmt.Errorf("failed to create cloned workout: %w", err)
+		}
 
-	// Clone each exercise item with its type and exercises
-	for _, item := range exerciseItems {
-		// Create the cloned exercise item with the same type
-		newItemId, err := w.exerciseItemSvc.CreateAndReturnId(context, item.Type, cloneId, userId)
+		// Get exercise items from the original workout (with their type and associated exercises)
+		exerciseItems, err := repos.ExerciseItems.GetByWorkoutIdWithExercises(context, repository.GetExerciseItemsByWorkoutIdParams{
+			WorkoutID: workoutId,
+			UserID:    userId,
+		})
 		if err != nil {
-			return "", fmt.Errorf("failed to create cloned exercise item: %w", err)
+			return fmt.Errorf("failed to get exercise items: %w", err)
 		}
 
-		// Clone all exercises in this item
-		for _, exercise := range item.Exercises {
-			exerciseUuid, err := uuid.NewV7()
+		// Clone each exercise item with its type and exercises
+		for _, item := range exerciseItems {
+			// Grouped items keep their rounds, interval and time cap so the clone is run the same way
+			newItemId, err := repos.ExerciseItems.CreateAndReturnId(context, item.Settings(), cloneId, userId)
 			if err != nil {
-				return "", fmt.Errorf("failed to generate UUID for exercise: %w", err)
+				return fmt.Errorf("failed to create cloned exercise item: %w", err)
 			}
 
-			_, err = w.exerciseRepo.CreateAndReturnId(context, repository.CreateExerciseAndReturnIdParams{
-				ID:             exerciseUuid.String(),
-				WorkoutID:      cloneId,
-				ExerciseItemID: newItemId,
-				Name:           exercise.Name,
-				ExerciseTypeID: exercise.ExerciseTypeID,
-				CreatedOn:      time.Now().UTC().Format(time.RFC3339),
-				UserID:         userId,
-				UpdatedOn:      time.Now().UTC().Format(time.RFC3339),
-			})
-
-			if err != nil {
-				return "", fmt.Errorf("failed to create cloned exercise: %w", err)
+			// Clone all exercises in this item
+			for _, exercise := range item.Exercises {
+				exerciseUuid, err := uuid.NewV7()
+				if err != nil {
+					return fmt.Errorf("failed to generate UUID for exercise: %w", err)
+				}
+
+				_, err = repos.Exercises.CreateAndReturnId(context, repository.CreateExerciseAndReturnIdParams{
+					ID:             exerciseUuid.String(),
+					WorkoutID:      cloneId,
+					ExerciseItemID: newItemId,
+					Name:           exercise.Name,
+					ExerciseTypeID: exercise.ExerciseTypeID,
+					CreatedOn:      time.Now().UTC().Format(time.RFC3339),
+					UserID:         userId,
+					UpdatedOn:      time.Now().UTC().Format(time.RFC3339),
+				})
+
+				if err != nil {
+					return fmt.Errorf("failed to create cloned exercise: %w", err)
+				}
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
 
 	return cloneId, nil
@@ -142,15 +250,32 @@ func (w *workoutsService) CompleteById(context context.Context, workoutId string
 		UserID:      userId,
 	}
 
-	_, err := w.repo.CompleteById(context, completeParams)
+	rows, err := w.repo.CompleteById(context, completeParams)
 	if err != nil {
 		return fmt.Errorf("failed to complete workout: %w", err)
 	}
 
+	// The workout does not exist or was already completed, the listeners ran then
+	if rows == 0 {
+		return nil
+	}
+
+	// The workout is already completed, a failing listener should not undo that
+	for _, listener := range w.listeners {
+		err = listener.OnWorkoutCompleted(context, workoutId, userId)
+		if err != nil {
+			slog.Error("Completion listener failed", "error", err, "workoutId", workoutId)
+		}
+	}
+
 	return nil
 }
 
 func (w *workoutsService) CreateAndReturnId(context context.Context, t createWorkoutRequest, userId string) (string, error) {
+	return createAndReturnId(context, w.repo, t, userId)
+}
+
+func createAndReturnId(context context.Context, repo WorkoutsRepository, t createWorkoutRequest, userId string) (string, error) {
 	uuid, err := uuid.NewV7()
 	if err != nil {
 		return "", fmt.Errorf("failed to generate UUID: %w", err)
@@ -164,7 +289,7 @@ func (w *workoutsService) CreateAndReturnId(context context.Context, t createWor
 		UserID:    userId,
 	}
 
-	id, err := w.repo.CreateAndReturnId(context, workout)
+	id, err := repo.CreateAndReturnId(context, workout)
 
 	if err != nil {
 		return "", fmt.Errorf("failed to create workout: %w", err)
@@ -229,6 +354,6 @@ func (w *workoutsService) GetById(context context.Context, id string, userId str
 	return workout, err
 }
 
-func NewService(repo WorkoutsRepository, exerciseRepo exercises.ExerciseRepository, exerciseItemSvc exerciseitems.Service) Service {
-	return &workoutsService{repo, exerciseRepo, exerciseItemSvc}
+func NewService(repo WorkoutsRepository, exerciseRepo exercises.ExerciseRepository, exerciseItemSvc exerciseitems.Service, withTx Transactor, listeners ...CompletionListener) Service {
+	return &workoutsService{repo, exerciseRepo, exerciseItemSvc, withTx, listeners}
 }
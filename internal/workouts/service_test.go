@@ -2,6 +2,7 @@ package workouts
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -32,6 +33,21 @@ func (r *exerciseRepoMock) GetByExerciseItemId(context context.Context, exercise
 	args := r.Called(context, exerciseItemId, userId)
 	return args.Get(0).([]exercises.Exercise), args.Error(1)
 }
+
+func (r *exerciseRepoMock) GetById(context context.Context, arg repository.GetExerciseByIdParams) (exercises.Exercise, error) {
+	args := r.Called(context, arg)
+	return args.Get(0).(exercises.Exercise), args.Error(1)
+}
+
+func (r *exerciseRepoMock) UpdateById(context context.Context, arg repository.UpdateExerciseParams) error {
+	args := r.Called(context, arg)
+	return args.Error(0)
+}
+
+func (r *exerciseRepoMock) UpdatePosition(context context.Context, arg repository.UpdateExercisePositionParams) error {
+	args := r.Called(context, arg)
+	return args.Error(0)
+}
 func (r *exerciseRepoMock) DeleteById(context context.Context, arg repository.DeleteExerciseByIdParams) error {
 	args := r.Called(context, arg)
 	return args.Error(0)
@@ -69,14 +85,19 @@ func (r *exerciseItemsMock) GetByWorkoutIdWithExercises(ctx context.Context, arg
 	return args.Get(0).([]exerciseitems.ExerciseItemWithExercises), args.Error(1)
 }
 
-func (r *exerciseItemsMock) CreateAndReturnId(ctx context.Context, itemType string, workoutId string, userId string) (string, error) {
-	args := r.Called(ctx, itemType, workoutId, userId)
+func (r *exerciseItemsMock) CreateAndReturnId(ctx context.Context, settings exerciseitems.Settings, workoutId string, userId string) (string, error) {
+	args := r.Called(ctx, settings, workoutId, userId)
 	return args.String(0), args.Error(1)
 }
 
-func (r *exerciseItemsMock) UpdateType(ctx context.Context, arg repository.UpdateExerciseItemTypeParams) (int64, error) {
-	args := r.Called(ctx, arg)
-	return args.Get(0).(int64), args.Error(1)
+func (r *exerciseItemsMock) UpdateById(ctx context.Context, exerciseItemId string, settings exerciseitems.Settings, userId string) error {
+	args := r.Called(ctx, exerciseItemId, settings, userId)
+	return args.Error(0)
+}
+
+func (r *exerciseItemsMock) Reorder(ctx context.Context, workoutId string, ids []string, userId string) error {
+	args := r.Called(ctx, workoutId, ids, userId)
+	return args.Error(0)
 }
 
 func (r *exerciseItemsMock) DeleteById(ctx context.Context, arg repository.DeleteExerciseItemByIdParams) (int64, error) {
@@ -98,6 +119,11 @@ func (r *repoMock) UpdateById(ctx context.Context, arg repository.UpdateWorkoutB
 	return args.Error(0)
 }
 
+func (r *repoMock) UpdateBodyweight(ctx context.Context, arg repository.UpdateWorkoutBodyweightParams) error {
+	args := r.Called(ctx, arg)
+	return args.Error(0)
+}
+
 func (r *repoMock) CompleteById(ctx context.Context, arg repository.CompleteWorkoutByIdParams) (int64, error) {
 	args := r.Called(ctx, arg)
 	return args.Get(0).(int64), args.Error(1)
@@ -128,6 +154,21 @@ func (r *repoMock) DeleteById(ctx context.Context, arg repository.DeleteWorkoutB
 	return args.Error(0)
 }
 
+func (r *repoMock) StartById(ctx context.Context, arg repository.StartWorkoutByIdParams) (int64, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (r *repoMock) PauseById(ctx context.Context, arg repository.PauseWorkoutByIdParams) (int64, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (r *repoMock) ResumeById(ctx context.Context, arg repository.ResumeWorkoutByIdParams) (int64, error) {
+	args := r.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func TestGetAll(t *testing.T) {
 	userId := "userid"
 	expected := []Workout{
@@ -144,7 +185,7 @@ func TestGetAll(t *testing.T) {
 		{ID: "a", Name: "A", CreatedOn: time.Now().UTC().Format(time.RFC3339), CompletedOn: time.Now().UTC().Format(time.RFC3339), UpdatedOn: time.Now().UTC().Format(time.RFC3339)},
 		{ID: "b", Name: "B", CreatedOn: time.Now().Add(time.Minute * 2).UTC().Format(time.RFC3339), CompletedOn: time.Now().Add(time.Minute * 2).UTC().Format(time.RFC3339), UpdatedOn: time.Now().Add(time.Minute * 2).UTC().Format(time.RFC3339)},
 	}, nil).Once()
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
 
 	result, err := service.GetAll(ctx, userId, 1, 10)
 
@@ -171,7 +212,7 @@ func TestGetAllDefaultPage(t *testing.T) {
 		{ID: "a", Name: "A", CreatedOn: time.Now().UTC().Format(time.RFC3339), CompletedOn: time.Now().UTC().Format(time.RFC3339), UpdatedOn: time.Now().UTC().Format(time.RFC3339)},
 		{ID: "b", Name: "B", CreatedOn: time.Now().Add(time.Minute * 2).UTC().Format(time.RFC3339), CompletedOn: time.Now().Add(time.Minute * 2).UTC().Format(time.RFC3339), UpdatedOn: time.Now().Add(time.Minute * 2).UTC().Format(time.RFC3339)},
 	}, nil).Once()
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
 
 	result, err := service.GetAll(ctx, userId, 0, 0)
 
@@ -192,7 +233,7 @@ func TestGetAllRepoErr(t *testing.T) {
 		return input.UserID == userId && input.Offset == 0 && input.Limit == 10
 	})).Return([]Workout{}, testError).Once()
 
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
 
 	result, err := service.GetAll(ctx, userId, 1, 10)
 
@@ -226,7 +267,7 @@ func TestGetById(t *testing.T) {
 		UpdatedOn:   time.Now().UTC().Format(time.RFC3339),
 	}, nil).Once()
 
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
 
 	result, err := service.GetById(ctx, workoutId, userId)
 	assert.Nil(t, err)
@@ -248,7 +289,7 @@ func TestCreateAndReturnId(t *testing.T) {
 		return input.Name == "A" && input.ID != "" && input.CreatedOn != "" && input.UpdatedOn != "" && input.UserID == userId
 	})).Return(workoutId, nil).Once()
 
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
 
 	result, err := service.CreateAndReturnId(ctx, request, userId)
 
@@ -267,12 +308,63 @@ func TestCompleteById(t *testing.T) {
 		return input.ID == workoutId && input.UserID == userId && input.CompletedOn != ""
 	})).Return(int64(1), nil).Once()
 
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
+
+	err := service.CompleteById(ctx, workoutId, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+type completionListenerMock struct {
+	mock.Mock
+}
+
+func (l *completionListenerMock) OnWorkoutCompleted(ctx context.Context, workoutId string, userId string) error {
+	args := l.Called(ctx, workoutId, userId)
+	return args.Error(0)
+}
+
+func TestCompleteByIdNotifiesListeners(t *testing.T) {
+	userId := "userid"
+	workoutId := "workoutId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("CompleteById", ctx, mock.Anything).Return(int64(1), nil).Once()
+
+	first := completionListenerMock{}
+	first.On("OnWorkoutCompleted", ctx, workoutId, userId).Return(testError).Once()
+	second := completionListenerMock{}
+	second.On("OnWorkoutCompleted", ctx, workoutId, userId).Return(nil).Once()
+
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil, &first, &second)
+
+	err := service.CompleteById(ctx, workoutId, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+	first.AssertExpectations(t)
+	second.AssertExpectations(t)
+}
+
+func TestCompleteByIdNotFoundSkipsListeners(t *testing.T) {
+	userId := "userid"
+	workoutId := "workoutId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("CompleteById", ctx, mock.Anything).Return(int64(0), nil).Once()
+
+	listener := completionListenerMock{}
+
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil, &listener)
 
 	err := service.CompleteById(ctx, workoutId, userId)
 
 	assert.Nil(t, err)
 	repoMock.AssertExpectations(t)
+	listener.AssertNotCalled(t, "OnWorkoutCompleted", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestDeleteById(t *testing.T) {
@@ -285,7 +377,7 @@ func TestDeleteById(t *testing.T) {
 		return input.ID == workoutId && input.UserID == userId
 	})).Return(nil).Once()
 
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
 	err := service.DeleteById(ctx, workoutId, userId)
 
 	assert.Nil(t, err)
@@ -315,7 +407,7 @@ func TestUpdateById(t *testing.T) {
 		return input.ID == workoutId && input.UserID == userId && input.Note == request.Note
 	})).Return(nil).Once()
 
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
 
 	err := service.UpdateById(ctx, workoutId, request, userId)
 
@@ -323,6 +415,40 @@ func TestUpdateById(t *testing.T) {
 	repoMock.AssertExpectations(t)
 }
 
+func TestUpdateByIdWithBodyweight(t *testing.T) {
+	userId := "userid"
+	workoutId := "workoutId"
+	ctx := context.Background()
+	bodyweight := 82.5
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, mock.Anything).Return(Workout{ID: workoutId}, nil).Once()
+	repoMock.On("UpdateById", ctx, mock.Anything).Return(nil).Once()
+	repoMock.On("UpdateBodyweight", ctx, mock.MatchedBy(func(input repository.UpdateWorkoutBodyweightParams) bool {
+		return input.ID == workoutId && input.UserID == userId && input.Bodyweight == 82.5
+	})).Return(nil).Once()
+
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
+
+	err := service.UpdateById(ctx, workoutId, updateWorkoutRequest{Note: "The note", Bodyweight: &bodyweight}, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestUpdateByIdInvalidBodyweight(t *testing.T) {
+	ctx := context.Background()
+	bodyweight := -1.0
+
+	repoMock := repoMock{}
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
+
+	err := service.UpdateById(ctx, "workoutId", updateWorkoutRequest{Bodyweight: &bodyweight}, "userid")
+
+	assert.NotNil(t, err)
+	repoMock.AssertNotCalled(t, "UpdateById", mock.Anything, mock.Anything)
+}
+
 func TestUpdateByIdNotFound(t *testing.T) {
 	userId := "userid"
 	workoutId := "workoutId"
@@ -337,7 +463,7 @@ func TestUpdateByIdNotFound(t *testing.T) {
 		return input.ID == workoutId && input.UserID == userId
 	})).Return(Workout{}, testError).Once()
 
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
 
 	err := service.UpdateById(ctx, workoutId, request, userId)
 
@@ -367,7 +493,7 @@ func TestUpdateByIdUpdateErr(t *testing.T) {
 	}, nil).Once()
 	repoMock.On("UpdateById", ctx, mock.Anything).Return(testError).Once()
 
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
 
 	err := service.UpdateById(ctx, workoutId, request, userId)
 
@@ -376,11 +502,23 @@ func TestUpdateByIdUpdateErr(t *testing.T) {
 	repoMock.AssertExpectations(t)
 }
 
+// transactorStub runs fn on the repositories it holds and counts the transactions
+type transactorStub struct {
+	repos Repositories
+	runs  int
+}
+
+func (s *transactorStub) withTx(ctx context.Context, fn func(Repositories) error) error {
+	s.runs++
+	return fn(s.repos)
+}
+
 func TestCloneByIdAndReturnId(t *testing.T) {
 	userId := "userid"
 	workoutId := "workoutId"
 	newWorkoutId := "newWorkoutId"
 	newExerciseItemId := "newExerciseItemId"
+	rounds := int64(3)
 	ctx := context.Background()
 
 	request := createWorkoutRequest{
@@ -406,8 +544,9 @@ func TestCloneByIdAndReturnId(t *testing.T) {
 		return input.UserID == userId && input.WorkoutID == workoutId
 	})).Return([]exerciseitems.ExerciseItemWithExercises{
 		{
-			ID:   "exerciseItemId",
-			Type: "exercise",
+			ID:     "exerciseItemId",
+			Type:   exerciseitems.KindCircuit,
+			Rounds: &rounds,
 			Exercises: []exercises.Exercise{
 				{
 					ID:             "exerciseId",
@@ -419,19 +558,22 @@ func TestCloneByIdAndReturnId(t *testing.T) {
 		},
 	}, nil).Once()
 
-	exerciseItemsMock.On("CreateAndReturnId", ctx, "exercise", newWorkoutId, userId).Return(newExerciseItemId, nil).Once()
+	// The clone is run the same way, grouped items keep their kind and rounds
+	exerciseItemsMock.On("CreateAndReturnId", ctx, exerciseitems.Settings{Type: exerciseitems.KindCircuit, Rounds: &rounds}, newWorkoutId, userId).Return(newExerciseItemId, nil).Once()
 
 	exerciseRepoMock := exerciseRepoMock{}
 	exerciseRepoMock.On("CreateAndReturnId", ctx, mock.MatchedBy(func(input repository.CreateExerciseAndReturnIdParams) bool {
 		return input.WorkoutID == newWorkoutId && input.UserID == userId && input.Name == "Exercise A" && input.ExerciseTypeID == "exerciseTypeId" && input.ExerciseItemID == newExerciseItemId
 	})).Return("newExerciseId", nil).Once()
 
-	service := NewService(&repoMock, &exerciseRepoMock, &exerciseItemsMock)
+	transactor := transactorStub{repos: Repositories{Workouts: &repoMock, Exercises: &exerciseRepoMock, ExerciseItems: &exerciseItemsMock}}
+	service := NewService(&repoMock, &exerciseRepoMock, &exerciseItemsMock, transactor.withTx)
 
 	result, err := service.CloneByIdAndReturnId(ctx, workoutId, userId)
 
 	assert.Nil(t, err)
 	assert.Equal(t, newWorkoutId, result)
+	assert.Equal(t, 1, transactor.runs)
 	repoMock.AssertExpectations(t)
 	exerciseRepoMock.AssertExpectations(t)
 	exerciseItemsMock.AssertExpectations(t)
@@ -447,7 +589,7 @@ func TestCloneByIdAndReturnIdSourceNotFound(t *testing.T) {
 		return input.ID == workoutId && input.UserID == userId
 	})).Return(Workout{}, testError).Once()
 
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
 
 	result, err := service.CloneByIdAndReturnId(ctx, workoutId, userId)
 
@@ -475,7 +617,8 @@ func TestCloneByIdAndReturnIdCreateAndReturnIdErr(t *testing.T) {
 
 	repoMock.On("CreateAndReturnId", ctx, mock.Anything).Return("", testError).Once()
 
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	transactor := transactorStub{repos: Repositories{Workouts: &repoMock, ExerciseItems: &exerciseItemsMock{}}}
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, transactor.withTx)
 
 	result, err := service.CloneByIdAndReturnId(ctx, workoutId, userId)
 
@@ -528,15 +671,18 @@ func TestCloneByIdAndReturnIdCreateExerciseErr(t *testing.T) {
 		},
 	}, nil).Once()
 
-	exerciseItemsMock.On("CreateAndReturnId", ctx, "exercise", newWorkoutId, userId).Return(newExerciseItemId, nil).Once()
+	exerciseItemsMock.On("CreateAndReturnId", ctx, exerciseitems.Settings{Type: "exercise"}, newWorkoutId, userId).Return(newExerciseItemId, nil).Once()
 
 	exerciseRepoMock := exerciseRepoMock{}
 	exerciseRepoMock.On("CreateAndReturnId", ctx, mock.Anything).Return("", testError).Once()
 
-	service := NewService(&repoMock, &exerciseRepoMock, &exerciseItemsMock)
+	transactor := transactorStub{repos: Repositories{Workouts: &repoMock, Exercises: &exerciseRepoMock, ExerciseItems: &exerciseItemsMock}}
+	service := NewService(&repoMock, &exerciseRepoMock, &exerciseItemsMock, transactor.withTx)
 
 	result, err := service.CloneByIdAndReturnId(ctx, workoutId, userId)
 
+	// The error is returned from the transaction, so the partial clone is rolled back
+	assert.Equal(t, 1, transactor.runs)
 	assert.NotNil(t, err)
 	assert.ErrorIs(t, err, testError)
 	assert.Equal(t, "", result)
@@ -552,7 +698,7 @@ func TestGetAllCount(t *testing.T) {
 	repoMock := repoMock{}
 	repoMock.On("GetAllCount", ctx, userId).Return(int64(expectedCount), nil).Once()
 
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
 
 	count, err := service.GetAllCount(ctx, userId)
 
@@ -568,7 +714,7 @@ func TestGetAllCountError(t *testing.T) {
 	repoMock := repoMock{}
 	repoMock.On("GetAllCount", ctx, userId).Return(int64(0), testError).Once()
 
-	service := NewService(&repoMock, nil, &exerciseItemsMock{})
+	service := NewService(&repoMock, nil, &exerciseItemsMock{}, nil)
 
 	count, err := service.GetAllCount(ctx, userId)
 
@@ -577,3 +723,89 @@ func TestGetAllCountError(t *testing.T) {
 	assert.Equal(t, 0, count)
 	repoMock.AssertExpectations(t)
 }
+
+func TestStartById(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, repository.GetWorkoutByIdParams{ID: workoutId, UserID: userId}).Return(Workout{ID: workoutId}, nil).Once()
+	repoMock.On("StartById", ctx, mock.MatchedBy(func(input repository.StartWorkoutByIdParams) bool {
+		_, err := time.Parse(time.RFC3339, input.StartedOn)
+		return err == nil && input.ID == workoutId && input.UserID == userId
+	})).Return(int64(1), nil).Once()
+
+	service := NewService(&repoMock, &exerciseRepoMock{}, &exerciseItemsMock{}, nil)
+	err := service.StartById(ctx, workoutId, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
+
+func TestStartByIdAlreadyStarted(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, repository.GetWorkoutByIdParams{ID: workoutId, UserID: userId}).Return(Workout{ID: workoutId}, nil).Once()
+	repoMock.On("StartById", ctx, mock.Anything).Return(int64(0), nil).Once()
+
+	service := NewService(&repoMock, &exerciseRepoMock{}, &exerciseItemsMock{}, nil)
+	err := service.StartById(ctx, workoutId, userId)
+
+	assert.EqualError(t, err, "workout is already started or completed")
+	repoMock.AssertExpectations(t)
+}
+
+func TestPauseByIdNotFound(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, repository.GetWorkoutByIdParams{ID: workoutId, UserID: userId}).Return(Workout{}, sql.ErrNoRows).Once()
+
+	service := NewService(&repoMock, &exerciseRepoMock{}, &exerciseItemsMock{}, nil)
+	err := service.PauseById(ctx, workoutId, userId)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	repoMock.AssertNotCalled(t, "PauseById", mock.Anything, mock.Anything)
+}
+
+func TestPauseByIdNotRunning(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, repository.GetWorkoutByIdParams{ID: workoutId, UserID: userId}).Return(Workout{ID: workoutId}, nil).Once()
+	repoMock.On("PauseById", ctx, mock.MatchedBy(func(input repository.PauseWorkoutByIdParams) bool {
+		return input.ID == workoutId && input.UserID == userId
+	})).Return(int64(0), nil).Once()
+
+	service := NewService(&repoMock, &exerciseRepoMock{}, &exerciseItemsMock{}, nil)
+	err := service.PauseById(ctx, workoutId, userId)
+
+	assert.EqualError(t, err, "workout is not running")
+	repoMock.AssertExpectations(t)
+}
+
+func TestResumeById(t *testing.T) {
+	userId := "userId"
+	workoutId := "workoutId"
+	ctx := context.Background()
+
+	repoMock := repoMock{}
+	repoMock.On("GetById", ctx, repository.GetWorkoutByIdParams{ID: workoutId, UserID: userId}).Return(Workout{ID: workoutId}, nil).Once()
+	repoMock.On("ResumeById", ctx, mock.MatchedBy(func(input repository.ResumeWorkoutByIdParams) bool {
+		return input.ID == workoutId && input.UserID == userId
+	})).Return(int64(1), nil).Once()
+
+	service := NewService(&repoMock, &exerciseRepoMock{}, &exerciseItemsMock{}, nil)
+	err := service.ResumeById(ctx, workoutId, userId)
+
+	assert.Nil(t, err)
+	repoMock.AssertExpectations(t)
+}
@@ -0,0 +1,179 @@
+-- name: GetWorkoutsByUserId :many
+SELECT * FROM workouts
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetExerciseItemsByUserId :many
+SELECT * FROM exercise_items
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetExercisesByUserId :many
+SELECT * FROM exercises
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetSetsByUserId :many
+SELECT * FROM sets
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetExerciseTypesByUserId :many
+SELECT * FROM exercise_types
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetProgressionRulesByUserId :many
+SELECT * FROM progression_rules
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetRecordsByUserId :many
+SELECT * FROM records
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetTemplatesByUserId :many
+SELECT * FROM templates
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetTemplateExerciseItemsByUserId :many
+SELECT * FROM template_exercise_items
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetTemplateExercisesByUserId :many
+SELECT * FROM template_exercises
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetTemplateSetsByUserId :many
+SELECT * FROM template_sets
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetProgramsByUserId :many
+SELECT * FROM programs
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetProgramDaysByUserId :many
+SELECT * FROM program_days
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetProgramEnrollmentsByUserId :many
+SELECT * FROM program_enrollments
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetProgramProgressByUserId :many
+SELECT * FROM program_progress
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetBodyMetricsByUserId :many
+SELECT * FROM body_metrics
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetBodyMeasurementsByUserId :many
+SELECT * FROM body_measurements
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetGoalsByUserId :many
+SELECT * FROM goals
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- name: GetGoalProgressByUserId :many
+SELECT * FROM goal_progress
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY created_on, id;
+
+-- Rows are deleted children first, so no row is left pointing at a deleted one
+-- name: DeleteGoalProgressByUserId :execrows
+DELETE FROM goal_progress
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteGoalsByUserId :execrows
+DELETE FROM goals
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteBodyMeasurementsByUserId :execrows
+DELETE FROM body_measurements
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteBodyMetricsByUserId :execrows
+DELETE FROM body_metrics
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeletePreferencesByUserId :execrows
+DELETE FROM user_preferences
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteRecordsByUserId :execrows
+DELETE FROM records
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteProgressionRulesByUserId :execrows
+DELETE FROM progression_rules
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteExerciseTypeMuscleGroupsByUserId :execrows
+DELETE FROM exercise_type_muscle_groups
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteProgramProgressByUserId :execrows
+DELETE FROM program_progress
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteProgramEnrollmentsByUserId :execrows
+DELETE FROM program_enrollments
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteProgramDaysByUserId :execrows
+DELETE FROM program_days
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteProgramsByUserId :execrows
+DELETE FROM programs
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteTemplateSetsByUserId :execrows
+DELETE FROM template_sets
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteTemplateExercisesByUserId :execrows
+DELETE FROM template_exercises
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteTemplateExerciseItemsByUserId :execrows
+DELETE FROM template_exercise_items
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteTemplatesByUserId :execrows
+DELETE FROM templates
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteSetsByUserId :execrows
+DELETE FROM sets
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteExercisesByUserId :execrows
+DELETE FROM exercises
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteExerciseItemsByUserId :execrows
+DELETE FROM exercise_items
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteWorkoutsByUserId :execrows
+DELETE FROM workouts
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: DeleteExerciseTypesByUserId :execrows
+DELETE FROM exercise_types
+WHERE user_id = sqlc.arg(user_id);
@@ -0,0 +1,73 @@
+-- name: GetBodyMetricsBetweenDates :many
+SELECT * FROM body_metrics
+WHERE user_id = sqlc.arg(user_id)
+AND measured_on >= sqlc.arg(start_date)
+AND measured_on <= sqlc.arg(end_date)
+ORDER BY measured_on ASC, id ASC;
+
+-- name: GetBodyMetricById :one
+SELECT * FROM body_metrics
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: CreateBodyMetricAndReturnId :one
+INSERT INTO body_metrics (
+  id, measured_on, bodyweight, body_fat_percentage, created_on, updated_on, user_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(measured_on), sqlc.arg(bodyweight), sqlc.arg(body_fat_percentage), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id)
+)
+RETURNING id;
+
+-- name: UpdateBodyMetric :execrows
+UPDATE body_metrics
+SET measured_on = sqlc.arg(measured_on),
+bodyweight = sqlc.arg(bodyweight),
+body_fat_percentage = sqlc.arg(body_fat_percentage),
+updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: DeleteBodyMetricById :execrows
+DELETE FROM body_metrics
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: GetBodyMeasurementsBetweenDates :many
+SELECT bm.* FROM body_measurements bm
+JOIN body_metrics m ON bm.body_metric_id = m.id
+WHERE bm.user_id = sqlc.arg(user_id)
+AND m.measured_on >= sqlc.arg(start_date)
+AND m.measured_on <= sqlc.arg(end_date)
+ORDER BY bm.site ASC;
+
+-- name: GetBodyMeasurementsByBodyMetricId :many
+SELECT * FROM body_measurements
+WHERE body_metric_id = sqlc.arg(body_metric_id)
+AND user_id = sqlc.arg(user_id)
+ORDER BY site ASC;
+
+-- name: CreateBodyMeasurementAndReturnId :one
+INSERT INTO body_measurements (
+  id, site, centimeters, created_on, updated_on, user_id, body_metric_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(site), sqlc.arg(centimeters), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id), sqlc.arg(body_metric_id)
+)
+RETURNING id;
+
+-- name: DeleteBodyMeasurementsByBodyMetricId :execrows
+DELETE FROM body_measurements
+WHERE body_metric_id = sqlc.arg(body_metric_id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: SetWorkoutBodyweightFromBodyMetrics :execrows
+UPDATE workouts
+SET bodyweight = (SELECT m.bodyweight FROM body_metrics m
+WHERE m.user_id = workouts.user_id
+AND m.bodyweight IS NOT NULL
+AND m.measured_on <= substr(workouts.completed_on, 1, 10)
+ORDER BY m.measured_on DESC, m.id DESC
+LIMIT 1), updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id)
+AND completed_on IS NOT NULL
+AND bodyweight IS NULL;
@@ -1,8 +1,10 @@
 -- name: CreateExerciseItemAndReturnId :one
 INSERT INTO exercise_items (
-  id, type, user_id, workout_id, created_on, updated_on
+  id, type, rounds, interval_seconds, time_cap_seconds, position, user_id, workout_id, created_on, updated_on
 ) VALUES (
-  sqlc.arg(id), sqlc.arg(type), sqlc.arg(user_id), sqlc.arg(workout_id), sqlc.arg(created_on), sqlc.arg(updated_on)
+  sqlc.arg(id), sqlc.arg(type), sqlc.arg(rounds), sqlc.arg(interval_seconds), sqlc.arg(time_cap_seconds),
+  (SELECT COALESCE(MAX(position) + 1, 0) FROM exercise_items WHERE workout_id = sqlc.arg(workout_id) AND user_id = sqlc.arg(user_id)),
+  sqlc.arg(user_id), sqlc.arg(workout_id), sqlc.arg(created_on), sqlc.arg(updated_on)
 )
 RETURNING id;
 
@@ -15,11 +17,21 @@ AND user_id = sqlc.arg(user_id);
 SELECT * FROM exercise_items
 WHERE workout_id = sqlc.arg(workout_id)
 AND user_id = sqlc.arg(user_id)
-ORDER BY created_on;
+ORDER BY position, created_on;
 
--- name: UpdateExerciseItemType :execrows
+-- name: UpdateExerciseItem :execrows
 UPDATE exercise_items
-SET type = sqlc.arg(type), updated_on = sqlc.arg(updated_on)
+SET type = sqlc.arg(type),
+rounds = sqlc.arg(rounds),
+interval_seconds = sqlc.arg(interval_seconds),
+time_cap_seconds = sqlc.arg(time_cap_seconds),
+updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: UpdateExerciseItemPosition :execrows
+UPDATE exercise_items
+SET position = sqlc.arg(position), updated_on = sqlc.arg(updated_on)
 WHERE id = sqlc.arg(id)
 AND user_id = sqlc.arg(user_id);
 
@@ -10,9 +10,9 @@ AND user_id = sqlc.arg(user_id);
 
 -- name: CreateExerciseTypeAndReturnId :one
 INSERT INTO exercise_types (
-  id, name, created_on, updated_on, user_id
+  id, name, equipment, movement_pattern, measurement, created_on, updated_on, user_id
 ) VALUES (
-  sqlc.arg(id), sqlc.arg(name), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id)
+  sqlc.arg(id), sqlc.arg(name), sqlc.arg(equipment), sqlc.arg(movement_pattern), sqlc.arg(measurement), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id)
 )
 RETURNING id;
 
@@ -26,19 +26,30 @@ AND user_id = sqlc.arg(user_id);
 SELECT COALESCE(s.weight, 0) as weight, COALESCE(Max(s.repetitions), 0) as repetitions FROM exercises e
 LEFT JOIN sets s ON s.exercise_id = e.id
 WHERE e.exercise_type_id = sqlc.arg(id) AND (s.user_id = sqlc.arg(user_id) OR s.id IS NULL)
+AND (s.type != 'warmup' OR s.id IS NULL)
 AND (s.weight = (SELECT Max(s.weight) as weight FROM exercises e
 JOIN sets s ON s.exercise_id = e.id
-WHERE e.exercise_type_id = sqlc.arg(id) AND s.user_id = sqlc.arg(user_id)) OR s.weight IS NULL)
+WHERE e.exercise_type_id = sqlc.arg(id) AND s.user_id = sqlc.arg(user_id)
+AND s.type != 'warmup') OR s.weight IS NULL)
 GROUP BY e.exercise_type_id;
 
+-- name: GetMeasuredSetsByExerciseTypeId :many
+SELECT s.weight, s.repetitions, s.duration_seconds, s.distance_meters FROM exercises e
+JOIN sets s ON s.exercise_id = e.id
+WHERE e.exercise_type_id = sqlc.arg(id)
+AND s.user_id = sqlc.arg(user_id)
+AND s.type != 'warmup'
+ORDER BY s.id ASC;
+
 -- name: GetLastWeightRepsByExerciseTypeId :one
-SELECT s.repetitions, s.weight FROM exercises e
+SELECT s.repetitions, s.weight, s.duration_seconds, s.distance_meters FROM exercises e
 JOIN sets s ON s.exercise_id = e.id
 JOIN workouts w ON e.workout_id = w.id
 WHERE exercise_type_id = sqlc.arg(id) 
 AND s.user_id = sqlc.arg(user_id)
+AND s.type != 'warmup'
 AND w.completed_on IS NOT NULL
-ORDER BY s.id desc LIMIT 1;
+ORDER BY w.completed_on DESC, s.id DESC LIMIT 1;
 
 
 -- name: UpdateExerciseType :execrows
@@ -46,3 +57,88 @@ UPDATE exercise_types
 SET name = sqlc.arg(name), updated_on = sqlc.arg(updated_on)
 WHERE id = sqlc.arg(id)
 AND user_id = sqlc.arg(user_id);
+
+-- name: GetSetHistoryByExerciseTypeId :many
+SELECT w.id as workout_id, w.completed_on, s.weight, s.repetitions FROM exercises e
+JOIN sets s ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+WHERE e.exercise_type_id = sqlc.arg(id)
+AND s.user_id = sqlc.arg(user_id)
+AND s.type != 'warmup'
+AND w.completed_on IS NOT NULL
+ORDER BY w.completed_on DESC, s.id ASC
+LIMIT sqlc.arg(limit);
+
+-- name: GetExerciseTypeHistory :many
+SELECT w.id as workout_id, w.name as workout_name, w.completed_on, s.id, s.type, s.weight, s.repetitions, s.duration_seconds, s.distance_meters, s.rpe, s.rir, s.note FROM exercises e
+JOIN sets s ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+WHERE e.exercise_type_id = sqlc.arg(id)
+AND s.user_id = sqlc.arg(user_id)
+AND w.completed_on IS NOT NULL
+AND w.id IN (SELECT hw.id FROM workouts hw
+JOIN exercises he ON he.workout_id = hw.id
+WHERE he.exercise_type_id = sqlc.arg(id)
+AND hw.user_id = sqlc.arg(user_id)
+AND hw.completed_on IS NOT NULL
+GROUP BY hw.id
+ORDER BY MAX(hw.completed_on) DESC, hw.id DESC
+LIMIT sqlc.arg(limit))
+ORDER BY w.completed_on DESC, w.id DESC, s.id ASC;
+
+-- name: GetCompletedSetsByExerciseTypeId :many
+SELECT w.completed_on, s.weight, s.repetitions,
+CAST(CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END AS REAL) as bodyweight FROM exercises e
+JOIN sets s ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN exercise_types et ON e.exercise_type_id = et.id
+WHERE e.exercise_type_id = sqlc.arg(id)
+AND s.user_id = sqlc.arg(user_id)
+AND s.type != 'warmup'
+AND w.completed_on IS NOT NULL
+ORDER BY w.completed_on ASC, s.id ASC;
+
+-- name: UpdateExerciseTypeMetadata :execrows
+UPDATE exercise_types
+SET equipment = sqlc.arg(equipment), movement_pattern = sqlc.arg(movement_pattern), updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: UpdateExerciseTypeRestSeconds :execrows
+UPDATE exercise_types
+SET rest_seconds = sqlc.arg(rest_seconds), updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: GetLastPerformedOnByExerciseTypeId :one
+SELECT CAST(s.performed_on AS TEXT) as performed_on FROM sets s
+JOIN exercises e ON s.exercise_id = e.id
+WHERE e.exercise_type_id = sqlc.arg(exercise_type_id)
+AND s.user_id = sqlc.arg(user_id)
+AND s.performed_on IS NOT NULL
+ORDER BY s.performed_on DESC
+LIMIT 1;
+
+-- name: GetMuscleGroupsByUserId :many
+SELECT * FROM exercise_type_muscle_groups
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY exercise_type_id ASC, role ASC, muscle_group ASC;
+
+-- name: GetMuscleGroupsByExerciseTypeId :many
+SELECT * FROM exercise_type_muscle_groups
+WHERE exercise_type_id = sqlc.arg(exercise_type_id)
+AND user_id = sqlc.arg(user_id)
+ORDER BY role ASC, muscle_group ASC;
+
+-- name: CreateExerciseTypeMuscleGroupAndReturnId :one
+INSERT INTO exercise_type_muscle_groups (
+  id, muscle_group, role, created_on, updated_on, user_id, exercise_type_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(muscle_group), sqlc.arg(role), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id), sqlc.arg(exercise_type_id)
+)
+RETURNING id;
+
+-- name: DeleteMuscleGroupsByExerciseTypeId :execrows
+DELETE FROM exercise_type_muscle_groups
+WHERE exercise_type_id = sqlc.arg(exercise_type_id)
+AND user_id = sqlc.arg(user_id);
@@ -10,20 +10,41 @@ AND user_id = sqlc.arg(user_id);
 
 -- name: CreateExerciseAndReturnId :one
 INSERT INTO exercises (
-  id, name, workout_id, exercise_type_id, exercise_item_id, created_on, updated_on, user_id
+  id, name, workout_id, exercise_type_id, exercise_item_id, position, created_on, updated_on, user_id
 ) VALUES (
-  sqlc.arg(id), sqlc.arg(name), sqlc.arg(workout_id), sqlc.arg(exercise_type_id), sqlc.arg(exercise_item_id), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id)
+  sqlc.arg(id), sqlc.arg(name), sqlc.arg(workout_id), sqlc.arg(exercise_type_id), sqlc.arg(exercise_item_id),
+  (SELECT COALESCE(MAX(position) + 1, 0) FROM exercises WHERE exercise_item_id = sqlc.arg(exercise_item_id) AND user_id = sqlc.arg(user_id)),
+  sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id)
 )
 RETURNING id;
 
 -- name: GetExercisesByWorkoutId :many
 SELECT * FROM exercises
 WHERE workout_id = sqlc.arg(workout_id)
-AND user_id = sqlc.arg(user_id);
+AND user_id = sqlc.arg(user_id)
+ORDER BY (SELECT i.position FROM exercise_items i WHERE i.id = exercises.exercise_item_id), position, id;
 
 -- name: GetExercisesByExerciseItemId :many
 SELECT * FROM exercises
 WHERE exercise_item_id = sqlc.arg(exercise_item_id)
+AND user_id = sqlc.arg(user_id)
+ORDER BY position, id;
+
+-- name: UpdateExercise :execrows
+UPDATE exercises
+SET name = sqlc.arg(name),
+exercise_type_id = sqlc.arg(exercise_type_id),
+position = CASE WHEN exercise_item_id = sqlc.arg(exercise_item_id) THEN position
+  ELSE (SELECT COALESCE(MAX(e.position) + 1, 0) FROM exercises e WHERE e.exercise_item_id = sqlc.arg(exercise_item_id) AND e.user_id = sqlc.arg(user_id)) END,
+exercise_item_id = sqlc.arg(exercise_item_id),
+updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: UpdateExercisePosition :execrows
+UPDATE exercises
+SET position = sqlc.arg(position), updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
 AND user_id = sqlc.arg(user_id);
 
 -- name: DeleteExerciseById :execrows
@@ -0,0 +1,60 @@
+-- name: GetAllGoals :many
+SELECT * FROM goals
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY deadline ASC, id ASC;
+
+-- name: GetGoalById :one
+SELECT * FROM goals
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: GetActiveGoals :many
+SELECT * FROM goals
+WHERE user_id = sqlc.arg(user_id)
+AND achieved_on IS NULL
+ORDER BY id ASC;
+
+-- name: CreateGoalAndReturnId :one
+INSERT INTO goals (
+  id, kind, target_weight, target_repetitions, target_value, start_value, deadline, created_on, updated_on, user_id, exercise_type_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(kind), sqlc.arg(target_weight), sqlc.arg(target_repetitions), sqlc.arg(target_value), sqlc.arg(start_value), sqlc.arg(deadline), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id), sqlc.arg(exercise_type_id)
+)
+RETURNING id;
+
+-- name: UpdateGoalAchievedOn :execrows
+UPDATE goals
+SET achieved_on = sqlc.arg(achieved_on), updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: DeleteGoalById :execrows
+DELETE FROM goals
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: GetGoalProgressByGoalId :many
+SELECT * FROM goal_progress
+WHERE goal_id = sqlc.arg(goal_id)
+AND user_id = sqlc.arg(user_id)
+ORDER BY recorded_on ASC, id ASC;
+
+-- name: CreateGoalProgressAndReturnId :one
+INSERT INTO goal_progress (
+  id, value, recorded_on, created_on, updated_on, user_id, goal_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(value), sqlc.arg(recorded_on), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id), sqlc.arg(goal_id)
+)
+RETURNING id;
+
+-- name: DeleteGoalProgressByGoalId :execrows
+DELETE FROM goal_progress
+WHERE goal_id = sqlc.arg(goal_id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: GetLatestBodyweight :one
+SELECT CAST(bodyweight AS REAL) as bodyweight FROM body_metrics
+WHERE user_id = sqlc.arg(user_id)
+AND bodyweight IS NOT NULL
+ORDER BY measured_on DESC, id DESC
+LIMIT 1;
@@ -0,0 +1,12 @@
+-- name: GetPreferencesByUserId :one
+SELECT * FROM user_preferences
+WHERE user_id = sqlc.arg(user_id);
+
+-- name: UpsertPreferences :execrows
+INSERT INTO user_preferences (
+  user_id, weight_unit, week_start, timezone, default_rest_seconds, weekly_target, created_on, updated_on
+) VALUES (
+  sqlc.arg(user_id), sqlc.arg(weight_unit), sqlc.arg(week_start), sqlc.arg(timezone), sqlc.arg(default_rest_seconds), sqlc.arg(weekly_target), sqlc.arg(created_on), sqlc.arg(updated_on)
+)
+ON CONFLICT(user_id) DO UPDATE
+SET weight_unit = excluded.weight_unit, week_start = excluded.week_start, timezone = excluded.timezone, default_rest_seconds = excluded.default_rest_seconds, weekly_target = excluded.weekly_target, updated_on = excluded.updated_on;
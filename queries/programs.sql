@@ -0,0 +1,111 @@
+-- name: GetAllPrograms :many
+SELECT * FROM programs
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY name;
+
+-- name: GetProgramById :one
+SELECT * FROM programs
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: CreateProgramAndReturnId :one
+INSERT INTO programs (
+  id, name, created_on, updated_on, user_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(name), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id)
+)
+RETURNING id;
+
+-- name: DeleteProgramById :execrows
+DELETE FROM programs
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: CreateProgramDayAndReturnId :one
+INSERT INTO program_days (
+  id, week, day, created_on, updated_on, user_id, program_id, template_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(week), sqlc.arg(day), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id), sqlc.arg(program_id), sqlc.arg(template_id)
+)
+RETURNING id;
+
+-- name: GetProgramDaysByProgramId :many
+SELECT * FROM program_days
+WHERE program_id = sqlc.arg(program_id)
+AND user_id = sqlc.arg(user_id)
+ORDER BY week, day;
+
+-- name: DeleteProgramDaysByProgramId :execrows
+DELETE FROM program_days
+WHERE program_id = sqlc.arg(program_id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: CreateProgramEnrollmentAndReturnId :one
+INSERT INTO program_enrollments (
+  id, started_on, created_on, updated_on, user_id, program_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(started_on), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id), sqlc.arg(program_id)
+)
+RETURNING id;
+
+-- name: GetActiveProgramEnrollment :one
+SELECT * FROM program_enrollments
+WHERE user_id = sqlc.arg(user_id)
+AND ended_on IS NULL
+ORDER BY started_on DESC
+LIMIT 1;
+
+-- name: EndProgramEnrollmentById :execrows
+UPDATE program_enrollments
+SET ended_on = sqlc.arg(ended_on), updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: EndActiveProgramEnrollments :execrows
+UPDATE program_enrollments
+SET ended_on = sqlc.arg(ended_on), updated_on = sqlc.arg(updated_on)
+WHERE user_id = sqlc.arg(user_id)
+AND ended_on IS NULL;
+
+-- name: DeleteProgramEnrollmentsByProgramId :execrows
+DELETE FROM program_enrollments
+WHERE program_id = sqlc.arg(program_id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: CreateProgramProgressAndReturnId :one
+INSERT INTO program_progress (
+  id, created_on, updated_on, user_id, program_id, program_enrollment_id, program_day_id, workout_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id), sqlc.arg(program_id), sqlc.arg(program_enrollment_id), sqlc.arg(program_day_id), sqlc.arg(workout_id)
+)
+RETURNING id;
+
+-- A session whose workout was deleted before it was completed is left out,
+-- so the day can be started again
+-- name: GetProgramProgressByEnrollmentId :many
+SELECT * FROM program_progress
+WHERE program_enrollment_id = sqlc.arg(program_enrollment_id)
+AND user_id = sqlc.arg(user_id)
+AND (completed_on IS NOT NULL OR workout_id IN (
+  SELECT id FROM workouts WHERE user_id = sqlc.arg(user_id)
+))
+ORDER BY created_on;
+
+-- name: DeleteUnfinishedProgramProgressByDayId :execrows
+DELETE FROM program_progress
+WHERE program_enrollment_id = sqlc.arg(program_enrollment_id)
+AND program_day_id = sqlc.arg(program_day_id)
+AND user_id = sqlc.arg(user_id)
+AND completed_on IS NULL;
+
+-- name: CompleteProgramProgressByWorkoutId :execrows
+UPDATE program_progress
+SET completed_on = sqlc.arg(completed_on), updated_on = sqlc.arg(updated_on)
+WHERE workout_id = sqlc.arg(workout_id)
+AND user_id = sqlc.arg(user_id)
+AND completed_on IS NULL;
+
+-- name: DeleteProgramProgressByProgramId :execrows
+DELETE FROM program_progress
+WHERE program_id = sqlc.arg(program_id)
+AND user_id = sqlc.arg(user_id);
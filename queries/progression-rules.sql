@@ -0,0 +1,21 @@
+-- name: GetProgressionRuleByExerciseTypeId :one
+SELECT * FROM progression_rules
+WHERE exercise_type_id = sqlc.arg(exercise_type_id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: UpsertProgressionRule :execrows
+INSERT INTO progression_rules (
+  id, rule, increment, min_reps, max_reps, training_max, percentage, bar_weight, plates, created_on, updated_on, user_id, exercise_type_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(rule), sqlc.arg(increment), sqlc.arg(min_reps), sqlc.arg(max_reps), sqlc.arg(training_max), sqlc.arg(percentage), sqlc.arg(bar_weight), sqlc.arg(plates), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id), sqlc.arg(exercise_type_id)
+)
+ON CONFLICT(user_id, exercise_type_id) DO UPDATE SET
+  rule = excluded.rule,
+  increment = excluded.increment,
+  min_reps = excluded.min_reps,
+  max_reps = excluded.max_reps,
+  training_max = excluded.training_max,
+  percentage = excluded.percentage,
+  bar_weight = excluded.bar_weight,
+  plates = excluded.plates,
+  updated_on = excluded.updated_on;
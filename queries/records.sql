@@ -0,0 +1,51 @@
+-- name: GetAllRecords :many
+SELECT r.id, r.kind, r.value, r.weight, r.repetitions, r.achieved_on, r.exercise_type_id, et.name as exercise_type_name, r.workout_id FROM records r
+JOIN exercise_types et ON r.exercise_type_id = et.id
+JOIN workouts w ON r.workout_id = w.id
+WHERE r.user_id = sqlc.arg(user_id)
+ORDER BY r.achieved_on DESC, r.id DESC;
+
+-- name: GetRecordsByWorkoutId :many
+SELECT r.id, r.kind, r.value, r.weight, r.repetitions, r.achieved_on, r.exercise_type_id, et.name as exercise_type_name, r.workout_id FROM records r
+JOIN exercise_types et ON r.exercise_type_id = et.id
+WHERE r.workout_id = sqlc.arg(workout_id)
+AND r.user_id = sqlc.arg(user_id)
+ORDER BY r.id ASC;
+
+-- name: GetPreviousSetsForRecordsByExerciseTypeId :many
+SELECT e.workout_id, e.exercise_type_id, s.weight, s.repetitions,
+CAST(CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END AS REAL) as bodyweight FROM sets s
+JOIN exercises e ON s.exercise_id = e.id
+JOIN exercise_types et ON e.exercise_type_id = et.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN workouts c ON c.id = sqlc.arg(workout_id) AND c.user_id = sqlc.arg(user_id)
+WHERE e.exercise_type_id = sqlc.arg(exercise_type_id)
+AND s.user_id = sqlc.arg(user_id)
+AND s.type != 'warmup'
+AND w.completed_on IS NOT NULL
+AND (w.completed_on < c.completed_on OR (w.completed_on = c.completed_on AND w.id < c.id))
+ORDER BY w.completed_on ASC, w.id ASC, s.id ASC;
+
+-- name: GetSetsForRecordsByWorkoutId :many
+SELECT e.exercise_type_id, s.weight, s.repetitions,
+CAST(CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END AS REAL) as bodyweight FROM sets s
+JOIN exercises e ON s.exercise_id = e.id
+JOIN exercise_types et ON e.exercise_type_id = et.id
+JOIN workouts w ON e.workout_id = w.id
+WHERE e.workout_id = sqlc.arg(workout_id)
+AND s.user_id = sqlc.arg(user_id)
+AND s.type != 'warmup'
+ORDER BY s.id ASC;
+
+-- name: CreateRecordAndReturnId :one
+INSERT INTO records (
+  id, kind, value, weight, repetitions, achieved_on, created_on, updated_on, user_id, exercise_type_id, workout_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(kind), sqlc.arg(value), sqlc.arg(weight), sqlc.arg(repetitions), sqlc.arg(achieved_on), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id), sqlc.arg(exercise_type_id), sqlc.arg(workout_id)
+)
+RETURNING id;
+
+-- name: DeleteRecordsByWorkoutId :execrows
+DELETE FROM records
+WHERE workout_id = sqlc.arg(workout_id)
+AND user_id = sqlc.arg(user_id);
@@ -9,15 +9,70 @@ WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id);
 
 -- name: CreateSetAndReturnId :one
 INSERT INTO sets (
-  id, repetitions, weight, exercise_id, created_on, updated_on, user_id
+  id, repetitions, weight, duration_seconds, distance_meters, type, rpe, rir, note, round_number, performed_on, rest_seconds, exercise_id, position, created_on, updated_on, user_id
 ) VALUES (
-  sqlc.arg(id), sqlc.arg(repetitions), sqlc.arg(weight), sqlc.arg(exercise_id), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id)
+  sqlc.arg(id), sqlc.arg(repetitions), sqlc.arg(weight), sqlc.arg(duration_seconds), sqlc.arg(distance_meters), sqlc.arg(type), sqlc.arg(rpe), sqlc.arg(rir), sqlc.arg(note), sqlc.arg(round_number), sqlc.arg(performed_on), sqlc.arg(rest_seconds), sqlc.arg(exercise_id),
+  (SELECT COALESCE(MAX(position) + 1, 0) FROM sets WHERE exercise_id = sqlc.arg(exercise_id) AND user_id = sqlc.arg(user_id)),
+  sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id)
 )
 RETURNING id;
 
 -- name: GetSetsByExerciseId :many
 SELECT * FROM sets 
 WHERE exercise_id = sqlc.arg(exercise_id)
+AND user_id = sqlc.arg(user_id)
+ORDER BY position, id;
+
+-- name: GetSetsByWorkoutId :many
+SELECT * FROM sets
+WHERE exercise_id IN (SELECT e.id FROM exercises e WHERE e.workout_id = sqlc.arg(workout_id) AND e.user_id = sqlc.arg(user_id))
+AND user_id = sqlc.arg(user_id)
+ORDER BY (SELECT i.position FROM exercises e JOIN exercise_items i ON i.id = e.exercise_item_id WHERE e.id = sets.exercise_id),
+(SELECT e.position FROM exercises e WHERE e.id = sets.exercise_id), position, id;
+
+-- name: GetNextRoundByExerciseItemId :one
+SELECT CAST(COALESCE(MAX(s.round_number), 0) + 1 AS INTEGER) as next_round FROM sets s
+JOIN exercises e ON s.exercise_id = e.id
+WHERE e.exercise_item_id = sqlc.arg(exercise_item_id)
+AND s.user_id = sqlc.arg(user_id);
+
+-- name: GetPreviousPerformedOnByExerciseId :one
+SELECT CAST(s.performed_on AS TEXT) as performed_on FROM sets s
+JOIN exercises e ON s.exercise_id = e.id
+WHERE e.workout_id = (SELECT workout_id FROM exercises WHERE exercises.id = sqlc.arg(exercise_id) AND exercises.user_id = sqlc.arg(user_id))
+AND s.user_id = sqlc.arg(user_id)
+AND s.id != sqlc.arg(id)
+AND s.performed_on IS NOT NULL
+AND s.performed_on <= sqlc.arg(performed_on)
+ORDER BY s.performed_on DESC, s.id DESC
+LIMIT 1;
+
+-- name: GetMeasurementByExerciseId :one
+SELECT t.measurement FROM exercises e
+JOIN exercise_types t ON t.id = e.exercise_type_id
+WHERE e.id = sqlc.arg(exercise_id)
+AND e.user_id = sqlc.arg(user_id);
+
+-- name: UpdateSet :execrows
+UPDATE sets
+SET repetitions = sqlc.arg(repetitions),
+weight = sqlc.arg(weight),
+duration_seconds = sqlc.arg(duration_seconds),
+distance_meters = sqlc.arg(distance_meters),
+type = sqlc.arg(type),
+rpe = sqlc.arg(rpe),
+rir = sqlc.arg(rir),
+note = sqlc.arg(note),
+performed_on = sqlc.arg(performed_on),
+rest_seconds = sqlc.arg(rest_seconds),
+updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: UpdateSetPosition :execrows
+UPDATE sets
+SET position = sqlc.arg(position), updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
 AND user_id = sqlc.arg(user_id);
 
 -- name: DeleteSetById :execrows
@@ -9,4 +9,95 @@ SELECT count(*) FROM
 workouts
 WHERE user_id = sqlc.arg(user_id) AND
 completed_on >= sqlc.arg(start_date) AND
-completed_on <= sqlc.arg(end_date);
+completed_on < sqlc.arg(end_date);
+
+-- name: GetVolumeSinceDate :one
+SELECT CAST(COALESCE(SUM((s.weight + CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END) * s.repetitions), 0) AS REAL) as tonnage, count(s.id) as set_count, CAST(COALESCE(SUM(s.repetitions), 0) AS INTEGER) as repetitions,
+CAST(COALESCE(SUM(s.duration_seconds), 0) AS INTEGER) as duration_seconds, CAST(COALESCE(SUM(s.distance_meters), 0) AS REAL) as distance_meters FROM
+sets s
+JOIN exercises e ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN exercise_types et ON e.exercise_type_id = et.id
+WHERE w.user_id = sqlc.arg(user_id) AND
+s.type != 'warmup' AND
+w.completed_on >= sqlc.arg(start_date);
+
+-- name: GetVolumeBetweenDates :one
+SELECT CAST(COALESCE(SUM((s.weight + CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END) * s.repetitions), 0) AS REAL) as tonnage, count(s.id) as set_count, CAST(COALESCE(SUM(s.repetitions), 0) AS INTEGER) as repetitions,
+CAST(COALESCE(SUM(s.duration_seconds), 0) AS INTEGER) as duration_seconds, CAST(COALESCE(SUM(s.distance_meters), 0) AS REAL) as distance_meters FROM
+sets s
+JOIN exercises e ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN exercise_types et ON e.exercise_type_id = et.id
+WHERE w.user_id = sqlc.arg(user_id) AND
+s.type != 'warmup' AND
+w.completed_on >= sqlc.arg(start_date) AND
+w.completed_on < sqlc.arg(end_date);
+
+-- name: GetVolumePerExerciseTypeBetweenDates :many
+SELECT e.exercise_type_id, et.name, CAST(COALESCE(SUM((s.weight + CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END) * s.repetitions), 0) AS REAL) as tonnage, count(s.id) as set_count, CAST(COALESCE(SUM(s.repetitions), 0) AS INTEGER) as repetitions,
+CAST(COALESCE(SUM(s.duration_seconds), 0) AS INTEGER) as duration_seconds, CAST(COALESCE(SUM(s.distance_meters), 0) AS REAL) as distance_meters FROM
+sets s
+JOIN exercises e ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN exercise_types et ON e.exercise_type_id = et.id
+WHERE w.user_id = sqlc.arg(user_id) AND
+s.type != 'warmup' AND
+w.completed_on >= sqlc.arg(start_date) AND
+w.completed_on < sqlc.arg(end_date)
+GROUP BY e.exercise_type_id, et.name
+ORDER BY et.name ASC;
+
+-- name: GetVolumePerExerciseItemTypeBetweenDates :many
+SELECT ei.type, count(DISTINCT ei.id) as item_count, count(DISTINCT ei.id || ':' || s.round_number) as round_count,
+CAST(COALESCE(SUM((s.weight + CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END) * s.repetitions), 0) AS REAL) as tonnage, count(s.id) as set_count FROM
+sets s
+JOIN exercises e ON s.exercise_id = e.id
+JOIN exercise_items ei ON e.exercise_item_id = ei.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN exercise_types et ON e.exercise_type_id = et.id
+WHERE w.user_id = sqlc.arg(user_id) AND
+s.type != 'warmup' AND
+w.completed_on >= sqlc.arg(start_date) AND
+w.completed_on < sqlc.arg(end_date)
+GROUP BY ei.type
+ORDER BY ei.type ASC;
+
+-- name: GetMuscleGroupSetsBetweenDates :many
+SELECT CAST(w.completed_on AS TEXT) as completed_on, mg.muscle_group, mg.role FROM
+sets s
+JOIN exercises e ON s.exercise_id = e.id
+JOIN workouts w ON e.workout_id = w.id
+JOIN exercise_type_muscle_groups mg ON mg.exercise_type_id = e.exercise_type_id
+WHERE w.user_id = sqlc.arg(user_id) AND
+s.type != 'warmup' AND
+w.completed_on >= sqlc.arg(start_date) AND
+w.completed_on < sqlc.arg(end_date)
+ORDER BY w.completed_on ASC;
+
+-- name: GetSessionDurationsBetweenDates :one
+SELECT count(*) as session_count, CAST(COALESCE(SUM(active_seconds), 0) AS INTEGER) as active_seconds FROM
+workouts
+WHERE user_id = sqlc.arg(user_id) AND
+started_on IS NOT NULL AND
+completed_on >= sqlc.arg(start_date) AND
+completed_on < sqlc.arg(end_date);
+
+-- name: GetWorkoutTonnageBetweenDates :many
+SELECT CAST(w.completed_on AS TEXT) as completed_on, CAST(COALESCE(SUM((s.weight + CASE WHEN et.measurement = 'bodyweight_reps' THEN COALESCE(w.bodyweight, 0) ELSE 0 END) * s.repetitions), 0) AS REAL) as tonnage FROM
+workouts w
+LEFT JOIN exercises e ON e.workout_id = w.id
+LEFT JOIN exercise_types et ON e.exercise_type_id = et.id
+LEFT JOIN sets s ON s.exercise_id = e.id AND s.type != 'warmup'
+WHERE w.user_id = sqlc.arg(user_id) AND
+w.completed_on >= sqlc.arg(start_date) AND
+w.completed_on < sqlc.arg(end_date)
+GROUP BY w.id, w.completed_on
+ORDER BY w.completed_on ASC;
+
+-- name: GetCompletedOnByUserId :many
+SELECT CAST(completed_on AS TEXT) as completed_on FROM
+workouts
+WHERE user_id = sqlc.arg(user_id) AND
+completed_on IS NOT NULL
+ORDER BY completed_on ASC;
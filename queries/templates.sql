@@ -0,0 +1,90 @@
+-- name: GetAllTemplates :many
+SELECT * FROM templates
+WHERE user_id = sqlc.arg(user_id)
+ORDER BY name;
+
+-- name: GetTemplateById :one
+SELECT * FROM templates
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: CreateTemplateAndReturnId :one
+INSERT INTO templates (
+  id, name, created_on, updated_on, user_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(name), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id)
+)
+RETURNING id;
+
+-- name: UpdateTemplateById :execrows
+UPDATE templates
+SET name = sqlc.arg(name), updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: DeleteTemplateById :execrows
+DELETE FROM templates
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: CountProgramDaysByTemplateId :one
+SELECT count(*) FROM program_days
+WHERE template_id = sqlc.arg(template_id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: CreateTemplateExerciseItemAndReturnId :one
+INSERT INTO template_exercise_items (
+  id, type, rounds, interval_seconds, time_cap_seconds, created_on, updated_on, user_id, template_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(type), sqlc.arg(rounds), sqlc.arg(interval_seconds), sqlc.arg(time_cap_seconds), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id), sqlc.arg(template_id)
+)
+RETURNING id;
+
+-- name: GetTemplateExerciseItemsByTemplateId :many
+SELECT * FROM template_exercise_items
+WHERE template_id = sqlc.arg(template_id)
+AND user_id = sqlc.arg(user_id)
+ORDER BY id;
+
+-- name: DeleteTemplateExerciseItemsByTemplateId :execrows
+DELETE FROM template_exercise_items
+WHERE template_id = sqlc.arg(template_id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: CreateTemplateExerciseAndReturnId :one
+INSERT INTO template_exercises (
+  id, name, created_on, updated_on, user_id, template_id, template_exercise_item_id, exercise_type_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(name), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id), sqlc.arg(template_id), sqlc.arg(template_exercise_item_id), sqlc.arg(exercise_type_id)
+)
+RETURNING id;
+
+-- name: GetTemplateExercisesByTemplateId :many
+SELECT * FROM template_exercises
+WHERE template_id = sqlc.arg(template_id)
+AND user_id = sqlc.arg(user_id)
+ORDER BY id;
+
+-- name: DeleteTemplateExercisesByTemplateId :execrows
+DELETE FROM template_exercises
+WHERE template_id = sqlc.arg(template_id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: CreateTemplateSetAndReturnId :one
+INSERT INTO template_sets (
+  id, repetitions, weight, duration_seconds, distance_meters, type, created_on, updated_on, user_id, template_id, template_exercise_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(repetitions), sqlc.arg(weight), sqlc.arg(duration_seconds), sqlc.arg(distance_meters), sqlc.arg(type), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id), sqlc.arg(template_id), sqlc.arg(template_exercise_id)
+)
+RETURNING id;
+
+-- name: GetTemplateSetsByTemplateId :many
+SELECT * FROM template_sets
+WHERE template_id = sqlc.arg(template_id)
+AND user_id = sqlc.arg(user_id)
+ORDER BY id;
+
+-- name: DeleteTemplateSetsByTemplateId :execrows
+DELETE FROM template_sets
+WHERE template_id = sqlc.arg(template_id)
+AND user_id = sqlc.arg(user_id);
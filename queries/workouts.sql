@@ -13,6 +13,12 @@ SELECT * FROM workouts
 WHERE id = sqlc.arg(id)
 AND user_id = sqlc.arg(user_id);
 
+-- name: GetWorkoutCompletedOn :one
+SELECT CAST(completed_on AS TEXT) as completed_on FROM workouts
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id)
+AND completed_on IS NOT NULL;
+
 -- name: CreateWorkoutAndReturnId :one
 INSERT INTO workouts (
   id, name, created_on, updated_on, user_id
@@ -21,11 +27,22 @@ INSERT INTO workouts (
 )
 RETURNING id;
 
+-- name: CreateCompletedWorkoutAndReturnId :one
+INSERT INTO workouts (
+  id, name, note, started_on, completed_on, active_seconds, average_heart_rate, created_on, updated_on, user_id
+) VALUES (
+  sqlc.arg(id), sqlc.arg(name), sqlc.arg(note), sqlc.arg(started_on), sqlc.arg(completed_on), sqlc.arg(active_seconds), sqlc.arg(average_heart_rate), sqlc.arg(created_on), sqlc.arg(updated_on), sqlc.arg(user_id)
+)
+RETURNING id;
+
 -- name: CompleteWorkoutById :execrows
 UPDATE workouts 
-SET completed_on = sqlc.arg(completed_on), updated_on = sqlc.arg(updated_on)
+SET completed_on = sqlc.arg(completed_on), updated_on = sqlc.arg(updated_on),
+active_seconds = active_seconds + CASE WHEN resumed_on IS NULL THEN 0 ELSE MAX(strftime('%s', sqlc.arg(completed_on)) - strftime('%s', resumed_on), 0) END,
+resumed_on = NULL
 WHERE id = sqlc.arg(id)
-AND user_id = sqlc.arg(user_id);
+AND user_id = sqlc.arg(user_id)
+AND completed_on IS NULL;
 
 -- name: DeleteWorkoutById :execrows
 DELETE FROM workouts
@@ -43,3 +60,34 @@ UPDATE workouts
 SET completed_on = NULL, updated_on = sqlc.arg(updated_on)
 WHERE id = sqlc.arg(id)
 AND user_id = sqlc.arg(user_id);
+
+-- name: UpdateWorkoutBodyweight :execrows
+UPDATE workouts
+SET bodyweight = sqlc.arg(bodyweight), updated_on = sqlc.arg(updated_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id);
+
+-- name: StartWorkoutById :execrows
+UPDATE workouts
+SET started_on = sqlc.arg(started_on), resumed_on = sqlc.arg(started_on), updated_on = sqlc.arg(started_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id)
+AND started_on IS NULL
+AND completed_on IS NULL;
+
+-- name: PauseWorkoutById :execrows
+UPDATE workouts
+SET active_seconds = active_seconds + MAX(strftime('%s', sqlc.arg(paused_on)) - strftime('%s', resumed_on), 0), resumed_on = NULL, updated_on = sqlc.arg(paused_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id)
+AND resumed_on IS NOT NULL
+AND completed_on IS NULL;
+
+-- name: ResumeWorkoutById :execrows
+UPDATE workouts
+SET resumed_on = sqlc.arg(resumed_on), updated_on = sqlc.arg(resumed_on)
+WHERE id = sqlc.arg(id)
+AND user_id = sqlc.arg(user_id)
+AND started_on IS NOT NULL
+AND resumed_on IS NULL
+AND completed_on IS NULL;